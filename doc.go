@@ -36,6 +36,50 @@
 //	    return c.JSON(200, user)
 //	}
 //
+// c is canceled when the client disconnects or the request times out, which
+// is what reads and renders should follow. Work that must finish regardless,
+// such as enqueueing a job or a goroutine started from the handler, should use
+// c.BackgroundContext(): it keeps the request's values, so log extractors still
+// find the request ID, but drops the cancellation and deadline. c.Enqueue
+// uses it already:
+//
+//	go h.audit.Record(c.BackgroundContext(), "invoice.sent", invoiceID)
+//
+// # Context Values
+//
+// Middleware passes values to handlers through the context. Use an
+// unexported empty struct type as the key so keys from different packages
+// never collide, and the typed helpers instead of raw Set and Get:
+//
+//	type tenantKey struct{}
+//
+//	forge.SetContextValue(c, tenantKey{}, tenant)
+//
+//	tenant := forge.ContextValue[*Tenant](c, tenantKey{})        // zero value if absent
+//	tenant, ok := forge.ContextValueOK[*Tenant](c, tenantKey{})  // absent vs. zero
+//	tenant := forge.MustContextValue[*Tenant](c, tenantKey{})    // panics if absent
+//
+// Once memoizes a lookup for the rest of the request, so middleware and the
+// handler can all ask for the same value with a single backend round-trip.
+// Results are dropped with the request and need no TTL:
+//
+//	user, err := forge.Once(c, "current_user", func() (*User, error) {
+//	    return repo.GetUser(c, c.UserID())
+//	})
+//
+// # Flash Messages
+//
+// Flash messages carry a notification across a redirect. They are stored in
+// an encrypted cookie, so they need WithCookieOptions(WithCookieSecret(...));
+// without a secret they are silently dropped:
+//
+//	c.FlashSuccess("Invoice sent")
+//	return c.Redirect(http.StatusSeeOther, "/invoices")
+//
+// The next page reads and clears them, usually in the layout:
+//
+//	msgs, err := c.Flashes() // []forge.FlashMessage{{Level: "success", Message: "Invoice sent"}}
+//
 // # Identity and Authentication
 //
 // Context provides convenience methods for checking the current user.
@@ -57,6 +101,36 @@
 //	    return c.Render(200, views.Profile(user, canEdit))
 //	}
 //
+// Sessions can hold a CSRF secret. [Session.CSRFToken] creates it on first
+// use and returns a masked per-request token for forms; check submitted
+// tokens with [Session.ValidCSRFToken]:
+//
+//	sess, err := c.Session()
+//	if err != nil {
+//	    return err
+//	}
+//	if !sess.ValidCSRFToken(c.Form("_csrf")) {
+//	    return forge.ErrForbidden("Invalid CSRF token")
+//	}
+//
+// Because the server holds the secret, no CSRF cookie is needed, but every
+// visitor who sees a form needs a stored session. c.AuthenticateSession
+// rotates the secret, so forms rendered before login must be reloaded, and
+// destroying the session invalidates open forms too. See CSRFToken for how
+// this compares to the double-submit cookie scheme. There is no CSRF
+// middleware yet; handlers check tokens themselves as above.
+//
+// When an account is deleted, c.DestroyUserSessions signs the user out on
+// every device and returns how many sessions were removed. Outside a
+// request, app.SessionManager().DeleteAllForUser does the same. Register
+// WithSessionOnBulkDestroy to record each purge in an audit log:
+//
+//	n, err := c.DestroyUserSessions(userID)
+//	if err != nil {
+//	    return err
+//	}
+//	c.LogInfo("account deleted", "user_id", userID, "sessions", n)
+//
 // # Role-Based Access Control (RBAC)
 //
 // Configure permissions with [WithRoles]. The role extractor is called
@@ -83,6 +157,80 @@
 //	    return h.repo.DeleteUser(c, forge.Param[string](c, "id"))
 //	}
 //
+// # Request Binding
+//
+// [Context.BindValidated] binds, sanitizes, and validates in one call.
+// Validation failures become a 422 [HTTPError] whose Fields carry the
+// translated messages for the error handler to render:
+//
+//	func (h *Handler) createUser(c forge.Context) error {
+//	    var req CreateUserRequest
+//	    if err := c.BindValidated(&req); err != nil {
+//	        return err
+//	    }
+//	    // ...
+//	}
+//
+// Endpoints shared by a browser form and an API client can use
+// [Context.BindAuto], which picks JSON, form, or query binding from the
+// method and Content-Type; Bind, BindJSON, and BindQuery stay available for
+// handlers that want to be explicit.
+//
+// In the error handler, [AsHTTPError] exposes the field errors:
+//
+//	if httpErr := forge.AsHTTPError(err); httpErr != nil && len(httpErr.Fields) > 0 {
+//	    return c.ValidationResponse(httpErr.Code, httpErr.Fields)
+//	}
+//
+// [Context.ValidationResponse] writes field errors in a fixed shape, with
+// messages translated through the request's translator:
+//
+//	{"error": "validation failed", "fields": {"email": ["is required"]}}
+//
+// JSON APIs can render any error, field errors included, as an RFC 7807
+// problem+json body with [Context.JSONError]:
+//
+//	forge.WithErrorHandler(func(c forge.Context, err error) error {
+//	    return c.JSONError(err)
+//	})
+//
+// With middlewares.RequestID installed, the body carries the same request_id
+// as the response header and log lines; [Context.RequestID] returns it.
+//
+// [DefaultNotFoundHandler] and [DefaultMethodNotAllowedHandler] answer
+// unmatched routes the same way for JSON clients and with a minimal HTML page
+// for browsers:
+//
+//	forge.WithNotFoundHandler(forge.DefaultNotFoundHandler()),
+//	forge.WithMethodNotAllowedHandler(forge.DefaultMethodNotAllowedHandler()),
+//
+// [WithJSONEncoder] changes how c.JSON and c.JSONError encode for the whole
+// app, for example to keep & and < unescaped in URLs or to pretty-print
+// when a developer adds ?pretty=1. [Context.JSONPretty] always indents:
+//
+//	forge.WithJSONEncoder(
+//	    forge.WithJSONEscapeHTML(false),
+//	    forge.WithJSONPrettyQuery(),
+//	),
+//
+// [Context.BindJSON] and [Context.BindValidated] report JSON that cannot be
+// parsed as a 400 [HTTPError] wrapping [ErrMalformedJSON], distinct from the
+// 422 for validation failures, and oversized bodies as a 413 wrapping
+// [ErrBodyTooLarge]. Bodies are capped at 1MB; middlewares.BodyLimit changes
+// the cap per route.
+//
+// Middleware that needs the raw body, such as webhook signature checks, can
+// call [Context.RawBody]. The body is cached and restored, so handlers can
+// still bind it:
+//
+//	body, err := c.RawBody()
+//	if err != nil {
+//	    return err
+//	}
+//	if !verifySignature(body, c.Header("Stripe-Signature")) {
+//	    return c.Error(http.StatusUnauthorized, "invalid signature")
+//	}
+//
 // # Type-Safe Parameter Helpers
 //
 // Generic helper functions provide type-safe access to URL and query
@@ -110,6 +258,105 @@
 //
 // Supported types: ~string, ~int, ~int64, ~float64, ~bool.
 //
+// When a zero value would be mistaken for real input, use the stricter
+// [ParamRequired]. It returns a 400 [HTTPError] for a missing or unparseable
+// parameter, so /items/abc fails instead of fetching item 0.
+// [Context.ParamOK] tells an absent parameter from an empty one:
+//
+//	id, err := forge.ParamRequired[int64](c, "id")
+//	if err != nil {
+//	    return err
+//	}
+//
+// Routes with several parameters can bind them into a struct in one call.
+// Fields map by `param` tag or lowercased field name, and unparseable values
+// come back as ValidationErrors:
+//
+//	// GET /orgs/{org}/projects/{project}/items/{id}
+//	func (h *Handler) getProjectItem(c forge.Context) error {
+//	    var p struct {
+//	        Org     string
+//	        Project string
+//	        ID      int64
+//	    }
+//	    if err := forge.BindParams(c, &p); err != nil {
+//	        return err
+//	    }
+//	    // ...
+//	}
+//
+// Filter endpoints read lists with [QuerySlice], which accepts repeated
+// values (?ids=1&ids=2), comma-separated values (?ids=1,2), or both, and
+// skips elements that do not parse. [QuerySliceStrict] returns a 400
+// [HTTPError] for them instead:
+//
+//	ids, err := forge.QuerySliceStrict[int64](c, "ids")
+//	if err != nil {
+//	    return err
+//	}
+//	statuses := forge.QuerySlice[string](c, "status")
+//
+// # Pagination
+//
+// List endpoints read page, limit, sort, and order with
+// [Context.BindPagination]. Sort is checked against an allowlist, so it is
+// safe to use in ORDER BY:
+//
+//	func (h *Handler) listItems(c forge.Context) error {
+//	    p, err := c.BindPagination(
+//	        forge.WithAllowedSortFields("name", "created_at"),
+//	        forge.WithDefaultSort("created_at"),
+//	        forge.WithMaxLimit(50),
+//	    )
+//	    if err != nil {
+//	        return err
+//	    }
+//	    items, err := h.repo.ListItems(c, p.Sort, p.Order, p.Limit, p.Offset)
+//	    // ...
+//	}
+//
+// # Conditional Requests
+//
+// Pages whose version is known before rendering, such as from a row's
+// updated_at, can answer 304 Not Modified without the expensive work.
+// [Context.CheckETag] and [Context.SetLastModified] set the validators, and
+// [Context.CheckETag] and [Context.NotModifiedSince] report whether the
+// client's copy is still fresh:
+//
+//	func (h *Handler) showArticle(c forge.Context) error {
+//	    updatedAt, err := h.repo.ArticleUpdatedAt(c, id)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    c.SetLastModified(updatedAt)
+//	    if c.NotModifiedSince(updatedAt) {
+//	        return c.NoContent(http.StatusNotModified)
+//	    }
+//	    article, err := h.repo.GetArticle(c, id)
+//	    // ...
+//	}
+//
+// # Streaming and Trailers
+//
+// [Context.Stream] flushes every write, which suits large exports and
+// server-sent events. Handlers that write to [Context.Response] directly call
+// [Context.Flush] to push data out; flushing before the first write sends
+// the headers. [Context.SetTrailer] adds a trailer sent after the body, such
+// as a status known only once streaming ends:
+//
+//	return c.Stream(http.StatusOK, "text/csv", func(w io.Writer) error {
+//	    status := "complete"
+//	    if err := h.repo.ExportOrders(c, w); err != nil {
+//	        status = "failed"
+//	    }
+//	    c.SetTrailer("X-Export-Status", status)
+//	    return nil
+//	})
+//
+// Trailers need HTTP/2 or a chunked HTTP/1.1 response, so do not set
+// Content-Length. Flushed responses are never replayed to waiting requests
+// by the Coalesce middleware.
+//
 // # Multi-Domain Routing
 //
 // For applications that need host-based routing, compose multiple Apps
@@ -132,6 +379,12 @@
 //	    log.Fatal(err)
 //	}
 //
+// For path-based composition, mount an App under a prefix with [WithMount].
+// The sub-app keeps its own middleware and not-found handler within the prefix:
+//
+//	admin := forge.New(forge.WithHandlers(handlers.NewAdmin(repo)))
+//	app := forge.New(forge.WithMount("/admin", admin))
+//
 // # Handlers
 //
 // Handlers implement the [Handler] interface to declare routes:
@@ -154,6 +407,32 @@
 //	    return c.Render(200, views.LoginPage())
 //	}
 //
+// [Resource] registers standard REST routes for a type with any of List,
+// Create, Show, Update, and Delete methods. The collection lives at /prefix
+// and items at /prefix/{id}; Update serves both PUT and PATCH. Verbs whose
+// method is missing are not registered and get a 405:
+//
+//	func (h *PostsHandler) Routes(r forge.Router) {
+//	    forge.Resource(r, "/posts", h.posts) // List, Show, Create
+//	}
+//
+//	func (p *postsResource) Show(c forge.Context) error {
+//	    post, err := p.repo.GetPost(c, c.Param("id"))
+//	    // ...
+//	}
+//
+// App.Routes lists every registered route, including those of mounted
+// sub-apps, with the number of middlewares that run for it. [PrintRoutes]
+// writes the same list as a table, for example on startup:
+//
+//	app := forge.New(forge.WithHandlers(handlers.NewAuth(repo)))
+//	_ = forge.PrintRoutes(app, os.Stdout)
+//
+//	// METHOD  PATTERN  MIDDLEWARES
+//	// GET     /login   2
+//	// POST    /login   2
+//	// POST    /logout  2
+//
 // # Middleware
 //
 // Middleware wraps handlers to add cross-cutting concerns:
@@ -166,6 +445,8 @@
 //	            log.Info("request",
 //	                "method", c.Request().Method,
 //	                "path", c.Request().URL.Path,
+//	                "status", c.Status(),
+//	                "bytes", c.BytesWritten(),
 //	                "duration", time.Since(start),
 //	            )
 //	            return err
@@ -173,6 +454,90 @@
 //	    }
 //	}
 //
+// [Context.Status] and [Context.BytesWritten] report what the rest of the
+// chain wrote, including the error handler's response, so middleware can
+// act on the outcome without wrapping the writer. Status is 0 until
+// something is written, and StatusClientClosedRequest (499) when c.JSON or
+// c.String found the client gone; those calls then return
+// ErrClientDisconnected, which the App neither renders nor logs as a 500.
+//
+// Routes can declare metadata, such as a required permission or a rate-limit
+// tier, with [Meta]. Middleware added with [WithRouteMiddleware] runs inside
+// every route after the route's own middleware, so one policy middleware can
+// read it with [RouteMeta]:
+//
+//	r.GET("/admin", h.admin, forge.Meta("permission", forge.Permission("admin.access")))
+//
+//	forge.WithRouteMiddleware(func(next forge.HandlerFunc) forge.HandlerFunc {
+//	    return func(c forge.Context) error {
+//	        if p, ok := forge.RouteMeta(c, "permission").(forge.Permission); ok && !c.Can(p) {
+//	            return forge.ErrForbidden("forbidden")
+//	        }
+//	        return next(c)
+//	    }
+//	})
+//
+// Meta passed to Router.With or Use applies to every route in the group, and
+// a route's own Meta for the same key wins. Global [WithMiddleware]
+// middleware runs before routing and does not see route metadata.
+//
+// Every App has a built-in panic safety net outside all other middleware.
+// A panic that escapes the chain is logged with its stack and passed to the
+// error handler as a [PanicError], so the response is a 500 and the server
+// keeps running even without middlewares.Recover. Recover still runs first
+// when installed and stays the place to tune stack size and logging.
+//
+// Work that should not delay the response, such as audit logging or cache
+// invalidation, can be registered with [Context.Defer]. Deferred functions
+// run after the response has been written and flushed, in registration
+// order, with panics recovered and logged. They still run on the request
+// goroutine, so anything slow belongs in the job queue:
+//
+//	c.Defer(func() {
+//	    h.audit.Record(context.WithoutCancel(c), "user.updated", id)
+//	})
+//
+// # WebSockets
+//
+// [Context.Upgrade] performs the WebSocket handshake. The connection is
+// closed when the handler returns or the server shuts down:
+//
+//	func (h *Handler) live(c forge.Context) error {
+//	    ws, err := c.Upgrade(forge.WithWSAllowOrigins("https://app.example.com"))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    defer ws.Close()
+//
+//	    for {
+//	        var msg Message
+//	        if err := ws.ReadJSON(&msg); err != nil {
+//	            return nil
+//	        }
+//	        // ...
+//	    }
+//	}
+//
+// # Startup
+//
+// The server binds its port, then runs startup steps in a fixed order:
+// migrations from [WithMigrations], job workers, and finally hooks registered
+// with [StartupHook]. While they run, readiness responds 503 with status
+// "starting", liveness stays OK, and other requests get 503 with Retry-After.
+// If a step fails, the port is released and Run returns the error:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	pool := db.MustOpen(ctx, cfg.DatabaseURL)
+//	app := forge.New(
+//	    forge.WithJobs(pool, job.WithTask(sendEmail)),
+//	    forge.WithHealthChecks(forge.WithReadinessCheck("db", db.Healthcheck(pool))),
+//	)
+//	err := app.Run(":8080",
+//	    forge.WithMigrations(pool, migrations, db.WithMigrationLock(4242)),
+//	)
+//
 // # Shutdown
 //
 // The application handles SIGINT/SIGTERM for graceful shutdown.
@@ -184,11 +549,24 @@
 //	    }),
 //	)
 //
+// With [WithMaxConcurrentRequests], requests beyond the limit are rejected
+// with 503 and a Retry-After header. On shutdown, in-flight requests are
+// drained before other shutdown hooks run, within the same shutdown timeout.
+//
 // # Testing
 //
-// For testing, use httptest.NewServer with the app's Router():
+// The forgetest package sends requests straight to the app's router without
+// a server, and carries cookies between requests so login flows can be tested:
 //
 //	app := forge.New(forge.WithHandlers(myHandler))
+//	client := forgetest.NewClient(t, app)
+//
+//	var items []Item
+//	client.GET("/items").AssertStatus(http.StatusOK).DecodeJSON(&items)
+//
+// For tests that need a real listener, use httptest.NewServer with the
+// app's Router():
+//
 //	ts := httptest.NewServer(app.Router())
 //	defer ts.Close()
 //
@@ -116,10 +116,7 @@ func (h *echoHandler) echo(c forge.Context) error {
 	if validationErrs, err := c.BindJSON(&req); err != nil {
 		return fmt.Errorf("bind error: %w", err)
 	} else if len(validationErrs) > 0 {
-		return c.JSON(http.StatusBadRequest, map[string]any{
-			"error":  "validation failed",
-			"fields": validationErrs,
-		})
+		return c.ValidationResponse(http.StatusBadRequest, validationErrs)
 	}
 
 	resp := echoResponse{
@@ -2,6 +2,8 @@ package forge
 
 import (
 	"context"
+	"embed"
+	"io"
 	"io/fs"
 	"log/slog"
 	"net/http"
@@ -11,11 +13,15 @@ import (
 
 	"github.com/dmitrymomot/forge/internal"
 	"github.com/dmitrymomot/forge/middlewares"
+	"github.com/dmitrymomot/forge/pkg/binder"
 	"github.com/dmitrymomot/forge/pkg/cookie"
+	"github.com/dmitrymomot/forge/pkg/db"
 	"github.com/dmitrymomot/forge/pkg/i18n"
 	"github.com/dmitrymomot/forge/pkg/job"
+	"github.com/dmitrymomot/forge/pkg/jwt"
 	"github.com/dmitrymomot/forge/pkg/logger"
 	"github.com/dmitrymomot/forge/pkg/session"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
 	"github.com/dmitrymomot/forge/pkg/storage"
 )
 
@@ -28,6 +34,9 @@ type (
 	// Router is the interface handlers use to declare routes.
 	Router = internal.Router
 
+	// RouteInfo describes a registered route, as returned by App.Routes.
+	RouteInfo = internal.RouteInfo
+
 	// Context provides request/response access and helper methods.
 	Context = internal.Context
 
@@ -37,6 +46,24 @@ type (
 	// HandlerFunc is the signature for route handlers.
 	HandlerFunc = internal.HandlerFunc
 
+	// ResourceHandler is a REST resource registered with Resource.
+	ResourceHandler = internal.ResourceHandler
+
+	// ResourceLister handles GET /prefix.
+	ResourceLister = internal.ResourceLister
+
+	// ResourceCreator handles POST /prefix.
+	ResourceCreator = internal.ResourceCreator
+
+	// ResourceShower handles GET /prefix/{id}.
+	ResourceShower = internal.ResourceShower
+
+	// ResourceUpdater handles PUT and PATCH /prefix/{id}.
+	ResourceUpdater = internal.ResourceUpdater
+
+	// ResourceDeleter handles DELETE /prefix/{id}.
+	ResourceDeleter = internal.ResourceDeleter
+
 	// Middleware wraps a HandlerFunc to add cross-cutting concerns.
 	Middleware = internal.Middleware
 
@@ -58,6 +85,9 @@ type (
 	// HealthOption configures health check endpoints.
 	HealthOption = internal.HealthOption
 
+	// JSONOption configures the JSON encoder used by c.JSON.
+	JSONOption = internal.JSONOption
+
 	// CheckFunc is the standard health check function signature.
 	CheckFunc = internal.CheckFunc
 
@@ -68,6 +98,9 @@ type (
 	// CookieOption configures the cookie manager.
 	CookieOption = cookie.Option
 
+	// CookieProtection selects how c.SetCookieRaw encodes a cookie's value.
+	CookieProtection = cookie.Protection
+
 	// SessionOption configures the session manager.
 	SessionOption = internal.SessionOption
 
@@ -95,6 +128,18 @@ type (
 	// JobEnqueuer provides job enqueueing without worker processing.
 	JobEnqueuer = job.Enqueuer
 
+	// JobRetryPolicy computes the delay before retrying a failed job.
+	JobRetryPolicy = job.RetryPolicy
+
+	// JobReporter records the progress of a running job.
+	JobReporter = job.Reporter
+
+	// JobStatus is a snapshot of a job's state and last reported progress.
+	JobStatus = job.JobStatus
+
+	// JobState is the lifecycle state of a job.
+	JobState = job.JobState
+
 	// Storage defines the interface for file storage operations.
 	Storage = storage.Storage
 
@@ -110,15 +155,33 @@ type (
 	// URLOption configures URL generation.
 	URLOption = storage.URLOption
 
+	// ServeFileOption configures Context.ServeFile.
+	ServeFileOption = internal.ServeFileOption
+
+	// FlashMessage is a one-time notification read with Context.Flashes.
+	FlashMessage = internal.FlashMessage
+
+	// FlashLevel is the severity of a FlashMessage.
+	FlashLevel = internal.FlashLevel
+
 	// ACL represents access control levels for stored files.
 	ACL = storage.ACL
 
+	// SSEMode selects server-side encryption at rest for stored objects.
+	SSEMode = storage.SSEMode
+
 	// ValidationRule defines a validation check for file uploads.
 	ValidationRule = storage.ValidationRule
 
 	// FileValidationError represents a file validation failure.
 	FileValidationError = storage.FileValidationError
 
+	// StorageKeyStrategy builds the storage key for an upload.
+	StorageKeyStrategy = storage.KeyStrategy
+
+	// StorageKeyContext carries the parts a StorageKeyStrategy builds a key from.
+	StorageKeyContext = storage.KeyContext
+
 	// HTTPError represents an HTTP error with all data needed for rendering.
 	HTTPError = internal.HTTPError
 
@@ -150,6 +213,18 @@ type (
 	// ExtractorSource extracts a value from the request context.
 	// Returns the value and true if found, or ("", false) if not present.
 	ExtractorSource = internal.ExtractorSource
+
+	// Pagination holds validated list parameters from Context.BindPagination.
+	Pagination = internal.Pagination
+
+	// PaginationOption configures Context.BindPagination.
+	PaginationOption = internal.PaginationOption
+
+	// WSConn is a WebSocket connection created by Context.Upgrade.
+	WSConn = internal.WSConn
+
+	// WSOption configures a WebSocket upgrade.
+	WSOption = internal.WSOption
 )
 
 // Constructors
@@ -203,6 +278,26 @@ func WithMiddleware(mw ...Middleware) Option {
 	return internal.WithMiddleware(mw...)
 }
 
+// WithRouteMiddleware adds middleware that runs inside every route, after the
+// route's own middleware and just before its handler, so it can read
+// metadata declared with Meta.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithRouteMiddleware(func(next forge.HandlerFunc) forge.HandlerFunc {
+//	        return func(c forge.Context) error {
+//	            if p, ok := forge.RouteMeta(c, "permission").(forge.Permission); ok && !c.Can(p) {
+//	                return forge.ErrForbidden("forbidden")
+//	            }
+//	            return next(c)
+//	        }
+//	    }),
+//	)
+func WithRouteMiddleware(mw ...Middleware) Option {
+	return internal.WithRouteMiddleware(mw...)
+}
+
 // WithHandlers registers handlers that declare routes.
 // Each handler's Routes method is called during setup.
 func WithHandlers(h ...Handler) Option {
@@ -224,8 +319,38 @@ func WithStaticFiles(pattern string, fsys fs.FS, subDir string) Option {
 	return internal.WithStaticFiles(pattern, fsys, subDir)
 }
 
+// WithMount mounts a sub-application under a path prefix.
+// The sub-app's routes, middleware, and not-found handler apply within the
+// prefix. Path parameters and static files resolve relative to the prefix.
+//
+// Example:
+//
+//	admin := forge.New(forge.WithHandlers(handlers.NewAdmin(repo)))
+//
+//	app := forge.New(
+//	    forge.WithHandlers(handlers.NewPages(repo)),
+//	    forge.WithMount("/admin", admin),
+//	)
+func WithMount(prefix string, sub *App) Option {
+	return internal.WithMount(prefix, sub)
+}
+
+// PrintRoutes writes the app's routes to w as a table of method, pattern,
+// and middleware count. Handy for logging the route table on startup.
+//
+// Example:
+//
+//	app := forge.New(forge.WithHandlers(handlers.NewPages(repo)))
+//	if err := forge.PrintRoutes(app, os.Stdout); err != nil {
+//	    log.Fatal(err)
+//	}
+func PrintRoutes(app *App, w io.Writer) error {
+	return internal.PrintRoutes(app, w)
+}
+
 // WithErrorHandler sets a custom error handler for handler errors.
-// Called when a handler returns a non-nil error.
+// Called when a handler returns a non-nil error before writing a response.
+// Errors returned after the response has started are logged, not rendered.
 func WithErrorHandler(h ErrorHandler) Option {
 	return internal.WithErrorHandler(h)
 }
@@ -240,6 +365,26 @@ func WithMethodNotAllowedHandler(h HandlerFunc) Option {
 	return internal.WithMethodNotAllowedHandler(h)
 }
 
+// DefaultNotFoundHandler returns a 404 handler that negotiates on Accept:
+// browsers get a minimal HTML page, JSON clients a problem+json body.
+//
+// Example:
+//
+//	forge.WithNotFoundHandler(forge.DefaultNotFoundHandler())
+func DefaultNotFoundHandler() HandlerFunc {
+	return internal.DefaultNotFoundHandler()
+}
+
+// DefaultMethodNotAllowedHandler returns a 405 handler that negotiates on
+// Accept like DefaultNotFoundHandler.
+//
+// Example:
+//
+//	forge.WithMethodNotAllowedHandler(forge.DefaultMethodNotAllowedHandler())
+func DefaultMethodNotAllowedHandler() HandlerFunc {
+	return internal.DefaultMethodNotAllowedHandler()
+}
+
 // WithHealthChecks enables health check endpoints with optional configuration.
 // Liveness (/health/live): Always returns OK if process is running.
 // Readiness (/health/ready): Runs all configured checks.
@@ -253,6 +398,47 @@ func WithHealthChecks(opts ...HealthOption) Option {
 	return internal.WithHealthChecks(opts...)
 }
 
+// WithMaxConcurrentRequests caps the number of requests handled at once.
+// When all n slots are busy, new requests get 503 with a Retry-After header.
+// Health check endpoints are never limited, and in-flight requests are
+// drained within the shutdown timeout. Use App.InFlightRequests to report
+// the current count.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithMaxConcurrentRequests(200),
+//	)
+func WithMaxConcurrentRequests(n int) Option {
+	return internal.WithMaxConcurrentRequests(n)
+}
+
+// WithRequestTimeout puts a deadline of d on every request's context.
+// Handlers that fail because the deadline passed return a *TimeoutError,
+// answered with 504 when no error handler is set. Combined with
+// middlewares.Timeout or TimeoutFor, the shortest deadline wins. Lift it for
+// websockets and streams with SkipRequestTimeout.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithRequestTimeout(10 * time.Second),
+//	)
+func WithRequestTimeout(d time.Duration) Option {
+	return internal.WithRequestTimeout(d)
+}
+
+// SkipRequestTimeout returns middleware that lifts the WithRequestTimeout
+// deadline for a route or group, such as websockets and server-sent events.
+// The request context is still canceled when the client disconnects.
+//
+// Example:
+//
+//	r.GET("/ws", h.socket, forge.SkipRequestTimeout())
+func SkipRequestTimeout() Middleware {
+	return internal.SkipRequestTimeout()
+}
+
 // WithLogger creates a logger with a component name and optional extractors.
 // The component name is added to every log entry for easy filtering.
 // Extractors pull values from context (e.g., request_id, user_id).
@@ -291,6 +477,51 @@ func WithBaseDomain(domain string) Option {
 	return internal.WithBaseDomain(domain)
 }
 
+// WithMaxMultipartMemory sets how many bytes of a multipart form are kept in
+// memory while parsing (default: 10MB). Larger file parts spill to temporary
+// files on disk, so this bounds memory use, not upload size; cap uploads
+// with middlewares.BodyLimit, which makes c.Bind and c.FormFile fail with
+// ErrUploadTooLarge.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithMaxMultipartMemory(2 << 20),
+//	)
+func WithMaxMultipartMemory(n int64) Option {
+	return internal.WithMaxMultipartMemory(n)
+}
+
+// WithJSONEncoder configures how c.JSON and c.JSONError encode responses.
+// Without it, output matches encoding/json's defaults. Field names are not
+// configurable here; set them with json struct tags.
+//
+// Example:
+//
+//	forge.WithJSONEncoder(
+//	    forge.WithJSONEscapeHTML(false),
+//	    forge.WithJSONPrettyQuery(),
+//	)
+func WithJSONEncoder(opts ...JSONOption) Option {
+	return internal.WithJSONEncoder(opts...)
+}
+
+// WithJSONIndent indents every c.JSON response with indent per level.
+func WithJSONIndent(indent string) JSONOption {
+	return internal.WithJSONIndent(indent)
+}
+
+// WithJSONEscapeHTML sets whether <, >, and & are escaped (default: true).
+func WithJSONEscapeHTML(escape bool) JSONOption {
+	return internal.WithJSONEscapeHTML(escape)
+}
+
+// WithJSONPrettyQuery makes c.JSON pretty-print when the request has a
+// ?pretty query parameter.
+func WithJSONPrettyQuery() JSONOption {
+	return internal.WithJSONPrettyQuery()
+}
+
 // WithRoles configures role-based access control for the application.
 // The permissions map defines which permissions each role grants.
 // The extractor function determines the current user's role from the request context.
@@ -313,6 +544,20 @@ func WithRoles(permissions RolePermissions, extractor RoleExtractorFunc) Option
 	return internal.WithRoles(permissions, extractor)
 }
 
+// MergePatchContentType is the media type of RFC 7396 JSON Merge Patch
+// bodies read by c.BindMergePatch.
+const MergePatchContentType = internal.MergePatchContentType
+
+// DefaultJWTCookie is the cookie c.SetJWTCookie writes and the JWT
+// middleware reads with WithJWTCookieFallback.
+const DefaultJWTCookie = internal.DefaultJWTCookie
+
+// WithJWTCookieName sets the cookie c.SetJWTCookie writes and the JWT
+// middleware reads with WithJWTCookieFallback.
+func WithJWTCookieName(name string) Option {
+	return internal.WithJWTCookieName(name)
+}
+
 // WithCookieOptions configures the cookie manager.
 //
 // Example:
@@ -369,9 +614,10 @@ func ShutdownTimeout(d time.Duration) RunOption {
 }
 
 // StartupHook registers a function to run during server startup.
-// Hooks are called in the order they were registered, after the port is bound
-// but before serving requests. If any hook fails, the server stops and
-// returns the error.
+// Hooks are called in the order they were registered, after migrations and
+// job workers have started. Until all of them finish, readiness reports
+// "starting" and other requests get 503. If any hook fails, the server
+// closes its port and returns the error.
 //
 // Example:
 //
@@ -380,6 +626,23 @@ func StartupHook(fn func(context.Context) error) RunOption {
 	return internal.StartupHook(fn)
 }
 
+// WithMigrations runs the embedded migrations as the first startup step,
+// before job workers start. Readiness reports "starting" until they finish,
+// and a failed migration aborts startup with the port released.
+// db.WithLogger and db.WithMigrationLock apply; other db options are ignored.
+//
+// Example:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	app.Run(":8080",
+//	    forge.WithMigrations(pool, migrations, db.WithMigrationLock(4242)),
+//	)
+func WithMigrations(pool *pgxpool.Pool, migrations embed.FS, opts ...db.Option) RunOption {
+	return internal.WithMigrations(pool, migrations, opts...)
+}
+
 // ShutdownHook registers a cleanup function to run during shutdown.
 // Hooks are called in the order they were registered.
 // Each hook receives a context with the shutdown timeout.
@@ -439,6 +702,93 @@ func ContextValue[T any](c Context, key any) T {
 	return internal.ContextValue[T](c, key)
 }
 
+// ContextValueOK retrieves a typed value from the context and reports
+// whether it was present with type T, distinguishing "absent" from "zero".
+//
+// Example:
+//
+//	if limit, ok := forge.ContextValueOK[int](c, rateLimitKey{}); ok {
+//	    // ...
+//	}
+func ContextValueOK[T any](c Context, key any) (T, bool) {
+	return internal.ContextValueOK[T](c, key)
+}
+
+// MustContextValue retrieves a typed value from the context and panics if
+// it is missing or has a different type. Use it for values that middleware
+// guarantees to set, such as the current tenant behind a tenant middleware.
+//
+// Example:
+//
+//	tenant := forge.MustContextValue[*Tenant](c, tenantKey{})
+func MustContextValue[T any](c Context, key any) T {
+	return internal.MustContextValue[T](c, key)
+}
+
+// SetContextValue stores a typed value in the context. Pair it with
+// ContextValue using an unexported empty struct type as the key, so keys
+// from different packages can never collide:
+//
+//	type tenantKey struct{}
+//
+//	forge.SetContextValue(c, tenantKey{}, tenant)
+//	tenant := forge.ContextValue[*Tenant](c, tenantKey{})
+func SetContextValue[T any](c Context, key any, v T) {
+	internal.SetContextValue(c, key, v)
+}
+
+// Once memoizes fn for the rest of the request: the first call for key runs
+// fn and later calls with the same key return its result, including an
+// error, without running it again. Use it for lookups several middlewares
+// and the handler all need, such as the current user. Results live in the
+// request context, so they are discarded with the request and need no TTL.
+//
+// Concurrent calls for the same key wait for the first to finish. If fn
+// panics, the panic reaches the first caller and later calls return it as a
+// *PanicError. Calling Once for one key with different types T panics. The first Once call in a
+// request stores the memo in the context, so make it on the request
+// goroutine, as with SetContextValue.
+//
+// Example:
+//
+//	user, err := forge.Once(c, "current_user", func() (*User, error) {
+//	    return repo.GetUser(c, c.UserID())
+//	})
+func Once[T any](c Context, key string, fn func() (T, error)) (T, error) {
+	return internal.Once(c, key, fn)
+}
+
+// Meta returns middleware that declares key/value metadata for a route,
+// such as the permission it requires or its rate-limit tier. Read it with
+// RouteMeta from later per-route middleware, WithRouteMiddleware, or the
+// handler. Passed to Router.With or Use, it applies to every route in the
+// group; a route's own Meta for the same key wins.
+//
+// Example:
+//
+//	r.GET("/admin", h.admin, forge.Meta("permission", forge.Permission("admin.access")))
+func Meta(key string, value any) Middleware {
+	return internal.Meta(key, value)
+}
+
+// RouteMeta returns the metadata value declared with Meta for key,
+// or nil if the route declares none.
+func RouteMeta(c Context, key string) any {
+	return internal.RouteMeta(c, key)
+}
+
+// Resource registers REST routes for the methods h implements:
+// GET and POST on prefix for List and Create, and GET, PUT, PATCH, and
+// DELETE on prefix/{id} for Show, Update, and Delete. It panics if h
+// implements none of them.
+//
+// Example:
+//
+//	forge.Resource(r, "/posts", postsResource)
+func Resource(r Router, prefix string, h ResourceHandler) {
+	internal.Resource(r, prefix, h)
+}
+
 // Param retrieves a typed URL parameter from the request.
 // Uses strconv for type conversion. Returns the zero value of T on parse error.
 //
@@ -450,6 +800,50 @@ func Param[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string)
 	return internal.Param[T](c, name)
 }
 
+// ParamRequired retrieves a typed URL parameter, the strict counterpart of Param.
+// Returns a 400 HTTPError when the parameter is missing, empty, or cannot be
+// parsed, so /items/abc fails instead of silently reading item 0.
+//
+// Example:
+//
+//	id, err := forge.ParamRequired[int64](c, "id")
+//	if err != nil {
+//	    return err
+//	}
+func ParamRequired[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) (T, error) {
+	return internal.ParamRequired[T](c, name)
+}
+
+// BindParams maps URL parameters into a struct by `param` tag or lowercased field name.
+// Supports the same field kinds as Param. Unparseable values are returned as ValidationErrors.
+//
+// Example:
+//
+//	// Route: /orgs/{org}/projects/{project}/items/{id}
+//	var p struct {
+//	    Org     string
+//	    Project string
+//	    ID      int64 `param:"id"`
+//	}
+//	if err := forge.BindParams(c, &p); err != nil {
+//	    return err
+//	}
+func BindParams(c Context, v any) error {
+	return internal.BindParams(c, v)
+}
+
+// RenderComponent renders a component into a string using ctx.
+// Use it outside handlers, e.g. to pre-render fragments in background jobs:
+//
+//	html, err := forge.RenderComponent(ctx, views.Sidebar(menu))
+//	if err != nil {
+//	    return err
+//	}
+//	return cache.Set(ctx, "sidebar", html, time.Hour)
+func RenderComponent(ctx context.Context, component Component) (string, error) {
+	return internal.RenderComponent(ctx, component)
+}
+
 // Query retrieves a typed query parameter from the request.
 // Uses strconv for type conversion. Returns the zero value of T on parse error.
 //
@@ -472,6 +866,31 @@ func QueryDefault[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name
 	return internal.QueryDefault[T](c, name, defaultValue)
 }
 
+// QuerySlice retrieves a typed list from a query parameter.
+// Accepts repeated values (?ids=1&ids=2), comma-separated values (?ids=1,2),
+// or a mix of both. Empty and unparseable elements are skipped.
+//
+// Example:
+//
+//	ids := forge.QuerySlice[int64](c, "ids")
+//	tags := forge.QuerySlice[string](c, "tag")
+func QuerySlice[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) []T {
+	return internal.QuerySlice[T](c, name)
+}
+
+// QuerySliceStrict retrieves a typed list like QuerySlice, but returns a 400
+// HTTPError when any element cannot be parsed instead of skipping it.
+//
+// Example:
+//
+//	ids, err := forge.QuerySliceStrict[int64](c, "ids")
+//	if err != nil {
+//	    return err
+//	}
+func QuerySliceStrict[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) ([]T, error) {
+	return internal.QuerySliceStrict[T](c, name)
+}
+
 // Extractor helpers
 
 // NewExtractor creates an Extractor that tries the given sources in order.
@@ -514,6 +933,11 @@ func FromCookieEncrypted(name string) ExtractorSource {
 	return internal.FromCookieEncrypted(name)
 }
 
+// FromJWTCookie returns an ExtractorSource that reads the app's JWT cookie.
+func FromJWTCookie() ExtractorSource {
+	return internal.FromJWTCookie()
+}
+
 // FromParam returns an ExtractorSource that reads from a URL parameter.
 func FromParam(name string) ExtractorSource {
 	return internal.FromParam(name)
@@ -536,6 +960,54 @@ func FromBearerToken() ExtractorSource {
 	return internal.FromBearerToken()
 }
 
+// FromBasicAuth returns an ExtractorSource that reads the username from
+// HTTP Basic credentials. Malformed credentials are treated as a miss.
+//
+// Example:
+//
+//	apiKey := forge.NewExtractor(
+//	    forge.FromBearerToken(),
+//	    forge.FromBasicAuth(),
+//	)
+func FromBasicAuth() ExtractorSource {
+	return internal.FromBasicAuth()
+}
+
+// FromBasicAuthPassword returns an ExtractorSource that reads the password
+// from HTTP Basic credentials. Malformed credentials are treated as a miss.
+func FromBasicAuthPassword() ExtractorSource {
+	return internal.FromBasicAuthPassword()
+}
+
+// FromJSONBody returns an ExtractorSource that reads a field from a JSON
+// request body, using a dot path for nested fields. Requests not sent as
+// application/json or a +json type are a miss. The body is buffered via
+// Context.RawBody, so binders downstream still see it.
+//
+// Example:
+//
+//	token := forge.NewExtractor(
+//	    forge.FromBearerToken(),
+//	    forge.FromJSONBody("auth.token"),
+//	)
+func FromJSONBody(field string) ExtractorSource {
+	return internal.FromJSONBody(field)
+}
+
+// Transform wraps an ExtractorSource with a normalizing or validating step.
+// A false return from fn is treated as a miss, so the Extractor falls
+// through to its next source.
+//
+// Example:
+//
+//	ext := forge.NewExtractor(
+//	    forge.Transform(forge.FromQuery("token"), validULID),
+//	    forge.FromHeader("X-Token"),
+//	)
+func Transform(src ExtractorSource, fn func(string) (string, bool)) ExtractorSource {
+	return internal.Transform(src, fn)
+}
+
 // Cookie options
 
 // WithCookieSecret sets the secret for signing and encryption.
@@ -569,6 +1041,13 @@ func WithCookieSameSite(ss http.SameSite) CookieOption {
 	return cookie.WithSameSite(ss)
 }
 
+// Cookie protection modes for c.SetCookieRaw.
+const (
+	CookiePlain     = cookie.Plain
+	CookieSigned    = cookie.Signed
+	CookieEncrypted = cookie.Encrypted
+)
+
 // Cookie errors for checking return values.
 var (
 	ErrCookieNotFound  = cookie.ErrNotFound
@@ -662,6 +1141,76 @@ func WithSessionFingerprint(mode FingerprintMode, strictness FingerprintStrictne
 	return internal.WithSessionFingerprint(mode, strictness)
 }
 
+// WithSessionMaxPerUser limits how many sessions one user may hold at once.
+// AuthenticateSession evicts the user's oldest sessions beyond the limit, or
+// rejects the login with ErrTooManySessions under SessionRejectNew.
+// Anonymous and expired sessions are not counted.
+//
+// Example:
+//
+//	forge.WithSession(store,
+//	    forge.WithSessionMaxPerUser(3),
+//	    forge.WithSessionLimitStrategy(forge.SessionRejectNew),
+//	)
+func WithSessionMaxPerUser(n int) SessionOption {
+	return internal.WithSessionMaxPerUser(n)
+}
+
+// WithSessionLimitStrategy sets what happens when a login would exceed
+// WithSessionMaxPerUser. Defaults to SessionEvictOldest.
+func WithSessionLimitStrategy(strategy SessionLimitStrategy) SessionOption {
+	return internal.WithSessionLimitStrategy(strategy)
+}
+
+// WithSessionOnAuthenticate registers a hook called after every
+// AuthenticateSession decision, with the sessions evicted or whether the
+// login was rejected.
+func WithSessionOnAuthenticate(fn func(c Context, e SessionAuthEvent)) SessionOption {
+	return internal.WithSessionOnAuthenticate(fn)
+}
+
+// WithSessionOnBulkDestroy registers a hook called once after a user's
+// sessions are purged with DestroyUserSessions or
+// SessionManager.DeleteAllForUser, with the number deleted.
+//
+// Example:
+//
+//	forge.WithSession(store,
+//	    forge.WithSessionOnBulkDestroy(func(ctx context.Context, userID string, count int) {
+//	        audit.Record(ctx, "sessions.purged", userID, count)
+//	    }),
+//	)
+func WithSessionOnBulkDestroy(fn func(ctx context.Context, userID string, count int)) SessionOption {
+	return internal.WithSessionOnBulkDestroy(fn)
+}
+
+// Session limit types for session configuration.
+type (
+	// SessionLimitStrategy determines what happens when a user exceeds WithSessionMaxPerUser.
+	SessionLimitStrategy = internal.SessionLimitStrategy
+
+	// SessionAuthEvent describes the outcome of AuthenticateSession.
+	SessionAuthEvent = internal.SessionAuthEvent
+
+	// SessionManager handles session lifecycle; get it from App.SessionManager.
+	SessionManager = internal.SessionManager
+)
+
+// Flash message levels.
+const (
+	FlashLevelSuccess = internal.FlashLevelSuccess
+	FlashLevelError   = internal.FlashLevelError
+	FlashLevelWarning = internal.FlashLevelWarning
+)
+
+// Session limit strategy constants.
+const (
+	// SessionEvictOldest signs the user in and deletes their oldest sessions.
+	SessionEvictOldest = internal.SessionEvictOldest
+	// SessionRejectNew refuses the login with ErrTooManySessions.
+	SessionRejectNew = internal.SessionRejectNew
+)
+
 // Fingerprint types for session configuration.
 type (
 	// FingerprintMode determines which fingerprint generation algorithm to use.
@@ -700,6 +1249,7 @@ var (
 	ErrSessionExpired             = session.ErrExpired
 	ErrSessionInvalidToken        = session.ErrInvalidToken
 	ErrSessionFingerprintMismatch = session.ErrFingerprintMismatch
+	ErrTooManySessions            = session.ErrTooManySessions
 )
 
 // Job options
@@ -758,6 +1308,25 @@ func WithJobWorker(pool *pgxpool.Pool, opts ...JobOption) Option {
 	return internal.WithJobWorker(pool, opts...)
 }
 
+// WithJobContextPropagation copies the request context values stored under
+// keys into the metadata of every job enqueued from a handler. Pair it with
+// WithJobRestoreContext on the worker to restore them before Handle runs.
+// Values must be JSON-encodable; only strings round-trip with their type
+// intact.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithJobs(pool,
+//	        forge.WithTask(tasks.NewSendEmail(mailer)),
+//	        forge.WithJobRestoreContext(tenantKey, traceKey),
+//	    ),
+//	    forge.WithJobContextPropagation(tenantKey, traceKey),
+//	)
+func WithJobContextPropagation(keys ...any) Option {
+	return internal.WithJobContextPropagation(keys...)
+}
+
 // Job registration options - re-exported from pkg/job
 
 // WithTask registers a task handler using structural typing.
@@ -769,8 +1338,18 @@ func WithTask[P any, T interface {
 	return job.WithTask[P, T](task)
 }
 
+// WithProgressTask registers a task whose Handle also receives a JobReporter.
+// Progress is read back with c.JobStatus.
+func WithProgressTask[P any, T interface {
+	Name() string
+	Handle(context.Context, P, JobReporter) error
+}](task T) JobOption {
+	return job.WithProgressTask[P, T](task)
+}
+
 // WithScheduledTask registers a periodic task.
 // The task must implement Name(), Schedule(), and Handle(ctx) methods.
+// An optional TimeZone() string method evaluates the schedule in that location.
 func WithScheduledTask[T interface {
 	Name() string
 	Schedule() string
@@ -794,6 +1373,19 @@ func WithJobMaxWorkers(n int) JobOption {
 	return job.WithMaxWorkers(n)
 }
 
+// WithJobRetryPolicy sets the backoff for failed jobs whose task does not
+// implement NextRetry. See job.ExponentialBackoff, job.FixedBackoff, and
+// job.LinearBackoff.
+func WithJobRetryPolicy(p JobRetryPolicy) JobOption {
+	return job.WithRetryPolicy(p)
+}
+
+// WithJobRestoreContext restores context values captured at enqueue time
+// into the context passed to Handle. See WithJobContextPropagation.
+func WithJobRestoreContext(keys ...any) JobOption {
+	return job.WithContextPropagation(keys...)
+}
+
 // Enqueue options - re-exported from pkg/job
 
 // InQueue specifies which queue to use for the job.
@@ -843,6 +1435,7 @@ var (
 	ErrJobInvalidPayload    = job.ErrInvalidPayload
 	ErrJobHealthcheckFailed = job.ErrHealthcheckFailed
 	ErrJobPoolRequired      = job.ErrPoolRequired
+	ErrJobNoRetry           = job.ErrNoRetry
 )
 
 // JobHealthcheck returns a health check function for the job manager.
@@ -879,6 +1472,18 @@ const (
 	ACLPublicRead = storage.ACLPublicRead
 )
 
+// Storage server-side encryption modes.
+const (
+	// SSENone sends no encryption header; the bucket's default encryption applies.
+	SSENone = storage.SSENone
+
+	// SSES3 encrypts with S3-managed keys.
+	SSES3 = storage.SSES3
+
+	// SSEKMS encrypts with an AWS KMS key.
+	SSEKMS = storage.SSEKMS
+)
+
 // Storage options
 
 // WithStorage configures file storage for the application.
@@ -916,11 +1521,58 @@ func WithStorageTenant(id string) StorageOption {
 	return storage.WithTenant(id)
 }
 
+// WithStorageFilename sets the original filename used by key strategies.
+func WithStorageFilename(name string) StorageOption {
+	return storage.WithFilename(name)
+}
+
+// WithStorageKeyStrategy sets how the key is built for this upload,
+// overriding StorageConfig.KeyStrategy.
+func WithStorageKeyStrategy(fn StorageKeyStrategy) StorageOption {
+	return storage.WithKeyStrategy(fn)
+}
+
+// DateStorageKeyStrategy partitions keys by upload month: {tenant}/{prefix}/{yyyy}/{mm}/{ulid}.{ext}.
+func DateStorageKeyStrategy() StorageKeyStrategy {
+	return storage.DateKeyStrategy()
+}
+
+// FilenameStorageKeyStrategy keeps a slug of the original filename: {tenant}/{prefix}/{ulid}/{slug}.{ext}.
+func FilenameStorageKeyStrategy() StorageKeyStrategy {
+	return storage.FilenameKeyStrategy()
+}
+
 // WithStorageContentType overrides the auto-detected content type.
 func WithStorageContentType(ct string) StorageOption {
 	return storage.WithContentType(ct)
 }
 
+// WithStorageContentDisposition stores a Content-Disposition header on the object.
+func WithStorageContentDisposition(cd string) StorageOption {
+	return storage.WithContentDisposition(cd)
+}
+
+// WithStorageCacheControl stores a Cache-Control header on the object.
+func WithStorageCacheControl(cc string) StorageOption {
+	return storage.WithCacheControl(cc)
+}
+
+// WithStorageTags sets S3 object tags, which lifecycle rules can match to expire objects.
+func WithStorageTags(tags map[string]string) StorageOption {
+	return storage.WithTags(tags)
+}
+
+// WithStorageMetadata stores user metadata on the object, returned in FileInfo.Metadata.
+func WithStorageMetadata(md map[string]string) StorageOption {
+	return storage.WithMetadata(md)
+}
+
+// WithStorageSSE sets server-side encryption for this upload, overriding
+// StorageConfig.ServerSideEncryption. kmsKeyID is used only with SSEKMS.
+func WithStorageSSE(mode SSEMode, kmsKeyID string) StorageOption {
+	return storage.WithSSE(mode, kmsKeyID)
+}
+
 // WithStorageACL overrides the default ACL for this upload.
 func WithStorageACL(acl ACL) StorageOption {
 	return storage.WithACL(acl)
@@ -931,6 +1583,16 @@ func WithStorageValidation(rules ...ValidationRule) StorageOption {
 	return storage.WithValidation(rules...)
 }
 
+// WithStorageMultipartThreshold sets the size above which uploads use S3 multipart upload.
+func WithStorageMultipartThreshold(n int64) StorageOption {
+	return storage.WithMultipartThreshold(n)
+}
+
+// WithStorageProgress sets a callback that reports upload progress.
+func WithStorageProgress(fn func(uploaded, total int64)) StorageOption {
+	return storage.WithProgress(fn)
+}
+
 // Storage URL options - re-exported from pkg/storage
 
 // WithURLExpiry sets the expiry duration for signed URLs.
@@ -953,6 +1615,18 @@ func WithURLPublic() URLOption {
 	return storage.WithPublic()
 }
 
+// ServeFile options - re-exported from internal
+
+// WithServeFileDownload makes the browser save the file as filename instead of displaying it.
+func WithServeFileDownload(filename string) ServeFileOption {
+	return internal.WithServeFileDownload(filename)
+}
+
+// WithServeFileCacheControl sets the Cache-Control header for a served file.
+func WithServeFileCacheControl(cc string) ServeFileOption {
+	return internal.WithServeFileCacheControl(cc)
+}
+
 // Storage validation rules - re-exported from pkg/storage
 
 // MaxFileSize returns a rule that rejects files larger than the specified size.
@@ -1003,11 +1677,58 @@ var (
 	ErrStorageAccessDenied   = storage.ErrAccessDenied
 	ErrStorageUploadFailed   = storage.ErrUploadFailed
 	ErrStorageDeleteFailed   = storage.ErrDeleteFailed
+	ErrStorageHeadFailed     = storage.ErrHeadFailed
+	ErrStorageInvalidTags    = storage.ErrInvalidTags
+	ErrStorageTaggingFailed  = storage.ErrTaggingFailed
 	ErrStoragePresignFailed  = storage.ErrPresignFailed
 	ErrStorageInvalidURL     = storage.ErrInvalidURL
 	ErrStorageDownloadFailed = storage.ErrDownloadFailed
 )
 
+// Signed URLs
+
+// URLSigner creates and verifies tamper-proof, expiring URLs.
+type URLSigner = signedurl.Signer
+
+// NewURLSigner creates a URLSigner from a secret of at least 32 bytes.
+// The cookie secret can be reused; the signing key is derived from it.
+func NewURLSigner(secret string) (*URLSigner, error) {
+	return signedurl.New(secret)
+}
+
+// WithURLSigner configures the signer used by c.SignedURL().
+// Protect the target routes with middlewares.VerifySignedURL using the same signer.
+//
+// Example:
+//
+//	signer, err := forge.NewURLSigner(os.Getenv("COOKIE_SECRET"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	forge.New(
+//	    forge.WithURLSigner(signer),
+//	)
+func WithURLSigner(s *URLSigner) Option {
+	return internal.WithURLSigner(s)
+}
+
+// Signed URL errors for checking return values.
+var (
+	ErrURLSignerNotConfigured = signedurl.ErrNotConfigured
+	ErrURLSignerBadSecret     = signedurl.ErrBadSecret
+	ErrURLInvalidSignature    = signedurl.ErrInvalidSignature
+	ErrURLExpired             = signedurl.ErrExpired
+)
+
+// Request binding errors for checking return values. Errors from BindJSON
+// and BindValidated wrap them inside 413 and 400 HTTPErrors; Bind and
+// FormFile wrap ErrUploadTooLarge, which also matches ErrBodyTooLarge, in a 413.
+var (
+	ErrBodyTooLarge   = binder.ErrBodyTooLarge
+	ErrUploadTooLarge = binder.ErrUploadTooLarge
+	ErrMalformedJSON  = binder.ErrMalformedJSON
+)
+
 // Middleware error types - re-exported from middlewares
 type (
 	// PanicError represents a recovered panic.
@@ -1025,6 +1746,9 @@ type (
 	// JWTOption configures the JWT middleware.
 	JWTOption = middlewares.JWTOption
 
+	// JWTPair is an access token with the refresh token used to renew it.
+	JWTPair = middlewares.JWTPair
+
 	// Translator provides a simplified translation interface with a fixed language and namespace context.
 	Translator = i18n.Translator
 
@@ -1119,6 +1843,20 @@ func WithJWTExtractor(ext Extractor) JWTOption {
 	return middlewares.WithJWTExtractor(ext)
 }
 
+// IssueJWT signs claims with svc, setting iat and, for a positive ttl, exp.
+func IssueJWT[T any](svc *jwt.Service, claims T, ttl time.Duration) (string, error) {
+	return middlewares.IssueJWT(svc, claims, ttl)
+}
+
+// IssueJWTPair issues an access token and a refresh token signed by
+// separate services.
+func IssueJWTPair[A, R any](
+	accessSvc *jwt.Service, access A, accessTTL time.Duration,
+	refreshSvc *jwt.Service, refresh R, refreshTTL time.Duration,
+) (*JWTPair, error) {
+	return middlewares.IssueJWTPair(accessSvc, access, accessTTL, refreshSvc, refresh, refreshTTL)
+}
+
 // I18n middleware option constructors
 
 // WithI18nNamespace sets the default namespace for the context translator.
@@ -1173,6 +1911,11 @@ func WithError(err error) HTTPErrorOption {
 	return internal.WithError(err)
 }
 
+// WithFields attaches field-level validation errors.
+func WithFields(fields ValidationErrors) HTTPErrorOption {
+	return internal.WithFields(fields)
+}
+
 // Convenience constructors for common HTTP errors.
 
 // ErrBadRequest creates a 400 Bad Request error.
@@ -1200,6 +1943,11 @@ func ErrConflict(message string, opts ...HTTPErrorOption) *HTTPError {
 	return internal.ErrConflict(message, opts...)
 }
 
+// ErrPayloadTooLarge creates a 413 Payload Too Large error.
+func ErrPayloadTooLarge(message string, opts ...HTTPErrorOption) *HTTPError {
+	return internal.ErrPayloadTooLarge(message, opts...)
+}
+
 // ErrUnprocessable creates a 422 Unprocessable Entity error.
 func ErrUnprocessable(message string, opts ...HTTPErrorOption) *HTTPError {
 	return internal.ErrUnprocessable(message, opts...)
@@ -1227,3 +1975,78 @@ func IsHTTPError(err error) bool {
 func AsHTTPError(err error) *HTTPError {
 	return internal.AsHTTPError(err)
 }
+
+// ErrClientDisconnected is returned by c.JSON and c.String when the client
+// closed the connection. Error handlers can ignore it.
+var ErrClientDisconnected = internal.ErrClientDisconnected
+
+// StatusClientClosedRequest is what c.Status reports once a write found the
+// client gone (499, as in nginx).
+const StatusClientClosedRequest = internal.StatusClientClosedRequest
+
+// IsClientDisconnected returns true if the error is ErrClientDisconnected.
+func IsClientDisconnected(err error) bool {
+	return internal.IsClientDisconnected(err)
+}
+
+// WebSocket options for Context.Upgrade.
+
+// WithWSAllowOrigins sets the origins allowed to open a WebSocket.
+// Origins are matched exactly; "*" allows all. Without origin options only
+// same-host requests are accepted.
+func WithWSAllowOrigins(origins ...string) WSOption {
+	return internal.WithWSAllowOrigins(origins...)
+}
+
+// WithWSAllowOriginFunc sets a dynamic origin validator.
+// When set, it completely overrides WithWSAllowOrigins.
+func WithWSAllowOriginFunc(fn func(origin string) bool) WSOption {
+	return internal.WithWSAllowOriginFunc(fn)
+}
+
+// WithWSReadTimeout limits how long WSConn.ReadJSON waits for a message.
+func WithWSReadTimeout(d time.Duration) WSOption {
+	return internal.WithWSReadTimeout(d)
+}
+
+// WithWSWriteTimeout limits how long WSConn.WriteJSON may take.
+func WithWSWriteTimeout(d time.Duration) WSOption {
+	return internal.WithWSWriteTimeout(d)
+}
+
+// WithWSMaxMessageSize sets the maximum size in bytes of a received message.
+// Defaults to 32KB.
+func WithWSMaxMessageSize(n int64) WSOption {
+	return internal.WithWSMaxMessageSize(n)
+}
+
+// Pagination options for Context.BindPagination.
+
+// Sort orders accepted by Context.BindPagination.
+const (
+	SortAsc  = internal.SortAsc
+	SortDesc = internal.SortDesc
+)
+
+// WithAllowedSortFields sets the fields the sort parameter may name.
+// Without it, any sort parameter is rejected, so user input never reaches
+// ORDER BY unchecked.
+func WithAllowedSortFields(fields ...string) PaginationOption {
+	return internal.WithAllowedSortFields(fields...)
+}
+
+// WithDefaultSort sets the sort field used when the request has none.
+func WithDefaultSort(field string) PaginationOption {
+	return internal.WithDefaultSort(field)
+}
+
+// WithDefaultLimit sets the page size used when the request has none.
+// Defaults to 20.
+func WithDefaultLimit(n int) PaginationOption {
+	return internal.WithDefaultLimit(n)
+}
+
+// WithMaxLimit caps the page size. Defaults to 100.
+func WithMaxLimit(n int) PaginationOption {
+	return internal.WithMaxLimit(n)
+}
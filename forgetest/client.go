@@ -0,0 +1,226 @@
+package forgetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// baseURL is the origin requests are made to. HTTPS keeps Secure cookies in the jar.
+const baseURL = "https://example.com"
+
+// Client sends requests to an App's router and records the responses.
+// It is not safe for concurrent use.
+type Client struct {
+	tb      testing.TB
+	handler http.Handler
+	jar     http.CookieJar
+	header  http.Header
+	cookies []*http.Cookie
+}
+
+// NewClient creates a client for app. Failures are reported through tb.
+func NewClient(tb testing.TB, app *internal.App) *Client {
+	tb.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		tb.Fatalf("forgetest: create cookie jar: %v", err)
+	}
+
+	return &Client{
+		tb:      tb,
+		handler: app.Router(),
+		jar:     jar,
+		header:  make(http.Header),
+	}
+}
+
+// WithHeader returns a copy of the client that sets header key to value on
+// every request. The copy shares the cookie jar.
+func (c *Client) WithHeader(key, value string) *Client {
+	cp := c.clone()
+	cp.header.Set(key, value)
+	return cp
+}
+
+// WithCookie returns a copy of the client that sends cookie with every
+// request in addition to the jar's cookies. The copy shares the cookie jar.
+func (c *Client) WithCookie(cookie *http.Cookie) *Client {
+	cp := c.clone()
+	cp.cookies = append(cp.cookies, cookie)
+	return cp
+}
+
+// Cookie returns the jar's cookie with the given name, or nil if none is stored.
+func (c *Client) Cookie(name string) *http.Cookie {
+	u, _ := url.Parse(baseURL + "/")
+	for _, cookie := range c.jar.Cookies(u) {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// GET sends a GET request to path.
+func (c *Client) GET(path string) *Response {
+	c.tb.Helper()
+	return c.Request(http.MethodGet, path, nil)
+}
+
+// POST sends a POST request to path with body encoded as described in the
+// package documentation.
+func (c *Client) POST(path string, body any) *Response {
+	c.tb.Helper()
+	return c.Request(http.MethodPost, path, body)
+}
+
+// PUT sends a PUT request to path.
+func (c *Client) PUT(path string, body any) *Response {
+	c.tb.Helper()
+	return c.Request(http.MethodPut, path, body)
+}
+
+// PATCH sends a PATCH request to path.
+func (c *Client) PATCH(path string, body any) *Response {
+	c.tb.Helper()
+	return c.Request(http.MethodPatch, path, body)
+}
+
+// DELETE sends a DELETE request to path.
+func (c *Client) DELETE(path string) *Response {
+	c.tb.Helper()
+	return c.Request(http.MethodDelete, path, nil)
+}
+
+// Request sends a request with the given method, path, and body.
+func (c *Client) Request(method, path string, body any) *Response {
+	c.tb.Helper()
+
+	r, contentType, err := encodeBody(body)
+	if err != nil {
+		c.tb.Fatalf("forgetest: encode %s %s body: %v", method, path, err)
+	}
+
+	req := httptest.NewRequest(method, baseURL+path, r)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.Do(req)
+}
+
+// Do sends req after adding the client's headers and cookies, and stores
+// cookies set by the response in the jar.
+func (c *Client) Do(req *http.Request) *Response {
+	c.tb.Helper()
+
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	for _, cookie := range c.jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	result := rec.Result()
+	c.jar.SetCookies(req.URL, result.Cookies())
+
+	return &Response{Recorder: rec, tb: c.tb, request: req}
+}
+
+func (c *Client) clone() *Client {
+	cp := *c
+	cp.header = c.header.Clone()
+	cp.cookies = append([]*http.Cookie(nil), c.cookies...)
+	return &cp
+}
+
+func encodeBody(body any) (io.Reader, string, error) {
+	switch v := body.(type) {
+	case nil:
+		return nil, "", nil
+	case url.Values:
+		return strings.NewReader(v.Encode()), "application/x-www-form-urlencoded", nil
+	case string:
+		return strings.NewReader(v), "", nil
+	case []byte:
+		return bytes.NewReader(v), "", nil
+	case io.Reader:
+		return v, "", nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, "", err
+		}
+		return bytes.NewReader(data), "application/json", nil
+	}
+}
+
+// Response is a recorded response with assertion helpers.
+// Assertions stop the test with tb.Fatalf on failure.
+type Response struct {
+	// Recorder holds the raw recorded response.
+	Recorder *httptest.ResponseRecorder
+
+	tb      testing.TB
+	request *http.Request
+}
+
+// Code returns the response status code.
+func (r *Response) Code() int {
+	return r.Recorder.Code
+}
+
+// Header returns the response headers.
+func (r *Response) Header() http.Header {
+	return r.Recorder.Header()
+}
+
+// Body returns the response body as a string.
+func (r *Response) Body() string {
+	return r.Recorder.Body.String()
+}
+
+// AssertStatus fails the test unless the response has the given status code.
+func (r *Response) AssertStatus(code int) *Response {
+	r.tb.Helper()
+	if r.Recorder.Code != code {
+		r.tb.Fatalf("forgetest: %s %s: status = %d, want %d; body: %s",
+			r.request.Method, r.request.URL.RequestURI(), r.Recorder.Code, code, r.Body())
+	}
+	return r
+}
+
+// AssertHeader fails the test unless header key has the given value.
+func (r *Response) AssertHeader(key, value string) *Response {
+	r.tb.Helper()
+	if got := r.Recorder.Header().Get(key); got != value {
+		r.tb.Fatalf("forgetest: %s %s: header %s = %q, want %q",
+			r.request.Method, r.request.URL.RequestURI(), key, got, value)
+	}
+	return r
+}
+
+// DecodeJSON decodes the response body into v and fails the test on error.
+func (r *Response) DecodeJSON(v any) *Response {
+	r.tb.Helper()
+	if err := json.Unmarshal(r.Recorder.Body.Bytes(), v); err != nil {
+		r.tb.Fatalf("forgetest: %s %s: decode JSON: %v; body: %s",
+			r.request.Method, r.request.URL.RequestURI(), err, r.Body())
+	}
+	return r
+}
@@ -0,0 +1,100 @@
+package forgetest_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/forgetest"
+	"github.com/dmitrymomot/forge/internal"
+)
+
+type routes func(r internal.Router)
+
+func (f routes) Routes(r internal.Router) { f(r) }
+
+func newApp() *internal.App {
+	return internal.New(internal.WithHandlers(routes(func(r internal.Router) {
+		r.POST("/login", func(c internal.Context) error {
+			c.SetCookie("user", c.Form("email"), 3600)
+			return c.Redirect(http.StatusSeeOther, "/dashboard")
+		})
+		r.POST("/logout", func(c internal.Context) error {
+			c.DeleteCookie("user")
+			return c.NoContent(http.StatusNoContent)
+		})
+		r.GET("/dashboard", func(c internal.Context) error {
+			user, err := c.Cookie("user")
+			if err != nil || user == "" {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+			return c.JSON(http.StatusOK, map[string]string{"user": user})
+		})
+		r.POST("/echo", func(c internal.Context) error {
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+			c.SetHeader("X-Content-Type", c.Header("Content-Type"))
+			c.SetHeader("X-Trace", c.Header("X-Trace"))
+			return c.String(http.StatusOK, string(body))
+		})
+	})))
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("follows cookies across requests", func(t *testing.T) {
+		t.Parallel()
+
+		client := forgetest.NewClient(t, newApp())
+		client.GET("/dashboard").AssertStatus(http.StatusUnauthorized)
+
+		client.POST("/login", url.Values{"email": {"alice@example.com"}}).
+			AssertStatus(http.StatusSeeOther).
+			AssertHeader("Location", "/dashboard")
+		require.Equal(t, "alice@example.com", client.Cookie("user").Value)
+
+		var got map[string]string
+		client.GET("/dashboard").AssertStatus(http.StatusOK).DecodeJSON(&got)
+		require.Equal(t, "alice@example.com", got["user"])
+
+		client.POST("/logout", nil).AssertStatus(http.StatusNoContent)
+		client.GET("/dashboard").AssertStatus(http.StatusUnauthorized)
+	})
+
+	t.Run("encodes bodies by type", func(t *testing.T) {
+		t.Parallel()
+
+		client := forgetest.NewClient(t, newApp())
+
+		res := client.POST("/echo", map[string]int{"n": 1})
+		require.Equal(t, `{"n":1}`, res.Body())
+		require.Equal(t, "application/json", res.Header().Get("X-Content-Type"))
+
+		res = client.POST("/echo", url.Values{"a": {"b"}})
+		require.Equal(t, "a=b", res.Body())
+		require.Equal(t, "application/x-www-form-urlencoded", res.Header().Get("X-Content-Type"))
+
+		res = client.POST("/echo", "raw")
+		require.Equal(t, "raw", res.Body())
+		require.Empty(t, res.Header().Get("X-Content-Type"))
+	})
+
+	t.Run("WithHeader and WithCookie apply to the copy only", func(t *testing.T) {
+		t.Parallel()
+
+		client := forgetest.NewClient(t, newApp())
+
+		client.WithHeader("X-Trace", "abc").POST("/echo", nil).AssertHeader("X-Trace", "abc")
+		client.POST("/echo", nil).AssertHeader("X-Trace", "")
+
+		authed := client.WithCookie(&http.Cookie{Name: "user", Value: "bob"})
+		authed.GET("/dashboard").AssertStatus(http.StatusOK)
+		client.GET("/dashboard").AssertStatus(http.StatusUnauthorized)
+	})
+}
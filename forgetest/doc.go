@@ -0,0 +1,43 @@
+// Package forgetest provides a test client that sends requests straight to a
+// Forge application's router, without starting a server or opening a socket.
+//
+// It lives outside the root forge package so the testing helpers never end up
+// in production builds. Import it only from _test.go files:
+//
+//	import "github.com/dmitrymomot/forge/forgetest"
+//
+//	func TestDashboard(t *testing.T) {
+//	    app := forge.New(forge.WithHandlers(handlers.NewPages(repo)))
+//	    client := forgetest.NewClient(t, app)
+//
+//	    client.POST("/login", url.Values{"email": {"alice@example.com"}}).
+//	        AssertStatus(http.StatusSeeOther)
+//
+//	    var stats handlers.Stats
+//	    client.GET("/api/stats").AssertStatus(http.StatusOK).DecodeJSON(&stats)
+//	}
+//
+// # Cookies
+//
+// Cookies set by responses are kept in a cookie jar and sent with later
+// requests, so session-based flows such as login followed by an
+// authenticated page work across calls. Requests are made to
+// https://example.com, so cookies with the Secure flag are kept as well.
+//
+// # Request Bodies
+//
+// The body passed to POST, PUT, and PATCH is encoded by type:
+//
+//   - url.Values: form-encoded (application/x-www-form-urlencoded)
+//   - string, []byte, io.Reader: sent as is, with no Content-Type
+//   - nil: no body
+//   - anything else: JSON-encoded (application/json)
+//
+// # Per-Request Headers
+//
+// WithHeader and WithCookie return a copy of the client that adds the header
+// or cookie to its requests. The copy shares the cookie jar, so session
+// state still carries over:
+//
+//	client.WithHeader("HX-Request", "true").GET("/items").AssertStatus(http.StatusOK)
+package forgetest
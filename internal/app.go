@@ -1,15 +1,18 @@
 package internal
 
 import (
-	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"runtime/debug"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/dmitrymomot/forge/pkg/cookie"
 	"github.com/dmitrymomot/forge/pkg/logger"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
 	"github.com/dmitrymomot/forge/pkg/storage"
 )
 
@@ -36,13 +39,25 @@ type App struct {
 	sessionManager          *SessionManager
 	jobEnqueuer             *JobEnqueuer
 	jobWorker               *JobManager
+	jobContextKeys          []any
+	requestTimeout          time.Duration
+	limiter                 *requestLimiter
 	storage                 storage.Storage
+	urlSigner               *signedurl.Signer
 	rolePermissions         RolePermissions
 	roleExtractor           RoleExtractorFunc
 	baseDomain              string
+	maxMultipartMemory      int64
+	json                    *jsonEncoder
+	jwtCookie               string
 	middlewares             []Middleware
+	routeMiddlewares        []Middleware
 	handlers                []Handler
 	staticRoutes            []staticRoute
+	mounts                  []mountedApp
+
+	// starting is set while the server runs startup hooks; see startupGate.
+	starting atomic.Bool
 }
 
 // staticRoute represents a static file handler mount point.
@@ -68,6 +83,8 @@ func New(opts ...Option) *App {
 		router:        chi.NewRouter(),
 		logger:        logger.NewNope(), // Default: noop logger (before options)
 		cookieManager: cookie.New(),     // Default: cookie manager (no secret)
+		json:          defaultJSON,      // Default: encoding/json defaults
+		jwtCookie:     DefaultJWTCookie,
 	}
 
 	for _, opt := range opts {
@@ -95,6 +112,23 @@ func (a *App) JobWorker() *JobManager {
 	return a.jobWorker
 }
 
+// SessionManager returns the session manager if WithSession was used, nil
+// otherwise. Code outside a request, such as an account-deletion job, uses it
+// to purge a user's sessions.
+func (a *App) SessionManager() *SessionManager {
+	return a.sessionManager
+}
+
+// InFlightRequests returns the number of requests currently being handled.
+// It is tracked only when WithMaxConcurrentRequests is configured and
+// returns 0 otherwise. Health checks and metrics can report it.
+func (a *App) InFlightRequests() int64 {
+	if a.limiter == nil {
+		return 0
+	}
+	return a.limiter.inFlight.Load()
+}
+
 // Run starts a single-domain HTTP server and blocks until shutdown.
 // This is a convenience method for the common single-app case.
 // If job workers are configured, they start automatically before serving
@@ -109,27 +143,24 @@ func (a *App) JobWorker() *JobManager {
 func (a *App) Run(addr string, opts ...RunOption) error {
 	cfg := buildRunConfig(opts...)
 
-	startupHooks := cfg.startupHooks
-	shutdownHooks := cfg.shutdownHooks
-
-	// Auto-register worker hooks if configured
-	if a.jobWorker != nil {
-		startupHooks = append([]func(context.Context) error{a.jobWorker.Manager().StartFunc()}, startupHooks...)
-		shutdownHooks = append(shutdownHooks, a.jobWorker.Shutdown())
-	}
-
-	return runServer(runtimeConfig{
-		handler:         a.router,
-		address:         addr,
-		logger:          cfg.logger,
-		shutdownTimeout: cfg.shutdownTimeout,
-		startupHooks:    startupHooks,
-		shutdownHooks:   shutdownHooks,
-		baseCtx:         cfg.baseCtx,
-	})
+	// Auto-register migration, worker, and draining hooks, including mounted apps
+	rc := lifecycle(a.appTree(), cfg)
+	rc.handler = a.router
+	rc.address = addr
+	return runServer(rc)
 }
 
 func (a *App) setupRoutes() {
+	// Last-resort panic recovery wraps everything else, including the user's
+	// middleware, so a panic never escapes to net/http
+	a.router.Use(a.adaptMiddleware(recoverPanics))
+
+	// The request deadline covers everything below: the concurrency
+	// limiter, all user middleware, and the handlers
+	if a.requestTimeout > 0 {
+		a.router.Use(a.withRequestTimeout)
+	}
+
 	// Set custom error handlers on chi router
 	if a.notFoundHandler != nil {
 		a.router.NotFound(a.wrapHandler(a.notFoundHandler))
@@ -138,6 +169,20 @@ func (a *App) setupRoutes() {
 		a.router.MethodNotAllowed(a.wrapHandler(a.methodNotAllowedHandler))
 	}
 
+	// Bound concurrent requests before any other middleware runs
+	if a.limiter != nil {
+		a.limiter.skip = a.isHealthRequest
+		a.limiter.reject = a.wrapHandler(func(c Context) error {
+			err := ErrServiceUnavailable("Server is busy, please retry later")
+			if a.errorHandler == nil {
+				http.Error(c.Response(), err.Message, err.Code)
+				return nil
+			}
+			return err
+		})
+		a.router.Use(a.limiter.middleware)
+	}
+
 	// Apply global middleware
 	for _, mw := range a.middlewares {
 		a.router.Use(a.adaptMiddleware(mw))
@@ -148,10 +193,15 @@ func (a *App) setupRoutes() {
 		a.router.Mount(sr.pattern, sr.handler)
 	}
 
+	// Mount sub-applications
+	for _, m := range a.mounts {
+		a.router.Mount(m.prefix, m.mountHandler())
+	}
+
 	// Register health check endpoints
 	if a.healthConfig != nil {
 		a.router.Get(a.healthConfig.livenessPath, livenessHandler())
-		a.router.Get(a.healthConfig.readinessPath, readinessHandler(a.healthConfig.checks))
+		a.router.Get(a.healthConfig.readinessPath, readinessHandler(a.healthConfig.checks, a.starting.Load))
 	}
 
 	// Register handlers
@@ -161,23 +211,83 @@ func (a *App) setupRoutes() {
 	}
 }
 
+// isHealthRequest reports whether r targets a health check endpoint.
+// Health checks bypass the concurrency limit so probes keep working
+// while the app is saturated.
+func (a *App) isHealthRequest(r *http.Request) bool {
+	if a.healthConfig == nil {
+		return false
+	}
+	return r.URL.Path == a.healthConfig.livenessPath || r.URL.Path == a.healthConfig.readinessPath
+}
+
 func (a *App) wrapHandler(h HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		c := newContext(w, r, a)
-		if err := h(c); err != nil {
-			a.handleError(c, err)
-		}
+		a.serve(newContext(w, r, a), h)
 	}
 }
 
+// serve runs h, passes any returned error to the error handler, and then
+// runs functions registered with Context.Defer.
+func (a *App) serve(c *requestContext, h HandlerFunc) {
+	if err := h(c); err != nil {
+		a.handleError(c, err)
+	}
+	c.runDeferred()
+}
+
+// recoverPanics converts a panic that escaped the rest of the chain into a
+// PanicError, which serve renders through the error handler as a 500.
+// middlewares.Recover, when installed, catches panics first, so this only
+// fires when it is missing or a panic happens outside of it.
+// http.ErrAbortHandler is re-raised so net/http can abort the response.
+func recoverPanics(next HandlerFunc) HandlerFunc {
+	return func(c Context) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+			stack := debug.Stack()
+			c.LogError("panic recovered", "panic", r, "stack", string(stack))
+			err = &PanicError{Value: r, Stack: stack, FormattedStack: FormatPanicStack(StackFormat{})}
+		}()
+		return next(c)
+	}
+}
+
+// handleError renders err through the error handler. Once a response has
+// started, headers can no longer change, so the error is only logged.
+//
+// A client that disconnected gets no response at all, so
+// ErrClientDisconnected reaches the error handler only when nothing was
+// written, and is otherwise dropped without logging an error.
+//
+// An *HTTPError without a request ID gets the request's, so errors built
+// with ErrNotFound and friends carry it like those from c.Error.
 func (a *App) handleError(c Context, err error) {
-	// Check if response has already been written
+	err = a.asRequestTimeout(c, err)
+	err = withRequestID(err, c.RequestID())
+	if IsClientDisconnected(err) {
+		if a.errorHandler != nil && !c.Written() {
+			_ = a.errorHandler(c, err)
+		}
+		return
+	}
 	if c.Written() {
+		c.LogError("handler returned error after writing response", "error", err)
 		return
 	}
-	if a.errorHandler != nil {
+	var te *TimeoutError
+	switch {
+	case a.errorHandler != nil:
 		_ = a.errorHandler(c, err)
-	} else {
+	case errors.As(err, &te):
+		http.Error(c.Response(), "Gateway Timeout", http.StatusGatewayTimeout)
+	default:
 		http.Error(c.Response(), "Internal Server Error", http.StatusInternalServerError)
 	}
 }
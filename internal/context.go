@@ -1,17 +1,26 @@
 package internal
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"runtime/debug"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/coder/websocket"
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5"
 
@@ -23,6 +32,7 @@ import (
 	"github.com/dmitrymomot/forge/pkg/job"
 	"github.com/dmitrymomot/forge/pkg/sanitizer"
 	"github.com/dmitrymomot/forge/pkg/session"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
 	"github.com/dmitrymomot/forge/pkg/storage"
 	"github.com/dmitrymomot/forge/pkg/validator"
 )
@@ -48,6 +58,13 @@ type LanguageKey struct{}
 // JWTClaimsKey is the context key used to store parsed JWT claims.
 type JWTClaimsKey struct{}
 
+// DefaultJWTCookie is the cookie c.SetJWTCookie writes and the JWT
+// middleware reads with WithJWTCookieFallback.
+const DefaultJWTCookie = "access_token"
+
+// RequestIDKey is the context key used to store the request ID.
+type RequestIDKey struct{}
+
 // Component is the interface for renderable templates.
 // This is compatible with templ.Component.
 type Component interface {
@@ -68,10 +85,24 @@ type Context interface {
 	// Context returns the request's context.Context.
 	Context() context.Context
 
+	// BackgroundContext returns a context carrying the request's values
+	// (request ID, tenant, trace, anything added with Set) but not its
+	// cancellation or deadline. Use it for work that must complete even if
+	// the client disconnects or the request times out: enqueueing jobs,
+	// writing audit records, or goroutines and Defer callbacks that outlive
+	// the response. Use Context for everything the response depends on, so
+	// abandoned requests stop early. Apply a timeout of your own when the
+	// work may block.
+	BackgroundContext() context.Context
+
 	// Param returns the URL parameter value by name.
 	// Returns empty string if the parameter doesn't exist.
 	Param(name string) string
 
+	// ParamOK returns the URL parameter value by name and whether the
+	// current route declares it, distinguishing absent from empty.
+	ParamOK(name string) (string, bool)
+
 	// Query returns the query parameter value by name.
 	// Returns empty string if the parameter doesn't exist.
 	Query(name string) string
@@ -85,7 +116,9 @@ type Context interface {
 	Form(name string) string
 
 	// FormFile returns the first file for the given form key.
-	// Returns the file, its header, and any error.
+	// Returns the file, its header, and any error. A multipart body over the
+	// limit set by middlewares.BodyLimit fails with a 413 *HTTPError wrapping
+	// binder.ErrUploadTooLarge.
 	FormFile(name string) (multipart.File, *multipart.FileHeader, error)
 
 	// UserID returns the authenticated user's ID from the session.
@@ -119,21 +152,91 @@ type Context interface {
 	// SetHeader sets a response header.
 	SetHeader(name, value string)
 
-	// JSON writes a JSON response with the given status code.
+	// SetLastModified sets the Last-Modified response header to t in UTC,
+	// truncated to whole seconds. A zero t is ignored.
+	SetLastModified(t time.Time)
+
+	// NotModifiedSince reports whether a GET or HEAD request's
+	// If-Modified-Since header shows the client already has the version last
+	// modified at t, so the handler can return c.NoContent(304) before
+	// rendering. It returns false when the request carries If-None-Match,
+	// which takes precedence and is checked by CheckETag.
+	NotModifiedSince(t time.Time) bool
+
+	// CheckETag sets the ETag response header and reports whether a GET or
+	// HEAD request's If-None-Match header matches it, so the handler can
+	// return c.NoContent(304) before rendering. Unquoted tags are quoted;
+	// weak tags (W/"...") compare by their opaque value.
+	CheckETag(etag string) bool
+
+	// JSON writes a JSON response with the given status code, encoded as
+	// configured with WithJSONEncoder.
 	JSON(code int, v any) error
 
+	// JSONPretty writes an indented JSON response with the given status code.
+	JSONPretty(code int, v any) error
+
+	// JSONError writes err as an RFC 7807 application/problem+json response.
+	// Status, title, detail, error code, request ID, and field errors come
+	// from the HTTPError in err's chain; when the error has no request ID,
+	// the one from RequestID is used. Any other error is logged and
+	// answered with a generic 500 so internal details are not leaked.
+	JSONError(err error) error
+
+	// ValidationResponse writes errs as JSON with the given status code:
+	//
+	//	{"error": "validation failed", "fields": {"email": ["is required"]}}
+	//
+	// Messages are translated with the configured translator first, so
+	// errors built with validator.Apply are localized like those from Bind.
+	ValidationResponse(code int, errs ValidationErrors) error
+
 	// String writes a plain text response with the given status code.
 	String(code int, s string) error
 
 	// NoContent writes a response with no body.
 	NoContent(code int) error
 
+	// Stream writes the status code and content type, then calls fn with a
+	// writer for the response body. Every write is flushed to the client when
+	// the underlying writer supports http.Flusher, so large bodies never need
+	// to be buffered in memory. Trailers set with SetTrailer inside fn are
+	// sent after the body.
+	Stream(code int, contentType string, fn func(w io.Writer) error) error
+
+	// StreamJSON writes the items received from ch as a JSON array, encoding
+	// and flushing each item as it arrives. It returns when ch is closed or
+	// the request context is canceled.
+	StreamJSON(code int, ch <-chan any) error
+
+	// Flush sends any buffered response data to the client. Flushing before
+	// the first write sends the headers. It is a no-op if the underlying
+	// writer cannot flush. Flushed responses are not shared by Coalesce.
+	Flush() error
+
+	// SetTrailer sets an HTTP trailer sent after the response body, such as
+	// a status or checksum known only once streaming ends. It may be called
+	// before or after the body is written. Trailers are sent only over
+	// HTTP/2 or chunked HTTP/1.1 responses (no Content-Length).
+	SetTrailer(name, value string)
+
+	// Trailer returns a request trailer value by name. Trailers are only
+	// available after the request body has been read to EOF.
+	Trailer(name string) string
+
 	// Redirect redirects to the given URL with the given status code.
 	// Handles both regular HTTP redirects and HTMX requests.
 	Redirect(code int, url string) error
 
+	// Upgrade performs the WebSocket handshake and hijacks the connection.
+	// The connection closes when the handler returns, the request context is
+	// canceled, or the server shuts down. When the handshake fails, including
+	// a rejected origin, Upgrade writes the error response itself.
+	Upgrade(opts ...WSOption) (*WSConn, error)
+
 	// Error creates and returns an HTTPError without writing a response.
 	// The error should be returned from the handler to trigger the error handler.
+	// The current request ID, if any, is attached unless opts set their own.
 	Error(code int, message string, opts ...HTTPErrorOption) *HTTPError
 
 	// IsHTMX returns true if the request originated from HTMX.
@@ -152,8 +255,28 @@ type Context interface {
 	// Optional render options configure HTMX response headers (only applied for HTMX requests).
 	RenderPartial(code int, fullPage, partial Component, opts ...htmx.RenderOption) error
 
+	// RenderOOB renders main followed by out-of-band fragments with HTTP 200.
+	// Every oob component must render a root element with id and hx-swap-oob
+	// attributes; the fragments are validated before anything is written.
+	// For regular requests only main is rendered.
+	RenderOOB(main Component, oob ...Component) error
+
+	// RenderFragments serves a full page and its targeted swaps from one
+	// handler. For HTMX requests whose HX-Target names a key in fragments,
+	// only that fragment is rendered with HTTP 200. Otherwise layout, which
+	// should embed every fragment, is rendered with the provided status code.
+	// Optional render options configure HTMX response headers.
+	RenderFragments(code int, layout Component, fragments map[string]Component, opts ...htmx.RenderOption) error
+
+	// RenderToString renders a component into a string without touching the
+	// response. Use it for email bodies or cached fragments.
+	RenderToString(component Component) (string, error)
+
 	// Bind binds form data, sanitizes, and validates into a struct.
-	// Returns validation errors separately from system errors.
+	// Multipart requests also populate *multipart.FileHeader and
+	// []*multipart.FileHeader fields declared with a form tag.
+	// Returns validation errors separately from system errors. An upload over
+	// the body limit fails with a 413 *HTTPError wrapping binder.ErrUploadTooLarge.
 	Bind(v any) (ValidationErrors, error)
 
 	// BindQuery binds query parameters, sanitizes, and validates into a struct.
@@ -164,9 +287,69 @@ type Context interface {
 	// Returns validation errors separately from system errors.
 	BindJSON(v any) (ValidationErrors, error)
 
+	// BindAuto picks the binder from the request, for endpoints that serve
+	// both browser forms and API clients: application/json bodies bind as
+	// with BindJSON, GET and HEAD requests and requests without a
+	// Content-Type bind as with BindQuery, and everything else binds as with
+	// Bind, which rejects unsupported media types.
+	BindAuto(v any) (ValidationErrors, error)
+
+	// BindPagination reads page, limit, sort, and order query parameters.
+	// Page and limit must be positive integers; limit is capped at the
+	// configured maximum, and page may not be so large that the offset
+	// overflows an int. Sort must be one of WithAllowedSortFields and order
+	// must be "asc" or "desc". Invalid values are returned as translated
+	// ValidationErrors.
+	BindPagination(opts ...PaginationOption) (Pagination, error)
+
+	// RawBody reads the request body once and caches it for the request.
+	// The body is replaced with a fresh reader on every call, so binders and
+	// later middleware can still read it. Use it for signature verification
+	// of webhooks. Bodies larger than binder.DefaultMaxJSONSize, or the limit
+	// set by middlewares.BodyLimit, are rejected with a 413 *HTTPError.
+	RawBody() ([]byte, error)
+
+	// BindValidated binds, sanitizes, and validates into a struct in one step.
+	// JSON requests are bound from the body; all others use form binding.
+	// Validation failures are returned as a 422 *HTTPError whose Fields hold
+	// the translated ValidationErrors, so the error handler can render them.
+	// System errors are returned wrapped, as with Bind.
+	BindValidated(v any) error
+
+	// BindMergePatch applies an RFC 7396 JSON Merge Patch body to current, a
+	// struct or non-nil pointer to struct, without modifying it; any other
+	// current is an error. merged is a pointer
+	// to a new value of the same struct type, sanitized and validated as with
+	// BindValidated. fields lists the top-level members present in the
+	// patch, sorted, so handlers can tell an absent field from one explicitly
+	// set to null, which clears it to its zero value.
+	// The body must be a JSON object sent as application/merge-patch+json
+	// or application/json; other media types get a 415 *HTTPError and
+	// malformed patches a 400 *HTTPError.
+	BindMergePatch(current any) (merged any, fields []string, err error)
+
 	// Written returns true if a response has already been written.
 	Written() bool
 
+	// Status returns the HTTP status code written to the response, or 0 if
+	// nothing has been written yet. Middleware can read it after calling
+	// next to log or count responses by status. Global middleware sees the
+	// status rendered by the error handler; route middleware runs before a
+	// returned error is rendered, so it sees 0 for that request.
+	// When c.JSON or c.String found the client gone, it is
+	// StatusClientClosedRequest (499) instead.
+	Status() int
+
+	// BytesWritten returns the number of response body bytes written so far.
+	BytesWritten() int
+
+	// Defer registers fn to run after the handler chain has finished and the
+	// response has been flushed. Functions run in registration order on the
+	// request goroutine, so they delay the end of the response; panics are
+	// recovered and logged. Use it for cheap side effects and send anything
+	// slow to the job queue instead.
+	Defer(fn func())
+
 	// Logger returns the logger for advanced usage.
 	Logger() *slog.Logger
 
@@ -190,9 +373,19 @@ type Context interface {
 	// Returns nil if the key is not found.
 	Get(key any) any
 
+	// RequestID returns the ID assigned by the RequestID middleware.
+	// Returns an empty string if the middleware is not installed.
+	RequestID() string
+
 	// Cookie returns a plain cookie value.
 	Cookie(name string) (string, error)
 
+	// Cookies returns all cookies sent with the request.
+	Cookies() []*http.Cookie
+
+	// HasCookie reports whether the request carries a cookie with the given name.
+	HasCookie(name string) bool
+
 	// SetCookie sets a plain cookie.
 	SetCookie(name, value string, maxAge int)
 
@@ -215,6 +408,22 @@ type Context interface {
 	// Returns cookie.ErrNoSecret if no secret is configured.
 	SetCookieEncrypted(name, value string, maxAge int) error
 
+	// SetCookieRaw sets a cookie with its own attributes instead of the
+	// configured ones, e.g. a custom Domain or no Max-Age. p selects whether
+	// the value is sent as-is, signed, or encrypted.
+	// Returns cookie.ErrNoSecret if p requires a secret and none is configured.
+	SetCookieRaw(ck *http.Cookie, p cookie.Protection) error
+
+	// JWTCookie returns the token from the app's JWT cookie
+	// (see WithJWTCookieName).
+	JWTCookie() (string, error)
+
+	// SetJWTCookie stores a token issued at login in the app's JWT cookie,
+	// which FromJWTCookie and the JWT middleware's cookie fallback read.
+	// The token is already signed, so the cookie is plain. Use a negative
+	// maxAge to remove it.
+	SetJWTCookie(token string, maxAge int)
+
 	// Flash reads and deletes a flash message.
 	// Returns cookie.ErrNoSecret if no secret is configured.
 	Flash(key string, dest any) error
@@ -223,6 +432,23 @@ type Context interface {
 	// Returns cookie.ErrNoSecret if no secret is configured.
 	SetFlash(key string, value any) error
 
+	// FlashSuccess queues a success message for the next Flashes call,
+	// typically on the page the response redirects to. Messages are written
+	// to a single encrypted cookie when the response starts and are dropped
+	// if no cookie secret is configured.
+	FlashSuccess(msg string)
+
+	// FlashError queues an error message; see FlashSuccess.
+	FlashError(msg string)
+
+	// FlashWarning queues a warning message; see FlashSuccess.
+	FlashWarning(msg string)
+
+	// Flashes returns and clears the flash messages set by the previous
+	// request, followed by any queued in this one. Returns an empty slice
+	// when there are none or no cookie secret is configured.
+	Flashes() ([]FlashMessage, error)
+
 	// Session returns the current session, loading or creating it as needed.
 	// Returns session.ErrNotConfigured if WithSession was not called.
 	// Returns nil, nil if no session exists and lazy loading is disabled.
@@ -234,6 +460,8 @@ type Context interface {
 
 	// AuthenticateSession associates a user with the session and rotates the token.
 	// Creates a new session if one doesn't exist.
+	// With WithSessionMaxPerUser, enforces the per-user session limit and
+	// returns session.ErrTooManySessions if the login is rejected.
 	// Returns session.ErrNotConfigured if WithSession was not called.
 	AuthenticateSession(userID string) error
 
@@ -256,11 +484,20 @@ type Context interface {
 	// Returns session.ErrNotConfigured if WithSession was not called.
 	DestroySession() error
 
+	// DestroyUserSessions deletes every session of userID on all devices and
+	// returns how many were deleted. If the current session belongs to
+	// userID, its cookie is cleared too. It uses BackgroundContext, so the
+	// purge completes even if the client disconnects.
+	// Returns session.ErrNotConfigured if WithSession was not called.
+	DestroyUserSessions(userID string) (int, error)
+
 	// ResponseWriter returns the underlying ResponseWriter for advanced usage.
 	// Returns nil if not using the wrapped response writer.
 	ResponseWriter() *ResponseWriter
 
 	// Enqueue adds a job to the queue for background processing.
+	// It uses BackgroundContext, so the enqueue is not cut short by the
+	// request's deadline or a disconnected client.
 	// Returns job.ErrNotConfigured if WithJobs was not called.
 	// Returns job.ErrUnknownTask if the task name is not registered.
 	Enqueue(name string, payload any, opts ...job.EnqueueOption) error
@@ -271,6 +508,17 @@ type Context interface {
 	// Returns job.ErrUnknownTask if the task name is not registered.
 	EnqueueTx(tx pgx.Tx, name string, payload any, opts ...job.EnqueueOption) error
 
+	// EnqueueWithID is like Enqueue but also returns the job ID, for
+	// polling progress with JobStatus.
+	EnqueueWithID(name string, payload any, opts ...job.EnqueueOption) (int64, error)
+
+	// JobStatus returns the state and last reported progress of a job.
+	// It does not check who enqueued the job, so verify that the current
+	// user owns the ID before calling it with one taken from the request.
+	// Returns job.ErrNotConfigured if WithJobs was not called.
+	// Returns job.ErrJobNotFound if no job has the given ID.
+	JobStatus(id int64) (*job.JobStatus, error)
+
 	// Storage returns the configured storage client.
 	// Returns storage.ErrNotConfigured if WithStorage was not called.
 	Storage() (storage.Storage, error)
@@ -287,10 +535,34 @@ type Context interface {
 	// Returns storage.ErrNotConfigured if WithStorage was not called.
 	DeleteFile(key string) error
 
+	// FileInfo returns a file's metadata without downloading it.
+	// Returns storage.ErrNotFound if the key does not exist.
+	// Returns storage.ErrNotConfigured if WithStorage was not called.
+	FileInfo(key string) (*storage.FileInfo, error)
+
+	// FileExists reports whether a file exists without downloading it.
+	// Returns storage.ErrNotConfigured if WithStorage was not called.
+	FileExists(key string) (bool, error)
+
 	// FileURL generates a URL for accessing the file.
 	// Returns storage.ErrNotConfigured if WithStorage was not called.
 	FileURL(key string, opts ...storage.URLOption) (string, error)
 
+	// ServeFile streams a file from storage to the response, for private
+	// files served through the app's own access checks instead of presigned
+	// URLs. Content-Type, Content-Length, Content-Disposition, and
+	// Cache-Control come from the stored metadata unless overridden by opts.
+	// A single byte range in the Range header is answered with 206 Partial
+	// Content, so media players can seek; a range past the end gets 416.
+	// Missing files return a 404 *HTTPError and forbidden ones a 403.
+	// Returns storage.ErrNotConfigured if WithStorage was not called.
+	ServeFile(key string, opts ...ServeFileOption) error
+
+	// SignedURL returns path with params, an expiry, and a signature appended,
+	// for links verified by the VerifySignedURL middleware.
+	// Returns signedurl.ErrNotConfigured if WithURLSigner was not called.
+	SignedURL(path string, expiry time.Time, params url.Values) (string, error)
+
 	// T translates a key using the Translator stored in context by the I18n middleware.
 	// Returns the key itself if no translator is in context.
 	T(key string, placeholders ...i18n.M) string
@@ -334,6 +606,8 @@ type requestContext struct {
 	// Storage
 	storage storage.Storage
 
+	urlSigner *signedurl.Signer
+
 	request        *http.Request
 	responseWriter *ResponseWriter
 	logger         *slog.Logger
@@ -344,7 +618,8 @@ type requestContext struct {
 	session        *session.Session
 
 	// Job management
-	jobEnqueuer *JobEnqueuer
+	jobEnqueuer    *JobEnqueuer
+	jobContextKeys []any
 
 	// RBAC
 	rolePermissions RolePermissions
@@ -353,11 +628,23 @@ type requestContext struct {
 
 	baseDomain string
 
+	maxMultipartMem int64
+
+	// JSON encoding settings shared by the app
+	json *jsonEncoder
+
+	jwtCookie string
+
 	roleOnce sync.Once
 
 	sessionHookOnce sync.Once
 
 	sessionLoaded bool
+
+	// Flash messages queued in this request
+	flashes       []FlashMessage
+	flashHookOnce sync.Once
+	flashesRead   bool
 }
 
 // newContext creates a new context with the response wrapper.
@@ -373,8 +660,13 @@ func newContext(w http.ResponseWriter, r *http.Request, app *App) *requestContex
 		cookieManager:   app.cookieManager,
 		sessionManager:  app.sessionManager,
 		jobEnqueuer:     app.jobEnqueuer,
+		jobContextKeys:  app.jobContextKeys,
 		storage:         app.storage,
+		urlSigner:       app.urlSigner,
 		baseDomain:      app.baseDomain,
+		maxMultipartMem: app.maxMultipartMemory,
+		json:            app.json,
+		jwtCookie:       app.jwtCookie,
 		rolePermissions: app.rolePermissions,
 		roleExtractor:   app.roleExtractor,
 	}
@@ -392,10 +684,28 @@ func (c *requestContext) Context() context.Context {
 	return c.request.Context()
 }
 
+func (c *requestContext) BackgroundContext() context.Context {
+	return context.WithoutCancel(c.request.Context())
+}
+
 func (c *requestContext) Param(name string) string {
 	return chi.URLParam(c.request, name)
 }
 
+func (c *requestContext) ParamOK(name string) (string, bool) {
+	rctx := chi.RouteContext(c.request.Context())
+	if rctx == nil {
+		return "", false
+	}
+	// Search from the end like chi.URLParam, so the innermost route wins.
+	for i := len(rctx.URLParams.Keys) - 1; i >= 0; i-- {
+		if rctx.URLParams.Keys[i] == name {
+			return rctx.URLParams.Values[i], true
+		}
+	}
+	return "", false
+}
+
 func (c *requestContext) Query(name string) string {
 	return c.request.URL.Query().Get(name)
 }
@@ -409,13 +719,45 @@ func (c *requestContext) QueryDefault(name, defaultValue string) string {
 }
 
 func (c *requestContext) Form(name string) string {
+	// FormValue ignores parse errors, so the error is dropped here too; the
+	// call only makes sure the configured memory limit is used.
+	_ = c.parseMultipartForm()
 	return c.request.FormValue(name)
 }
 
 func (c *requestContext) FormFile(name string) (multipart.File, *multipart.FileHeader, error) {
+	if err := c.parseMultipartForm(); err != nil {
+		return nil, nil, err
+	}
 	return c.request.FormFile(name)
 }
 
+// parseMultipartForm parses a multipart body with the configured memory
+// limit. Non-multipart requests are left to the caller.
+func (c *requestContext) parseMultipartForm() error {
+	if c.request.MultipartForm != nil {
+		return nil
+	}
+	err := c.request.ParseMultipartForm(c.multipartMemory())
+	switch {
+	case err == nil, errors.Is(err, http.ErrNotMultipart):
+		return nil
+	case binder.IsUploadTooLarge(err):
+		return ErrPayloadTooLarge("Upload too large",
+			WithError(fmt.Errorf("parse multipart form: %w: %w", binder.ErrUploadTooLarge, err)))
+	}
+	return fmt.Errorf("parse multipart form: %w", err)
+}
+
+// multipartMemory returns the WithMaxMultipartMemory setting, or
+// binder.DefaultMaxMemory when none is set.
+func (c *requestContext) multipartMemory() int64 {
+	if c.maxMultipartMem > 0 {
+		return c.maxMultipartMem
+	}
+	return binder.DefaultMaxMemory
+}
+
 func (c *requestContext) Deadline() (time.Time, bool) {
 	return c.request.Context().Deadline()
 }
@@ -497,17 +839,126 @@ func (c *requestContext) SetHeader(name, value string) {
 	c.response.Header().Set(name, value)
 }
 
+func (c *requestContext) SetLastModified(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	c.response.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
+
+func (c *requestContext) NotModifiedSince(t time.Time) bool {
+	if !isConditionalMethod(c.request.Method) || t.IsZero() {
+		return false
+	}
+	if c.request.Header.Get("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(c.request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have second precision, so compare at that granularity.
+	return !t.Truncate(time.Second).After(since)
+}
+
+func (c *requestContext) CheckETag(etag string) bool {
+	if etag == "" {
+		return false
+	}
+	if !strings.HasPrefix(etag, `"`) && !strings.HasPrefix(etag, `W/"`) {
+		etag = `"` + etag + `"`
+	}
+	c.response.Header().Set("ETag", etag)
+
+	if !isConditionalMethod(c.request.Method) {
+		return false
+	}
+	for candidate := range strings.SplitSeq(c.request.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isConditionalMethod reports whether a conditional GET may answer method
+// with 304 Not Modified.
+func isConditionalMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
 func (c *requestContext) JSON(code int, v any) error {
+	pretty := c.json.prettyQuery && wantsPretty(c.request.URL.Query())
+	return c.writeJSON(code, v, pretty)
+}
+
+func (c *requestContext) JSONPretty(code int, v any) error {
+	return c.writeJSON(code, v, true)
+}
+
+func (c *requestContext) writeJSON(code int, v any, pretty bool) error {
+	if err := c.clientGone(nil); err != nil {
+		return err
+	}
 	c.response.Header().Set("Content-Type", "application/json; charset=utf-8")
 	c.response.WriteHeader(code)
-	return json.NewEncoder(c.response).Encode(v)
+	return c.clientGone(c.json.encode(c.response, v, pretty))
+}
+
+// clientGone returns ErrClientDisconnected, wrapping err, when the request
+// context was canceled because the client went away. Otherwise it returns
+// err unchanged. Deadlines, such as the Timeout middleware's, are not
+// disconnects and pass through.
+func (c *requestContext) clientGone(err error) error {
+	ctxErr := c.request.Context().Err()
+	if !errors.Is(ctxErr, context.Canceled) {
+		return err
+	}
+	c.responseWriter.markDisconnected()
+	if err == nil {
+		err = ctxErr
+	}
+	return fmt.Errorf("%w: %w", ErrClientDisconnected, err)
+}
+
+func (c *requestContext) JSONError(err error) error {
+	httpErr := AsHTTPError(err)
+	if httpErr == nil {
+		c.LogError("unhandled error", "error", err)
+		httpErr = ErrInternal("An unexpected error occurred.")
+	}
+
+	c.response.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	c.response.WriteHeader(httpErr.Code)
+	p := newProblemDetails(httpErr, c.request.URL.Path)
+	if p.RequestID == "" {
+		p.RequestID = c.RequestID()
+	}
+	return c.json.encode(c.response, p, false)
+}
+
+// validationResponse is the body written by ValidationResponse.
+type validationResponse struct {
+	Error  string           `json:"error"`
+	Fields ValidationErrors `json:"fields"`
+}
+
+func (c *requestContext) ValidationResponse(code int, errs ValidationErrors) error {
+	if tr := c.translator(); tr != nil {
+		errs.Translate(tr.TranslateMessage)
+	}
+	return c.JSON(code, validationResponse{Error: "validation failed", Fields: errs})
 }
 
 func (c *requestContext) String(code int, s string) error {
+	if err := c.clientGone(nil); err != nil {
+		return err
+	}
 	c.response.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	c.response.WriteHeader(code)
 	_, err := c.response.Write([]byte(s))
-	return err
+	return c.clientGone(err)
 }
 
 func (c *requestContext) NoContent(code int) error {
@@ -515,13 +966,116 @@ func (c *requestContext) NoContent(code int) error {
 	return nil
 }
 
+func (c *requestContext) Stream(code int, contentType string, fn func(w io.Writer) error) error {
+	c.response.Header().Set("Content-Type", contentType)
+	c.response.WriteHeader(code)
+	return fn(&flushWriter{w: c.response, rc: http.NewResponseController(c.response)})
+}
+
+func (c *requestContext) StreamJSON(code int, ch <-chan any) error {
+	return c.Stream(code, "application/json; charset=utf-8", func(w io.Writer) error {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		ctx := c.request.Context()
+		sep := ""
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-ch:
+				if !ok {
+					_, err := io.WriteString(w, "]\n")
+					return err
+				}
+				b, err := json.Marshal(item)
+				if err != nil {
+					return fmt.Errorf("stream json: %w", err)
+				}
+				if _, err := w.Write(append([]byte(sep), b...)); err != nil {
+					return err
+				}
+				sep = ","
+			}
+		}
+	})
+}
+
+func (c *requestContext) Flush() error {
+	if err := http.NewResponseController(c.response).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func (c *requestContext) SetTrailer(name, value string) {
+	c.response.Header().Set(http.TrailerPrefix+name, value)
+}
+
+func (c *requestContext) Trailer(name string) string {
+	return c.request.Trailer.Get(name)
+}
+
+// flushWriter flushes the response after every write so streamed data
+// reaches the client without waiting for the handler to return.
+type flushWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if err := f.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return n, err
+	}
+	return n, nil
+}
+
 func (c *requestContext) Redirect(code int, url string) error {
 	htmx.RedirectWithStatus(c.response, c.request, url, code)
 	return nil
 }
 
+func (c *requestContext) Upgrade(opts ...WSOption) (*WSConn, error) {
+	cfg := &wsConfig{maxMessageSize: defaultWSMaxMessageSize}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	acceptOpts := &websocket.AcceptOptions{}
+	if cfg.allowOriginFunc != nil || len(cfg.allowOrigins) > 0 {
+		if origin := c.request.Header.Get("Origin"); !cfg.originAllowed(origin) {
+			http.Error(c.response, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return nil, fmt.Errorf("websocket: origin %q not allowed", origin)
+		}
+		// Origin already validated above
+		acceptOpts.InsecureSkipVerify = true
+	}
+
+	conn, err := websocket.Accept(c.response, c.request, acceptOpts)
+	if err != nil {
+		return nil, fmt.Errorf("websocket: accept: %w", err)
+	}
+	conn.SetReadLimit(cfg.maxMessageSize)
+
+	ctx, cancel := context.WithCancel(c.request.Context())
+	ws := &WSConn{
+		conn:         conn,
+		ctx:          ctx,
+		cancel:       cancel,
+		readTimeout:  cfg.readTimeout,
+		writeTimeout: cfg.writeTimeout,
+	}
+	go ws.watch(serverShutdown(c.request.Context()))
+	return ws, nil
+}
+
 func (c *requestContext) Error(code int, message string, opts ...HTTPErrorOption) *HTTPError {
 	err := NewHTTPError(code, message)
+	err.RequestID = c.RequestID()
 	for _, opt := range opts {
 		opt(err)
 	}
@@ -581,8 +1135,41 @@ func (c *requestContext) RenderPartial(code int, fullPage, partial Component, op
 	return c.Render(code, fullPage) // opts ignored for non-HTMX (graceful degradation)
 }
 
+// RenderOOB renders main followed by out-of-band fragments with HTTP 200.
+// The fragments are rendered into a buffer first so a validation error can
+// still be reported as a proper error response.
+func (c *requestContext) RenderOOB(main Component, oob ...Component) error {
+	if !htmx.IsHTMX(c.request) || len(oob) == 0 {
+		return c.Render(http.StatusOK, main)
+	}
+
+	var buf bytes.Buffer
+	if err := htmx.OOBList(oob...).Render(c.request.Context(), &buf); err != nil {
+		return err
+	}
+
+	if err := c.Render(http.StatusOK, main); err != nil {
+		return err
+	}
+	_, err := c.response.Write(buf.Bytes())
+	return err
+}
+
+// RenderFragments renders the fragment named by HX-Target, falling back to
+// the full layout when the request is not HTMX or targets an unknown id.
+func (c *requestContext) RenderFragments(code int, layout Component, fragments map[string]Component, opts ...htmx.RenderOption) error {
+	if fragment, ok := fragments[htmx.Target(c.request)]; ok && fragment != nil {
+		return c.Render(code, fragment, opts...)
+	}
+	return c.Render(code, layout, opts...)
+}
+
+func (c *requestContext) RenderToString(component Component) (string, error) {
+	return RenderComponent(c.request.Context(), component)
+}
+
 func (c *requestContext) Bind(v any) (ValidationErrors, error) {
-	return c.bindAndValidate(binder.Form(), v, "bind form")
+	return c.bindAndValidate(binder.Form(binder.WithMaxMemory(c.multipartMemory())), v, "bind form")
 }
 
 func (c *requestContext) BindQuery(v any) (ValidationErrors, error) {
@@ -590,13 +1177,154 @@ func (c *requestContext) BindQuery(v any) (ValidationErrors, error) {
 }
 
 func (c *requestContext) BindJSON(v any) (ValidationErrors, error) {
-	return c.bindAndValidate(binder.JSON(), v, "bind json")
+	return c.bindAndValidate(binder.JSON(binder.WithMaxJSONSize(c.bodyLimit())), v, "bind json")
+}
+
+func (c *requestContext) BindAuto(v any) (ValidationErrors, error) {
+	bind, label := c.contentTypeBinder(true)
+	return c.bindAndValidate(bind, v, label)
+}
+
+// contentTypeBinder picks the binder for the request's Content-Type, with
+// the label bindAndValidate wraps its errors in: JSON for application/json
+// and form binding, which rejects unsupported media types, for the rest.
+// With queryFallback, GET and HEAD requests and requests without a
+// Content-Type bind from the query string instead.
+func (c *requestContext) contentTypeBinder(queryFallback bool) (binder.Binder, string) {
+	mediaType, _, _ := mime.ParseMediaType(c.request.Header.Get("Content-Type"))
+	switch {
+	case queryFallback && (c.request.Method == http.MethodGet || c.request.Method == http.MethodHead || mediaType == ""):
+		return binder.Query(), "bind query"
+	case mediaType == "application/json":
+		return binder.JSON(binder.WithMaxJSONSize(c.bodyLimit())), "bind json"
+	default:
+		return binder.Form(binder.WithMaxMemory(c.multipartMemory())), "bind form"
+	}
+}
+
+func (c *requestContext) BindPagination(opts ...PaginationOption) (Pagination, error) {
+	p, verrs := parsePagination(c.Query, opts...)
+	if len(verrs) > 0 {
+		if tr := c.translator(); tr != nil {
+			verrs.Translate(tr.TranslateMessage)
+		}
+		return p, verrs
+	}
+	return p, nil
+}
+
+// rawBodyKey caches the raw request body in the request context so it
+// survives across middleware layers.
+type rawBodyKey struct{}
+
+// bodyLimitKey holds the request body limit set by SetBodyLimit.
+type bodyLimitKey struct{}
+
+// SetBodyLimit records the request body size limit for c. JSON binding and
+// RawBody use it in place of binder.DefaultMaxJSONSize. It does not wrap the
+// body itself; middlewares.BodyLimit does both.
+func SetBodyLimit(c Context, n int64) {
+	c.Set(bodyLimitKey{}, n)
+}
+
+// bodyLimit returns the limit recorded by SetBodyLimit, or
+// binder.DefaultMaxJSONSize when none is set.
+func (c *requestContext) bodyLimit() int64 {
+	if n, ok := c.Get(bodyLimitKey{}).(int64); ok && n > 0 {
+		return n
+	}
+	return binder.DefaultMaxJSONSize
+}
+
+func (c *requestContext) RawBody() ([]byte, error) {
+	body, cached := c.Get(rawBodyKey{}).([]byte)
+	if !cached && c.request.Body != nil {
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(c.response, c.request.Body, c.bodyLimit()))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return nil, ErrPayloadTooLarge("Request body too large",
+					WithError(fmt.Errorf("read body: %w: %w", binder.ErrBodyTooLarge, err)))
+			}
+			return nil, fmt.Errorf("read body: %w", err)
+		}
+		c.Set(rawBodyKey{}, body)
+	}
+	c.request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func (c *requestContext) BindValidated(v any) error {
+	bind, label := c.contentTypeBinder(false)
+	ve, err := c.bindAndValidate(bind, v, label)
+	if err != nil {
+		return err
+	}
+	if len(ve) > 0 {
+		return ErrUnprocessable("Validation failed", WithFields(ve), WithError(ve))
+	}
+	return nil
+}
+
+func (c *requestContext) BindMergePatch(current any) (any, []string, error) {
+	// Decoding into a fresh value makes removed members zero, which
+	// decoding into current would leave untouched.
+	out, err := newLike(current)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bind merge patch: %w", err)
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.request.Header.Get("Content-Type"))
+	if mediaType != MergePatchContentType && mediaType != "application/json" {
+		e := NewHTTPError(http.StatusUnsupportedMediaType, "Unsupported media type")
+		e.Err = fmt.Errorf("bind merge patch: %w: %q", binder.ErrUnsupportedMediaType, mediaType)
+		return nil, nil, e
+	}
+	body, err := c.RawBody()
+	if err != nil {
+		return nil, nil, err
+	}
+	patch, fields, err := decodeMergePatch(body)
+	if err != nil {
+		return nil, nil, ErrBadRequest("Malformed merge patch",
+			WithError(fmt.Errorf("bind merge patch: %w: %w", binder.ErrMalformedJSON, err)))
+	}
+	merged, err := applyMergePatch(current, patch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bind merge patch: %w", err)
+	}
+
+	bind := func(_ *http.Request, v any) error {
+		if err := json.Unmarshal(merged, v); err != nil {
+			return errors.Join(binder.ErrMalformedJSON, err)
+		}
+		return nil
+	}
+	ve, err := c.bindAndValidate(bind, out, "bind merge patch")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ve) > 0 {
+		return nil, nil, ErrUnprocessable("Validation failed", WithFields(ve), WithError(ve))
+	}
+	return out, fields, nil
 }
 
 // bindAndValidate binds request data, sanitizes, and validates into a struct.
+// Oversized and malformed JSON bodies come back as 413 and 400 HTTPErrors
+// that still wrap the binder's sentinel errors.
 func (c *requestContext) bindAndValidate(bind func(*http.Request, any) error, v any, label string) (ValidationErrors, error) {
 	if err := bind(c.request, v); err != nil {
-		return nil, fmt.Errorf("%s: %w", label, err)
+		err = fmt.Errorf("%s: %w", label, err)
+		switch {
+		case errors.Is(err, binder.ErrUploadTooLarge):
+			return nil, ErrPayloadTooLarge("Upload too large", WithError(err))
+		case errors.Is(err, binder.ErrBodyTooLarge):
+			return nil, ErrPayloadTooLarge("Request body too large", WithError(err))
+		case errors.Is(err, binder.ErrMalformedJSON):
+			return nil, ErrBadRequest("Malformed JSON request body", WithError(err))
+		}
+		return nil, err
 	}
 	if err := sanitizer.SanitizeStruct(v); err != nil {
 		return nil, fmt.Errorf("sanitize: %w", err)
@@ -618,6 +1346,53 @@ func (c *requestContext) Written() bool {
 	return c.responseWriter.Written()
 }
 
+func (c *requestContext) Status() int {
+	if c.responseWriter.disconnected() {
+		return StatusClientClosedRequest
+	}
+	if !c.responseWriter.Written() {
+		return 0
+	}
+	return c.responseWriter.Status()
+}
+
+func (c *requestContext) BytesWritten() int {
+	return int(c.responseWriter.Size())
+}
+
+func (c *requestContext) Defer(fn func()) {
+	c.responseWriter.OnAfterWrite(fn)
+}
+
+// runDeferred runs functions registered with Defer. Only the context owning
+// the outermost response writer runs them, after every layer has returned.
+func (c *requestContext) runDeferred() {
+	if c.responseWriter.root() != c.responseWriter {
+		return
+	}
+	for {
+		hooks := c.responseWriter.takeAfterWrite()
+		if len(hooks) == 0 {
+			return
+		}
+		if c.responseWriter.Written() {
+			c.responseWriter.Flush()
+		}
+		for _, fn := range hooks {
+			c.runDeferredFunc(fn)
+		}
+	}
+}
+
+func (c *requestContext) runDeferredFunc(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.LogError("deferred function panicked", "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+	fn()
+}
+
 func (c *requestContext) Logger() *slog.Logger {
 	return c.logger
 }
@@ -647,10 +1422,24 @@ func (c *requestContext) Get(key any) any {
 	return c.request.Context().Value(key)
 }
 
+func (c *requestContext) RequestID() string {
+	id, _ := c.Get(RequestIDKey{}).(string)
+	return id
+}
+
 func (c *requestContext) Cookie(name string) (string, error) {
 	return c.cookieManager.Get(c.request, name)
 }
 
+func (c *requestContext) Cookies() []*http.Cookie {
+	return c.request.Cookies()
+}
+
+func (c *requestContext) HasCookie(name string) bool {
+	_, err := c.request.Cookie(name)
+	return err == nil
+}
+
 func (c *requestContext) SetCookie(name, value string, maxAge int) {
 	c.cookieManager.Set(c.response, name, value, maxAge)
 }
@@ -675,6 +1464,18 @@ func (c *requestContext) SetCookieEncrypted(name, value string, maxAge int) erro
 	return c.cookieManager.SetEncrypted(c.response, name, value, maxAge)
 }
 
+func (c *requestContext) SetCookieRaw(ck *http.Cookie, p cookie.Protection) error {
+	return c.cookieManager.SetRaw(c.response, ck, p)
+}
+
+func (c *requestContext) JWTCookie() (string, error) {
+	return c.cookieManager.Get(c.request, c.jwtCookie)
+}
+
+func (c *requestContext) SetJWTCookie(token string, maxAge int) {
+	c.cookieManager.Set(c.response, c.jwtCookie, token, maxAge)
+}
+
 func (c *requestContext) Flash(key string, dest any) error {
 	return c.cookieManager.Flash(c.response, c.request, key, dest)
 }
@@ -683,6 +1484,61 @@ func (c *requestContext) SetFlash(key string, value any) error {
 	return c.cookieManager.SetFlash(c.response, key, value)
 }
 
+func (c *requestContext) FlashSuccess(msg string) {
+	c.addFlash(FlashLevelSuccess, msg)
+}
+
+func (c *requestContext) FlashError(msg string) {
+	c.addFlash(FlashLevelError, msg)
+}
+
+func (c *requestContext) FlashWarning(msg string) {
+	c.addFlash(FlashLevelWarning, msg)
+}
+
+// addFlash queues a message and registers the hook that writes the queue
+// to the flash cookie before the response is written.
+func (c *requestContext) addFlash(level FlashLevel, msg string) {
+	c.flashes = append(c.flashes, FlashMessage{Level: level, Message: msg})
+	if c.responseWriter == nil {
+		return
+	}
+	c.flashHookOnce.Do(func() {
+		c.responseWriter.OnBeforeWrite(func() {
+			if len(c.flashes) == 0 {
+				return
+			}
+			// Best-effort like the session hook: a missing secret means
+			// flashes are disabled, anything else is logged.
+			err := c.cookieManager.SetFlash(c.response, flashMessagesKey, c.flashes)
+			if err != nil && !errors.Is(err, cookie.ErrNoSecret) {
+				c.logger.ErrorContext(c.Context(), "failed to save flash messages", "error", err)
+			}
+		})
+	})
+}
+
+func (c *requestContext) Flashes() ([]FlashMessage, error) {
+	pending := c.flashes
+	c.flashes = nil
+	if c.flashesRead {
+		return pending, nil
+	}
+	c.flashesRead = true
+
+	var msgs []FlashMessage
+	err := c.cookieManager.Flash(c.response, c.request, flashMessagesKey, &msgs)
+	switch {
+	case err == nil, errors.Is(err, cookie.ErrNotFound), errors.Is(err, cookie.ErrNoSecret):
+		return append(msgs, pending...), nil
+	case errors.Is(err, cookie.ErrDecrypt):
+		// Flash leaves a cookie it cannot read in place; drop it so a
+		// rotated secret does not fail every later request.
+		c.cookieManager.Delete(c.response, "flash_"+flashMessagesKey)
+	}
+	return pending, err
+}
+
 // registerSessionHook registers a hook to save dirty sessions before response write.
 // Uses sync.Once to ensure the hook is registered only once per request.
 func (c *requestContext) registerSessionHook() {
@@ -748,7 +1604,8 @@ func (c *requestContext) InitSession() error {
 	return nil
 }
 
-// AuthenticateSession associates a user with the session and rotates the token.
+// AuthenticateSession associates a user with the session and rotates the token
+// and the CSRF secret.
 // Creates a new session if one doesn't exist.
 // Returns session.ErrNotConfigured if WithSession was not called.
 func (c *requestContext) AuthenticateSession(userID string) error {
@@ -761,6 +1618,19 @@ func (c *requestContext) AuthenticateSession(userID string) error {
 	if err != nil {
 		c.logger.WarnContext(c.Context(), "failed to load session", "error", err)
 	}
+
+	// Check the limit before creating anything, so a rejected login leaves no trace
+	var currentID string
+	if sess != nil {
+		currentID = sess.ID
+	}
+	if err := c.sessionManager.checkSessionLimit(c.Context(), userID, currentID); err != nil {
+		if errors.Is(err, session.ErrTooManySessions) && c.sessionManager.onAuthenticate != nil {
+			c.sessionManager.onAuthenticate(c, SessionAuthEvent{UserID: userID, Rejected: true})
+		}
+		return err
+	}
+
 	if sess == nil {
 		if err := c.InitSession(); err != nil {
 			return err
@@ -772,13 +1642,30 @@ func (c *requestContext) AuthenticateSession(userID string) error {
 	sess.UserID = &userID
 	sess.MarkDirty()
 
-	// CRITICAL: Rotate token to prevent session fixation attacks
+	// CRITICAL: Rotate token to prevent session fixation attacks. The CSRF
+	// secret goes too, so tokens issued before login stop working
+	sess.RotateCSRFSecret()
 	if err := c.sessionManager.RotateToken(c.Context(), sess); err != nil {
 		return err
 	}
 
 	// Update cookie with new token
 	c.sessionManager.SaveSession(c.response, sess)
+
+	// Eviction is best effort: the user is already signed in, so store errors
+	// are logged rather than failing the login.
+	evicted, err := c.sessionManager.trimSessions(c.Context(), userID, sess.ID)
+	if err != nil {
+		c.logger.ErrorContext(c.Context(), "failed to enforce session limit", "error", err)
+	}
+
+	if c.sessionManager.onAuthenticate != nil {
+		c.sessionManager.onAuthenticate(c, SessionAuthEvent{
+			Evicted:   evicted,
+			UserID:    userID,
+			SessionID: sess.ID,
+		})
+	}
 	return nil
 }
 
@@ -845,6 +1732,24 @@ func (c *requestContext) DestroySession() error {
 	return nil
 }
 
+func (c *requestContext) DestroyUserSessions(userID string) (int, error) {
+	if c.sessionManager == nil {
+		return 0, session.ErrNotConfigured
+	}
+
+	n, err := c.sessionManager.DeleteAllForUser(c.BackgroundContext(), userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.session != nil && c.session.UserID != nil && *c.session.UserID == userID {
+		c.sessionManager.DeleteSession(c.response)
+		c.session = nil
+		c.sessionLoaded = true
+	}
+	return n, nil
+}
+
 func (c *requestContext) ResponseWriter() *ResponseWriter {
 	return c.responseWriter
 }
@@ -853,7 +1758,8 @@ func (c *requestContext) Enqueue(name string, payload any, opts ...job.EnqueueOp
 	if c.jobEnqueuer == nil {
 		return job.ErrNotConfigured
 	}
-	return c.jobEnqueuer.Enqueue(c.Context(), name, payload, opts...)
+	// A request near its deadline must not cancel the INSERT halfway.
+	return c.jobEnqueuer.Enqueue(c.BackgroundContext(), name, payload, c.jobOptions(opts)...)
 }
 
 // EnqueueTx adds a job to the queue within a transaction.
@@ -862,7 +1768,30 @@ func (c *requestContext) EnqueueTx(tx pgx.Tx, name string, payload any, opts ...
 	if c.jobEnqueuer == nil {
 		return job.ErrNotConfigured
 	}
-	return c.jobEnqueuer.EnqueueTx(c.Context(), tx, name, payload, opts...)
+	return c.jobEnqueuer.EnqueueTx(c.Context(), tx, name, payload, c.jobOptions(opts)...)
+}
+
+func (c *requestContext) EnqueueWithID(name string, payload any, opts ...job.EnqueueOption) (int64, error) {
+	if c.jobEnqueuer == nil {
+		return 0, job.ErrNotConfigured
+	}
+	return c.jobEnqueuer.EnqueueWithID(c.BackgroundContext(), name, payload, c.jobOptions(opts)...)
+}
+
+// jobOptions prepends the capture of the propagated context keys, if any,
+// so the caller's own options still apply on top.
+func (c *requestContext) jobOptions(opts []job.EnqueueOption) []job.EnqueueOption {
+	if len(c.jobContextKeys) == 0 {
+		return opts
+	}
+	return append([]job.EnqueueOption{job.CaptureContext(c.Context(), c.jobContextKeys...)}, opts...)
+}
+
+func (c *requestContext) JobStatus(id int64) (*job.JobStatus, error) {
+	if c.jobEnqueuer == nil {
+		return nil, job.ErrNotConfigured
+	}
+	return c.jobEnqueuer.Status(c.Context(), id)
 }
 
 func (c *requestContext) Storage() (storage.Storage, error) {
@@ -893,6 +1822,20 @@ func (c *requestContext) DeleteFile(key string) error {
 	return c.storage.Delete(c.Context(), key)
 }
 
+func (c *requestContext) FileInfo(key string) (*storage.FileInfo, error) {
+	if c.storage == nil {
+		return nil, storage.ErrNotConfigured
+	}
+	return c.storage.Head(c.Context(), key)
+}
+
+func (c *requestContext) FileExists(key string) (bool, error) {
+	if c.storage == nil {
+		return false, storage.ErrNotConfigured
+	}
+	return storage.Exists(c.Context(), c.storage, key)
+}
+
 func (c *requestContext) FileURL(key string, opts ...storage.URLOption) (string, error) {
 	if c.storage == nil {
 		return "", storage.ErrNotConfigured
@@ -900,6 +1843,83 @@ func (c *requestContext) FileURL(key string, opts ...storage.URLOption) (string,
 	return c.storage.URL(c.Context(), key, opts...)
 }
 
+func (c *requestContext) ServeFile(key string, opts ...ServeFileOption) error {
+	if c.storage == nil {
+		return storage.ErrNotConfigured
+	}
+	var cfg serveFileConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	info, err := c.storage.Head(c.Context(), key)
+	if err != nil {
+		return storageHTTPError(err)
+	}
+
+	h := c.response.Header()
+	h.Set("Accept-Ranges", "bytes")
+
+	status, r := http.StatusOK, byteRange{length: info.Size}
+	// If-Range would need a validator the storage does not return, so a
+	// conditional range request always gets the whole file.
+	if header := c.request.Header.Get("Range"); header != "" && c.request.Header.Get("If-Range") == "" {
+		rng, ok, err := parseRange(header, info.Size)
+		if err != nil {
+			h.Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+			c.response.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return nil
+		}
+		if ok {
+			status, r = http.StatusPartialContent, rng
+			h.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", r.start, r.start+r.length-1, info.Size))
+		}
+	}
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h.Set("Content-Type", contentType)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Content-Length", strconv.FormatInt(r.length, 10))
+
+	cacheControl := cmp.Or(cfg.cacheControl, info.CacheControl, defaultServeFileCacheControl)
+	h.Set("Cache-Control", cacheControl)
+	if disposition := cmp.Or(cfg.disposition, info.ContentDisposition); disposition != "" {
+		h.Set("Content-Disposition", disposition)
+	}
+
+	if c.request.Method == http.MethodHead {
+		c.response.WriteHeader(status)
+		return nil
+	}
+
+	var body io.ReadCloser
+	if status == http.StatusPartialContent {
+		body, err = storage.GetRange(c.Context(), c.storage, key, r.start, r.length)
+	} else {
+		body, err = c.storage.Get(c.Context(), key)
+	}
+	if err != nil {
+		return storageHTTPError(err)
+	}
+	defer body.Close()
+
+	c.response.WriteHeader(status)
+	if _, err := io.Copy(c.response, body); err != nil {
+		return fmt.Errorf("serve file %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *requestContext) SignedURL(path string, expiry time.Time, params url.Values) (string, error) {
+	if c.urlSigner == nil {
+		return "", signedurl.ErrNotConfigured
+	}
+	return c.urlSigner.Sign(path, expiry, params), nil
+}
+
 func (c *requestContext) translator() *i18n.Translator {
 	if tr, ok := c.Get(TranslatorKey{}).(*i18n.Translator); ok {
 		return tr
@@ -0,0 +1,455 @@
+package internal_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/binder"
+)
+
+// postVia runs fn inside a POST / handler and returns the recorder.
+func postVia(t *testing.T, req *http.Request, opts []internal.Option, fn func(c internal.Context)) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := &postCaptureHandler{fn: fn}
+	opts = append(opts, internal.WithHandlers(h))
+	app := internal.New(opts...)
+
+	w := httptest.NewRecorder()
+	app.Router().ServeHTTP(w, req)
+	return w
+}
+
+func TestBindMultipart(t *testing.T) {
+	t.Parallel()
+
+	type profileForm struct {
+		Avatar *multipart.FileHeader `form:"avatar"`
+		Name   string                `form:"name" validate:"required"`
+	}
+
+	t.Run("binds text fields and files in one call", func(t *testing.T) {
+		t.Parallel()
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		require.NoError(t, mw.WriteField("name", "Jane"))
+		part, err := mw.CreateFormFile("avatar", "me.png")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("png-bytes"))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+
+		called := false
+		postVia(t, req, nil, func(c internal.Context) {
+			called = true
+
+			var form profileForm
+			verrs, err := c.Bind(&form)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+			require.Equal(t, "Jane", form.Name)
+			require.NotNil(t, form.Avatar)
+			require.Equal(t, "me.png", form.Avatar.Filename)
+			require.Equal(t, int64(len("png-bytes")), form.Avatar.Size)
+		})
+		require.True(t, called)
+	})
+
+	t.Run("text value for file field on url-encoded request is a bind error", func(t *testing.T) {
+		t.Parallel()
+
+		form := url.Values{"name": {"Jane"}, "avatar": {"me.png"}}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		called := false
+		postVia(t, req, nil, func(c internal.Context) {
+			called = true
+
+			var in profileForm
+			verrs, err := c.Bind(&in)
+			require.Nil(t, verrs)
+			require.ErrorIs(t, err, binder.ErrUnsupportedMediaType)
+		})
+		require.True(t, called)
+	})
+
+	t.Run("body size limit is reported as MaxBytesError", func(t *testing.T) {
+		t.Parallel()
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, err := mw.CreateFormFile("avatar", "big.bin")
+		require.NoError(t, err)
+		_, err = part.Write(bytes.Repeat([]byte("x"), 8192))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+
+		called := false
+		postVia(t, req, nil, func(c internal.Context) {
+			called = true
+			c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, 1024)
+
+			var in profileForm
+			_, err := c.Bind(&in)
+			var maxErr *http.MaxBytesError
+			require.True(t, errors.As(err, &maxErr))
+		})
+		require.True(t, called)
+	})
+}
+
+func TestBindValidated(t *testing.T) {
+	t.Parallel()
+
+	type signupForm struct {
+		Email string `form:"email" json:"email" validate:"required;email"`
+		Name  string `form:"name" json:"name" validate:"required"`
+	}
+
+	t.Run("valid form binds without error", func(t *testing.T) {
+		t.Parallel()
+
+		body := url.Values{"email": {"a@example.com"}, "name": {"Alice"}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var got signupForm
+		postVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.BindValidated(&got))
+		})
+		require.Equal(t, "a@example.com", got.Email)
+		require.Equal(t, "Alice", got.Name)
+	})
+
+	t.Run("valid json binds from body", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a@example.com","name":"Alice"}`))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		var got signupForm
+		postVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.BindValidated(&got))
+		})
+		require.Equal(t, "Alice", got.Name)
+	})
+
+	t.Run("validation failure returns 422 with fields", func(t *testing.T) {
+		t.Parallel()
+
+		body := url.Values{"email": {"not-an-email"}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			var form signupForm
+			err := c.BindValidated(&form)
+			require.Error(t, err)
+
+			httpErr := internal.AsHTTPError(err)
+			require.NotNil(t, httpErr)
+			require.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+			require.True(t, httpErr.Fields.Has("Email"))
+			require.True(t, httpErr.Fields.Has("Name"))
+		})
+	})
+
+	t.Run("system error is returned unwrapped from HTTPError", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+		req.Header.Set("Content-Type", "text/plain")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			var form signupForm
+			err := c.BindValidated(&form)
+			require.Error(t, err)
+			require.False(t, internal.IsHTTPError(err))
+			require.ErrorIs(t, err, binder.ErrUnsupportedMediaType)
+		})
+	})
+
+	t.Run("malformed json returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":`))
+		req.Header.Set("Content-Type", "application/json")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			var form signupForm
+			err := c.BindValidated(&form)
+			httpErr := internal.AsHTTPError(err)
+			require.NotNil(t, httpErr)
+			require.Equal(t, http.StatusBadRequest, httpErr.Code)
+			require.ErrorIs(t, err, binder.ErrMalformedJSON)
+			require.Empty(t, httpErr.Fields)
+		})
+	})
+
+	t.Run("oversized json returns 413", func(t *testing.T) {
+		t.Parallel()
+
+		body := `{"name":"` + strings.Repeat("x", binder.DefaultMaxJSONSize) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			var form signupForm
+			err := c.BindValidated(&form)
+			httpErr := internal.AsHTTPError(err)
+			require.NotNil(t, httpErr)
+			require.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+			require.ErrorIs(t, err, binder.ErrBodyTooLarge)
+		})
+	})
+
+	t.Run("body limit from context applies to json", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a@example.com","name":"Alice"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			internal.SetBodyLimit(c, 16)
+			var form signupForm
+			_, err := c.BindJSON(&form)
+			require.ErrorIs(t, err, binder.ErrBodyTooLarge)
+			require.Equal(t, http.StatusRequestEntityTooLarge, internal.AsHTTPError(err).Code)
+		})
+	})
+
+	t.Run("error handler receives 422 HTTPError", func(t *testing.T) {
+		t.Parallel()
+
+		body := url.Values{"email": {"a@example.com"}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var fields []string
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				httpErr := internal.AsHTTPError(err)
+				require.NotNil(t, httpErr)
+				fields = httpErr.Fields.Fields()
+				return c.NoContent(httpErr.Code)
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.POST("/", func(c internal.Context) error {
+					var form signupForm
+					return c.BindValidated(&form)
+				})
+			})),
+		)
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Equal(t, []string{"Name"}, fields)
+	})
+}
+
+func TestBindAuto(t *testing.T) {
+	t.Parallel()
+
+	type searchInput struct {
+		Term string `form:"term" json:"term" query:"term" validate:"required"`
+	}
+
+	t.Run("json body", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"term":"go"}`))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		var got searchInput
+		postVia(t, req, nil, func(c internal.Context) {
+			verrs, err := c.BindAuto(&got)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+		})
+		require.Equal(t, "go", got.Term)
+	})
+
+	t.Run("url-encoded form", func(t *testing.T) {
+		t.Parallel()
+
+		body := url.Values{"term": {"go"}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var got searchInput
+		postVia(t, req, nil, func(c internal.Context) {
+			verrs, err := c.BindAuto(&got)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+		})
+		require.Equal(t, "go", got.Term)
+	})
+
+	t.Run("multipart form", func(t *testing.T) {
+		t.Parallel()
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		require.NoError(t, mw.WriteField("term", "go"))
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+
+		var got searchInput
+		postVia(t, req, nil, func(c internal.Context) {
+			verrs, err := c.BindAuto(&got)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+		})
+		require.Equal(t, "go", got.Term)
+	})
+
+	t.Run("GET binds query", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/?term=go", nil)
+
+		var got searchInput
+		requestVia(t, req, nil, func(c internal.Context) {
+			verrs, err := c.BindAuto(&got)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+		})
+		require.Equal(t, "go", got.Term)
+	})
+
+	t.Run("POST without content type binds query", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/?term=go", nil)
+
+		var got searchInput
+		postVia(t, req, nil, func(c internal.Context) {
+			verrs, err := c.BindAuto(&got)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+		})
+		require.Equal(t, "go", got.Term)
+	})
+
+	t.Run("returns validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			var in searchInput
+			verrs, err := c.BindAuto(&in)
+			require.NoError(t, err)
+			require.True(t, verrs.Has("Term"))
+		})
+	})
+
+	t.Run("rejects unsupported media type", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("term=go"))
+		req.Header.Set("Content-Type", "text/plain")
+
+		postVia(t, req, nil, func(c internal.Context) {
+			var in searchInput
+			_, err := c.BindAuto(&in)
+			require.ErrorIs(t, err, binder.ErrUnsupportedMediaType)
+		})
+	})
+}
+
+func TestRawBody(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Event string `json:"event"`
+	}
+
+	t.Run("middleware read leaves body for binders", func(t *testing.T) {
+		t.Parallel()
+
+		var raw []byte
+		var got payload
+		app := internal.New(
+			internal.WithMiddleware(func(next internal.HandlerFunc) internal.HandlerFunc {
+				return func(c internal.Context) error {
+					body, err := c.RawBody()
+					require.NoError(t, err)
+					raw = body
+					return next(c)
+				}
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.POST("/", func(c internal.Context) error {
+					ve, err := c.BindJSON(&got)
+					require.NoError(t, err)
+					require.Empty(t, ve)
+
+					// Cached body is still available after binding consumed the reader
+					body, err := c.RawBody()
+					require.NoError(t, err)
+					require.Equal(t, raw, body)
+					return c.NoContent(http.StatusNoContent)
+				})
+			})),
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"event":"invoice.paid"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.JSONEq(t, `{"event":"invoice.paid"}`, string(raw))
+		require.Equal(t, "invoice.paid", got.Event)
+	})
+
+	t.Run("repeated calls return the same bytes", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+		postVia(t, req, nil, func(c internal.Context) {
+			first, err := c.RawBody()
+			require.NoError(t, err)
+			second, err := c.RawBody()
+			require.NoError(t, err)
+			require.Equal(t, "hello", string(first))
+			require.Equal(t, first, second)
+		})
+	})
+
+	t.Run("oversized body is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		body := strings.Repeat("x", binder.DefaultMaxJSONSize+1)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		postVia(t, req, nil, func(c internal.Context) {
+			_, err := c.RawBody()
+			var maxErr *http.MaxBytesError
+			require.ErrorAs(t, err, &maxErr)
+			require.ErrorIs(t, err, binder.ErrBodyTooLarge)
+			require.Equal(t, http.StatusRequestEntityTooLarge, internal.AsHTTPError(err).Code)
+		})
+	})
+}
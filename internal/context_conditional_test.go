@@ -0,0 +1,151 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestContextNotModifiedSince(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{"no header", nil, false},
+		{"same second", map[string]string{"If-Modified-Since": updated.Format(http.TimeFormat)}, true},
+		{"client newer", map[string]string{"If-Modified-Since": updated.Add(time.Hour).Format(http.TimeFormat)}, true},
+		{"client older", map[string]string{"If-Modified-Since": updated.Add(-time.Hour).Format(http.TimeFormat)}, false},
+		{"malformed date", map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"if-none-match takes precedence", map[string]string{
+			"If-Modified-Since": updated.Format(http.TimeFormat),
+			"If-None-Match":     `"v2"`,
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			var got bool
+			requestVia(t, req, nil, func(c internal.Context) {
+				got = c.NotModifiedSince(updated)
+			})
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("unsafe method is never fresh", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("If-Modified-Since", updated.Format(http.TimeFormat))
+		var got bool
+		postVia(t, req, nil, func(c internal.Context) {
+			got = c.NotModifiedSince(updated)
+		})
+		require.False(t, got)
+	})
+}
+
+func TestContextSetLastModified(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes UTC http date", func(t *testing.T) {
+		t.Parallel()
+
+		local := time.Date(2026, 3, 1, 14, 0, 0, 0, time.FixedZone("EET", 2*60*60))
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			c.SetLastModified(local)
+		})
+		require.Equal(t, "Sun, 01 Mar 2026 12:00:00 GMT", w.Header().Get("Last-Modified"))
+	})
+
+	t.Run("zero time is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			c.SetLastModified(time.Time{})
+		})
+		require.Empty(t, w.Header().Get("Last-Modified"))
+	})
+}
+
+func TestContextCheckETag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		etag        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"no header", "v1", "", false},
+		{"match quotes unquoted tag", "v1", `"v1"`, true},
+		{"mismatch", "v1", `"v2"`, false},
+		{"match in list", `"v1"`, `"v0", "v1"`, true},
+		{"wildcard", "v1", "*", true},
+		{"weak comparison", `W/"v1"`, `"v1"`, true},
+		{"weak request tag", "v1", `W/"v1"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			var got bool
+			requestVia(t, req, nil, func(c internal.Context) {
+				got = c.CheckETag(tt.etag)
+			})
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("sets etag header", func(t *testing.T) {
+		t.Parallel()
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			c.CheckETag("v1")
+		})
+		require.Equal(t, `"v1"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("handler returns 304 before rendering", func(t *testing.T) {
+		t.Parallel()
+
+		rendered := false
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				if c.CheckETag("v1") {
+					return c.NoContent(http.StatusNotModified)
+				}
+				rendered = true
+				return c.String(http.StatusOK, "page")
+			})
+		})))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", `"v1"`)
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotModified, w.Code)
+		require.Equal(t, `"v1"`, w.Header().Get("ETag"))
+		require.False(t, rendered)
+	})
+}
@@ -0,0 +1,101 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestContextDefer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs after the response in registration order", func(t *testing.T) {
+		t.Parallel()
+
+		tr := &traceRecorder{}
+		deferStep := func(name string) internal.Middleware {
+			return func(next internal.HandlerFunc) internal.HandlerFunc {
+				return func(c internal.Context) error {
+					c.Defer(func() {
+						require.True(t, c.Written())
+						tr.add("defer:" + name)
+					})
+					return next(c)
+				}
+			}
+		}
+
+		app := internal.New(
+			internal.WithMiddleware(deferStep("global")),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.Use(deferStep("use"))
+				r.GET("/", func(c internal.Context) error {
+					c.Defer(func() { tr.add("defer:handler") })
+					tr.add("handler")
+					return c.String(http.StatusOK, "ok")
+				}, deferStep("route"))
+			})),
+		)
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "ok", w.Body.String())
+		require.Equal(t, "handler,defer:global,defer:use,defer:route,defer:handler", tr.take())
+	})
+
+	t.Run("runs after the error handler", func(t *testing.T) {
+		t.Parallel()
+
+		var status int
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				c.Defer(func() {
+					status = c.Response().(*internal.ResponseWriter).Status()
+				})
+				return internal.ErrNotFound("missing")
+			})
+		})))
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Equal(t, http.StatusInternalServerError, status)
+	})
+
+	t.Run("recovers panics and keeps running", func(t *testing.T) {
+		t.Parallel()
+
+		var ran []string
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.Defer(func() { panic("boom") })
+			c.Defer(func() { ran = append(ran, "second") })
+			require.NoError(t, c.NoContent(http.StatusNoContent))
+		})
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, []string{"second"}, ran)
+	})
+
+	t.Run("runs functions deferred from deferred functions", func(t *testing.T) {
+		t.Parallel()
+
+		var ran []string
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			c.Defer(func() {
+				ran = append(ran, "outer")
+				c.Defer(func() { ran = append(ran, "inner") })
+			})
+		})
+
+		require.Equal(t, []string{"outer", "inner"}, ran)
+	})
+}
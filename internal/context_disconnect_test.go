@@ -0,0 +1,108 @@
+package internal_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/logger"
+)
+
+func TestClientDisconnected(t *testing.T) {
+	t.Parallel()
+
+	// canceledRequest returns a request whose client has already gone away.
+	canceledRequest := func() *http.Request {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	}
+
+	writers := map[string]internal.HandlerFunc{
+		"json": func(c internal.Context) error {
+			return c.JSON(http.StatusOK, map[string]string{"ok": "true"})
+		},
+		"string": func(c internal.Context) error {
+			return c.String(http.StatusOK, "ok")
+		},
+	}
+
+	for name, h := range writers {
+		t.Run(name+" returns ErrClientDisconnected and status 499", func(t *testing.T) {
+			t.Parallel()
+
+			var handlerErr error
+			var status int
+			log, capture := logger.NewTestLogger()
+			app := internal.New(
+				internal.WithCustomLogger(log),
+				internal.WithMiddleware(func(next internal.HandlerFunc) internal.HandlerFunc {
+					return func(c internal.Context) error {
+						err := next(c)
+						status = c.Status()
+						return err
+					}
+				}),
+				internal.WithHandlers(routesFunc(func(r internal.Router) {
+					r.GET("/", func(c internal.Context) error {
+						handlerErr = h(c)
+						return handlerErr
+					})
+				})),
+			)
+
+			w := serveRecorder(app.Router(), canceledRequest())
+			require.ErrorIs(t, handlerErr, internal.ErrClientDisconnected)
+			require.ErrorIs(t, handlerErr, context.Canceled)
+			require.True(t, internal.IsClientDisconnected(handlerErr))
+			require.Equal(t, internal.StatusClientClosedRequest, status)
+			require.Empty(t, w.Body.String(), "no 500 body is written for a gone client")
+			require.Empty(t, capture.Records())
+		})
+	}
+
+	t.Run("error handler receives it and can ignore it", func(t *testing.T) {
+		t.Parallel()
+
+		var got error
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				got = err
+				if internal.IsClientDisconnected(err) {
+					return nil
+				}
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", writers["json"])
+			})),
+		)
+
+		serveRecorder(app.Router(), canceledRequest())
+		require.ErrorIs(t, got, internal.ErrClientDisconnected)
+	})
+
+	t.Run("deadline is not a disconnect", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+		<-ctx.Done()
+
+		var handlerErr error
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				handlerErr = c.String(http.StatusOK, "ok")
+				return handlerErr
+			})
+		})))
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+		require.NoError(t, handlerErr)
+		require.Equal(t, "ok", w.Body.String())
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/dmitrymomot/forge/internal"
 	"github.com/dmitrymomot/forge/pkg/i18n"
+	"github.com/dmitrymomot/forge/pkg/validator"
 )
 
 func newTestI18nService(t *testing.T) *i18n.I18n {
@@ -338,6 +339,37 @@ func TestBindAutoTranslatesWithTranslator(t *testing.T) {
 		})
 	})
 
+	t.Run("translates conditional required errors keyed to dependent field", func(t *testing.T) {
+		t.Parallel()
+
+		svc, err := i18n.New(
+			i18n.WithDefaultLanguage("en"),
+			i18n.WithLanguages("en"),
+			i18n.WithTranslations("en", "common", map[string]any{
+				"validation.required_if": "{{field}} is required for {{value}}",
+			}),
+		)
+		require.NoError(t, err)
+		tr := i18n.NewTranslator(svc, "en", "common", nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/?country=DE", nil)
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(internal.TranslatorKey{}, tr)
+
+			type input struct {
+				Country   string `query:"country"`
+				VATNumber string `query:"vat_number" validate:"required_if:Country,DE,FR"`
+			}
+			var in input
+			verrs, sysErr := c.BindQuery(&in)
+			require.NoError(t, sysErr)
+			require.Len(t, verrs, 1)
+			require.Equal(t, "VATNumber", verrs[0].Field)
+			require.Equal(t, "VATNumber is required for DE, FR", verrs[0].Message)
+		})
+	})
+
 	t.Run("returns untranslated errors when no translator", func(t *testing.T) {
 		t.Parallel()
 
@@ -365,3 +397,61 @@ func TestBindAutoTranslatesWithTranslator(t *testing.T) {
 		})
 	})
 }
+
+func TestContextValidationResponse(t *testing.T) {
+	t.Parallel()
+
+	newErrs := func() validator.ValidationErrors {
+		return validator.ValidationErrors{
+			{
+				Field:             "email",
+				Message:           "email is required",
+				TranslationKey:    "validation.required",
+				TranslationValues: map[string]any{"field": "email"},
+			},
+			{Field: "email", Message: "must be a valid email"},
+			{Field: "name", Message: "is too short"},
+		}
+	}
+
+	t.Run("writes field messages with status", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.ValidationResponse(http.StatusUnprocessableEntity, newErrs()))
+		})
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.JSONEq(t, `{
+			"error": "validation failed",
+			"fields": {
+				"email": ["email is required", "must be a valid email"],
+				"name": ["is too short"]
+			}
+		}`, w.Body.String())
+	})
+
+	t.Run("translates messages", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newTestI18nService(t)
+		tr := i18n.NewTranslator(svc, "de", "common", nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(internal.TranslatorKey{}, tr)
+			require.NoError(t, c.ValidationResponse(http.StatusBadRequest, newErrs()))
+		})
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.JSONEq(t, `{
+			"error": "validation failed",
+			"fields": {
+				"email": ["email ist erforderlich", "must be a valid email"],
+				"name": ["is too short"]
+			}
+		}`, w.Body.String())
+	})
+}
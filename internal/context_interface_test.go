@@ -2,6 +2,8 @@ package internal_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/cookie"
 	"github.com/dmitrymomot/forge/pkg/session"
 )
 
@@ -199,6 +202,34 @@ func TestContextImplementsContextInterface(t *testing.T) {
 	})
 }
 
+func TestContextBackgroundContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps values after the request is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		type tenantKey struct{}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(internal.RequestIDKey{}, "req-1")
+			c.Set(tenantKey{}, "acme")
+			bg := c.BackgroundContext()
+
+			cancel()
+			require.Error(t, c.Err())
+
+			require.NoError(t, bg.Err())
+			require.Nil(t, bg.Done())
+			_, hasDeadline := bg.Deadline()
+			require.False(t, hasDeadline)
+			require.Equal(t, "req-1", bg.Value(internal.RequestIDKey{}))
+			require.Equal(t, "acme", bg.Value(tenantKey{}))
+		})
+	})
+}
+
 // --- Identity methods tests ---
 
 func TestIdentityMethods(t *testing.T) {
@@ -464,6 +495,315 @@ func TestAuthenticateSessionRotatesToken(t *testing.T) {
 	require.True(t, found, "expected __sid cookie in response")
 }
 
+func TestAuthenticateSessionRotatesCSRFSecret(t *testing.T) {
+	t.Parallel()
+
+	store := &mockSessionStore{
+		getFn: func(_ context.Context, token string) (*session.Session, error) {
+			return session.New("sess-1", token, time.Now().Add(24*time.Hour)), nil
+		},
+		updateFn: func(_ context.Context, _ *session.Session) error { return nil },
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "__sid", Value: "old-token-abc"})
+
+	requestVia(t, req, []internal.Option{internal.WithSession(store)}, func(c internal.Context) {
+		sess, err := c.Session()
+		require.NoError(t, err)
+		before := sess.CSRFToken()
+		require.True(t, sess.ValidCSRFToken(before))
+
+		require.NoError(t, c.AuthenticateSession("user-1"))
+
+		sess, err = c.Session()
+		require.NoError(t, err)
+		require.False(t, sess.ValidCSRFToken(before), "tokens issued before login must be rejected")
+		require.True(t, sess.ValidCSRFToken(sess.CSRFToken()))
+	})
+}
+
+func TestAuthenticateSessionLimit(t *testing.T) {
+	t.Parallel()
+
+	// userSessions returns n active sessions for user-1, oldest first.
+	userSessions := func(n int) []*session.Session {
+		base := time.Now().Add(-time.Hour)
+		out := make([]*session.Session, n)
+		for i := range out {
+			s := session.New(fmt.Sprintf("old-%d", i), fmt.Sprintf("tok-%d", i), time.Now().Add(time.Hour))
+			s.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			uid := "user-1"
+			s.UserID = &uid
+			out[i] = s
+		}
+		return out
+	}
+
+	t.Run("evicts oldest sessions beyond the limit", func(t *testing.T) {
+		t.Parallel()
+
+		var deleted []string
+		var event internal.SessionAuthEvent
+		existing := userSessions(3)
+		store := &mockSessionStore{
+			listByUserIDFn: func(context.Context, string) ([]*session.Session, error) {
+				return existing, nil
+			},
+			deleteFn: func(_ context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+		}
+
+		opts := []internal.Option{internal.WithSession(store,
+			internal.WithSessionMaxPerUser(2),
+			internal.WithSessionOnAuthenticate(func(_ internal.Context, e internal.SessionAuthEvent) {
+				event = e
+			}),
+		)}
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.AuthenticateSession("user-1"))
+		})
+
+		require.ElementsMatch(t, []string{"old-0", "old-1"}, deleted)
+		require.ElementsMatch(t, deleted, event.Evicted)
+		require.Equal(t, "user-1", event.UserID)
+		require.NotEmpty(t, event.SessionID)
+		require.False(t, event.Rejected)
+	})
+
+	t.Run("expired sessions are not counted", func(t *testing.T) {
+		t.Parallel()
+
+		existing := userSessions(2)
+		existing[0].ExpiresAt = time.Now().Add(-time.Minute)
+		var deleted []string
+		store := &mockSessionStore{
+			listByUserIDFn: func(context.Context, string) ([]*session.Session, error) {
+				return existing, nil
+			},
+			deleteFn: func(_ context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+		}
+
+		opts := []internal.Option{internal.WithSession(store, internal.WithSessionMaxPerUser(2))}
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.AuthenticateSession("user-1"))
+		})
+		require.Empty(t, deleted)
+	})
+
+	t.Run("concurrently deleted sessions are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockSessionStore{
+			listByUserIDFn: func(context.Context, string) ([]*session.Session, error) {
+				return userSessions(2), nil
+			},
+			deleteFn: func(context.Context, string) error {
+				return session.ErrNotFound
+			},
+		}
+
+		opts := []internal.Option{internal.WithSession(store, internal.WithSessionMaxPerUser(1))}
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.AuthenticateSession("user-1"))
+		})
+	})
+
+	t.Run("reject strategy refuses login at the limit", func(t *testing.T) {
+		t.Parallel()
+
+		created := false
+		var event internal.SessionAuthEvent
+		store := &mockSessionStore{
+			createFn: func(context.Context, *session.Session) error {
+				created = true
+				return nil
+			},
+			listByUserIDFn: func(context.Context, string) ([]*session.Session, error) {
+				return userSessions(2), nil
+			},
+		}
+
+		opts := []internal.Option{internal.WithSession(store,
+			internal.WithSessionMaxPerUser(2),
+			internal.WithSessionLimitStrategy(internal.SessionRejectNew),
+			internal.WithSessionOnAuthenticate(func(_ internal.Context, e internal.SessionAuthEvent) {
+				event = e
+			}),
+		)}
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.ErrorIs(t, c.AuthenticateSession("user-1"), session.ErrTooManySessions)
+		})
+
+		require.False(t, created, "rejected login must not create a session")
+		require.Empty(t, w.Result().Cookies())
+		require.True(t, event.Rejected)
+		require.Empty(t, event.SessionID)
+	})
+
+	t.Run("reject strategy allows login below the limit", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockSessionStore{
+			listByUserIDFn: func(context.Context, string) ([]*session.Session, error) {
+				return userSessions(1), nil
+			},
+		}
+
+		opts := []internal.Option{internal.WithSession(store,
+			internal.WithSessionMaxPerUser(2),
+			internal.WithSessionLimitStrategy(internal.SessionRejectNew),
+		)}
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.AuthenticateSession("user-1"))
+		})
+	})
+
+	t.Run("no limit does not list sessions", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockSessionStore{
+			listByUserIDFn: func(context.Context, string) ([]*session.Session, error) {
+				t.Error("ListByUserID should not be called without a limit")
+				return nil, nil
+			},
+		}
+
+		opts := []internal.Option{internal.WithSession(store)}
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.AuthenticateSession("user-1"))
+		})
+	})
+}
+
+func TestDestroyUserSessions(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(sessions []*session.Session, deleted *[]string) *mockSessionStore {
+		return &mockSessionStore{
+			getFn: func(_ context.Context, token string) (*session.Session, error) {
+				for _, s := range sessions {
+					if s.Token == token {
+						return s, nil
+					}
+				}
+				return nil, session.ErrNotFound
+			},
+			listByUserIDFn: func(_ context.Context, userID string) ([]*session.Session, error) {
+				var out []*session.Session
+				for _, s := range sessions {
+					if s.UserID != nil && *s.UserID == userID {
+						out = append(out, s)
+					}
+				}
+				return out, nil
+			},
+			deleteByUserIDFn: func(_ context.Context, userID string) error {
+				*deleted = append(*deleted, userID)
+				return nil
+			},
+		}
+	}
+	userSession := func(id, token, userID string) *session.Session {
+		s := session.New(id, token, time.Now().Add(time.Hour))
+		s.UserID = &userID
+		return s
+	}
+
+	t.Run("purges sessions, clears own cookie, and fires hook", func(t *testing.T) {
+		t.Parallel()
+
+		var deleted []string
+		var hookUser string
+		var hookCount int
+		store := newStore([]*session.Session{
+			userSession("s1", "tok-1", "user-1"),
+			userSession("s2", "tok-2", "user-1"),
+			userSession("s3", "tok-3", "user-2"),
+		}, &deleted)
+		opts := []internal.Option{internal.WithSession(store,
+			internal.WithSessionOnBulkDestroy(func(_ context.Context, userID string, count int) {
+				hookUser, hookCount = userID, count
+			}),
+		)}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "__sid", Value: "tok-1"})
+		w := requestVia(t, req, opts, func(c internal.Context) {
+			require.Equal(t, "user-1", c.UserID())
+
+			n, err := c.DestroyUserSessions("user-1")
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+			require.False(t, c.IsAuthenticated())
+		})
+
+		require.Equal(t, []string{"user-1"}, deleted)
+		require.Equal(t, "user-1", hookUser)
+		require.Equal(t, 2, hookCount)
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, -1, cookies[0].MaxAge)
+	})
+
+	t.Run("keeps current session of another user", func(t *testing.T) {
+		t.Parallel()
+
+		var deleted []string
+		store := newStore([]*session.Session{
+			userSession("admin", "tok-admin", "admin-1"),
+			userSession("s1", "tok-1", "user-1"),
+		}, &deleted)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "__sid", Value: "tok-admin"})
+		w := requestVia(t, req, []internal.Option{internal.WithSession(store)}, func(c internal.Context) {
+			require.True(t, c.IsAuthenticated())
+
+			n, err := c.DestroyUserSessions("user-1")
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+			require.Equal(t, "admin-1", c.UserID())
+		})
+
+		require.Equal(t, []string{"user-1"}, deleted)
+		require.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("store error is returned without hook", func(t *testing.T) {
+		t.Parallel()
+
+		errStore := errors.New("store down")
+		hookCalled := false
+		store := &mockSessionStore{
+			deleteByUserIDFn: func(context.Context, string) error { return errStore },
+		}
+		opts := []internal.Option{internal.WithSession(store,
+			internal.WithSessionOnBulkDestroy(func(context.Context, string, int) { hookCalled = true }),
+		)}
+
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			_, err := c.DestroyUserSessions("user-1")
+			require.ErrorIs(t, err, errStore)
+		})
+		require.False(t, hookCalled)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		t.Parallel()
+
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			_, err := c.DestroyUserSessions("user-1")
+			require.ErrorIs(t, err, session.ErrNotConfigured)
+		})
+	})
+}
+
 // --- RBAC tests ---
 
 func TestRBAC(t *testing.T) {
@@ -709,6 +1049,7 @@ type mockSessionStore struct {
 	updateFn         func(ctx context.Context, s *session.Session) error
 	deleteFn         func(ctx context.Context, id string) error
 	deleteByUserIDFn func(ctx context.Context, userID string) error
+	listByUserIDFn   func(ctx context.Context, userID string) ([]*session.Session, error)
 }
 
 func (m *mockSessionStore) Create(ctx context.Context, s *session.Session) error {
@@ -739,6 +1080,13 @@ func (m *mockSessionStore) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *mockSessionStore) ListByUserID(ctx context.Context, userID string) ([]*session.Session, error) {
+	if m.listByUserIDFn != nil {
+		return m.listByUserIDFn(ctx, userID)
+	}
+	return nil, nil
+}
+
 func (m *mockSessionStore) DeleteByUserID(ctx context.Context, userID string) error {
 	if m.deleteByUserIDFn != nil {
 		return m.deleteByUserIDFn(ctx, userID)
@@ -749,3 +1097,105 @@ func (m *mockSessionStore) DeleteByUserID(ctx context.Context, userID string) er
 func (m *mockSessionStore) Touch(ctx context.Context, id string, lastActiveAt time.Time) error {
 	return nil
 }
+
+func TestContextCookies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists request cookies", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+		req.AddCookie(&http.Cookie{Name: "consent", Value: "yes"})
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			cookies := c.Cookies()
+			require.Len(t, cookies, 2)
+			require.Equal(t, "session", cookies[0].Name)
+			require.Equal(t, "abc", cookies[0].Value)
+			require.Equal(t, "consent", cookies[1].Name)
+
+			require.True(t, c.HasCookie("consent"))
+			require.False(t, c.HasCookie("theme"))
+		})
+	})
+
+	t.Run("empty without cookies", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			require.Empty(t, c.Cookies())
+			require.False(t, c.HasCookie("session"))
+		})
+	})
+	t.Run("sets raw cookie with own attributes", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, []internal.Option{
+			internal.WithCookieOptions(cookie.WithSecret("this-is-a-32-byte-or-longer-key!")),
+		}, func(c internal.Context) {
+			require.NoError(t, c.SetCookieRaw(&http.Cookie{
+				Name:   "sid",
+				Value:  "abc",
+				Domain: "example.com",
+			}, cookie.Encrypted))
+		})
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, "sid", cookies[0].Name)
+		require.Equal(t, "example.com", cookies[0].Domain)
+		require.Zero(t, cookies[0].MaxAge)
+		require.NotEqual(t, "abc", cookies[0].Value)
+	})
+
+	t.Run("raw cookie protection requires secret", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			err := c.SetCookieRaw(&http.Cookie{Name: "sid", Value: "abc"}, cookie.Signed)
+			require.ErrorIs(t, err, cookie.ErrNoSecret)
+		})
+	})
+	t.Run("JWT cookie uses configured name", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, []internal.Option{internal.WithJWTCookieName("auth")}, func(c internal.Context) {
+			c.SetJWTCookie("token-value", 3600)
+		})
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, "auth", cookies[0].Name)
+		require.True(t, cookies[0].HttpOnly)
+
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookies[0])
+		requestVia(t, req, []internal.Option{internal.WithJWTCookieName("auth")}, func(c internal.Context) {
+			token, err := c.JWTCookie()
+			require.NoError(t, err)
+			require.Equal(t, "token-value", token)
+
+			token, ok := internal.NewExtractor(internal.FromJWTCookie()).Extract(c)
+			require.True(t, ok)
+			require.Equal(t, "token-value", token)
+		})
+	})
+
+	t.Run("JWT cookie defaults to access_token", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.SetJWTCookie("token-value", 3600)
+		})
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, internal.DefaultJWTCookie, cookies[0].Name)
+	})
+}
@@ -0,0 +1,161 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/binder"
+)
+
+func TestBindMergePatch(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+	type profile struct {
+		Nickname *string  `json:"nickname"`
+		Address  address  `json:"address"`
+		Name     string   `json:"name" validate:"required"`
+		Bio      string   `json:"bio"`
+		Tags     []string `json:"tags"`
+		ID       int64    `json:"id"`
+	}
+
+	nick := "ali"
+	current := func() *profile {
+		return &profile{
+			ID:       9007199254740993,
+			Name:     "Alice",
+			Bio:      "Gopher",
+			Nickname: &nick,
+			Tags:     []string{"go", "sql"},
+			Address:  address{City: "Berlin", Country: "DE"},
+		}
+	}
+
+	patch := func(t *testing.T, body, contentType string, cur any) (any, []string, error) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		var merged any
+		var fields []string
+		var err error
+		routesApp(func(r internal.Router) {
+			r.PATCH("/", func(c internal.Context) error {
+				merged, fields, err = c.BindMergePatch(cur)
+				return nil
+			})
+		}).ServeHTTP(httptest.NewRecorder(), req)
+		return merged, fields, err
+	}
+
+	t.Run("absent fields keep their values", func(t *testing.T) {
+		t.Parallel()
+
+		cur := current()
+		merged, fields, err := patch(t, `{"bio":"Rustacean"}`, internal.MergePatchContentType, cur)
+		require.NoError(t, err)
+		require.Equal(t, []string{"bio"}, fields)
+
+		got := merged.(*profile)
+		require.Equal(t, "Rustacean", got.Bio)
+		require.Equal(t, "Alice", got.Name)
+		require.Equal(t, int64(9007199254740993), got.ID)
+		require.Equal(t, []string{"go", "sql"}, got.Tags)
+		require.Equal(t, "Gopher", cur.Bio, "current must not be modified")
+	})
+
+	t.Run("explicit null clears the field", func(t *testing.T) {
+		t.Parallel()
+
+		merged, fields, err := patch(t, `{"bio":null,"nickname":null,"tags":null}`, internal.MergePatchContentType, current())
+		require.NoError(t, err)
+		require.Equal(t, []string{"bio", "nickname", "tags"}, fields)
+
+		got := merged.(*profile)
+		require.Empty(t, got.Bio)
+		require.Nil(t, got.Nickname)
+		require.Nil(t, got.Tags)
+		require.Equal(t, "Alice", got.Name)
+	})
+
+	t.Run("nested objects merge and arrays replace", func(t *testing.T) {
+		t.Parallel()
+
+		merged, fields, err := patch(t, `{"address":{"city":"Paris","country":null},"tags":["rust"]}`, "application/json", current())
+		require.NoError(t, err)
+		require.Equal(t, []string{"address", "tags"}, fields)
+
+		got := merged.(*profile)
+		require.Equal(t, address{City: "Paris"}, got.Address)
+		require.Equal(t, []string{"rust"}, got.Tags)
+	})
+
+	t.Run("value current returns a pointer", func(t *testing.T) {
+		t.Parallel()
+
+		merged, _, err := patch(t, `{}`, internal.MergePatchContentType, *current())
+		require.NoError(t, err)
+		require.Equal(t, current(), merged)
+	})
+
+	t.Run("validation runs on the merged value", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := patch(t, `{"name":null}`, internal.MergePatchContentType, current())
+		var httpErr *internal.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		require.NotEmpty(t, httpErr.Fields)
+	})
+
+	t.Run("wrong member type is a bad request", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := patch(t, `{"name":42}`, internal.MergePatchContentType, current())
+		var httpErr *internal.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.ErrorIs(t, err, binder.ErrMalformedJSON)
+	})
+
+	t.Run("rejects malformed and non-object patches", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{`{"name":`, `["name"]`, `null`, `{"a":1} {"b":2}`} {
+			_, _, err := patch(t, body, internal.MergePatchContentType, current())
+			var httpErr *internal.HTTPError
+			require.ErrorAs(t, err, &httpErr, body)
+			require.Equal(t, http.StatusBadRequest, httpErr.Code, body)
+			require.ErrorIs(t, err, binder.ErrMalformedJSON, body)
+		}
+	})
+
+	t.Run("rejects targets that are not structs", func(t *testing.T) {
+		t.Parallel()
+
+		var nilProfile *profile
+		for name, cur := range map[string]any{"nil": nil, "nil pointer": nilProfile, "map": map[string]any{}} {
+			merged, _, err := patch(t, `{"bio":"x"}`, internal.MergePatchContentType, cur)
+			require.Error(t, err, name)
+			require.Nil(t, merged, name)
+		}
+	})
+
+	t.Run("rejects other media types", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := patch(t, `{"bio":"x"}`, "application/json-patch+json", current())
+		var httpErr *internal.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusUnsupportedMediaType, httpErr.Code)
+		require.ErrorIs(t, err, binder.ErrUnsupportedMediaType)
+	})
+}
@@ -0,0 +1,49 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
+)
+
+func TestContextSignedURL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signs with configured signer", func(t *testing.T) {
+		t.Parallel()
+
+		signer, err := signedurl.New("test-secret-key-at-least-32-bytes!")
+		require.NoError(t, err)
+
+		var raw string
+		var signErr error
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil),
+			[]internal.Option{internal.WithURLSigner(signer)},
+			func(c internal.Context) {
+				raw, signErr = c.SignedURL("/magic", time.Now().Add(time.Hour), url.Values{"email": {"a@example.com"}})
+			})
+		require.NoError(t, signErr)
+
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		require.Equal(t, "/magic", u.Path)
+		require.NoError(t, signer.Verify(u.Path, u.Query()))
+	})
+
+	t.Run("returns ErrNotConfigured without signer", func(t *testing.T) {
+		t.Parallel()
+
+		var signErr error
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			_, signErr = c.SignedURL("/magic", time.Now().Add(time.Hour), nil)
+		})
+		require.ErrorIs(t, signErr, signedurl.ErrNotConfigured)
+	})
+}
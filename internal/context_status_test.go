@@ -0,0 +1,108 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestContextStatus(t *testing.T) {
+	t.Parallel()
+
+	// capture records the status and size seen by a global middleware after
+	// the rest of the chain has run.
+	capture := func(status, size *int) internal.Middleware {
+		return func(next internal.HandlerFunc) internal.HandlerFunc {
+			return func(c internal.Context) error {
+				require.Zero(t, c.Status())
+				require.Zero(t, c.BytesWritten())
+				err := next(c)
+				*status = c.Status()
+				*size = c.BytesWritten()
+				return err
+			}
+		}
+	}
+
+	t.Run("reflects c.JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var status, size int
+		app := internal.New(
+			internal.WithMiddleware(capture(&status, &size)),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.POST("/items", func(c internal.Context) error {
+					return c.JSON(http.StatusCreated, map[string]string{"id": "1"})
+				})
+			})),
+		)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodPost, "/items", nil))
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, http.StatusCreated, status)
+		require.Equal(t, w.Body.Len(), size)
+	})
+
+	t.Run("reflects c.String", func(t *testing.T) {
+		t.Parallel()
+
+		var status, size int
+		app := internal.New(
+			internal.WithMiddleware(capture(&status, &size)),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					return c.String(http.StatusAccepted, "queued")
+				})
+			})),
+		)
+
+		serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusAccepted, status)
+		require.Equal(t, len("queued"), size)
+	})
+
+	t.Run("reflects the error handler", func(t *testing.T) {
+		t.Parallel()
+
+		var status, size int
+		app := internal.New(
+			internal.WithMiddleware(capture(&status, &size)),
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				return c.String(http.StatusTeapot, err.Error())
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					return internal.ErrNotFound("missing")
+				})
+			})),
+		)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusTeapot, w.Code)
+		require.Equal(t, http.StatusTeapot, status)
+		require.Equal(t, w.Body.Len(), size)
+	})
+
+	t.Run("zero when nothing is written", func(t *testing.T) {
+		t.Parallel()
+
+		status, size := -1, -1
+		app := internal.New(
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					status = c.Status()
+					size = c.BytesWritten()
+					return nil
+				})
+			})),
+		)
+
+		serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Zero(t, status)
+		require.Zero(t, size)
+	})
+}
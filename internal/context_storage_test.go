@@ -20,6 +20,7 @@ type mockStorage struct {
 	putFn    func(ctx context.Context, r io.Reader, size int64, opts ...storage.Option) (*storage.FileInfo, error)
 	getFn    func(ctx context.Context, key string) (io.ReadCloser, error)
 	deleteFn func(ctx context.Context, key string) error
+	headFn   func(ctx context.Context, key string) (*storage.FileInfo, error)
 	urlFn    func(ctx context.Context, key string, opts ...storage.URLOption) (string, error)
 }
 
@@ -44,6 +45,21 @@ func (m *mockStorage) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (m *mockStorage) Head(ctx context.Context, key string) (*storage.FileInfo, error) {
+	if m.headFn != nil {
+		return m.headFn(ctx, key)
+	}
+	return &storage.FileInfo{Key: key}, nil
+}
+
+func (m *mockStorage) GetTags(ctx context.Context, key string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (m *mockStorage) SetTags(ctx context.Context, key string, tags map[string]string) error {
+	return nil
+}
+
 func (m *mockStorage) URL(ctx context.Context, key string, opts ...storage.URLOption) (string, error) {
 	if m.urlFn != nil {
 		return m.urlFn(ctx, key, opts...)
@@ -95,6 +111,26 @@ func TestStorageNotConfigured(t *testing.T) {
 		})
 	})
 
+	t.Run("FileInfo returns error when not configured", func(t *testing.T) {
+		t.Parallel()
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			info, err := c.FileInfo("test-key")
+			require.Nil(t, info)
+			require.ErrorIs(t, err, storage.ErrNotConfigured)
+		})
+	})
+
+	t.Run("FileExists returns error when not configured", func(t *testing.T) {
+		t.Parallel()
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			ok, err := c.FileExists("test-key")
+			require.False(t, ok)
+			require.ErrorIs(t, err, storage.ErrNotConfigured)
+		})
+	})
+
 	t.Run("FileURL returns error when not configured", func(t *testing.T) {
 		t.Parallel()
 
@@ -161,6 +197,28 @@ func TestStorageConfigured(t *testing.T) {
 		})
 	})
 
+	t.Run("FileInfo delegates to storage", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, opts, func(c internal.Context) {
+			info, err := c.FileInfo("test-key")
+			require.NoError(t, err)
+			require.Equal(t, "test-key", info.Key)
+		})
+	})
+
+	t.Run("FileExists delegates to storage", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, opts, func(c internal.Context) {
+			ok, err := c.FileExists("test-key")
+			require.NoError(t, err)
+			require.True(t, ok)
+		})
+	})
+
 	t.Run("FileURL delegates to storage", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,189 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestContextStream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes status content type and body", func(t *testing.T) {
+		t.Parallel()
+
+		var written bool
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			err := c.Stream(http.StatusAccepted, "text/csv", func(w io.Writer) error {
+				written = c.Written()
+				_, err := io.WriteString(w, "id,name\n")
+				if err != nil {
+					return err
+				}
+				_, err = io.WriteString(w, "1,alice\n")
+				return err
+			})
+			require.NoError(t, err)
+			require.True(t, c.Written())
+		})
+
+		require.True(t, written)
+		require.Equal(t, http.StatusAccepted, w.Code)
+		require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		require.Equal(t, "id,name\n1,alice\n", w.Body.String())
+		require.True(t, w.Flushed)
+	})
+
+	t.Run("returns callback error", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("boom")
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			err := c.Stream(http.StatusOK, "text/plain", func(io.Writer) error { return boom })
+			require.ErrorIs(t, err, boom)
+		})
+	})
+}
+
+func TestContextStreamJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encodes channel as json array", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan any, 3)
+		ch <- map[string]int{"id": 1}
+		ch <- "two"
+		ch <- 3
+		close(ch)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.StreamJSON(http.StatusOK, ch))
+		})
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.JSONEq(t, `[{"id":1},"two",3]`, w.Body.String())
+	})
+
+	t.Run("empty channel writes empty array", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan any)
+		close(ch)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.StreamJSON(http.StatusOK, ch))
+		})
+
+		require.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("stops when request is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		requestVia(t, req, nil, func(c internal.Context) {
+			err := c.StreamJSON(http.StatusOK, make(chan any))
+			require.ErrorIs(t, err, context.Canceled)
+		})
+	})
+
+	t.Run("returns encoding error", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan any, 1)
+		ch <- make(chan int)
+		close(ch)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			require.Error(t, c.StreamJSON(http.StatusOK, ch))
+		})
+	})
+}
+
+func TestContextFlushAndTrailers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flush sends headers and body written so far", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.SetHeader("Content-Type", "text/event-stream")
+			require.NoError(t, c.Flush())
+			require.True(t, c.Written())
+
+			_, err := io.WriteString(c.Response(), "data: hello\n\n")
+			require.NoError(t, err)
+			require.NoError(t, c.Flush())
+		})
+
+		require.True(t, w.Flushed)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		require.Equal(t, "data: hello\n\n", w.Body.String())
+	})
+
+	t.Run("sets trailers after streaming", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(routesApp(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				return c.Stream(http.StatusOK, "text/plain", func(w io.Writer) error {
+					if _, err := io.WriteString(w, "rows"); err != nil {
+						return err
+					}
+					c.SetTrailer("X-Export-Status", "complete")
+					return nil
+				})
+			})
+		}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "rows", string(body))
+		require.Equal(t, "complete", resp.Trailer.Get("X-Export-Status"))
+	})
+
+	t.Run("reads request trailers", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Trailer = http.Header{"X-Checksum": {"abc"}}
+		srv := routesApp(func(r internal.Router) {
+			r.POST("/", func(c internal.Context) error {
+				return c.String(http.StatusOK, c.Trailer("X-Checksum"))
+			})
+		})
+
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		require.Equal(t, "abc", w.Body.String())
+	})
+}
+
+// routesApp returns the router of an app with the given routes.
+func routesApp(fn func(r internal.Router)) http.Handler {
+	return internal.New(internal.WithHandlers(routesFunc(fn))).Router()
+}
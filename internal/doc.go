@@ -33,6 +33,15 @@
 //	    return c.JSON(200, user)
 //	}
 //
+// c is canceled when the client disconnects or the request times out, which
+// is what reads and renders should follow. Work that must finish regardless,
+// such as enqueueing a job or a goroutine started from the handler, should use
+// c.BackgroundContext(): it keeps the request's values, so log extractors still
+// find the request ID, but drops the cancellation and deadline. c.Enqueue
+// uses it already:
+//
+//	go h.audit.Record(c.BackgroundContext(), "invoice.sent", invoiceID)
+//
 // # Application Structure
 //
 // Create an application with New() and configure it using options:
@@ -163,6 +172,13 @@
 //	    return c.Error(http.StatusInternalServerError, "internal server error")
 //	}
 //
+// JSON APIs can delegate to JSONError, which renders any error as an
+// RFC 7807 problem+json body and hides details of non-HTTPError errors:
+//
+//	func apiErrorHandler(c internal.Context, err error) error {
+//	    return c.JSONError(err)
+//	}
+//
 // # Server Runtime
 //
 // Start the server with Run() or use Run() for multi-domain deployments:
@@ -182,14 +198,16 @@
 // The Context provides helpers for common request patterns:
 //   - JSON encoding/decoding
 //   - Form binding with validation and sanitization
-//   - Cookie management (plain, signed, encrypted, flash)
+//   - Cookie management (plain, signed, encrypted, flash, typed flash messages)
 //   - Session management (load, create, authenticate, destroy)
 //   - Identity shortcuts (UserID, IsAuthenticated, IsCurrentUser)
 //   - Role-based access control (Can with lazy role extraction)
 //   - Standard library context.Context compatibility
 //   - HTMX-aware response rendering
 //   - File upload/download with configurable storage
+//   - Streaming private files with range requests (ServeFile)
 //   - Background job enqueueing
+//   - WebSocket upgrades tied to the request lifetime
 //   - Structured logging with request-scoped values
 //   - Domain and subdomain extraction
 //   - Custom context values
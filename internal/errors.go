@@ -2,7 +2,13 @@ package internal
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // HTTPError represents an HTTP error with all data needed for rendering.
@@ -27,6 +33,9 @@ type HTTPError struct {
 	// RequestID is the request tracking ID.
 	RequestID string
 
+	// Fields holds field-level validation errors, if any.
+	Fields ValidationErrors
+
 	// Code is the HTTP status code (e.g., 404, 500).
 	Code int
 }
@@ -47,6 +56,42 @@ func (e *HTTPError) StatusText() string {
 	return http.StatusText(e.Code)
 }
 
+// problemDetails is an RFC 7807 problem+json response body.
+// Code, RequestID, and Errors are extension members.
+type problemDetails struct {
+	Errors    map[string][]string `json:"errors,omitempty"`
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	Code      string              `json:"code,omitempty"`
+	RequestID string              `json:"request_id,omitempty"`
+	Status    int                 `json:"status"`
+}
+
+// newProblemDetails builds a problem body from e for the given request path.
+func newProblemDetails(e *HTTPError, instance string) *problemDetails {
+	p := &problemDetails{
+		Type:      "about:blank",
+		Title:     e.Title,
+		Detail:    e.Detail,
+		Instance:  instance,
+		Code:      e.ErrorCode,
+		RequestID: e.RequestID,
+		Status:    e.Code,
+	}
+	if p.Title == "" {
+		p.Title = e.StatusText()
+	}
+	if p.Detail == "" {
+		p.Detail = e.Message
+	}
+	if len(e.Fields) > 0 {
+		p.Errors = e.Fields.AsMap()
+	}
+	return p
+}
+
 // HTTPErrorOption configures an HTTPError.
 type HTTPErrorOption func(*HTTPError)
 
@@ -88,6 +133,12 @@ func WithError(err error) HTTPErrorOption {
 	}
 }
 
+func WithFields(fields ValidationErrors) HTTPErrorOption {
+	return func(e *HTTPError) {
+		e.Fields = fields
+	}
+}
+
 // Convenience constructors for common HTTP errors.
 
 func ErrBadRequest(message string, opts ...HTTPErrorOption) *HTTPError {
@@ -130,6 +181,14 @@ func ErrConflict(message string, opts ...HTTPErrorOption) *HTTPError {
 	return e
 }
 
+func ErrPayloadTooLarge(message string, opts ...HTTPErrorOption) *HTTPError {
+	e := NewHTTPError(http.StatusRequestEntityTooLarge, message)
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
 func ErrUnprocessable(message string, opts ...HTTPErrorOption) *HTTPError {
 	e := NewHTTPError(http.StatusUnprocessableEntity, message)
 	for _, opt := range opts {
@@ -168,3 +227,167 @@ func AsHTTPError(err error) *HTTPError {
 	}
 	return nil
 }
+
+// withRequestID returns err with its *HTTPError carrying id, unless it
+// already has a request ID. The *HTTPError is copied rather than modified,
+// since handlers may return one shared across requests, such as a
+// package-level variable.
+func withRequestID(err error, id string) error {
+	httpErr := AsHTTPError(err)
+	if httpErr == nil || httpErr.RequestID != "" || id == "" {
+		return err
+	}
+	stamped := *httpErr
+	stamped.RequestID = id
+	if e, ok := err.(*HTTPError); ok && e == httpErr {
+		return &stamped
+	}
+	return &requestIDError{httpErr: &stamped, err: err}
+}
+
+// requestIDError pairs a request's copy of a wrapped *HTTPError with the
+// original error, so errors.As finds the copy and errors.Is still sees the
+// whole chain.
+type requestIDError struct {
+	httpErr *HTTPError
+	err     error
+}
+
+func (e *requestIDError) Error() string   { return e.err.Error() }
+func (e *requestIDError) Unwrap() []error { return []error{e.httpErr, e.err} }
+
+// StatusClientClosedRequest is the non-standard status Context.Status
+// reports when the client disconnected before the response was written,
+// following nginx. It is never sent to the client.
+const StatusClientClosedRequest = 499
+
+// ErrClientDisconnected is returned by c.JSON, c.JSONPretty, and c.String
+// when the client closed the connection before or during the write. The
+// App does not render or log it as a server error; a custom ErrorHandler
+// receives it only when nothing was written and can ignore it.
+var ErrClientDisconnected = errors.New("client disconnected")
+
+// IsClientDisconnected returns true if the error is ErrClientDisconnected.
+func IsClientDisconnected(err error) bool {
+	return errors.Is(err, ErrClientDisconnected)
+}
+
+// PanicError represents a recovered panic. It is returned by
+// middlewares.Recover and by the App's built-in safety net.
+type PanicError struct {
+	Value          any    // The panic value
+	Stack          []byte // Raw stack trace from runtime.Stack (nil if disabled)
+	FormattedStack string // Frames from the panic site down, see FormatPanicStack (empty if disabled)
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// TimeoutError represents a request timeout. It is returned by
+// middlewares.Timeout and, when WithRequestTimeout is set, by the App for
+// handlers that fail because the request deadline passed.
+type TimeoutError struct {
+	Err      error         // The error the handler returned, if any
+	Duration time.Duration // The timeout that was exceeded
+}
+
+// Error implements the error interface.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("request timeout after %s", e.Duration)
+}
+
+// Unwrap returns the error the handler returned.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultNotFoundHandler returns a 404 handler that negotiates on the Accept
+// header: browsers get a minimal HTML page, other clients a problem+json body.
+func DefaultNotFoundHandler() HandlerFunc {
+	return negotiatedStatusHandler(http.StatusNotFound, "The requested resource was not found.")
+}
+
+// DefaultMethodNotAllowedHandler returns a 405 handler that negotiates on the
+// Accept header like DefaultNotFoundHandler.
+func DefaultMethodNotAllowedHandler() HandlerFunc {
+	return negotiatedStatusHandler(http.StatusMethodNotAllowed, "The request method is not supported for this resource.")
+}
+
+// negotiatedStatusHandler responds with code as HTML when the client accepts
+// text/html and as problem+json with message as the detail otherwise.
+func negotiatedStatusHandler(code int, message string) HandlerFunc {
+	text := http.StatusText(code)
+	page := fmt.Sprintf("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%d %s</title></head>"+
+		"<body><h1>%d %s</h1></body></html>\n", code, text, code, text)
+
+	return func(c Context) error {
+		if acceptsHTML(c.Request()) {
+			c.SetHeader("Content-Type", "text/html; charset=utf-8")
+			c.Response().WriteHeader(code)
+			_, err := io.WriteString(c.Response(), page)
+			return err
+		}
+		return c.JSONError(NewHTTPError(code, message))
+	}
+}
+
+// acceptsHTML reports whether the Accept header prefers text/html over JSON.
+// Quality values count, so "application/json, text/html;q=0.1" gets JSON
+// and "text/html;q=0" never gets HTML. On a tie HTML wins only if text/html
+// is listed by name, so a bare "*/*" gets JSON.
+func acceptsHTML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	htmlQ, named := acceptQuality(accept, "text/html")
+	jsonQ, _ := acceptQuality(accept, "application/json", "application/problem+json")
+	return htmlQ > jsonQ || (htmlQ > 0 && htmlQ == jsonQ && named)
+}
+
+// acceptQuality returns the quality the Accept header gives the best of
+// types, taken from the most specific media range matching each, and
+// whether one of them is listed by name. Malformed ranges are ignored.
+func acceptQuality(accept string, types ...string) (q float64, named bool) {
+	best := 0 // specificity of the range q was taken from
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		rangeQ := 1.0
+		if v, ok := params["q"]; ok {
+			if rangeQ, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		for _, t := range types {
+			spec := mediaRangeSpecificity(mediaType, t)
+			if spec == 0 {
+				continue
+			}
+			if spec > best || (spec == best && rangeQ > q) {
+				best, q = spec, rangeQ
+			}
+			if spec == 3 {
+				named = true
+			}
+		}
+	}
+	return q, named
+}
+
+// mediaRangeSpecificity reports how closely the media range rng matches
+// mediaType: 3 for the type itself, 2 for "type/*", 1 for "*/*", and 0 if
+// it does not match.
+func mediaRangeSpecificity(rng, mediaType string) int {
+	major, _, _ := strings.Cut(mediaType, "/")
+	switch rng {
+	case mediaType:
+		return 3
+	case major + "/*":
+		return 2
+	case "*/*":
+		return 1
+	}
+	return 0
+}
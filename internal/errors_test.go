@@ -1,9 +1,14 @@
 package internal_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -84,3 +89,412 @@ func TestAsHTTPError(t *testing.T) {
 		require.Nil(t, internal.AsHTTPError(nil))
 	})
 }
+
+func TestContextJSONError(t *testing.T) {
+	t.Parallel()
+
+	decode := func(t *testing.T, body string) map[string]any {
+		t.Helper()
+		var got map[string]any
+		require.NoError(t, json.Unmarshal([]byte(body), &got))
+		return got
+	}
+
+	t.Run("renders HTTPError as problem json", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/?token=secret", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			err := internal.ErrNotFound("user not found",
+				internal.WithErrorCode("user.not_found"),
+				internal.WithRequestID("req-1"),
+			)
+			require.NoError(t, c.JSONError(fmt.Errorf("load user: %w", err)))
+		})
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, map[string]any{
+			"type":       "about:blank",
+			"title":      "Not Found",
+			"status":     float64(404),
+			"detail":     "user not found",
+			"instance":   "/",
+			"code":       "user.not_found",
+			"request_id": "req-1",
+		}, decode(t, w.Body.String()))
+	})
+
+	t.Run("prefers explicit title and detail", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			err := internal.ErrConflict("conflict",
+				internal.WithTitle("Email taken"),
+				internal.WithDetail("An account with this email already exists."),
+			)
+			require.NoError(t, c.JSONError(err))
+		})
+
+		got := decode(t, w.Body.String())
+		require.Equal(t, "Email taken", got["title"])
+		require.Equal(t, "An account with this email already exists.", got["detail"])
+	})
+
+	t.Run("includes field errors", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			fields := internal.ValidationErrors{
+				{Field: "email", Message: "is required"},
+				{Field: "email", Message: "must be an email"},
+			}
+			require.NoError(t, c.JSONError(internal.ErrUnprocessable("Validation failed", internal.WithFields(fields))))
+		})
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		got := decode(t, w.Body.String())
+		require.Equal(t, map[string]any{
+			"email": []any{"is required", "must be an email"},
+		}, got["errors"])
+	})
+
+	t.Run("falls back to context request ID", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(internal.RequestIDKey{}, "req-ctx")
+			require.Equal(t, "req-ctx", c.RequestID())
+			require.NoError(t, c.JSONError(internal.ErrNotFound("missing")))
+		})
+
+		require.Equal(t, "req-ctx", decode(t, w.Body.String())["request_id"])
+	})
+
+	t.Run("keeps explicit request ID", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(internal.RequestIDKey{}, "req-ctx")
+			require.NoError(t, c.JSONError(internal.ErrNotFound("missing", internal.WithRequestID("req-own"))))
+		})
+
+		require.Equal(t, "req-own", decode(t, w.Body.String())["request_id"])
+	})
+
+	t.Run("omits request ID when none is set", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.Empty(t, c.RequestID())
+			require.NoError(t, c.JSONError(internal.ErrNotFound("missing")))
+		})
+
+		require.NotContains(t, decode(t, w.Body.String()), "request_id")
+	})
+
+	t.Run("Error attaches context request ID", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(internal.RequestIDKey{}, "req-ctx")
+			require.Equal(t, "req-ctx", c.Error(http.StatusConflict, "taken").RequestID)
+			require.Equal(t, "req-own", c.Error(http.StatusConflict, "taken", internal.WithRequestID("req-own")).RequestID)
+		})
+	})
+
+	t.Run("returned errors get the request ID", func(t *testing.T) {
+		t.Parallel()
+
+		var got *internal.HTTPError
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				got = internal.AsHTTPError(err)
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					return internal.ErrNotFound("missing")
+				})
+			})),
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), internal.RequestIDKey{}, "req-ctx"))
+		w := serveRecorder(app.Router(), req)
+
+		require.NotNil(t, got)
+		require.Equal(t, "req-ctx", got.RequestID)
+		require.Equal(t, "req-ctx", decode(t, w.Body.String())["request_id"])
+	})
+
+	t.Run("shared errors are not modified", func(t *testing.T) {
+		t.Parallel()
+
+		shared := internal.ErrForbidden("nope")
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					return shared
+				})
+			})),
+		)
+
+		for _, id := range []string{"req-1", "req-2"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(context.WithValue(req.Context(), internal.RequestIDKey{}, id))
+			w := serveRecorder(app.Router(), req)
+			require.Equal(t, id, decode(t, w.Body.String())["request_id"])
+		}
+		require.Empty(t, shared.RequestID)
+	})
+
+	t.Run("hides non-HTTPError details", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.JSONError(errors.New("pq: connection refused")))
+		})
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotContains(t, w.Body.String(), "connection refused")
+		got := decode(t, w.Body.String())
+		require.Equal(t, "Internal Server Error", got["title"])
+	})
+}
+
+func TestDefaultStatusHandlers(t *testing.T) {
+	t.Parallel()
+
+	app := internal.New(
+		internal.WithNotFoundHandler(internal.DefaultNotFoundHandler()),
+		internal.WithMethodNotAllowedHandler(internal.DefaultMethodNotAllowedHandler()),
+		internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/items", func(c internal.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			})
+		})),
+	)
+
+	request := func(method, path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("not found renders html for browsers", func(t *testing.T) {
+		t.Parallel()
+
+		w := request(http.MethodGet, "/missing", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Body.String(), "<h1>404 Not Found</h1>")
+	})
+
+	t.Run("not found renders problem json for api clients", func(t *testing.T) {
+		t.Parallel()
+
+		w := request(http.MethodGet, "/missing", "application/json")
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.JSONEq(t, `{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "The requested resource was not found.",
+			"instance": "/missing"
+		}`, w.Body.String())
+	})
+
+	t.Run("not found defaults to problem json without accept", func(t *testing.T) {
+		t.Parallel()
+
+		w := request(http.MethodGet, "/missing", "")
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("not found honors accept quality values", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]string{
+			"application/json, text/html;q=0.1":   "application/problem+json; charset=utf-8",
+			"text/html;q=0, */*":                  "application/problem+json; charset=utf-8",
+			"*/*":                                 "application/problem+json; charset=utf-8",
+			"application/json;q=0.5, text/html":   "text/html; charset=utf-8",
+			"text/*, application/json;q=0.9":      "text/html; charset=utf-8",
+			"text/html, application/json":         "text/html; charset=utf-8",
+			"text/html;q=bogus, application/json": "application/problem+json; charset=utf-8",
+		}
+		for accept, want := range cases {
+			w := request(http.MethodGet, "/missing", accept)
+			require.Equal(t, want, w.Header().Get("Content-Type"), accept)
+		}
+	})
+
+	t.Run("method not allowed negotiates", func(t *testing.T) {
+		t.Parallel()
+
+		w := request(http.MethodPost, "/items", "text/html")
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		require.Contains(t, w.Body.String(), "<h1>405 Method Not Allowed</h1>")
+
+		w = request(http.MethodPost, "/items", "application/json")
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		require.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Body.String(), `"status":405`)
+	})
+}
+
+func TestErrorAfterResponseWritten(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	var handled bool
+	app := internal.New(
+		internal.WithCustomLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		internal.WithErrorHandler(func(c internal.Context, err error) error {
+			handled = true
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}),
+		internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				c.SetHeader("Content-Type", "application/json")
+				c.Response().WriteHeader(http.StatusOK)
+				_, _ = c.Response().Write([]byte(`{"items":[`))
+				return errors.New("encode failed")
+			})
+		})),
+	)
+
+	w := httptest.NewRecorder()
+	app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.False(t, handled)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"items":[`, w.Body.String())
+	require.Contains(t, logs.String(), "handler returned error after writing response")
+	require.Contains(t, logs.String(), "encode failed")
+}
+
+func TestBuiltInPanicRecovery(t *testing.T) {
+	t.Parallel()
+
+	panicking := func(r internal.Router) {
+		r.GET("/", func(c internal.Context) error {
+			panic("boom")
+		})
+	}
+
+	t.Run("renders 500 without error handler", func(t *testing.T) {
+		t.Parallel()
+
+		var logs bytes.Buffer
+		app := internal.New(
+			internal.WithCustomLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			internal.WithHandlers(routesFunc(panicking)),
+		)
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, logs.String(), "panic recovered")
+		require.Contains(t, logs.String(), "boom")
+	})
+
+	t.Run("passes PanicError to error handler", func(t *testing.T) {
+		t.Parallel()
+
+		var got *internal.PanicError
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				require.ErrorAs(t, err, &got)
+				return c.NoContent(http.StatusInternalServerError)
+			}),
+			internal.WithHandlers(routesFunc(panicking)),
+		)
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.NotNil(t, got)
+		require.Equal(t, "boom", got.Value)
+		require.NotEmpty(t, got.Stack)
+	})
+
+	t.Run("catches panics in global middleware", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithMiddleware(func(next internal.HandlerFunc) internal.HandlerFunc {
+				return func(c internal.Context) error {
+					panic("middleware boom")
+				}
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error { return c.NoContent(http.StatusOK) })
+			})),
+		)
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("user recovery runs first", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithMiddleware(func(next internal.HandlerFunc) internal.HandlerFunc {
+				return func(c internal.Context) (err error) {
+					defer func() {
+						if recover() != nil {
+							err = c.NoContent(http.StatusTeapot)
+						}
+					}()
+					return next(c)
+				}
+			}),
+			internal.WithHandlers(routesFunc(panicking)),
+		)
+
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("re-raises ErrAbortHandler", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				panic(http.ErrAbortHandler)
+			})
+		})))
+
+		require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			app.Router().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
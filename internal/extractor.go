@@ -1,7 +1,11 @@
 package internal
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"mime"
+	"strconv"
 	"strings"
 )
 
@@ -63,6 +67,18 @@ func FromCookie(name string) ExtractorSource {
 	}
 }
 
+// FromJWTCookie returns an ExtractorSource that reads the app's JWT cookie
+// (see WithJWTCookieName).
+func FromJWTCookie() ExtractorSource {
+	return func(c Context) (string, bool) {
+		v, err := c.JWTCookie()
+		if err != nil || v == "" {
+			return "", false
+		}
+		return v, true
+	}
+}
+
 // FromCookieSigned returns a source that reads from a signed cookie.
 func FromCookieSigned(name string) ExtractorSource {
 	return func(c Context) (string, bool) {
@@ -144,3 +160,99 @@ func FromBearerToken() ExtractorSource {
 		return token, true
 	}
 }
+
+// FromBasicAuth returns a source that reads the username from HTTP Basic
+// credentials in the Authorization header. Malformed credentials are a miss.
+func FromBasicAuth() ExtractorSource {
+	return func(c Context) (string, bool) {
+		user, _, ok := c.Request().BasicAuth()
+		if !ok || user == "" {
+			return "", false
+		}
+		return user, true
+	}
+}
+
+// FromBasicAuthPassword returns a source that reads the password from HTTP
+// Basic credentials in the Authorization header. Malformed credentials are a miss.
+func FromBasicAuthPassword() ExtractorSource {
+	return func(c Context) (string, bool) {
+		_, pass, ok := c.Request().BasicAuth()
+		if !ok || pass == "" {
+			return "", false
+		}
+		return pass, true
+	}
+}
+
+// FromJSONBody returns a source that reads a field from a JSON request body.
+// Nested fields use a dot path, e.g. "auth.token". String, number, and bool
+// values are returned as strings; a body that is not a JSON object, or a
+// missing, null, object, or array field, is a miss. So is a request whose
+// Content-Type is not application/json or a +json type; its body is left
+// unread. The body is read through Context.RawBody, so binders and handlers
+// downstream can still read it.
+func FromJSONBody(field string) ExtractorSource {
+	path := strings.Split(field, ".")
+	return func(c Context) (string, bool) {
+		mediaType, _, _ := mime.ParseMediaType(c.Header("Content-Type"))
+		if !isJSONMediaType(mediaType) {
+			return "", false
+		}
+		body, err := c.RawBody()
+		if err != nil || len(body) == 0 {
+			return "", false
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return "", false
+		}
+
+		for _, key := range path {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return "", false
+			}
+			if v, ok = obj[key]; !ok {
+				return "", false
+			}
+		}
+
+		switch val := v.(type) {
+		case string:
+			return val, val != ""
+		case json.Number:
+			return val.String(), true
+		case bool:
+			return strconv.FormatBool(val), true
+		default:
+			return "", false
+		}
+	}
+}
+
+// isJSONMediaType reports whether mediaType is application/json or a
+// structured syntax type such as application/merge-patch+json.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// Transform returns a source that runs src and passes the value through fn.
+// A false return from fn is treated as a miss, so an Extractor moves on to
+// its next source.
+func Transform(src ExtractorSource, fn func(string) (string, bool)) ExtractorSource {
+	return func(c Context) (string, bool) {
+		v, ok := src(c)
+		if !ok {
+			return "", false
+		}
+		v, ok = fn(v)
+		if !ok || v == "" {
+			return "", false
+		}
+		return v, true
+	}
+}
@@ -2,6 +2,7 @@ package internal_test
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -676,3 +677,298 @@ func TestFromBearerToken(t *testing.T) {
 		})
 	})
 }
+
+// --- FromBasicAuth tests ---
+
+func TestFromBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("username from valid credentials", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.FromBasicAuth()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("api-key-123", "")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.True(t, ok)
+			require.Equal(t, "api-key-123", v)
+		})
+	})
+
+	t.Run("password from valid credentials", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.FromBasicAuthPassword()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("user", "s3cret")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.True(t, ok)
+			require.Equal(t, "s3cret", v)
+		})
+	})
+
+	t.Run("empty password is a miss", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.FromBasicAuthPassword()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("user", "")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.False(t, ok)
+			require.Empty(t, v)
+		})
+	})
+
+	t.Run("malformed base64", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Basic !!!not-base64")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := internal.FromBasicAuth()(c)
+			require.False(t, ok)
+			require.Empty(t, v)
+
+			v, ok = internal.FromBasicAuthPassword()(c)
+			require.False(t, ok)
+			require.Empty(t, v)
+		})
+	})
+
+	t.Run("Bearer scheme", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.FromBasicAuth()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer my-token")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.False(t, ok)
+			require.Empty(t, v)
+		})
+	})
+
+	t.Run("composes with bearer token", func(t *testing.T) {
+		t.Parallel()
+
+		ext := internal.NewExtractor(internal.FromBearerToken(), internal.FromBasicAuth())
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("basic-key", "x")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := ext.Extract(c)
+			require.True(t, ok)
+			require.Equal(t, "basic-key", v)
+		})
+	})
+}
+
+// --- Transform tests ---
+
+func TestTransform(t *testing.T) {
+	t.Parallel()
+
+	normalize := func(v string) (string, bool) {
+		return strings.ToLower(strings.TrimSpace(v)), true
+	}
+	numeric := func(v string) (string, bool) {
+		for _, r := range v {
+			if r < '0' || r > '9' {
+				return "", false
+			}
+		}
+		return v, true
+	}
+
+	t.Run("normalizes value", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.Transform(internal.FromHeader("X-Tenant"), normalize)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Tenant", "  ACME ")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.True(t, ok)
+			require.Equal(t, "acme", v)
+		})
+	})
+
+	t.Run("rejected value falls through to next source", func(t *testing.T) {
+		t.Parallel()
+
+		ext := internal.NewExtractor(
+			internal.Transform(internal.FromQuery("token"), numeric),
+			internal.FromHeader("X-Token"),
+		)
+		req := httptest.NewRequest(http.MethodGet, "/?token=abc", nil)
+		req.Header.Set("X-Token", "42")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := ext.Extract(c)
+			require.True(t, ok)
+			require.Equal(t, "42", v)
+		})
+	})
+
+	t.Run("does not call fn on miss", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		src := internal.Transform(internal.FromQuery("token"), func(v string) (string, bool) {
+			called = true
+			return v, true
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.False(t, ok)
+			require.Empty(t, v)
+		})
+		require.False(t, called)
+	})
+
+	t.Run("empty result is a miss", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.Transform(internal.FromHeader("X-Tenant"), normalize)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Tenant", "   ")
+
+		requestVia(t, req, nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.False(t, ok)
+			require.Empty(t, v)
+		})
+	})
+}
+
+// --- FromJSONBody tests ---
+
+func TestFromJSONBody(t *testing.T) {
+	t.Parallel()
+
+	jsonRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("top level field", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.FromJSONBody("token")
+		postVia(t, jsonRequest(`{"token":"abc"}`), nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.True(t, ok)
+			require.Equal(t, "abc", v)
+		})
+	})
+
+	t.Run("nested field via dot path", func(t *testing.T) {
+		t.Parallel()
+
+		src := internal.FromJSONBody("auth.token")
+		postVia(t, jsonRequest(`{"auth":{"token":"nested"}}`), nil, func(c internal.Context) {
+			v, ok := src(c)
+			require.True(t, ok)
+			require.Equal(t, "nested", v)
+		})
+	})
+
+	t.Run("number and bool values", func(t *testing.T) {
+		t.Parallel()
+
+		postVia(t, jsonRequest(`{"id":12345678901234567890,"ok":true}`), nil, func(c internal.Context) {
+			v, ok := internal.FromJSONBody("id")(c)
+			require.True(t, ok)
+			require.Equal(t, "12345678901234567890", v)
+
+			v, ok = internal.FromJSONBody("ok")(c)
+			require.True(t, ok)
+			require.Equal(t, "true", v)
+		})
+	})
+
+	t.Run("misses", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]struct {
+			body  string
+			field string
+		}{
+			"not json":        {body: `token=abc`, field: "token"},
+			"absent field":    {body: `{"other":"x"}`, field: "token"},
+			"null field":      {body: `{"token":null}`, field: "token"},
+			"object field":    {body: `{"auth":{"token":"x"}}`, field: "auth"},
+			"path into array": {body: `{"auth":["x"]}`, field: "auth.token"},
+			"top level array": {body: `["x"]`, field: "token"},
+			"empty body":      {body: ``, field: "token"},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				postVia(t, jsonRequest(tc.body), nil, func(c internal.Context) {
+					v, ok := internal.FromJSONBody(tc.field)(c)
+					require.False(t, ok)
+					require.Empty(t, v)
+				})
+			})
+		}
+	})
+
+	t.Run("content type must be json", func(t *testing.T) {
+		t.Parallel()
+
+		for _, ct := range []string{"", "text/plain", "application/x-www-form-urlencoded"} {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"token":"abc"}`))
+			req.Header.Set("Content-Type", ct)
+			postVia(t, req, nil, func(c internal.Context) {
+				v, ok := internal.FromJSONBody("token")(c)
+				require.False(t, ok, ct)
+				require.Empty(t, v)
+
+				body, err := io.ReadAll(c.Request().Body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"token":"abc"}`, string(body), "body is left unread")
+			})
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"token":"abc"}`))
+		req.Header.Set("Content-Type", "application/vnd.api+json; charset=utf-8")
+		postVia(t, req, nil, func(c internal.Context) {
+			v, ok := internal.FromJSONBody("token")(c)
+			require.True(t, ok)
+			require.Equal(t, "abc", v)
+		})
+	})
+
+	t.Run("body remains readable by binders", func(t *testing.T) {
+		t.Parallel()
+
+		postVia(t, jsonRequest(`{"token":"abc","name":"Alice"}`), nil, func(c internal.Context) {
+			v, ok := internal.FromJSONBody("token")(c)
+			require.True(t, ok)
+			require.Equal(t, "abc", v)
+
+			var in struct {
+				Token string `json:"token"`
+				Name  string `json:"name"`
+			}
+			verrs, err := c.BindJSON(&in)
+			require.NoError(t, err)
+			require.Empty(t, verrs)
+			require.Equal(t, "Alice", in.Name)
+		})
+	})
+}
@@ -0,0 +1,22 @@
+package internal
+
+// FlashLevel is the severity of a FlashMessage, for choosing how it is styled.
+type FlashLevel string
+
+// Flash message levels.
+const (
+	FlashLevelSuccess FlashLevel = "success"
+	FlashLevelError   FlashLevel = "error"
+	FlashLevelWarning FlashLevel = "warning"
+)
+
+// FlashMessage is a one-time notification set with Context.FlashSuccess,
+// FlashError, or FlashWarning and read with Context.Flashes.
+type FlashMessage struct {
+	Level   FlashLevel `json:"level"`
+	Message string     `json:"message"`
+}
+
+// flashMessagesKey is the cookie flash key that holds the typed messages,
+// stored in the "flash_messages" cookie.
+const flashMessagesKey = "messages"
@@ -0,0 +1,119 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/cookie"
+)
+
+func TestFlashMessages(t *testing.T) {
+	t.Parallel()
+
+	secret := "this-is-a-32-byte-secret-key!!!!" // 32 bytes
+
+	t.Run("round-trips through a redirect", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithCookieOptions(cookie.WithSecret(secret))}
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			c.FlashSuccess("Saved")
+			c.FlashWarning("Quota almost used")
+			c.FlashError("Email not sent")
+			require.NoError(t, c.Redirect(http.StatusSeeOther, "/next"))
+		})
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, "flash_messages", cookies[0].Name)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookies[0])
+		w = requestVia(t, req, opts, func(c internal.Context) {
+			msgs, err := c.Flashes()
+			require.NoError(t, err)
+			require.Equal(t, []internal.FlashMessage{
+				{Level: internal.FlashLevelSuccess, Message: "Saved"},
+				{Level: internal.FlashLevelWarning, Message: "Quota almost used"},
+				{Level: internal.FlashLevelError, Message: "Email not sent"},
+			}, msgs)
+
+			// Read once: the second call finds nothing.
+			msgs, err = c.Flashes()
+			require.NoError(t, err)
+			require.Empty(t, msgs)
+		})
+
+		cleared := w.Result().Cookies()
+		require.Len(t, cleared, 1)
+		require.Equal(t, "flash_messages", cleared[0].Name)
+		require.Negative(t, cleared[0].MaxAge)
+	})
+
+	t.Run("includes messages queued in the same request", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithCookieOptions(cookie.WithSecret(secret))}
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			c.FlashError("Invalid input")
+			msgs, err := c.Flashes()
+			require.NoError(t, err)
+			require.Equal(t, []internal.FlashMessage{{Level: internal.FlashLevelError, Message: "Invalid input"}}, msgs)
+			require.NoError(t, c.String(http.StatusOK, "form"))
+		})
+		// Already shown, so nothing is carried to the next request.
+		require.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("no messages", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithCookieOptions(cookie.WithSecret(secret))}
+
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			msgs, err := c.Flashes()
+			require.NoError(t, err)
+			require.Empty(t, msgs)
+		})
+	})
+
+	t.Run("no secret degrades to empty", func(t *testing.T) {
+		t.Parallel()
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			c.FlashSuccess("Saved")
+			require.NoError(t, c.Redirect(http.StatusSeeOther, "/next"))
+		})
+		require.Equal(t, http.StatusSeeOther, w.Code)
+		require.Empty(t, w.Result().Cookies())
+
+		requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			msgs, err := c.Flashes()
+			require.NoError(t, err)
+			require.Empty(t, msgs)
+		})
+	})
+
+	t.Run("unreadable cookie is cleared", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithCookieOptions(cookie.WithSecret(secret))}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "flash_messages", Value: "bm90LWVuY3J5cHRlZA"})
+		w := requestVia(t, req, opts, func(c internal.Context) {
+			msgs, err := c.Flashes()
+			require.ErrorIs(t, err, cookie.ErrDecrypt)
+			require.Empty(t, msgs)
+		})
+
+		cleared := w.Result().Cookies()
+		require.Len(t, cleared, 1)
+		require.Negative(t, cleared[0].MaxAge)
+	})
+}
@@ -19,6 +19,8 @@ type Handler interface {
 // HandlerFunc is the signature for route handlers.
 // It receives a Context and returns an error.
 // Returning a non-nil error triggers the error handling middleware.
+// The error handler can only render the error if the handler has not written
+// a response yet; errors returned after writing are logged instead.
 type HandlerFunc func(c Context) error
 
 // Middleware wraps a HandlerFunc to add cross-cutting concerns.
@@ -16,6 +16,7 @@ const (
 
 	statusHealthy   = "healthy"
 	statusUnhealthy = "unhealthy"
+	statusStarting  = "starting"
 )
 
 // CheckFunc is the standard health check function signature.
@@ -50,14 +51,21 @@ func livenessHandler() http.HandlerFunc {
 }
 
 // readinessHandler returns an http.HandlerFunc that runs all provided checks.
-func readinessHandler(checks healthChecks) http.HandlerFunc {
+// While starting reports true, it responds 503 with status "starting"
+// without running the checks.
+func readinessHandler(checks healthChecks, starting func() bool) http.HandlerFunc {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		resp := runChecks(r.Context(), checks, defaultHealthTimeout, logger)
+		var resp *healthResponse
+		if starting() {
+			resp = &healthResponse{Status: statusStarting}
+		} else {
+			resp = runChecks(r.Context(), checks, defaultHealthTimeout, logger)
+		}
 
 		status := http.StatusOK
-		if resp.Status == statusUnhealthy {
+		if resp.Status != statusHealthy {
 			status = http.StatusServiceUnavailable
 		}
 
@@ -67,9 +75,12 @@ func readinessHandler(checks healthChecks) http.HandlerFunc {
 		}
 
 		w.WriteHeader(status)
-		if resp.Status == statusHealthy {
+		switch resp.Status {
+		case statusHealthy:
 			_, _ = w.Write([]byte("OK"))
-		} else {
+		case statusStarting:
+			_, _ = w.Write([]byte("Starting"))
+		default:
 			_, _ = w.Write([]byte("Service Unavailable"))
 		}
 	}
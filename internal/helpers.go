@@ -1,13 +1,49 @@
 package internal
 
-import "strconv"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dmitrymomot/forge/pkg/validator"
+)
+
+func RenderComponent(ctx context.Context, component Component) (string, error) {
+	var buf bytes.Buffer
+	if err := component.Render(ctx, &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
 
 func ContextValue[T any](c Context, key any) T {
-	if v, ok := c.Get(key).(T); ok {
-		return v
+	v, _ := ContextValueOK[T](c, key)
+	return v
+}
+
+func ContextValueOK[T any](c Context, key any) (T, bool) {
+	v, ok := c.Get(key).(T)
+	return v, ok
+}
+
+func MustContextValue[T any](c Context, key any) T {
+	raw := c.Get(key)
+	if raw == nil {
+		panic(fmt.Sprintf("forge: context value for key %T is not set", key))
 	}
-	var zero T
-	return zero
+	v, ok := raw.(T)
+	if !ok {
+		panic(fmt.Sprintf("forge: context value for key %T is %T, not %s", key, raw, reflect.TypeFor[T]()))
+	}
+	return v
+}
+
+func SetContextValue[T any](c Context, key any, v T) {
+	c.Set(key, v)
 }
 
 func Param[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) T {
@@ -15,6 +51,21 @@ func Param[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string)
 	return v
 }
 
+// ParamRequired retrieves a typed URL parameter, returning a 400 HTTPError
+// when the parameter is missing, empty, or cannot be parsed as T.
+func ParamRequired[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) (T, error) {
+	var zero T
+	raw, ok := c.ParamOK(name)
+	if !ok || raw == "" {
+		return zero, ErrBadRequest(fmt.Sprintf("missing URL parameter %q", name))
+	}
+	v, ok := convertParam[T](raw)
+	if !ok {
+		return zero, ErrBadRequest(fmt.Sprintf("invalid URL parameter %q", name))
+	}
+	return v, nil
+}
+
 func Query[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) T {
 	v, _ := convertParam[T](c.Query(name))
 	return v
@@ -34,6 +85,49 @@ func QueryDefault[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name
 	return v
 }
 
+// QuerySlice retrieves a typed list from a query parameter given either as
+// repeated values (?ids=1&ids=2) or comma-separated (?ids=1,2), or both.
+// Elements are trimmed; empty and unparseable elements are skipped.
+// Returns nil when the parameter is absent.
+func QuerySlice[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) []T {
+	var out []T
+	for _, raw := range querySliceElems(c, name) {
+		if v, ok := convertParam[T](raw); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// QuerySliceStrict is the strict counterpart of QuerySlice. It returns a 400
+// HTTPError when any non-empty element cannot be parsed as T.
+// A missing parameter yields a nil slice and no error.
+func QuerySliceStrict[T ~string | ~int | ~int64 | ~float64 | ~bool](c Context, name string) ([]T, error) {
+	var out []T
+	for _, raw := range querySliceElems(c, name) {
+		v, ok := convertParam[T](raw)
+		if !ok {
+			return nil, ErrBadRequest(fmt.Sprintf("invalid query parameter %q: %q", name, raw))
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// querySliceElems returns the non-empty, trimmed elements of every value of
+// the named query parameter, splitting each value on commas.
+func querySliceElems(c Context, name string) []string {
+	var elems []string
+	for _, value := range c.Request().URL.Query()[name] {
+		for elem := range strings.SplitSeq(value, ",") {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				elems = append(elems, elem)
+			}
+		}
+	}
+	return elems
+}
+
 // convertParam converts a raw string to the target type T.
 // Returns the converted value and true on success, or the zero value and false on failure.
 func convertParam[T ~string | ~int | ~int64 | ~float64 | ~bool](raw string) (T, bool) {
@@ -68,3 +162,107 @@ func convertParam[T ~string | ~int | ~int64 | ~float64 | ~bool](raw string) (T,
 	}
 	return zero, false
 }
+
+// BindParams maps URL parameters into the struct pointed to by v.
+// Each exported field is bound from the parameter named by its `param` tag,
+// or from the lowercased field name when the tag is absent; `param:"-"` skips the field.
+// Supported field kinds match Param: string, int, int64, float64, and bool
+// (including named types such as `type OrgID string`). Missing parameters
+// leave the field unchanged.
+//
+// Unparseable values are reported as ValidationErrors keyed by the Go field name,
+// so callers can treat them like Bind failures. Any other error means v is not a
+// pointer to a struct or declares an unsupported field kind.
+func BindParams(c Context, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("bind params: target must be a non-nil pointer to struct")
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var verrs ValidationErrors
+	for i := range rv.NumField() {
+		field := rv.Field(i)
+		sf := rt.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		name := sf.Tag.Get("param")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+
+		raw := c.Param(name)
+		if raw == "" {
+			continue
+		}
+
+		typeName, ok, err := setParamField(field, raw)
+		if err != nil {
+			return fmt.Errorf("bind params: field %s: %w", sf.Name, err)
+		}
+		if !ok {
+			verrs.Add(validator.ValidationError{
+				Field:          sf.Name,
+				Message:        "must be a valid " + typeName,
+				TranslationKey: "validation.invalid_type",
+				TranslationValues: map[string]any{
+					"field": sf.Name,
+					"type":  typeName,
+				},
+			})
+		}
+	}
+
+	if !verrs.IsEmpty() {
+		return verrs
+	}
+	return nil
+}
+
+// setParamField converts raw with the same strconv rules as convertParam.
+// It returns the human-readable target type, whether parsing succeeded, and an
+// error for kinds outside the Param constraint set.
+func setParamField(field reflect.Value, raw string) (string, bool, error) {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+		return "string", true, nil
+	case reflect.Int:
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return "integer", false, nil
+		}
+		field.SetInt(int64(v))
+		return "integer", true, nil
+	case reflect.Int64:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "integer", false, nil
+		}
+		field.SetInt(v)
+		return "integer", true, nil
+	case reflect.Float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "number", false, nil
+		}
+		field.SetFloat(v)
+		return "number", true, nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "boolean", false, nil
+		}
+		field.SetBool(v)
+		return "boolean", true, nil
+	default:
+		return "", false, fmt.Errorf("unsupported kind %s", field.Kind())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/cookie"
 	"github.com/dmitrymomot/forge/pkg/htmx"
 	"github.com/dmitrymomot/forge/pkg/i18n"
 	"github.com/dmitrymomot/forge/pkg/job"
@@ -41,26 +43,51 @@ func newParamContext(params map[string]string, queryString string) *paramContext
 	}
 }
 
-func (c *paramContext) Param(name string) string                 { return c.params[name] }
-func (c *paramContext) Query(name string) string                 { return c.request.URL.Query().Get(name) }
-func (c *paramContext) QueryDefault(name, def string) string     { return "" }
-func (c *paramContext) Request() *http.Request                   { return c.request }
-func (c *paramContext) Response() http.ResponseWriter            { return httptest.NewRecorder() }
-func (c *paramContext) Context() context.Context                 { return c.request.Context() }
-func (c *paramContext) Deadline() (time.Time, bool)              { return c.request.Context().Deadline() }
-func (c *paramContext) Done() <-chan struct{}                    { return c.request.Context().Done() }
-func (c *paramContext) Err() error                               { return c.request.Context().Err() }
-func (c *paramContext) Value(key any) any                        { return c.request.Context().Value(key) }
-func (c *paramContext) Domain() string                           { return "" }
-func (c *paramContext) Subdomain() string                        { return "" }
-func (c *paramContext) Header(name string) string                { return "" }
-func (c *paramContext) SetHeader(name, value string)             {}
-func (c *paramContext) JSON(code int, v any) error               { return nil }
-func (c *paramContext) String(code int, s string) error          { return nil }
-func (c *paramContext) NoContent(code int) error                 { return nil }
+func (c *paramContext) Param(name string) string { return c.params[name] }
+func (c *paramContext) ParamOK(name string) (string, bool) {
+	v, ok := c.params[name]
+	return v, ok
+}
+func (c *paramContext) Query(name string) string             { return c.request.URL.Query().Get(name) }
+func (c *paramContext) QueryDefault(name, def string) string { return "" }
+func (c *paramContext) Request() *http.Request               { return c.request }
+func (c *paramContext) Response() http.ResponseWriter        { return httptest.NewRecorder() }
+func (c *paramContext) Context() context.Context             { return c.request.Context() }
+func (c *paramContext) BackgroundContext() context.Context {
+	return context.WithoutCancel(c.request.Context())
+}
+func (c *paramContext) Deadline() (time.Time, bool)      { return c.request.Context().Deadline() }
+func (c *paramContext) Done() <-chan struct{}            { return c.request.Context().Done() }
+func (c *paramContext) Err() error                       { return c.request.Context().Err() }
+func (c *paramContext) Value(key any) any                { return c.request.Context().Value(key) }
+func (c *paramContext) Domain() string                   { return "" }
+func (c *paramContext) Subdomain() string                { return "" }
+func (c *paramContext) Header(name string) string        { return "" }
+func (c *paramContext) SetHeader(name, value string)     {}
+func (c *paramContext) JSON(code int, v any) error       { return nil }
+func (c *paramContext) JSONPretty(code int, v any) error { return nil }
+func (c *paramContext) JSONError(err error) error        { return nil }
+func (c *paramContext) ValidationResponse(code int, errs validator.ValidationErrors) error {
+	return nil
+}
+func (c *paramContext) String(code int, s string) error { return nil }
+func (c *paramContext) NoContent(code int) error        { return nil }
+func (c *paramContext) Stream(code int, contentType string, fn func(w io.Writer) error) error {
+	return nil
+}
+func (c *paramContext) StreamJSON(code int, ch <-chan any) error { return nil }
+func (c *paramContext) Flush() error                             { return nil }
+func (c *paramContext) SetTrailer(name, value string)            {}
+func (c *paramContext) Trailer(name string) string               { return "" }
 func (c *paramContext) Redirect(code int, url string) error      { return nil }
+func (c *paramContext) Upgrade(opts ...internal.WSOption) (*internal.WSConn, error) {
+	return nil, nil
+}
 func (c *paramContext) IsHTMX() bool                             { return false }
+func (c *paramContext) Defer(fn func())                          {}
 func (c *paramContext) Written() bool                            { return false }
+func (c *paramContext) Status() int                              { return 0 }
+func (c *paramContext) BytesWritten() int                        { return 0 }
 func (c *paramContext) Logger() *slog.Logger                     { return slog.Default() }
 func (c *paramContext) LogDebug(msg string, attrs ...any)        {}
 func (c *paramContext) LogInfo(msg string, attrs ...any)         {}
@@ -68,7 +95,10 @@ func (c *paramContext) LogWarn(msg string, attrs ...any)         {}
 func (c *paramContext) LogError(msg string, attrs ...any)        {}
 func (c *paramContext) Set(key, value any)                       { c.values[key] = value }
 func (c *paramContext) Get(key any) any                          { return c.values[key] }
+func (c *paramContext) RequestID() string                        { return "" }
 func (c *paramContext) Cookie(name string) (string, error)       { return "", nil }
+func (c *paramContext) Cookies() []*http.Cookie                  { return nil }
+func (c *paramContext) HasCookie(name string) bool               { return false }
 func (c *paramContext) SetCookie(name, value string, maxAge int) {}
 func (c *paramContext) DeleteCookie(name string)                 {}
 func (c *paramContext) UserID() string                           { return "" }
@@ -92,16 +122,41 @@ func (c *paramContext) RenderPartial(code int, fullPage, partial internal.Compon
 	return nil
 }
 
-func (c *paramContext) Bind(v any) (validator.ValidationErrors, error)      { return nil, nil }
-func (c *paramContext) BindQuery(v any) (validator.ValidationErrors, error) { return nil, nil }
-func (c *paramContext) BindJSON(v any) (validator.ValidationErrors, error)  { return nil, nil }
+func (c *paramContext) RenderOOB(main internal.Component, oob ...internal.Component) error {
+	return nil
+}
+
+func (c *paramContext) RenderFragments(code int, layout internal.Component, fragments map[string]internal.Component, opts ...htmx.RenderOption) error {
+	return nil
+}
+
+func (c *paramContext) RenderToString(component internal.Component) (string, error) { return "", nil }
+func (c *paramContext) Bind(v any) (validator.ValidationErrors, error)              { return nil, nil }
+func (c *paramContext) BindQuery(v any) (validator.ValidationErrors, error)         { return nil, nil }
+func (c *paramContext) BindJSON(v any) (validator.ValidationErrors, error)          { return nil, nil }
+func (c *paramContext) BindAuto(v any) (validator.ValidationErrors, error)          { return nil, nil }
+func (c *paramContext) BindPagination(opts ...internal.PaginationOption) (internal.Pagination, error) {
+	return internal.Pagination{}, nil
+}
+func (c *paramContext) RawBody() ([]byte, error)  { return nil, nil }
+func (c *paramContext) BindValidated(v any) error { return nil }
+func (c *paramContext) BindMergePatch(current any) (any, []string, error) {
+	return nil, nil, nil
+}
 
 func (c *paramContext) CookieSigned(name string) (string, error)                          { return "", nil }
 func (c *paramContext) SetCookieSigned(name, value string, maxAge int) error              { return nil }
 func (c *paramContext) CookieEncrypted(name string) (string, error)                       { return "", nil }
 func (c *paramContext) SetCookieEncrypted(name, value string, maxAge int) error           { return nil }
+func (c *paramContext) SetCookieRaw(ck *http.Cookie, p cookie.Protection) error           { return nil }
+func (c *paramContext) JWTCookie() (string, error)                                        { return "", nil }
+func (c *paramContext) SetJWTCookie(token string, maxAge int)                             {}
 func (c *paramContext) Flash(key string, dest any) error                                  { return nil }
 func (c *paramContext) SetFlash(key string, value any) error                              { return nil }
+func (c *paramContext) FlashSuccess(msg string)                                           {}
+func (c *paramContext) FlashError(msg string)                                             {}
+func (c *paramContext) FlashWarning(msg string)                                           {}
+func (c *paramContext) Flashes() ([]internal.FlashMessage, error)                         { return nil, nil }
 func (c *paramContext) Session() (*session.Session, error)                                { return nil, nil }
 func (c *paramContext) InitSession() error                                                { return nil }
 func (c *paramContext) AuthenticateSession(userID string) error                           { return nil }
@@ -109,8 +164,13 @@ func (c *paramContext) SessionValue(key string) (any, error)
 func (c *paramContext) SetSessionValue(key string, val any) error                         { return nil }
 func (c *paramContext) DeleteSessionValue(key string) error                               { return nil }
 func (c *paramContext) DestroySession() error                                             { return nil }
+func (c *paramContext) DestroyUserSessions(userID string) (int, error)                    { return 0, nil }
 func (c *paramContext) ResponseWriter() *internal.ResponseWriter                          { return nil }
 func (c *paramContext) Enqueue(name string, payload any, opts ...job.EnqueueOption) error { return nil }
+func (c *paramContext) EnqueueWithID(name string, payload any, opts ...job.EnqueueOption) (int64, error) {
+	return 0, nil
+}
+func (c *paramContext) JobStatus(id int64) (*job.JobStatus, error) { return nil, nil }
 func (c *paramContext) EnqueueTx(tx pgx.Tx, name string, payload any, opts ...job.EnqueueOption) error {
 	return nil
 }
@@ -120,7 +180,10 @@ func (c *paramContext) Upload(r io.Reader, size int64, opts ...storage.Option) (
 }
 func (c *paramContext) Download(key string) (io.ReadCloser, error)                    { return nil, nil }
 func (c *paramContext) DeleteFile(key string) error                                   { return nil }
+func (c *paramContext) FileInfo(key string) (*storage.FileInfo, error)                { return nil, nil }
+func (c *paramContext) FileExists(key string) (bool, error)                           { return false, nil }
 func (c *paramContext) FileURL(key string, opts ...storage.URLOption) (string, error) { return "", nil }
+func (c *paramContext) ServeFile(key string, opts ...internal.ServeFileOption) error  { return nil }
 func (c *paramContext) T(key string, _ ...i18n.M) string                              { return key }
 func (c *paramContext) Tn(key string, _ int, _ ...i18n.M) string                      { return key }
 func (c *paramContext) Language() string                                              { return "" }
@@ -133,6 +196,14 @@ func (c *paramContext) FormatDateTime(datetime time.Time) string {
 	return datetime.Format("2006-01-02 15:04:05")
 }
 
+func (c *paramContext) SignedURL(path string, expiry time.Time, params url.Values) (string, error) {
+	return "", nil
+}
+
+func (c *paramContext) SetLastModified(t time.Time)       {}
+func (c *paramContext) NotModifiedSince(t time.Time) bool { return false }
+func (c *paramContext) CheckETag(etag string) bool        { return false }
+
 func TestParam(t *testing.T) {
 	t.Parallel()
 
@@ -265,6 +336,154 @@ func TestParam(t *testing.T) {
 	})
 }
 
+func TestBindParams(t *testing.T) {
+	t.Parallel()
+
+	type orgID string
+
+	type itemParams struct {
+		Org      orgID
+		Project  string  `param:"project"`
+		ID       int64   `param:"id"`
+		Page     int     `param:"page"`
+		Score    float64 `param:"score"`
+		Archived bool    `param:"archived"`
+		Skipped  string  `param:"-"`
+		internal string
+	}
+
+	t.Run("binds all supported kinds", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(map[string]string{
+			"org":      "acme",
+			"project":  "forge",
+			"id":       "9001",
+			"page":     "3",
+			"score":    "4.5",
+			"archived": "true",
+			"skipped":  "nope",
+		}, "")
+
+		var p itemParams
+		require.NoError(t, internal.BindParams(c, &p))
+		require.Equal(t, orgID("acme"), p.Org)
+		require.Equal(t, "forge", p.Project)
+		require.Equal(t, int64(9001), p.ID)
+		require.Equal(t, 3, p.Page)
+		require.Equal(t, 4.5, p.Score)
+		require.True(t, p.Archived)
+		require.Empty(t, p.Skipped)
+		require.Empty(t, p.internal)
+	})
+
+	t.Run("missing params leave fields unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(map[string]string{"org": "acme"}, "")
+
+		p := itemParams{ID: 7}
+		require.NoError(t, internal.BindParams(c, &p))
+		require.Equal(t, orgID("acme"), p.Org)
+		require.Equal(t, int64(7), p.ID)
+	})
+
+	t.Run("unparseable values return validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(map[string]string{
+			"id":       "abc",
+			"score":    "high",
+			"archived": "maybe",
+		}, "")
+
+		var p itemParams
+		err := internal.BindParams(c, &p)
+		require.Error(t, err)
+		require.True(t, validator.IsValidationError(err))
+
+		verrs := validator.ExtractValidationErrors(err)
+		require.Equal(t, []string{"ID", "Score", "Archived"}, verrs.Fields())
+		require.Equal(t, "validation.invalid_type", verrs[0].TranslationKey)
+		require.Equal(t, "integer", verrs[0].TranslationValues["type"])
+	})
+
+	t.Run("rejects non-struct targets", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+
+		var s string
+		err := internal.BindParams(c, &s)
+		require.Error(t, err)
+		require.False(t, validator.IsValidationError(err))
+
+		require.Error(t, internal.BindParams(c, itemParams{}))
+	})
+
+	t.Run("rejects unsupported field kinds", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(map[string]string{"id": "1"}, "")
+
+		var p struct {
+			ID uint `param:"id"`
+		}
+		err := internal.BindParams(c, &p)
+		require.Error(t, err)
+		require.False(t, validator.IsValidationError(err))
+	})
+}
+
+func TestParamRequired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns parsed value", func(t *testing.T) {
+		t.Parallel()
+		c := newParamContext(map[string]string{"id": "42"}, "")
+		id, err := internal.ParamRequired[int64](c, "id")
+		require.NoError(t, err)
+		require.Equal(t, int64(42), id)
+	})
+
+	tests := []struct {
+		name   string
+		params map[string]string
+	}{
+		{"missing", map[string]string{}},
+		{"empty", map[string]string{"id": ""}},
+		{"unparseable", map[string]string{"id": "abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := newParamContext(tt.params, "")
+			id, err := internal.ParamRequired[int64](c, "id")
+			require.Zero(t, id)
+			httpErr := internal.AsHTTPError(err)
+			require.NotNil(t, httpErr)
+			require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		})
+	}
+}
+
+func TestContextParamOK(t *testing.T) {
+	t.Parallel()
+
+	app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+		r.GET("/items/{id}", func(c internal.Context) error {
+			id, ok := c.ParamOK("id")
+			_, slugOK := c.ParamOK("slug")
+			return c.String(http.StatusOK, fmt.Sprintf("%s %t %t", id, ok, slugOK))
+		})
+	})))
+
+	w := httptest.NewRecorder()
+	app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/7", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "7 true false", w.Body.String())
+}
+
 func TestQuery(t *testing.T) {
 	t.Parallel()
 
@@ -458,3 +677,299 @@ func TestContextValue(t *testing.T) {
 		require.Equal(t, user{}, got)
 	})
 }
+
+func TestContextValueOK(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports present zero value", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{}
+		c := newParamContext(nil, "")
+		internal.SetContextValue(c, key{}, 0)
+
+		v, ok := internal.ContextValueOK[int](c, key{})
+		require.True(t, ok)
+		require.Equal(t, 0, v)
+	})
+
+	t.Run("reports missing key", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{}
+		c := newParamContext(nil, "")
+
+		v, ok := internal.ContextValueOK[int](c, key{})
+		require.False(t, ok)
+		require.Equal(t, 0, v)
+	})
+
+	t.Run("reports wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{}
+		c := newParamContext(nil, "")
+		internal.SetContextValue(c, key{}, "42")
+
+		_, ok := internal.ContextValueOK[int](c, key{})
+		require.False(t, ok)
+	})
+}
+
+func TestMustContextValue(t *testing.T) {
+	t.Parallel()
+
+	type tenantKey struct{}
+
+	t.Run("returns value", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		internal.SetContextValue(c, tenantKey{}, "acme")
+
+		require.Equal(t, "acme", internal.MustContextValue[string](c, tenantKey{}))
+	})
+
+	t.Run("panics when missing", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+
+		require.PanicsWithValue(t, "forge: context value for key internal_test.tenantKey is not set", func() {
+			internal.MustContextValue[string](c, tenantKey{})
+		})
+	})
+
+	t.Run("panics on wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		internal.SetContextValue(c, tenantKey{}, 42)
+
+		require.PanicsWithValue(t, "forge: context value for key internal_test.tenantKey is int, not string", func() {
+			internal.MustContextValue[string](c, tenantKey{})
+		})
+	})
+}
+
+// componentFunc adapts a function to the Component interface.
+type componentFunc func(ctx context.Context, w io.Writer) error
+
+func (f componentFunc) Render(ctx context.Context, w io.Writer) error { return f(ctx, w) }
+
+func TestRenderComponent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renders into string", func(t *testing.T) {
+		t.Parallel()
+
+		html, err := internal.RenderComponent(context.Background(), componentFunc(func(_ context.Context, w io.Writer) error {
+			_, err := io.WriteString(w, "<p>hello</p>")
+			return err
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "<p>hello</p>", html)
+	})
+
+	t.Run("returns render error", func(t *testing.T) {
+		t.Parallel()
+
+		boom := fmt.Errorf("boom")
+		html, err := internal.RenderComponent(context.Background(), componentFunc(func(context.Context, io.Writer) error {
+			return boom
+		}))
+		require.ErrorIs(t, err, boom)
+		require.Empty(t, html)
+	})
+
+	t.Run("context method leaves response untouched", func(t *testing.T) {
+		t.Parallel()
+
+		type userKey struct{}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			c.Set(userKey{}, "alice")
+			html, err := c.RenderToString(componentFunc(func(ctx context.Context, w io.Writer) error {
+				_, err := fmt.Fprintf(w, "<b>%s</b>", ctx.Value(userKey{}))
+				return err
+			}))
+			require.NoError(t, err)
+			require.Equal(t, "<b>alice</b>", html)
+			require.False(t, c.Written())
+		})
+		require.Empty(t, w.Body.String())
+	})
+}
+
+func TestRenderOOB(t *testing.T) {
+	t.Parallel()
+
+	html := func(s string) internal.Component {
+		return componentFunc(func(_ context.Context, w io.Writer) error {
+			_, err := io.WriteString(w, s)
+			return err
+		})
+	}
+	main := html("<ul id=\"chat\"></ul>")
+	msgs := []internal.Component{
+		html(`<li id="m1" hx-swap-oob="beforeend:#chat">one</li>`),
+		html(`<li id="m2" hx-swap-oob="beforeend:#chat">two</li>`),
+	}
+
+	t.Run("htmx request gets main and fragments", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("HX-Request", "true")
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.RenderOOB(main, msgs...))
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `<ul id="chat"></ul><li id="m1" hx-swap-oob="beforeend:#chat">one</li><li id="m2" hx-swap-oob="beforeend:#chat">two</li>`, w.Body.String())
+	})
+
+	t.Run("regular request gets main only", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.RenderOOB(main, msgs...))
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `<ul id="chat"></ul>`, w.Body.String())
+	})
+
+	t.Run("invalid fragment fails before writing", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("HX-Request", "true")
+		requestVia(t, req, nil, func(c internal.Context) {
+			err := c.RenderOOB(main, html(`<li id="m1">one</li>`))
+			require.ErrorIs(t, err, htmx.ErrOOBMissingSwap)
+			require.False(t, c.Written())
+		})
+	})
+}
+
+func TestRenderFragments(t *testing.T) {
+	t.Parallel()
+
+	html := func(s string) internal.Component {
+		return componentFunc(func(_ context.Context, w io.Writer) error {
+			_, err := io.WriteString(w, s)
+			return err
+		})
+	}
+	layout := html(`<main><ul id="users"></ul><div id="stats"></div></main>`)
+	fragments := map[string]internal.Component{
+		"users": html(`<ul id="users"></ul>`),
+		"stats": html(`<div id="stats"></div>`),
+	}
+
+	t.Run("htmx request targeting a fragment gets only that fragment", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("HX-Request", "true")
+		req.Header.Set("HX-Target", "stats")
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.RenderFragments(http.StatusUnprocessableEntity, layout, fragments))
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `<div id="stats"></div>`, w.Body.String())
+	})
+
+	t.Run("htmx request targeting an unknown id gets the layout", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("HX-Request", "true")
+		req.Header.Set("HX-Target", "sidebar")
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.RenderFragments(http.StatusOK, layout, fragments))
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "<main>")
+	})
+
+	t.Run("regular request gets the layout with its status", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("HX-Target", "stats")
+		w := requestVia(t, req, nil, func(c internal.Context) {
+			require.NoError(t, c.RenderFragments(http.StatusUnprocessableEntity, layout, fragments))
+		})
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), "<main>")
+	})
+}
+
+func TestQuerySlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"repeated", "ids=1&ids=2&ids=3", []int{1, 2, 3}},
+		{"comma-separated", "ids=1,2,3", []int{1, 2, 3}},
+		{"mixed", "ids=1,2&ids=3", []int{1, 2, 3}},
+		{"trims and skips empty", "ids=1,+2,,3,", []int{1, 2, 3}},
+		{"skips invalid", "ids=1,abc,3", []int{1, 3}},
+		{"missing", "", nil},
+		{"empty value", "ids=", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := newParamContext(nil, tt.query)
+			require.Equal(t, tt.want, internal.QuerySlice[int](c, "ids"))
+		})
+	}
+
+	t.Run("other types", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "status=open,closed&id=9876543210&price=1.5,2&flag=true,0")
+		require.Equal(t, []string{"open", "closed"}, internal.QuerySlice[string](c, "status"))
+		require.Equal(t, []int64{9876543210}, internal.QuerySlice[int64](c, "id"))
+		require.Equal(t, []float64{1.5, 2}, internal.QuerySlice[float64](c, "price"))
+		require.Equal(t, []bool{true, false}, internal.QuerySlice[bool](c, "flag"))
+	})
+}
+
+func TestQuerySliceStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses all elements", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "ids=1,2&ids=3")
+		ids, err := internal.QuerySliceStrict[int64](c, "ids")
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2, 3}, ids)
+	})
+
+	t.Run("missing is not an error", func(t *testing.T) {
+		t.Parallel()
+
+		ids, err := internal.QuerySliceStrict[int64](newParamContext(nil, ""), "ids")
+		require.NoError(t, err)
+		require.Nil(t, ids)
+	})
+
+	t.Run("invalid element returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "ids=1,abc")
+		ids, err := internal.QuerySliceStrict[int64](c, "ids")
+		require.Nil(t, ids)
+		var httpErr *internal.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Contains(t, httpErr.Message, `"abc"`)
+	})
+}
@@ -34,6 +34,16 @@ func (je *JobEnqueuer) EnqueueTx(ctx context.Context, tx pgx.Tx, name string, pa
 	return je.enqueuer.EnqueueTx(ctx, tx, name, payload, opts...)
 }
 
+// EnqueueWithID adds a job to the queue and returns its ID.
+func (je *JobEnqueuer) EnqueueWithID(ctx context.Context, name string, payload any, opts ...job.EnqueueOption) (int64, error) {
+	return je.enqueuer.EnqueueWithID(ctx, name, payload, opts...)
+}
+
+// Status returns the state and last reported progress of a job.
+func (je *JobEnqueuer) Status(ctx context.Context, jobID int64) (*job.JobStatus, error) {
+	return je.enqueuer.Status(ctx, jobID)
+}
+
 // Enqueuer returns the underlying job.Enqueuer.
 func (je *JobEnqueuer) Enqueuer() *job.Enqueuer {
 	return je.enqueuer
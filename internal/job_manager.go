@@ -32,6 +32,17 @@ func (jm *JobManager) Stop(ctx context.Context) error {
 	return jm.manager.Stop(ctx)
 }
 
+// Drain blocks until the managed queues have no available or running jobs,
+// or until ctx is done. Intended for tests and controlled shutdowns.
+func (jm *JobManager) Drain(ctx context.Context) error {
+	return jm.manager.Drain(ctx)
+}
+
+// Status returns the state and last reported progress of a job.
+func (jm *JobManager) Status(ctx context.Context, jobID int64) (*job.JobStatus, error) {
+	return jm.manager.Status(ctx, jobID)
+}
+
 // Manager returns the underlying job.Manager.
 func (jm *JobManager) Manager() *job.Manager {
 	return jm.manager
@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// defaultJSONIndent is the indentation used by JSONPretty when the app's
+// JSON encoder has no indent of its own.
+const defaultJSONIndent = "  "
+
+// jsonEncoder holds the JSON encoding settings shared by every request.
+// It is built once by WithJSONEncoder and never modified afterwards.
+type jsonEncoder struct {
+	indent      string
+	escapeHTML  bool
+	prettyQuery bool
+}
+
+// defaultJSON matches encoding/json's defaults: compact output with HTML
+// characters escaped.
+var defaultJSON = &jsonEncoder{escapeHTML: true}
+
+// JSONOption configures the JSON encoder used by c.JSON.
+type JSONOption func(*jsonEncoder)
+
+// WithJSONIndent indents every c.JSON response with indent per level,
+// for example "  ".
+func WithJSONIndent(indent string) JSONOption {
+	return func(e *jsonEncoder) {
+		e.indent = indent
+	}
+}
+
+// WithJSONEscapeHTML sets whether <, >, and & are escaped as \u003c,
+// \u003e, and \u0026 (default: true). Disable it so URLs in responses stay
+// readable.
+func WithJSONEscapeHTML(escape bool) JSONOption {
+	return func(e *jsonEncoder) {
+		e.escapeHTML = escape
+	}
+}
+
+// WithJSONPrettyQuery makes c.JSON pretty-print when the request has a
+// pretty query parameter (?pretty or ?pretty=1). Meant for debugging and
+// developer-facing APIs.
+func WithJSONPrettyQuery() JSONOption {
+	return func(e *jsonEncoder) {
+		e.prettyQuery = true
+	}
+}
+
+// encode writes v to w followed by a newline. pretty indents the output
+// even when the encoder has no indent configured.
+func (e *jsonEncoder) encode(w io.Writer, v any, pretty bool) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(e.escapeHTML)
+	switch {
+	case e.indent != "":
+		enc.SetIndent("", e.indent)
+	case pretty:
+		enc.SetIndent("", defaultJSONIndent)
+	}
+	return enc.Encode(v)
+}
+
+// wantsPretty reports whether the pretty query parameter asks for indented
+// output. A bare ?pretty counts as true.
+func wantsPretty(query map[string][]string) bool {
+	values, ok := query["pretty"]
+	if !ok {
+		return false
+	}
+	if len(values) == 0 || values[0] == "" {
+		return true
+	}
+	pretty, _ := strconv.ParseBool(values[0])
+	return pretty
+}
@@ -0,0 +1,90 @@
+package internal_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	body := map[string]string{"url": "/a?b=1&c=<2>"}
+
+	t.Run("default matches encoding/json", func(t *testing.T) {
+		t.Parallel()
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/?pretty=1", nil), nil, func(c internal.Context) {
+			require.NoError(t, c.JSON(http.StatusOK, body))
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, `{"url":"/a?b=1\u0026c=\u003c2\u003e"}`+"\n", w.Body.String())
+	})
+
+	t.Run("JSONPretty indents", func(t *testing.T) {
+		t.Parallel()
+
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), nil, func(c internal.Context) {
+			require.NoError(t, c.JSONPretty(http.StatusCreated, body))
+		})
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, "{\n  \"url\": \"/a?b=1\\u0026c=\\u003c2\\u003e\"\n}\n", w.Body.String())
+	})
+
+	t.Run("without HTML escaping", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithJSONEncoder(internal.WithJSONEscapeHTML(false))}
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.JSON(http.StatusOK, body))
+		})
+		require.Equal(t, `{"url":"/a?b=1&c=<2>"}`+"\n", w.Body.String())
+	})
+
+	t.Run("configured indent", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithJSONEncoder(internal.WithJSONIndent("\t"))}
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			require.NoError(t, c.JSON(http.StatusOK, map[string]int{"a": 1}))
+		})
+		require.Equal(t, "{\n\t\"a\": 1\n}\n", w.Body.String())
+	})
+
+	t.Run("pretty query", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithJSONEncoder(internal.WithJSONPrettyQuery())}
+		for target, want := range map[string]string{
+			"/":               "{\"a\":1}\n",
+			"/?pretty":        "{\n  \"a\": 1\n}\n",
+			"/?pretty=1":      "{\n  \"a\": 1\n}\n",
+			"/?pretty=true":   "{\n  \"a\": 1\n}\n",
+			"/?pretty=0":      "{\"a\":1}\n",
+			"/?pretty=banana": "{\"a\":1}\n",
+		} {
+			w := requestVia(t, httptest.NewRequest(http.MethodGet, target, nil), opts, func(c internal.Context) {
+				require.NoError(t, c.JSON(http.StatusOK, map[string]int{"a": 1}))
+			})
+			require.Equal(t, want, w.Body.String(), target)
+		}
+	})
+
+	t.Run("JSONError uses encoder", func(t *testing.T) {
+		t.Parallel()
+
+		opts := []internal.Option{internal.WithJSONEncoder(internal.WithJSONEscapeHTML(false))}
+		w := requestVia(t, httptest.NewRequest(http.MethodGet, "/", nil), opts, func(c internal.Context) {
+			err := internal.ErrBadRequest("use <b>", internal.WithError(errors.New("x")))
+			require.NoError(t, c.JSONError(err))
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), `"use <b>"`)
+	})
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// defaultRetryAfter is the Retry-After hint sent with 503 responses
+// when the concurrent request limit is reached.
+const defaultRetryAfter = time.Second
+
+// requestLimiter bounds the number of requests handled concurrently.
+// Slots are held in a buffered channel used as a semaphore.
+type requestLimiter struct {
+	sem      chan struct{}
+	inFlight atomic.Int64
+	skip     func(r *http.Request) bool
+	reject   http.HandlerFunc
+}
+
+func newRequestLimiter(n int) *requestLimiter {
+	return &requestLimiter{sem: make(chan struct{}, n)}
+}
+
+// middleware rejects requests with 503 when all slots are taken.
+// Requests matched by skip are served without taking a slot.
+func (l *requestLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.skip != nil && l.skip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		select {
+		case l.sem <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", strconv.Itoa(int(defaultRetryAfter/time.Second)))
+			l.reject(w, r)
+			return
+		}
+
+		l.inFlight.Add(1)
+		defer func() {
+			l.inFlight.Add(-1)
+			<-l.sem
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// drain takes every slot so no new request is admitted, then returns once
+// all in-flight requests have finished or ctx is done.
+func (l *requestLimiter) drain(ctx context.Context) error {
+	for range cap(l.sem) {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return fmt.Errorf("drain in-flight requests: %d still running: %w", l.inFlight.Load(), ctx.Err())
+		}
+	}
+	return nil
+}
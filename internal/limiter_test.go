@@ -0,0 +1,119 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// serveRecorder serves req through h and returns the recorded response.
+func serveRecorder(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	// blockingApp returns an app whose GET /slow handler blocks until release
+	// is closed, signalling on started once it is running.
+	blockingApp := func(n int, opts ...internal.Option) (app *internal.App, started chan struct{}, release chan struct{}) {
+		started = make(chan struct{}, n)
+		release = make(chan struct{})
+		opts = append(opts,
+			internal.WithMaxConcurrentRequests(n),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/slow", func(c internal.Context) error {
+					started <- struct{}{}
+					<-release
+					return c.NoContent(http.StatusOK)
+				})
+				r.GET("/fast", func(c internal.Context) error {
+					return c.NoContent(http.StatusOK)
+				})
+			})),
+		)
+		return internal.New(opts...), started, release
+	}
+
+	t.Run("rejects requests over the limit with 503", func(t *testing.T) {
+		t.Parallel()
+
+		app, started, release := blockingApp(1)
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+		})
+		<-started
+		require.Equal(t, int64(1), app.InFlightRequests())
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, "1", w.Header().Get("Retry-After"))
+
+		close(release)
+		wg.Wait()
+		require.Equal(t, int64(0), app.InFlightRequests())
+
+		w = serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejection goes through the error handler", func(t *testing.T) {
+		t.Parallel()
+
+		var got int
+		app, started, release := blockingApp(1, internal.WithErrorHandler(func(c internal.Context, err error) error {
+			got = internal.AsHTTPError(err).Code
+			return c.String(got, "busy")
+		}))
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+		})
+		<-started
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+		require.Equal(t, http.StatusServiceUnavailable, got)
+		require.Equal(t, "busy", w.Body.String())
+
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("health checks bypass the limit", func(t *testing.T) {
+		t.Parallel()
+
+		app, started, release := blockingApp(1, internal.WithHealthChecks())
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+		})
+		<-started
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/health/live", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("non-positive limit disables limiting", func(t *testing.T) {
+		t.Parallel()
+
+		app, _, _ := blockingApp(0)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, int64(0), app.InFlightRequests())
+	})
+}
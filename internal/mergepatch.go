@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// MergePatchContentType is the media type of RFC 7396 JSON Merge Patch
+// bodies. c.BindMergePatch also accepts application/json.
+const MergePatchContentType = "application/merge-patch+json"
+
+// errMergePatchNotObject is returned when a merge patch body is not a JSON
+// object. RFC 7396 allows other values, which replace the whole target, but
+// a resource update always patches an object.
+var errMergePatchNotObject = errors.New("merge patch must be a JSON object")
+
+// errMergePatchTarget is returned when the value a merge patch is applied to
+// is not a struct or a non-nil pointer to one.
+var errMergePatchTarget = errors.New("merge patch target must be a struct or non-nil pointer to struct")
+
+// decodeMergePatch parses a merge patch body and returns it with the sorted
+// names of its top-level members, including those set to null.
+func decodeMergePatch(body []byte) (map[string]any, []string, error) {
+	var patch any
+	if err := decodeJSONNumber(body, &patch); err != nil {
+		return nil, nil, err
+	}
+	obj, ok := patch.(map[string]any)
+	if !ok {
+		return nil, nil, errMergePatchNotObject
+	}
+	return obj, slices.Sorted(maps.Keys(obj)), nil
+}
+
+// applyMergePatch encodes current, merges patch into it, and returns the
+// result as JSON. current itself is not modified.
+func applyMergePatch(current any, patch map[string]any) ([]byte, error) {
+	b, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("encode current: %w", err)
+	}
+	var target any
+	if err := decodeJSONNumber(b, &target); err != nil {
+		return nil, fmt.Errorf("decode current: %w", err)
+	}
+	return json.Marshal(mergePatch(target, patch))
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396: members set
+// to null are removed, objects are merged recursively, and any other value
+// replaces the target's member outright, arrays included.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// newLike returns a pointer to a new zero value of v's type, or of the type
+// v points to. v must be a struct or a non-nil pointer to one.
+func newLike(v any) (any, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errMergePatchTarget
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errMergePatchTarget
+	}
+	return reflect.New(rv.Type()).Interface(), nil
+}
+
+// decodeJSONNumber decodes b keeping numbers as json.Number, so large
+// integers survive the round trip through map[string]any.
+func decodeJSONNumber(b []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+)
+
+// mountedApp is a sub-application mounted under a path prefix.
+type mountedApp struct {
+	app    *App
+	prefix string
+}
+
+// mountPrefixKey stores the accumulated mount prefix in the request context.
+type mountPrefixKey struct{}
+
+// mountHandler returns the sub-app's router with the mount prefix recorded
+// in the request context. Nested mounts accumulate their prefixes.
+func (m mountedApp) mountHandler() http.Handler {
+	sub := m.app.Router()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := mountPrefix(r) + m.prefix
+		ctx := context.WithValue(r.Context(), mountPrefixKey{}, prefix)
+		sub.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// mountPrefix returns the path prefix the current app is mounted under,
+// or an empty string for a top-level app.
+func mountPrefix(r *http.Request) string {
+	prefix, _ := r.Context().Value(mountPrefixKey{}).(string)
+	return prefix
+}
+
+// stripMountPrefix serves h with the mount prefix removed from the URL path,
+// so file lookups resolve the same as in a standalone app.
+func stripMountPrefix(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if prefix := mountPrefix(r); prefix != "" {
+			http.StripPrefix(prefix, h).ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// appTree returns a and every app mounted beneath it, depth first.
+func (a *App) appTree() []*App {
+	apps := []*App{a}
+	for _, m := range a.mounts {
+		apps = append(apps, m.app.appTree()...)
+	}
+	return apps
+}
@@ -0,0 +1,105 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestWithMount(t *testing.T) {
+	t.Parallel()
+
+	newAdmin := func(trace *traceRecorder) *internal.App {
+		return internal.New(
+			internal.WithMiddleware(trace.mw("admin")),
+			internal.WithNotFoundHandler(func(c internal.Context) error {
+				return c.String(http.StatusNotFound, "admin not found")
+			}),
+			internal.WithStaticFiles("/assets/", fstest.MapFS{
+				"public/assets/app.css": {Data: []byte("body{}")},
+			}, "public"),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/users/{id}", func(c internal.Context) error {
+					trace.add("handler")
+					return c.String(http.StatusOK, c.Param("id")+" "+c.Request().URL.Path)
+				})
+			})),
+		)
+	}
+
+	newApp := func(trace *traceRecorder) *internal.App {
+		return internal.New(
+			internal.WithMiddleware(trace.mw("global")),
+			internal.WithMount("/admin", newAdmin(trace)),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					return c.String(http.StatusOK, "home")
+				})
+			})),
+		)
+	}
+
+	t.Run("routes resolve relative to prefix", func(t *testing.T) {
+		t.Parallel()
+
+		trace := &traceRecorder{}
+		w := serveRecorder(newApp(trace).Router(), httptest.NewRequest(http.MethodGet, "/admin/users/42", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "42 /admin/users/42", w.Body.String())
+		require.Equal(t, "global,admin,handler", trace.take())
+	})
+
+	t.Run("sub-app middleware does not leak to parent", func(t *testing.T) {
+		t.Parallel()
+
+		trace := &traceRecorder{}
+		w := serveRecorder(newApp(trace).Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, "home", w.Body.String())
+		require.Equal(t, "global", trace.take())
+	})
+
+	t.Run("sub-app not found handler applies within prefix", func(t *testing.T) {
+		t.Parallel()
+
+		trace := &traceRecorder{}
+		w := serveRecorder(newApp(trace).Router(), httptest.NewRequest(http.MethodGet, "/admin/missing", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "admin not found", w.Body.String())
+	})
+
+	t.Run("static files resolve relative to prefix", func(t *testing.T) {
+		t.Parallel()
+
+		trace := &traceRecorder{}
+		app := newApp(trace)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/admin/assets/app.css", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "body{}", w.Body.String())
+
+		standalone := newAdmin(&traceRecorder{})
+		w = serveRecorder(standalone.Router(), httptest.NewRequest(http.MethodGet, "/assets/app.css", nil))
+		require.Equal(t, "body{}", w.Body.String())
+	})
+
+	t.Run("nested mounts accumulate prefixes", func(t *testing.T) {
+		t.Parallel()
+
+		inner := internal.New(
+			internal.WithStaticFiles("/assets/", fstest.MapFS{
+				"public/assets/app.css": {Data: []byte("inner")},
+			}, "public"),
+		)
+		middle := internal.New(internal.WithMount("/v1", inner))
+		app := internal.New(internal.WithMount("/api", middle))
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/api/v1/assets/app.css", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "inner", w.Body.String())
+	})
+}
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+)
+
+// onceStoreKey holds the request's *onceStore in its context.
+type onceStoreKey struct{}
+
+// onceStore memoizes Once results for one request. The mutex guards the map
+// only; each entry's sync.Once serializes its own loader, so slow loaders
+// for different keys do not block each other.
+type onceStore struct {
+	mu      sync.Mutex
+	entries map[string]*onceEntry
+}
+
+type onceEntry struct {
+	once sync.Once
+	val  any
+	err  error
+}
+
+func (s *onceStore) entry(key string) *onceEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		e = &onceEntry{}
+		s.entries[key] = e
+	}
+	return e
+}
+
+// Once memoizes fn for the rest of the request under key. See forge.Once.
+//
+// If fn panics, the panic propagates to the first caller and later calls for
+// key return it as a *PanicError, since sync.Once never runs fn again.
+func Once[T any](c Context, key string, fn func() (T, error)) (T, error) {
+	store, ok := c.Get(onceStoreKey{}).(*onceStore)
+	if !ok {
+		store = &onceStore{entries: make(map[string]*onceEntry)}
+		c.Set(onceStoreKey{}, store)
+	}
+
+	e := store.entry(key)
+	e.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				e.err = &PanicError{Value: r, Stack: debug.Stack()}
+				panic(r)
+			}
+		}()
+		e.val, e.err = fn()
+	})
+
+	if e.val == nil {
+		var zero T
+		return zero, e.err
+	}
+	v, ok := e.val.(T)
+	if !ok {
+		panic(fmt.Sprintf("forge: Once key %q holds %T, not %s", key, e.val, reflect.TypeFor[T]()))
+	}
+	return v, e.err
+}
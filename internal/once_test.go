@@ -0,0 +1,155 @@
+package internal_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestOnce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs loader once per key", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		var calls int
+		load := func() (string, error) {
+			calls++
+			return "alice", nil
+		}
+
+		for range 3 {
+			v, err := internal.Once(c, "user", load)
+			require.NoError(t, err)
+			require.Equal(t, "alice", v)
+		}
+		require.Equal(t, 1, calls)
+
+		v, err := internal.Once(c, "other", func() (string, error) { return "bob", nil })
+		require.NoError(t, err)
+		require.Equal(t, "bob", v)
+	})
+
+	t.Run("memoizes errors", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		errLoad := errors.New("db down")
+		var calls int
+		load := func() (*struct{}, error) {
+			calls++
+			return nil, errLoad
+		}
+
+		_, err := internal.Once(c, "user", load)
+		require.ErrorIs(t, err, errLoad)
+		v, err := internal.Once(c, "user", load)
+		require.ErrorIs(t, err, errLoad)
+		require.Nil(t, v)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("waits for concurrent loader", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		var calls atomic.Int32
+		// The first call stores the memo; later calls may run concurrently.
+		_, _ = internal.Once(c, "warmup", func() (int, error) { return 0, nil })
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				v, err := internal.Once(c, "n", func() (int, error) {
+					calls.Add(1)
+					return 42, nil
+				})
+				require.NoError(t, err)
+				require.Equal(t, 42, v)
+			})
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("remembers a loader panic as an error", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		var calls int
+		load := func() (string, error) {
+			calls++
+			panic("db exploded")
+		}
+
+		require.PanicsWithValue(t, "db exploded", func() {
+			_, _ = internal.Once(c, "user", load)
+		})
+
+		v, err := internal.Once(c, "user", load)
+		var pe *internal.PanicError
+		require.ErrorAs(t, err, &pe)
+		require.Equal(t, "db exploded", pe.Value)
+		require.Empty(t, v)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("panics on type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		c := newParamContext(nil, "")
+		_, _ = internal.Once(c, "user", func() (string, error) { return "alice", nil })
+
+		require.PanicsWithValue(t, `forge: Once key "user" holds string, not int`, func() {
+			_, _ = internal.Once(c, "user", func() (int, error) { return 1, nil })
+		})
+	})
+
+	t.Run("shares results between middleware and handler but not requests", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		loadUser := func(c internal.Context) (string, error) {
+			return internal.Once(c, "current_user", func() (string, error) {
+				calls.Add(1)
+				return "alice", nil
+			})
+		}
+		mw := func(next internal.HandlerFunc) internal.HandlerFunc {
+			return func(c internal.Context) error {
+				if _, err := loadUser(c); err != nil {
+					return err
+				}
+				return next(c)
+			}
+		}
+
+		app := internal.New(
+			internal.WithMiddleware(mw, mw),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					user, err := loadUser(c)
+					if err != nil {
+						return err
+					}
+					return c.String(http.StatusOK, user)
+				})
+			})),
+		)
+
+		for range 2 {
+			w := httptest.NewRecorder()
+			app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			require.Equal(t, "alice", w.Body.String())
+		}
+		require.Equal(t, int32(2), calls.Load())
+	})
+}
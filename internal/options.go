@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/dmitrymomot/forge/pkg/job"
 	"github.com/dmitrymomot/forge/pkg/logger"
 	"github.com/dmitrymomot/forge/pkg/session"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
 	"github.com/dmitrymomot/forge/pkg/storage"
 )
 
@@ -33,6 +35,41 @@ func WithBaseDomain(domain string) Option {
 	}
 }
 
+// WithMaxMultipartMemory sets how many bytes of a multipart form c.Bind and
+// c.FormFile keep in memory (default: binder.DefaultMaxMemory, 10MB). File
+// parts beyond it are buffered in temporary files on disk, so this bounds
+// memory use, not upload size; cap uploads with middlewares.BodyLimit.
+func WithMaxMultipartMemory(n int64) Option {
+	return func(a *App) {
+		a.maxMultipartMemory = n
+	}
+}
+
+// WithJSONEncoder configures how c.JSON and c.JSONError encode responses.
+// Without it, output matches encoding/json's defaults: compact, with HTML
+// characters escaped. The settings are fixed at startup and shared by all
+// requests.
+//
+// There is no option for field-name casing such as snake_case or
+// camelCase: renaming keys at encode time would also rename map keys, which
+// are data. Set names with json struct tags.
+//
+// Example:
+//
+//	forge.WithJSONEncoder(
+//	    forge.WithJSONEscapeHTML(false),
+//	    forge.WithJSONPrettyQuery(), // ?pretty=1 indents c.JSON output
+//	)
+func WithJSONEncoder(opts ...JSONOption) Option {
+	return func(a *App) {
+		e := *defaultJSON
+		for _, opt := range opts {
+			opt(&e)
+		}
+		a.json = &e
+	}
+}
+
 // WithMiddleware adds global middleware to the application.
 // Middleware is applied in the order provided.
 func WithMiddleware(mw ...Middleware) Option {
@@ -41,6 +78,16 @@ func WithMiddleware(mw ...Middleware) Option {
 	}
 }
 
+// WithRouteMiddleware adds middleware that runs inside every route, after the
+// route's own middleware and just before its handler. Unlike WithMiddleware,
+// it sees metadata declared with Meta, so one policy middleware can enforce
+// what each route declares.
+func WithRouteMiddleware(mw ...Middleware) Option {
+	return func(a *App) {
+		a.routeMiddlewares = append(a.routeMiddlewares, mw...)
+	}
+}
+
 // WithHandlers registers handlers that declare routes.
 // Each handler's Routes method is called during setup.
 func WithHandlers(h ...Handler) Option {
@@ -82,12 +129,37 @@ func WithStaticFiles(pattern string, fsys fs.FS, subDir string) Option {
 			fileServer.ServeHTTP(w, r)
 		})
 
-		a.staticRoutes = append(a.staticRoutes, staticRoute{handler, pattern})
+		a.staticRoutes = append(a.staticRoutes, staticRoute{stripMountPrefix(handler), pattern})
+	}
+}
+
+// WithMount mounts a sub-application under a path prefix.
+// The sub-app's routes, middleware, and not-found handler apply within the
+// prefix, after the parent's global middleware. Route patterns, path
+// parameters, and static files resolve relative to the prefix, while
+// c.Request().URL.Path keeps the full path. Job workers and request limits
+// of mounted apps are started and drained with the parent.
+//
+// Example:
+//
+//	admin := forge.New(
+//	    forge.WithMiddleware(requireAdmin),
+//	    forge.WithHandlers(handlers.NewAdmin(repo)),
+//	)
+//
+//	app := forge.New(
+//	    forge.WithHandlers(handlers.NewPages(repo)),
+//	    forge.WithMount("/admin", admin),
+//	)
+func WithMount(prefix string, sub *App) Option {
+	return func(a *App) {
+		a.mounts = append(a.mounts, mountedApp{app: sub, prefix: prefix})
 	}
 }
 
 // WithErrorHandler sets a custom error handler for handler errors.
-// Called when a handler returns a non-nil error.
+// Called when a handler returns a non-nil error before writing a response.
+// Errors returned after the response has started are logged, not rendered.
 //
 // Example:
 //
@@ -153,6 +225,56 @@ func WithHealthChecks(opts ...HealthOption) Option {
 	}
 }
 
+// WithMaxConcurrentRequests caps the number of requests handled at once.
+// When all n slots are busy, new requests are rejected with 503 Service
+// Unavailable and a Retry-After header, rendered by the error handler.
+// Health check endpoints are never limited. On shutdown, in-flight requests
+// are drained within the shutdown timeout. A value of n <= 0 disables the limit.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithMaxConcurrentRequests(200), // keep below the DB pool size
+//	)
+func WithMaxConcurrentRequests(n int) Option {
+	return func(a *App) {
+		if n <= 0 {
+			a.limiter = nil
+			return
+		}
+		a.limiter = newRequestLimiter(n)
+	}
+}
+
+// WithRequestTimeout puts a deadline of d on every request's context, so
+// c.Context() carries it to all database and HTTP calls without adding
+// middleware. A handler that fails because the deadline passed has its
+// error wrapped in a *TimeoutError, which the error handler can answer with
+// 504 Gateway Timeout. Without an error handler the App responds 504 to
+// any *TimeoutError.
+//
+// The deadline only stops work that honors the context. Unlike
+// middlewares.Timeout, the App waits for the handler to return, so the
+// error handler never races a handler still writing the response.
+//
+// When both are configured, the shortest deadline wins: middlewares.Timeout
+// and TimeoutFor derive their contexts from the request context, so they
+// can shorten the app deadline but not extend it. Websocket connections
+// from c.Upgrade and streams such as c.StreamJSON end with the request
+// context too, so give those routes SkipRequestTimeout. A value of d <= 0
+// disables the timeout.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithRequestTimeout(10 * time.Second),
+//	)
+func WithRequestTimeout(d time.Duration) Option {
+	return func(a *App) {
+		a.requestTimeout = max(d, 0)
+	}
+}
+
 // WithLogger creates a logger with a component name and optional extractors.
 // The component name is added to every log entry for easy filtering.
 // Extractors pull values from context (e.g., request_id, user_id).
@@ -201,6 +323,16 @@ func WithCookieOptions(opts ...cookie.Option) Option {
 	}
 }
 
+// WithJWTCookieName sets the cookie c.SetJWTCookie writes and FromJWTCookie
+// reads (default: DefaultJWTCookie).
+func WithJWTCookieName(name string) Option {
+	return func(a *App) {
+		if name != "" {
+			a.jwtCookie = name
+		}
+	}
+}
+
 // WithSession enables server-side session management.
 // A session.Store implementation must be provided (e.g., PostgresStore).
 // Sessions are loaded lazily and saved automatically before the response is written.
@@ -296,6 +428,30 @@ func WithJobWorker(pool *pgxpool.Pool, opts ...job.Option) Option {
 	}
 }
 
+// WithJobContextPropagation copies the request context values stored under
+// keys into the metadata of every job enqueued with c.Enqueue, c.EnqueueTx,
+// and c.EnqueueWithID. Register the same keys on the worker with
+// job.WithContextPropagation to restore them into the context Handle
+// receives, so worker logs correlate with the originating request.
+//
+// Values must be JSON-encodable; only strings round-trip with their type
+// intact. See job.CaptureContext.
+//
+// Example:
+//
+//	forge.New(
+//	    forge.WithJobs(pool,
+//	        job.WithTask(tasks.NewSendEmail(mailer)),
+//	        job.WithContextPropagation(tenantKey, traceKey),
+//	    ),
+//	    forge.WithJobContextPropagation(tenantKey, traceKey),
+//	)
+func WithJobContextPropagation(keys ...any) Option {
+	return func(a *App) {
+		a.jobContextKeys = append(a.jobContextKeys, keys...)
+	}
+}
+
 // WithRoles configures role-based access control for the application.
 // The permissions map defines which permissions each role grants.
 // The extractor function determines the current user's role from the request context.
@@ -340,3 +496,20 @@ func WithStorage(s storage.Storage) Option {
 		a.storage = s
 	}
 }
+
+// WithURLSigner configures the signer used by c.SignedURL().
+//
+// Example:
+//
+//	signer, err := signedurl.New(os.Getenv("COOKIE_SECRET"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	forge.New(
+//	    forge.WithURLSigner(signer),
+//	)
+func WithURLSigner(s *signedurl.Signer) Option {
+	return func(a *App) {
+		a.urlSigner = s
+	}
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dmitrymomot/forge/pkg/validator"
+)
+
+// Default pagination settings.
+const (
+	defaultPaginationLimit    = 20
+	defaultPaginationMaxLimit = 100
+)
+
+// Sort orders accepted by BindPagination.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// Pagination holds validated list parameters read from the query string.
+// Offset and Limit are ready to pass to SQL; Sort is empty or one of the
+// allowed sort fields, so it is safe to interpolate into ORDER BY.
+type Pagination struct {
+	Sort   string
+	Order  string
+	Page   int
+	Limit  int
+	Offset int
+}
+
+// paginationConfig holds BindPagination settings.
+type paginationConfig struct {
+	allowedSort  []string
+	defaultSort  string
+	defaultLimit int
+	maxLimit     int
+}
+
+// PaginationOption configures BindPagination.
+type PaginationOption func(*paginationConfig)
+
+// WithAllowedSortFields sets the fields the sort parameter may name.
+// Without it, any sort parameter is rejected.
+func WithAllowedSortFields(fields ...string) PaginationOption {
+	return func(cfg *paginationConfig) {
+		cfg.allowedSort = fields
+	}
+}
+
+// WithDefaultSort sets the sort field used when the request has none.
+func WithDefaultSort(field string) PaginationOption {
+	return func(cfg *paginationConfig) {
+		cfg.defaultSort = field
+	}
+}
+
+// WithDefaultLimit sets the page size used when the request has none.
+// Defaults to 20.
+func WithDefaultLimit(n int) PaginationOption {
+	return func(cfg *paginationConfig) {
+		cfg.defaultLimit = n
+	}
+}
+
+// WithMaxLimit caps the page size. Larger limits are reduced to n.
+// Defaults to 100.
+func WithMaxLimit(n int) PaginationOption {
+	return func(cfg *paginationConfig) {
+		cfg.maxLimit = n
+	}
+}
+
+// parsePagination reads page, limit, sort, and order from query.
+// Invalid values are reported together as ValidationErrors.
+func parsePagination(query func(name string) string, opts ...PaginationOption) (Pagination, ValidationErrors) {
+	cfg := &paginationConfig{
+		defaultLimit: defaultPaginationLimit,
+		maxLimit:     defaultPaginationMaxLimit,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	p := Pagination{
+		Page:  1,
+		Limit: cfg.defaultLimit,
+		Sort:  cfg.defaultSort,
+		Order: SortAsc,
+	}
+
+	var verrs ValidationErrors
+	parsePositive := func(name string, dst *int) {
+		raw := query(name)
+		if raw == "" {
+			return
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			verrs.Add(validator.ValidationError{
+				Field:          name,
+				Message:        "must be a valid integer",
+				TranslationKey: "validation.invalid_type",
+				TranslationValues: map[string]any{
+					"field": name,
+					"type":  "integer",
+				},
+			})
+			return
+		}
+		if rule := validator.MinNum(name, n, 1); !rule.Check() {
+			verrs.Add(rule.Error)
+			return
+		}
+		*dst = n
+	}
+	parsePositive("page", &p.Page)
+	parsePositive("limit", &p.Limit)
+	p.Limit = min(p.Limit, cfg.maxLimit)
+
+	// Bound page so Offset cannot overflow.
+	if p.Limit > 0 {
+		if rule := validator.MaxNum("page", p.Page, math.MaxInt/p.Limit); !rule.Check() {
+			verrs.Add(rule.Error)
+			p.Page = 1
+		}
+	}
+
+	if sort := query("sort"); sort != "" {
+		if rule := validator.InListString("sort", sort, cfg.allowedSort); !rule.Check() {
+			verrs.Add(rule.Error)
+		} else {
+			p.Sort = sort
+		}
+	}
+
+	if order := strings.ToLower(query("order")); order != "" {
+		if rule := validator.InListString("order", order, []string{SortAsc, SortDesc}); !rule.Check() {
+			verrs.Add(rule.Error)
+		} else {
+			p.Order = order
+		}
+	}
+
+	p.Offset = (p.Page - 1) * p.Limit
+	return p, verrs
+}
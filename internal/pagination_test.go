@@ -0,0 +1,118 @@
+package internal_test
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/validator"
+)
+
+func TestBindPagination(t *testing.T) {
+	t.Parallel()
+
+	bind := func(t *testing.T, target string, opts ...internal.PaginationOption) (internal.Pagination, error) {
+		t.Helper()
+
+		var (
+			p   internal.Pagination
+			err error
+		)
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		requestVia(t, req, nil, func(c internal.Context) {
+			p, err = c.BindPagination(opts...)
+		})
+		return p, err
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := bind(t, "/")
+		require.NoError(t, err)
+		require.Equal(t, internal.Pagination{Page: 1, Limit: 20, Offset: 0, Order: internal.SortAsc}, p)
+	})
+
+	t.Run("computes offset", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := bind(t, "/?page=3&limit=25")
+		require.NoError(t, err)
+		require.Equal(t, 3, p.Page)
+		require.Equal(t, 25, p.Limit)
+		require.Equal(t, 50, p.Offset)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := bind(t, "/?page=2&limit=500", internal.WithMaxLimit(50))
+		require.NoError(t, err)
+		require.Equal(t, 50, p.Limit)
+		require.Equal(t, 50, p.Offset)
+	})
+
+	t.Run("custom default limit and sort", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := bind(t, "/", internal.WithDefaultLimit(10), internal.WithDefaultSort("created_at"))
+		require.NoError(t, err)
+		require.Equal(t, 10, p.Limit)
+		require.Equal(t, "created_at", p.Sort)
+	})
+
+	t.Run("allowed sort and order", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := bind(t, "/?sort=name&order=DESC", internal.WithAllowedSortFields("name", "created_at"))
+		require.NoError(t, err)
+		require.Equal(t, "name", p.Sort)
+		require.Equal(t, internal.SortDesc, p.Order)
+	})
+
+	t.Run("rejects sort outside allowlist", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := bind(t, "/?sort=password", internal.WithAllowedSortFields("name"))
+		ve := validator.ExtractValidationErrors(err)
+		require.True(t, ve.Has("sort"))
+	})
+
+	t.Run("rejects sort without allowlist", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := bind(t, "/?sort=name")
+		ve := validator.ExtractValidationErrors(err)
+		require.True(t, ve.Has("sort"))
+	})
+
+	t.Run("reports all invalid parameters", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := bind(t, "/?page=abc&limit=0&order=up")
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 3)
+		require.Equal(t, "validation.invalid_type", ve.GetErrors("page")[0].TranslationKey)
+		require.Equal(t, "validation.min", ve.GetErrors("limit")[0].TranslationKey)
+		require.Equal(t, "validation.in_list", ve.GetErrors("order")[0].TranslationKey)
+	})
+
+	t.Run("rejects pages whose offset would overflow", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := bind(t, "/?page="+strconv.Itoa(math.MaxInt/10+1)+"&limit=10")
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+		require.Equal(t, "validation.max", ve.GetErrors("page")[0].TranslationKey)
+		require.GreaterOrEqual(t, p.Offset, 0)
+
+		p, err = bind(t, "/?page="+strconv.Itoa(math.MaxInt/10)+"&limit=10")
+		require.NoError(t, err)
+		require.Positive(t, p.Offset)
+	})
+}
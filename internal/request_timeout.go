@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// requestTimeoutParentKey stores the request context as it was before
+// WithRequestTimeout added its deadline, for SkipRequestTimeout.
+type requestTimeoutParentKey struct{}
+
+// withRequestTimeout puts the WithRequestTimeout deadline on the request
+// context, so c.Context() carries it to every database and HTTP call.
+func (a *App) withRequestTimeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), a.requestTimeout)
+		defer cancel()
+		ctx = context.WithValue(ctx, requestTimeoutParentKey{}, r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// asRequestTimeout wraps err in a TimeoutError when it was caused by the
+// WithRequestTimeout deadline passing, so error handlers can tell it apart
+// with IsTimeoutError and answer 504.
+func (a *App) asRequestTimeout(c Context, err error) error {
+	if a.requestTimeout <= 0 || !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if !errors.Is(c.Context().Err(), context.DeadlineExceeded) {
+		return err
+	}
+	var te *TimeoutError
+	if errors.As(err, &te) {
+		return err
+	}
+	c.Logger().WarnContext(c.Context(), "request timeout", "timeout", a.requestTimeout.String())
+	return &TimeoutError{Duration: a.requestTimeout, Err: err}
+}
+
+// SkipRequestTimeout returns middleware that lifts the WithRequestTimeout
+// deadline for a route or group, for websockets, server-sent events, and
+// other long-lived responses:
+//
+//	r.GET("/ws", h.socket, forge.SkipRequestTimeout())
+//	r.With(forge.SkipRequestTimeout()).GET("/events", h.events)
+//
+// The request context keeps its values and is still canceled when the
+// client disconnects. Middleware that sets its own deadline after it, such
+// as middlewares.TimeoutFor, still applies. Without WithRequestTimeout it
+// does nothing.
+func SkipRequestTimeout() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			rc, ok := c.(*requestContext)
+			if !ok {
+				return next(c)
+			}
+			parent, ok := rc.request.Context().Value(requestTimeoutParentKey{}).(context.Context)
+			if !ok {
+				return next(c)
+			}
+			ctx := untimedContext{Context: rc.request.Context(), parent: parent}
+			rc.request = rc.request.WithContext(ctx)
+			return next(c)
+		}
+	}
+}
+
+// untimedContext keeps the values of Context but takes its deadline and
+// cancellation from parent, the request context before the app deadline.
+type untimedContext struct {
+	context.Context
+	parent context.Context
+}
+
+func (c untimedContext) Deadline() (time.Time, bool) { return c.parent.Deadline() }
+func (c untimedContext) Done() <-chan struct{}       { return c.parent.Done() }
+func (c untimedContext) Err() error                  { return c.parent.Err() }
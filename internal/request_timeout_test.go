@@ -0,0 +1,210 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestWithRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	// waitForDeadline blocks until the request context is done and returns
+	// its error, like a database call honoring the context would.
+	waitForDeadline := func(c internal.Context) error {
+		<-c.Context().Done()
+		return c.Context().Err()
+	}
+
+	t.Run("deadline is set on the request context", func(t *testing.T) {
+		t.Parallel()
+
+		var deadline time.Time
+		var ok bool
+		app := internal.New(
+			internal.WithRequestTimeout(time.Minute),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					deadline, ok = c.Context().Deadline()
+					return c.NoContent(http.StatusOK)
+				})
+			})),
+		)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	})
+
+	t.Run("no deadline by default", func(t *testing.T) {
+		t.Parallel()
+
+		var ok bool
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				_, ok = c.Context().Deadline()
+				return c.NoContent(http.StatusOK)
+			})
+		})))
+
+		serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.False(t, ok)
+	})
+
+	t.Run("exceeded deadline responds 504 without an error handler", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithRequestTimeout(10*time.Millisecond),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", waitForDeadline)
+			})),
+		)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+
+	t.Run("error handler receives a TimeoutError", func(t *testing.T) {
+		t.Parallel()
+
+		var got error
+		app := internal.New(
+			internal.WithRequestTimeout(10*time.Millisecond),
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				got = err
+				return c.NoContent(http.StatusGatewayTimeout)
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", waitForDeadline)
+			})),
+		)
+
+		serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		var te *internal.TimeoutError
+		require.ErrorAs(t, got, &te)
+		require.Equal(t, 10*time.Millisecond, te.Duration)
+		require.ErrorIs(t, got, context.DeadlineExceeded)
+	})
+
+	t.Run("other errors are left alone", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithRequestTimeout(time.Minute),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					return errors.New("boom")
+				})
+			})),
+		)
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("shorter middleware deadline wins", func(t *testing.T) {
+		t.Parallel()
+
+		var deadline time.Time
+		app := internal.New(
+			internal.WithRequestTimeout(time.Minute),
+			internal.WithMiddleware(func(next internal.HandlerFunc) internal.HandlerFunc {
+				return func(c internal.Context) error {
+					ctx, cancel := context.WithTimeout(c.Context(), time.Second)
+					defer cancel()
+					c.Set(deadlineKey{}, ctx)
+					return next(c)
+				}
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					deadline, _ = c.Get(deadlineKey{}).(context.Context).Deadline()
+					return c.NoContent(http.StatusOK)
+				})
+			})),
+		)
+
+		serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil))
+		require.WithinDuration(t, time.Now().Add(time.Second), deadline, 500*time.Millisecond)
+	})
+
+	t.Run("skipped route streams past the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// slowStream sends one item after the app deadline has passed.
+		slowStream := func(c internal.Context) error {
+			ch := make(chan any)
+			go func() {
+				time.Sleep(60 * time.Millisecond)
+				select {
+				case ch <- 1:
+					close(ch)
+				case <-c.Context().Done():
+				}
+			}()
+			return c.StreamJSON(http.StatusOK, ch)
+		}
+		app := internal.New(
+			internal.WithRequestTimeout(20*time.Millisecond),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/skipped", slowStream, internal.SkipRequestTimeout())
+				r.With(internal.SkipRequestTimeout()).GET("/group", slowStream)
+				r.GET("/timed", slowStream)
+			})),
+		)
+
+		for _, path := range []string{"/skipped", "/group"} {
+			w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, "[1]\n", w.Body.String(), path)
+		}
+
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/timed", nil))
+		require.Equal(t, "[", w.Body.String(), "the deadline ends the stream")
+	})
+
+	t.Run("skipped route keeps values and client cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		var hasDeadline bool
+		var value any
+		var done bool
+		app := internal.New(
+			internal.WithRequestTimeout(time.Minute),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", func(c internal.Context) error {
+					_, hasDeadline = c.Context().Deadline()
+					value = c.Get(deadlineKey{})
+					select {
+					case <-c.Context().Done():
+						done = true
+					case <-time.After(time.Second):
+					}
+					return nil
+				}, func(next internal.HandlerFunc) internal.HandlerFunc {
+					return func(c internal.Context) error {
+						c.Set(deadlineKey{}, "kept")
+						return next(c)
+					}
+				}, internal.SkipRequestTimeout())
+			})),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+		require.False(t, hasDeadline)
+		require.Equal(t, "kept", value)
+		require.True(t, done, "client cancellation still reaches the handler")
+	})
+}
+
+type deadlineKey struct{}
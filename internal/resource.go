@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceHandler is a REST resource registered with Resource. It implements
+// any of ResourceLister, ResourceCreator, ResourceShower, ResourceUpdater,
+// and ResourceDeleter; each implemented method gets its route.
+type ResourceHandler any
+
+// ResourceLister handles GET /prefix.
+type ResourceLister interface {
+	List(c Context) error
+}
+
+// ResourceCreator handles POST /prefix.
+type ResourceCreator interface {
+	Create(c Context) error
+}
+
+// ResourceShower handles GET /prefix/{id}.
+type ResourceShower interface {
+	Show(c Context) error
+}
+
+// ResourceUpdater handles PUT and PATCH /prefix/{id}.
+type ResourceUpdater interface {
+	Update(c Context) error
+}
+
+// ResourceDeleter handles DELETE /prefix/{id}.
+type ResourceDeleter interface {
+	Delete(c Context) error
+}
+
+// Resource registers the standard REST routes for h under prefix:
+//
+//	GET    /prefix       List
+//	POST   /prefix       Create
+//	GET    /prefix/{id}  Show
+//	PUT    /prefix/{id}  Update
+//	PATCH  /prefix/{id}  Update
+//	DELETE /prefix/{id}  Delete
+//
+// Only the methods h implements are registered, so other verbs on a matched
+// path get the router's usual 405. Handlers read the ID with c.Param("id").
+// Resource panics if h implements none of them, since that is always a
+// wiring mistake.
+func Resource(r Router, prefix string, h ResourceHandler) {
+	base := strings.TrimSuffix(prefix, "/")
+	collection, item := base, base+"/{id}"
+	if collection == "" {
+		collection = "/"
+	}
+
+	registered := false
+	if l, ok := h.(ResourceLister); ok {
+		r.GET(collection, l.List)
+		registered = true
+	}
+	if cr, ok := h.(ResourceCreator); ok {
+		r.POST(collection, cr.Create)
+		registered = true
+	}
+	if s, ok := h.(ResourceShower); ok {
+		r.GET(item, s.Show)
+		registered = true
+	}
+	if u, ok := h.(ResourceUpdater); ok {
+		r.PUT(item, u.Update)
+		r.PATCH(item, u.Update)
+		registered = true
+	}
+	if d, ok := h.(ResourceDeleter); ok {
+		r.DELETE(item, d.Delete)
+		registered = true
+	}
+
+	if !registered {
+		panic(fmt.Sprintf("forge: resource %q implements none of List, Create, Show, Update, Delete", prefix))
+	}
+}
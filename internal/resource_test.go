@@ -0,0 +1,105 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+type fullResource struct{}
+
+func (fullResource) List(c internal.Context) error   { return c.String(http.StatusOK, "list") }
+func (fullResource) Create(c internal.Context) error { return c.String(http.StatusCreated, "create") }
+func (fullResource) Show(c internal.Context) error {
+	return c.String(http.StatusOK, "show "+c.Param("id"))
+}
+func (fullResource) Update(c internal.Context) error {
+	return c.String(http.StatusOK, "update "+c.Param("id"))
+}
+func (fullResource) Delete(c internal.Context) error { return c.NoContent(http.StatusNoContent) }
+
+type readOnlyResource struct{}
+
+func (readOnlyResource) List(c internal.Context) error { return c.String(http.StatusOK, "list") }
+func (readOnlyResource) Show(c internal.Context) error {
+	return c.String(http.StatusOK, "show "+c.Param("id"))
+}
+
+func TestResource(t *testing.T) {
+	t.Parallel()
+
+	serve := func(t *testing.T, h internal.ResourceHandler, prefix, method, target string) *httptest.ResponseRecorder {
+		t.Helper()
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			internal.Resource(r, prefix, h)
+		})))
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	t.Run("registers every implemented method", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			method string
+			target string
+			code   int
+			body   string
+		}{
+			{http.MethodGet, "/posts", http.StatusOK, "list"},
+			{http.MethodPost, "/posts", http.StatusCreated, "create"},
+			{http.MethodGet, "/posts/42", http.StatusOK, "show 42"},
+			{http.MethodPut, "/posts/42", http.StatusOK, "update 42"},
+			{http.MethodPatch, "/posts/42", http.StatusOK, "update 42"},
+			{http.MethodDelete, "/posts/42", http.StatusNoContent, ""},
+		}
+		for _, tt := range tests {
+			w := serve(t, fullResource{}, "/posts", tt.method, tt.target)
+			require.Equal(t, tt.code, w.Code, "%s %s", tt.method, tt.target)
+			require.Equal(t, tt.body, w.Body.String(), "%s %s", tt.method, tt.target)
+		}
+	})
+
+	t.Run("unimplemented verbs return 405", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, http.StatusOK, serve(t, readOnlyResource{}, "/posts", http.MethodGet, "/posts/1").Code)
+		require.Equal(t, http.StatusMethodNotAllowed, serve(t, readOnlyResource{}, "/posts", http.MethodPost, "/posts").Code)
+		require.Equal(t, http.StatusMethodNotAllowed, serve(t, readOnlyResource{}, "/posts", http.MethodDelete, "/posts/1").Code)
+	})
+
+	t.Run("trailing slash in prefix is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		w := serve(t, fullResource{}, "/posts/", http.MethodGet, "/posts/7")
+		require.Equal(t, "show 7", w.Body.String())
+	})
+
+	t.Run("works inside Route groups", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.Route("/api", func(r internal.Router) {
+				internal.Resource(r, "/posts", readOnlyResource{})
+			})
+		})))
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/posts/9", nil))
+		require.Equal(t, "show 9", w.Body.String())
+	})
+
+	t.Run("panics without any resource method", func(t *testing.T) {
+		t.Parallel()
+
+		require.Panics(t, func() {
+			internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+				internal.Resource(r, "/posts", struct{}{})
+			}))).Router()
+		})
+	})
+}
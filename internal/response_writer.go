@@ -13,11 +13,15 @@ import (
 type ResponseWriter struct {
 	http.ResponseWriter
 	beforeWrite []func()
+	afterWrite  []func()
+	onWrite     []func([]byte)
 	status      int
 	size        int64
 	mu          sync.Mutex
 	written     bool
+	flushed     bool
 	isHTMX      bool
+	gone        bool
 }
 
 // NewResponseWriter creates a new ResponseWriter.
@@ -37,6 +41,81 @@ func (w *ResponseWriter) OnBeforeWrite(fn func()) {
 	w.beforeWrite = append(w.beforeWrite, fn)
 }
 
+// OnAfterWrite registers a hook to run once the response is complete.
+// Hooks are stored on the outermost ResponseWriter and run in registration
+// order after the whole handler chain returns.
+func (w *ResponseWriter) OnAfterWrite(fn func()) {
+	r := w.root()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.afterWrite = append(r.afterWrite, fn)
+}
+
+// OnWrite registers a hook that receives every chunk of the response body
+// after it is written to the client. Hooks run on the writing goroutine and
+// must not retain b; copy it to keep it.
+func (w *ResponseWriter) OnWrite(fn func(b []byte)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onWrite = append(w.onWrite, fn)
+}
+
+// takeAfterWrite returns the registered after-write hooks and clears them.
+func (w *ResponseWriter) takeAfterWrite() []func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	hooks := w.afterWrite
+	w.afterWrite = nil
+	return hooks
+}
+
+// root returns the outermost ResponseWriter in the wrapper chain.
+// Each middleware layer wraps the previous layer's writer, so the root
+// belongs to the layer that sees the request first and finishes last.
+func (w *ResponseWriter) root() *ResponseWriter {
+	root := w
+	next := w.ResponseWriter
+	for next != nil {
+		switch t := next.(type) {
+		case *ResponseWriter:
+			root = t
+			next = t.ResponseWriter
+		case interface{ Unwrap() http.ResponseWriter }:
+			next = t.Unwrap()
+		default:
+			return root
+		}
+	}
+	return root
+}
+
+// markDisconnected records that the client went away on w and on every
+// ResponseWriter it wraps, so outer middleware layers see it too.
+func (w *ResponseWriter) markDisconnected() {
+	var next http.ResponseWriter = w
+	for next != nil {
+		switch t := next.(type) {
+		case *ResponseWriter:
+			t.mu.Lock()
+			t.gone = true
+			t.mu.Unlock()
+			next = t.ResponseWriter
+		case interface{ Unwrap() http.ResponseWriter }:
+			next = t.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// disconnected reports whether a write on this or an inner layer found the
+// client gone.
+func (w *ResponseWriter) disconnected() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.gone
+}
+
 // WriteHeader sends an HTTP response header with the provided status code.
 // For HTMX requests, non-200 status codes are transformed to 200.
 func (w *ResponseWriter) WriteHeader(code int) {
@@ -59,8 +138,9 @@ func (w *ResponseWriter) WriteHeader(code int) {
 
 	// HTMX transformation: non-200 → 200
 	// HTMX requires 2xx status to trigger swaps; transform errors to 200
-	// and rely on response body to indicate the actual error state
-	if w.isHTMX && code != http.StatusOK {
+	// and rely on response body to indicate the actual error state.
+	// Protocol switches (WebSocket upgrades) must keep their status.
+	if w.isHTMX && code != http.StatusOK && code != http.StatusSwitchingProtocols {
 		code = http.StatusOK
 	}
 
@@ -88,7 +168,14 @@ func (w *ResponseWriter) Write(b []byte) (int, error) {
 	n, err := w.ResponseWriter.Write(b)
 	w.mu.Lock()
 	w.size += int64(n)
+	hooks := w.onWrite
 	w.mu.Unlock()
+
+	if n > 0 {
+		for _, fn := range hooks {
+			fn(b[:n])
+		}
+	}
 	return n, err
 }
 
@@ -113,11 +200,37 @@ func (w *ResponseWriter) Written() bool {
 	return w.written
 }
 
+// Flushed reports whether the response has been flushed to the client.
+// Middleware that buffers or replays responses should leave flushed
+// (streamed) responses alone.
+func (w *ResponseWriter) Flushed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushed
+}
+
 // Flush implements the http.Flusher interface.
+// Flushing before anything is written sends the headers with the current
+// status, running the before-write hooks first.
+// Wrapped writers that expose Unwrap, such as compression middleware, are
+// searched for a flusher so buffered output reaches the client.
 func (w *ResponseWriter) Flush() {
-	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
-		flusher.Flush()
+	_ = w.FlushError()
+}
+
+// FlushError flushes like Flush and returns the underlying writer's error.
+// It returns http.ErrNotSupported if no writer in the chain can flush.
+// http.ResponseController prefers it over Flush.
+func (w *ResponseWriter) FlushError() error {
+	w.mu.Lock()
+	written := w.written
+	w.flushed = true
+	w.mu.Unlock()
+
+	if !written {
+		w.WriteHeader(w.Status())
 	}
+	return http.NewResponseController(w.ResponseWriter).Flush()
 }
 
 // Hijack implements the http.Hijacker interface.
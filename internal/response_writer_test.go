@@ -57,6 +57,25 @@ func TestResponseWriterSizeTracking(t *testing.T) {
 	})
 }
 
+func TestResponseWriterOnWrite(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	rw := internal.NewResponseWriter(rec, false)
+
+	var chunks []string
+	rw.OnWrite(func(b []byte) { chunks = append(chunks, string(b)) })
+
+	rw.WriteHeader(http.StatusCreated)
+	_, err := rw.Write([]byte("hello"))
+	require.NoError(t, err)
+	_, err = rw.Write([]byte(" world"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"hello", " world"}, chunks)
+	require.Equal(t, "hello world", rec.Body.String())
+}
+
 func TestResponseWriterConcurrentAccess(t *testing.T) {
 	t.Parallel()
 
@@ -84,3 +103,72 @@ func TestResponseWriterConcurrentAccess(t *testing.T) {
 	wg.Wait()
 	require.Equal(t, int64(iterations), rw.Size())
 }
+
+// unwrapOnlyWriter wraps a writer without implementing http.Flusher itself,
+// like many compression middleware writers that only expose Unwrap.
+type unwrapOnlyWriter struct {
+	http.ResponseWriter
+}
+
+func (w *unwrapOnlyWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+func TestResponseWriterFlush(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flushes underlying writer", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		rw := internal.NewResponseWriter(rec, false)
+		rw.Flush()
+		require.True(t, rec.Flushed)
+	})
+
+	t.Run("flushes through unwrappable writer", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		rw := internal.NewResponseWriter(&unwrapOnlyWriter{ResponseWriter: rec}, false)
+		rw.Flush()
+		require.True(t, rec.Flushed)
+	})
+	t.Run("tracks flushed responses", func(t *testing.T) {
+		t.Parallel()
+
+		rw := internal.NewResponseWriter(httptest.NewRecorder(), false)
+		require.False(t, rw.Flushed())
+		rw.Flush()
+		require.True(t, rw.Flushed())
+	})
+
+	t.Run("flush before write sends headers after hooks", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		rw := internal.NewResponseWriter(rec, false)
+		rw.OnBeforeWrite(func() { rw.Header().Set("X-Hook", "ran") })
+
+		rw.Flush()
+
+		require.True(t, rw.Written())
+		require.True(t, rec.Flushed)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "ran", rec.Header().Get("X-Hook"))
+	})
+
+	t.Run("reports unsupported flush", func(t *testing.T) {
+		t.Parallel()
+
+		rw := internal.NewResponseWriter(&unwrapOnlyWriter{ResponseWriter: nonFlusher{httptest.NewRecorder()}}, false)
+		require.ErrorIs(t, rw.FlushError(), http.ErrNotSupported)
+	})
+}
+
+// nonFlusher hides the recorder's Flush method.
+type nonFlusher struct {
+	w http.ResponseWriter
+}
+
+func (n nonFlusher) Header() http.Header         { return n.w.Header() }
+func (n nonFlusher) Write(b []byte) (int, error) { return n.w.Write(b) }
+func (n nonFlusher) WriteHeader(code int)        { n.w.WriteHeader(code) }
@@ -0,0 +1,36 @@
+package internal
+
+import "maps"
+
+// routeMetaKey stores the metadata declared with Meta for the current request.
+type routeMetaKey struct{}
+
+// Meta returns middleware that declares key/value metadata for a route, such
+// as the permission it requires or its rate-limit tier:
+//
+//	r.GET("/admin", h.admin, Meta("permission", "admin.access"))
+//
+// Values are visible through RouteMeta to middleware that runs after Meta:
+// later per-route middleware, WithRouteMiddleware, and the handler. Passed to
+// Router.With or Use, Meta declares metadata for every route in the group;
+// a route's own Meta for the same key wins because it runs later.
+func Meta(key string, value any) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			// Copy so metadata set in one branch never leaks into a shared map
+			prev, _ := c.Get(routeMetaKey{}).(map[string]any)
+			meta := make(map[string]any, len(prev)+1)
+			maps.Copy(meta, prev)
+			meta[key] = value
+			c.Set(routeMetaKey{}, meta)
+			return next(c)
+		}
+	}
+}
+
+// RouteMeta returns the metadata value declared with Meta for key,
+// or nil if the route declares none.
+func RouteMeta(c Context, key string) any {
+	meta, _ := c.Get(routeMetaKey{}).(map[string]any)
+	return meta[key]
+}
@@ -0,0 +1,92 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestRouteMeta(t *testing.T) {
+	t.Parallel()
+
+	// requirePermission is a single policy middleware driven by route metadata.
+	requirePermission := func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			if p, ok := internal.RouteMeta(c, "permission").(string); ok && p != "" {
+				return c.NoContent(http.StatusForbidden)
+			}
+			return next(c)
+		}
+	}
+
+	serve := func(t *testing.T, app *internal.App, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("route middleware enforces declared metadata", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithRouteMiddleware(requirePermission),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/admin", func(c internal.Context) error {
+					return c.NoContent(http.StatusNoContent)
+				}, internal.Meta("permission", "admin.access"))
+				r.GET("/public", func(c internal.Context) error {
+					return c.NoContent(http.StatusNoContent)
+				})
+			})),
+		)
+
+		require.Equal(t, http.StatusForbidden, serve(t, app, "/admin").Code)
+		require.Equal(t, http.StatusNoContent, serve(t, app, "/public").Code)
+	})
+
+	t.Run("handler reads metadata", func(t *testing.T) {
+		t.Parallel()
+
+		var tier, missing any
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.GET("/", func(c internal.Context) error {
+				tier = internal.RouteMeta(c, "tier")
+				missing = internal.RouteMeta(c, "missing")
+				return nil
+			}, internal.Meta("tier", "burst"))
+		})))
+
+		serve(t, app, "/")
+		require.Equal(t, "burst", tier)
+		require.Nil(t, missing)
+	})
+
+	t.Run("group metadata is inherited and overridden per route", func(t *testing.T) {
+		t.Parallel()
+
+		got := map[string]any{}
+		record := func(name string) internal.HandlerFunc {
+			return func(c internal.Context) error {
+				got[name] = internal.RouteMeta(c, "tier")
+				return nil
+			}
+		}
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.Route("/api", func(r internal.Router) {
+				api := r.With(internal.Meta("tier", "standard"))
+				api.GET("/list", record("list"))
+				api.GET("/export", record("export"), internal.Meta("tier", "heavy"))
+			})
+		})))
+
+		serve(t, app, "/api/list")
+		serve(t, app, "/api/export")
+		require.Equal(t, "standard", got["list"])
+		require.Equal(t, "heavy", got["export"])
+	})
+}
@@ -42,6 +42,14 @@ type Router interface {
 	// Use appends middleware to the router's middleware stack.
 	Use(mw ...Middleware)
 
+	// With returns a router that applies mw to every route declared on it,
+	// including routes in nested Group and Route calls, without affecting
+	// the receiver. Middleware runs after global and Use middleware and
+	// before per-route middleware passed to the verb methods:
+	//
+	//	r.With(requireAdmin).GET("/admin", h.admin)
+	With(mw ...Middleware) Router
+
 	// Mount attaches an http.Handler at the given pattern.
 	// Use this for legacy handlers or third-party routers.
 	Mount(pattern string, h http.Handler)
@@ -54,31 +62,31 @@ type routerAdapter struct {
 }
 
 func (r *routerAdapter) GET(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Get(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodGet, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) POST(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Post(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodPost, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) PUT(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Put(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodPut, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) PATCH(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Patch(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodPatch, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) DELETE(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Delete(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodDelete, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) HEAD(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Head(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodHead, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) OPTIONS(path string, h HandlerFunc, mw ...Middleware) {
-	r.router.Options(path, r.wrap(h, mw...))
+	r.router.Method(http.MethodOptions, path, r.wrap(h, mw...))
 }
 
 func (r *routerAdapter) Group(fn func(Router)) {
@@ -99,28 +107,48 @@ func (r *routerAdapter) Use(mw ...Middleware) {
 	}
 }
 
+func (r *routerAdapter) With(mw ...Middleware) Router {
+	chiMw := make([]func(http.Handler) http.Handler, 0, len(mw))
+	for _, m := range mw {
+		chiMw = append(chiMw, r.app.adaptMiddleware(m))
+	}
+	return &routerAdapter{router: r.router.With(chiMw...), app: r.app}
+}
+
 func (r *routerAdapter) Mount(pattern string, h http.Handler) {
 	r.router.Mount(pattern, h)
 }
 
-func (r *routerAdapter) wrap(h HandlerFunc, mw ...Middleware) http.HandlerFunc {
+func (r *routerAdapter) wrap(h HandlerFunc, mw ...Middleware) http.Handler {
+	// App route middleware runs last so it sees metadata declared by the route's own
+	// middleware
+	chain := slices.Concat(mw, r.app.routeMiddlewares)
 	// Middleware wraps from last to first, so reverse to execute in registration order
-	slices.Reverse(mw)
-	for _, m := range mw {
+	slices.Reverse(chain)
+	for _, m := range chain {
 		h = m(h)
 	}
-	return r.adaptHandler(h)
+	return &routeEndpoint{handler: r.adaptHandler(h), middlewares: len(chain)}
 }
 
 func (r *routerAdapter) adaptHandler(h HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		c := newContext(w, req, r.app)
-		if err := h(c); err != nil {
-			r.app.handleError(c, err)
-		}
+		r.app.serve(newContext(w, req, r.app), h)
 	}
 }
 
+// routeEndpoint is the handler registered with chi for a route declared
+// through Router. Per-route middleware is applied inside the handler, so the
+// endpoint records how many there are for App.Routes.
+type routeEndpoint struct {
+	handler     http.HandlerFunc
+	middlewares int
+}
+
+func (e *routeEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.handler(w, r)
+}
+
 // adaptMiddleware converts a forge Middleware to chi middleware.
 // This adapter allows middleware to be written using the forge Context interface
 // while satisfying chi's http.Handler-based middleware signature.
@@ -135,10 +163,7 @@ func (a *App) adaptMiddleware(mw Middleware) func(http.Handler) http.Handler {
 			// Apply the forge middleware
 			wrapped := mw(nextFunc)
 			// Execute with a new context
-			c := newContext(w, r, a)
-			if err := wrapped(c); err != nil {
-				a.handleError(c, err)
-			}
+			a.serve(newContext(w, r, a), wrapped)
 		})
 	}
 }
@@ -0,0 +1,143 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// routesFunc adapts a function to the Handler interface.
+type routesFunc func(r internal.Router)
+
+func (f routesFunc) Routes(r internal.Router) { f(r) }
+
+// traceRecorder collects middleware/handler execution order for a request.
+type traceRecorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (tr *traceRecorder) mw(name string) internal.Middleware {
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			tr.add(name)
+			return next(c)
+		}
+	}
+}
+
+func (tr *traceRecorder) handler(name string) internal.HandlerFunc {
+	return func(c internal.Context) error {
+		tr.add(name)
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func (tr *traceRecorder) add(step string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.steps = append(tr.steps, step)
+}
+
+func (tr *traceRecorder) take() string {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	s := strings.Join(tr.steps, ",")
+	tr.steps = nil
+	return s
+}
+
+func serve(t *testing.T, h http.Handler, method, path string) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w.Code
+}
+
+func TestRouterWith(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies middleware only to routes declared on the returned router", func(t *testing.T) {
+		t.Parallel()
+
+		tr := &traceRecorder{}
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.With(tr.mw("auth")).GET("/admin", tr.handler("admin"))
+			r.GET("/public", tr.handler("public"))
+		})))
+
+		require.Equal(t, http.StatusNoContent, serve(t, app.Router(), http.MethodGet, "/admin"))
+		require.Equal(t, "auth,admin", tr.take())
+
+		require.Equal(t, http.StatusNoContent, serve(t, app.Router(), http.MethodGet, "/public"))
+		require.Equal(t, "public", tr.take())
+	})
+
+	t.Run("runs after global and Use middleware and before per-route middleware", func(t *testing.T) {
+		t.Parallel()
+
+		tr := &traceRecorder{}
+		app := internal.New(
+			internal.WithMiddleware(tr.mw("global")),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.Group(func(r internal.Router) {
+					r.Use(tr.mw("group"))
+					r.With(tr.mw("with")).POST("/items", tr.handler("create"), tr.mw("route"))
+				})
+			})),
+		)
+
+		require.Equal(t, http.StatusNoContent, serve(t, app.Router(), http.MethodPost, "/items"))
+		require.Equal(t, "global,group,with,route,create", tr.take())
+	})
+
+	t.Run("supports all verbs and nested Route and Group", func(t *testing.T) {
+		t.Parallel()
+
+		tr := &traceRecorder{}
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			admin := r.With(tr.mw("auth"))
+			admin.Route("/admin", func(r internal.Router) {
+				r.GET("/", tr.handler("get"))
+				r.PUT("/", tr.handler("put"))
+				r.PATCH("/", tr.handler("patch"))
+				r.DELETE("/", tr.handler("delete"))
+				r.Group(func(r internal.Router) {
+					r.With(tr.mw("audit")).POST("/users", tr.handler("post"))
+				})
+			})
+		})))
+
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			require.Equal(t, http.StatusNoContent, serve(t, app.Router(), method, "/admin/"))
+			require.Equal(t, "auth,"+strings.ToLower(method), tr.take())
+		}
+
+		require.Equal(t, http.StatusNoContent, serve(t, app.Router(), http.MethodPost, "/admin/users"))
+		require.Equal(t, "auth,audit,post", tr.take())
+	})
+
+	t.Run("short-circuiting middleware blocks the route", func(t *testing.T) {
+		t.Parallel()
+
+		deny := func(next internal.HandlerFunc) internal.HandlerFunc {
+			return func(c internal.Context) error {
+				return c.NoContent(http.StatusForbidden)
+			}
+		}
+
+		tr := &traceRecorder{}
+		app := internal.New(internal.WithHandlers(routesFunc(func(r internal.Router) {
+			r.With(deny).GET("/secret", tr.handler("secret"))
+		})))
+
+		require.Equal(t, http.StatusForbidden, serve(t, app.Router(), http.MethodGet, "/secret"))
+		require.Empty(t, tr.take())
+	})
+}
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RouteInfo describes a registered route.
+type RouteInfo struct {
+	// Method is the HTTP method, or "*" for mounted handlers such as static
+	// files that receive every method.
+	Method string
+
+	// Pattern is the full route pattern, including mount prefixes.
+	Pattern string
+
+	// Middlewares is the number of middlewares that run for the route:
+	// global, Use, With, per-route, and route middleware, including those of
+	// the parent app for mounted sub-apps.
+	Middlewares int
+}
+
+// Routes returns every route registered on the app, including routes of
+// mounted sub-apps, sorted by pattern and method. Use it to log the route
+// table on startup or to check in tests that Mount and Group composition
+// registered the expected endpoints.
+func (a *App) Routes() []RouteInfo {
+	routes := a.collectRoutes("", 0)
+	slices.SortFunc(routes, func(x, y RouteInfo) int {
+		return cmp.Or(cmp.Compare(x.Pattern, y.Pattern), cmp.Compare(x.Method, y.Method))
+	})
+	return routes
+}
+
+// collectRoutes walks the app's router. prefix is the accumulated mount
+// prefix and inherited the number of parent app middlewares.
+func (a *App) collectRoutes(prefix string, inherited int) []RouteInfo {
+	mounts := make(map[string]*App, len(a.mounts))
+	for _, m := range a.mounts {
+		mounts[mountPattern(m.prefix)] = m.app
+	}
+
+	var routes []RouteInfo
+	seen := make(map[string]bool) // mounted handlers are walked once per method
+	_ = chi.Walk(a.router, func(method, pattern string, h http.Handler, mw ...func(http.Handler) http.Handler) error {
+		count := inherited + len(mw) - a.frameworkMiddlewares()
+		if e, ok := h.(*routeEndpoint); ok {
+			routes = append(routes, RouteInfo{Method: method, Pattern: prefix + pattern, Middlewares: count + e.middlewares})
+			return nil
+		}
+		if !strings.HasSuffix(pattern, "/*") {
+			routes = append(routes, RouteInfo{Method: method, Pattern: prefix + pattern, Middlewares: count})
+			return nil
+		}
+		if seen[pattern] {
+			return nil
+		}
+		seen[pattern] = true
+		if sub, ok := mounts[pattern]; ok {
+			routes = append(routes, sub.collectRoutes(prefix+strings.TrimSuffix(pattern, "/*"), count)...)
+			return nil
+		}
+		routes = append(routes, RouteInfo{Method: "*", Pattern: prefix + pattern, Middlewares: count})
+		return nil
+	})
+	return routes
+}
+
+// frameworkMiddlewares is the number of middlewares setupRoutes installs on
+// the router before the app's own, which Routes does not count.
+func (a *App) frameworkMiddlewares() int {
+	n := 1 // recoverPanics
+	if a.requestTimeout > 0 {
+		n++ // withRequestTimeout
+	}
+	if a.limiter != nil {
+		n++ // the request limiter
+	}
+	return n
+}
+
+// mountPattern returns the catch-all pattern chi registers for a mount prefix.
+func mountPattern(prefix string) string {
+	if strings.HasSuffix(prefix, "/") {
+		return prefix + "*"
+	}
+	return prefix + "/*"
+}
+
+// PrintRoutes writes the app's routes to w as a table of method, pattern,
+// and middleware count.
+//
+// Example:
+//
+//	if err := forge.PrintRoutes(app, os.Stdout); err != nil {
+//	    log.Fatal(err)
+//	}
+func PrintRoutes(a *App, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATTERN\tMIDDLEWARES")
+	for _, r := range a.Routes() {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", r.Method, r.Pattern, r.Middlewares)
+	}
+	return tw.Flush()
+}
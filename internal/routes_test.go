@@ -0,0 +1,96 @@
+package internal_test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+func TestAppRoutes(t *testing.T) {
+	t.Parallel()
+
+	noop := func(c internal.Context) error { return c.NoContent(http.StatusOK) }
+	mw := func(next internal.HandlerFunc) internal.HandlerFunc { return next }
+
+	newApp := func() *internal.App {
+		admin := internal.New(
+			internal.WithMiddleware(mw),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/users/{id}", noop, mw)
+			})),
+		)
+		return internal.New(
+			internal.WithMiddleware(mw, mw),
+			internal.WithRouteMiddleware(mw),
+			internal.WithMaxConcurrentRequests(10),
+			internal.WithHealthChecks(),
+			internal.WithMount("/admin", admin),
+			internal.WithStaticFiles("/static/", fstest.MapFS{
+				"public/app.css": {Data: []byte("body{}")},
+			}, "public"),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", noop)
+				r.POST("/posts", noop, mw)
+				r.Route("/api", func(r internal.Router) {
+					r.Use(mw)
+					r.With(mw).DELETE("/posts/{id}", noop)
+				})
+			})),
+		)
+	}
+
+	t.Run("lists routes with middleware counts", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, []internal.RouteInfo{
+			{Method: http.MethodGet, Pattern: "/", Middlewares: 3},
+			{Method: http.MethodGet, Pattern: "/admin/users/{id}", Middlewares: 4},
+			{Method: http.MethodDelete, Pattern: "/api/posts/{id}", Middlewares: 5},
+			{Method: http.MethodGet, Pattern: "/health/live", Middlewares: 2},
+			{Method: http.MethodGet, Pattern: "/health/ready", Middlewares: 2},
+			{Method: http.MethodPost, Pattern: "/posts", Middlewares: 4},
+			{Method: "*", Pattern: "/static/*", Middlewares: 2},
+		}, newApp().Routes())
+	})
+
+	t.Run("prints table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, internal.PrintRoutes(newApp(), &buf))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 8)
+		require.Equal(t, []string{"METHOD", "PATTERN", "MIDDLEWARES"}, strings.Fields(lines[0]))
+		require.Equal(t, []string{"DELETE", "/api/posts/{id}", "5"}, strings.Fields(lines[3]))
+	})
+
+	t.Run("request timeout is not counted", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithRequestTimeout(time.Minute),
+			internal.WithMaxConcurrentRequests(10),
+			internal.WithMiddleware(mw),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/", noop, mw)
+			})),
+		)
+		require.Equal(t, []internal.RouteInfo{
+			{Method: http.MethodGet, Pattern: "/", Middlewares: 2},
+		}, app.Routes())
+	})
+
+	t.Run("empty app", func(t *testing.T) {
+		t.Parallel()
+
+		require.Empty(t, internal.New().Routes())
+	})
+}
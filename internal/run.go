@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/dmitrymomot/forge/pkg/hostrouter"
 )
@@ -61,27 +62,51 @@ func Run(opts ...RunOption) error {
 		return errors.New("forge.Run: no domains or fallback configured")
 	}
 
-	// Collect workers from all apps and deduplicate
-	startupHooks := cfg.startupHooks
-	shutdownHooks := cfg.shutdownHooks
+	// Collect workers from all apps, including mounted ones
+	var tree []*App
+	for _, app := range allApps {
+		tree = append(tree, app.appTree()...)
+	}
+	rc := lifecycle(tree, cfg)
+	rc.handler = handler
+	rc.address = cfg.address
+	return runServer(rc)
+}
+
+// lifecycle builds the runtime configuration for apps. Startup hooks run in a
+// fixed order: migrations, then job workers, then hooks registered with
+// StartupHook. Workers shared between apps are registered once. Request
+// draining runs before other shutdown hooks, within the same shutdown budget.
+func lifecycle(apps []*App, cfg *runConfig) runtimeConfig {
+	startupHooks := slices.Clone(cfg.migrationHooks)
+	var workerHooks, drainHooks []func(context.Context) error
+
 	seenWorkers := make(map[*JobManager]bool)
+	seenLimiters := make(map[*requestLimiter]bool)
+
+	for _, app := range apps {
+		if app.limiter != nil && !seenLimiters[app.limiter] {
+			seenLimiters[app.limiter] = true
+			drainHooks = append(drainHooks, app.limiter.drain)
+		}
 
-	for _, app := range allApps {
 		worker := app.JobWorker()
 		if worker != nil && !seenWorkers[worker] {
 			seenWorkers[worker] = true
-			startupHooks = append([]func(context.Context) error{worker.Manager().StartFunc()}, startupHooks...)
-			shutdownHooks = append(shutdownHooks, worker.Shutdown())
+			startupHooks = append(startupHooks, worker.Manager().StartFunc())
+			workerHooks = append(workerHooks, worker.Shutdown())
 		}
 	}
 
-	return runServer(runtimeConfig{
-		handler:         handler,
-		address:         cfg.address,
+	startupHooks = append(startupHooks, cfg.startupHooks...)
+	shutdownHooks := slices.Concat(drainHooks, cfg.shutdownHooks, workerHooks)
+
+	return runtimeConfig{
 		logger:          cfg.logger,
 		shutdownTimeout: cfg.shutdownTimeout,
 		startupHooks:    startupHooks,
 		shutdownHooks:   shutdownHooks,
+		startupGate:     newStartupGate(apps),
 		baseCtx:         cfg.baseCtx,
-	})
+	}
 }
@@ -2,8 +2,13 @@ package internal
 
 import (
 	"context"
+	"embed"
 	"log/slog"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/dmitrymomot/forge/pkg/db"
 )
 
 // RunOption configures the server runtime.
@@ -16,6 +21,7 @@ type runConfig struct {
 	domains         map[string]*App
 	fallback        *App
 	address         string
+	migrationHooks  []func(context.Context) error
 	startupHooks    []func(context.Context) error
 	shutdownHooks   []func(context.Context) error
 	shutdownTimeout time.Duration
@@ -65,9 +71,10 @@ func ShutdownTimeout(d time.Duration) RunOption {
 }
 
 // StartupHook registers a function to run during server startup.
-// Hooks are called in the order they were registered, after the port is bound
-// but before serving requests. If any hook fails, the server stops and
-// returns the error.
+// Hooks are called in the order they were registered, after migrations and
+// job workers have started. They run once the port is bound; until all of
+// them finish, readiness reports "starting" and other requests get 503.
+// If any hook fails, the server closes its port and returns the error.
 //
 // Example:
 //
@@ -80,6 +87,24 @@ func StartupHook(fn func(context.Context) error) RunOption {
 	}
 }
 
+// WithMigrations runs the embedded migrations on pool as the first startup
+// step, before job workers start, so workers never see an outdated schema.
+// Readiness reports "starting" until they finish. A failed migration aborts
+// startup and releases the port. Of opts, db.WithLogger and
+// db.WithMigrationLock apply; use the lock when several instances start
+// together.
+//
+// Example:
+//
+//	app.Run(":8080",
+//	    forge.WithMigrations(pool, migrations, db.WithMigrationLock(4242)),
+//	)
+func WithMigrations(pool *pgxpool.Pool, migrations embed.FS, opts ...db.Option) RunOption {
+	return func(c *runConfig) {
+		c.migrationHooks = append(c.migrationHooks, db.Migrator(pool, migrations, opts...))
+	}
+}
+
 // ShutdownHook registers a cleanup function to run during shutdown.
 // Hooks are called in the order they were registered.
 // Each hook receives a context with the shutdown timeout.
@@ -22,9 +22,22 @@ type runtimeConfig struct {
 	address         string
 	startupHooks    []func(context.Context) error
 	shutdownHooks   []func(context.Context) error
+	startupGate     *startupGate
 	shutdownTimeout time.Duration
 }
 
+// serverShutdownKey carries a channel that is closed when the server begins
+// shutting down. Hijacked connections, which Shutdown does not track, use it
+// to close themselves.
+type serverShutdownKey struct{}
+
+// serverShutdown returns the shutdown channel stored in ctx, or nil when the
+// request was not served by runServer.
+func serverShutdown(ctx context.Context) <-chan struct{} {
+	ch, _ := ctx.Value(serverShutdownKey{}).(<-chan struct{})
+	return ch
+}
+
 // runServer starts the HTTP server and blocks until shutdown.
 // This is the shared implementation for both app.Run() and forge.Run().
 func runServer(cfg runtimeConfig) error {
@@ -50,6 +63,12 @@ func runServer(cfg runtimeConfig) error {
 		MaxHeaderBytes:    defaultMaxHeaderBytes,
 	}
 
+	shutdownCh := make(chan struct{})
+	server.RegisterOnShutdown(func() { close(shutdownCh) })
+	server.BaseContext = func(net.Listener) context.Context {
+		return context.WithValue(context.Background(), serverShutdownKey{}, (<-chan struct{})(shutdownCh))
+	}
+
 	baseCtx := cfg.baseCtx
 	if baseCtx == nil {
 		baseCtx = context.Background()
@@ -62,12 +81,11 @@ func runServer(cfg runtimeConfig) error {
 		return err
 	}
 
-	// Execute startup hooks before serving requests
-	for _, hook := range cfg.startupHooks {
-		if err := hook(ctx); err != nil {
-			ln.Close()
-			return fmt.Errorf("startup hook failed: %w", err)
-		}
+	// Serve while startup hooks run so health probes get an answer; other
+	// requests are rejected with 503 until every hook has succeeded.
+	if cfg.startupGate != nil {
+		server.Handler = cfg.startupGate.middleware(server.Handler)
+		cfg.startupGate.close()
 	}
 
 	errCh := make(chan error, 1)
@@ -79,6 +97,20 @@ func runServer(cfg runtimeConfig) error {
 		close(errCh)
 	}()
 
+	for _, hook := range cfg.startupHooks {
+		if err := hook(ctx); err != nil {
+			// Close releases the port before Run returns.
+			_ = server.Close()
+			<-errCh
+			return fmt.Errorf("startup hook failed: %w", err)
+		}
+	}
+
+	if cfg.startupGate != nil {
+		cfg.startupGate.open()
+	}
+	logger.Info("server ready")
+
 	select {
 	case err := <-errCh:
 		return err
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"errors"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/dmitrymomot/forge/pkg/storage"
+)
+
+// defaultServeFileCacheControl lets the requesting browser reuse a file for
+// an hour but keeps it out of shared caches, since ServeFile is meant for
+// files behind the app's own access checks.
+const defaultServeFileCacheControl = "private, max-age=3600"
+
+// ServeFileOption configures Context.ServeFile.
+type ServeFileOption func(*serveFileConfig)
+
+type serveFileConfig struct {
+	cacheControl string
+	disposition  string
+}
+
+// WithServeFileDownload makes the browser save the file as filename
+// instead of displaying it.
+func WithServeFileDownload(filename string) ServeFileOption {
+	return func(cfg *serveFileConfig) {
+		cfg.disposition = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+	}
+}
+
+// WithServeFileCacheControl sets the Cache-Control header, overriding the
+// value stored with the file and the "private, max-age=3600" default.
+func WithServeFileCacheControl(cc string) ServeFileOption {
+	return func(cfg *serveFileConfig) {
+		cfg.cacheControl = cc
+	}
+}
+
+// errRangeNotSatisfiable reports a Range header that selects no byte of
+// the file.
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// byteRange is a satisfiable range within a file.
+type byteRange struct {
+	start, length int64
+}
+
+// parseRange resolves a Range header against a file of size bytes.
+// ok is false when the header should be ignored and the whole file served:
+// it is malformed, not in bytes, or asks for several ranges, which servers
+// may decline. errRangeNotSatisfiable means the range lies past the end.
+func parseRange(header string, size int64) (r byteRange, ok bool, err error) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return byteRange{}, false, nil
+	}
+	startStr, endStr, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return byteRange{}, false, nil
+	}
+	startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+
+	if startStr == "" {
+		// Suffix range: the last n bytes.
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || n < 0 {
+			return byteRange{}, false, nil
+		}
+		if n == 0 || size == 0 {
+			return byteRange{}, false, errRangeNotSatisfiable
+		}
+		n = min(n, size)
+		return byteRange{start: size - n, length: n}, true, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, false, nil
+	}
+	end := size - 1
+	if endStr != "" {
+		e, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || e < start {
+			return byteRange{}, false, nil
+		}
+		end = min(e, end)
+	}
+	if start >= size {
+		return byteRange{}, false, errRangeNotSatisfiable
+	}
+	return byteRange{start: start, length: end - start + 1}, true, nil
+}
+
+// storageHTTPError maps missing and forbidden files to 404 and 403 so the
+// error handler renders them as such. Other errors are returned unchanged.
+func storageHTTPError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrNotFound("File not found", WithError(err))
+	case errors.Is(err, storage.ErrAccessDenied):
+		return ErrForbidden("Access denied", WithError(err))
+	}
+	return err
+}
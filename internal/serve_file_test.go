@@ -0,0 +1,191 @@
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/storage"
+)
+
+func TestServeFile(t *testing.T) {
+	t.Parallel()
+
+	const content = "0123456789abcdefghij"
+
+	newStore := func(info *storage.FileInfo) *mockStorage {
+		return &mockStorage{
+			headFn: func(_ context.Context, key string) (*storage.FileInfo, error) {
+				if key != "videos/demo.mp4" {
+					return nil, fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+				}
+				return info, nil
+			},
+			getFn: func(context.Context, string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(content)), nil
+			},
+		}
+	}
+	defaultInfo := &storage.FileInfo{Key: "videos/demo.mp4", ContentType: "video/mp4", Size: int64(len(content))}
+
+	serve := func(t *testing.T, store storage.Storage, req *http.Request, opts ...internal.ServeFileOption) *httptest.ResponseRecorder {
+		t.Helper()
+		app := internal.New(
+			internal.WithStorage(store),
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				serveFile := func(c internal.Context) error {
+					return c.ServeFile(c.Param("*"), opts...)
+				}
+				r.GET("/files/*", serveFile)
+				r.HEAD("/files/*", serveFile)
+			})),
+		)
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("streams whole file with metadata headers", func(t *testing.T) {
+		t.Parallel()
+
+		w := serve(t, newStore(defaultInfo), httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, content, w.Body.String())
+		require.Equal(t, "video/mp4", w.Header().Get("Content-Type"))
+		require.Equal(t, "20", w.Header().Get("Content-Length"))
+		require.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+		require.Equal(t, "private, max-age=3600", w.Header().Get("Cache-Control"))
+		require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		require.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("uses stored and overridden headers", func(t *testing.T) {
+		t.Parallel()
+
+		info := *defaultInfo
+		info.CacheControl = "public, max-age=60"
+		info.ContentDisposition = "inline"
+
+		w := serve(t, newStore(&info), httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil))
+		require.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+		require.Equal(t, "inline", w.Header().Get("Content-Disposition"))
+
+		w = serve(t, newStore(&info), httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil),
+			internal.WithServeFileCacheControl("no-store"),
+			internal.WithServeFileDownload("demo video.mp4"),
+		)
+		require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		require.Equal(t, `attachment; filename="demo video.mp4"`, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("serves byte ranges", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			header       string
+			body         string
+			contentRange string
+		}{
+			{"bytes=0-4", "01234", "bytes 0-4/20"},
+			{"bytes=10-", "abcdefghij", "bytes 10-19/20"},
+			{"bytes=-3", "hij", "bytes 17-19/20"},
+			{"bytes=15-100", "fghij", "bytes 15-19/20"},
+			{"bytes=-100", content, "bytes 0-19/20"},
+		}
+		for _, tt := range tests {
+			req := httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil)
+			req.Header.Set("Range", tt.header)
+
+			w := serve(t, newStore(defaultInfo), req)
+			require.Equal(t, http.StatusPartialContent, w.Code, tt.header)
+			require.Equal(t, tt.body, w.Body.String(), tt.header)
+			require.Equal(t, tt.contentRange, w.Header().Get("Content-Range"), tt.header)
+			require.Equal(t, fmt.Sprint(len(tt.body)), w.Header().Get("Content-Length"), tt.header)
+		}
+	})
+
+	t.Run("ignores malformed, multiple, and conditional ranges", func(t *testing.T) {
+		t.Parallel()
+
+		for _, header := range []string{"bytes=abc", "items=0-4", "bytes=0-1,4-5", "bytes=5-2"} {
+			req := httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil)
+			req.Header.Set("Range", header)
+
+			w := serve(t, newStore(defaultInfo), req)
+			require.Equal(t, http.StatusOK, w.Code, header)
+			require.Equal(t, content, w.Body.String(), header)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil)
+		req.Header.Set("Range", "bytes=0-4")
+		req.Header.Set("If-Range", `"v1"`)
+		require.Equal(t, http.StatusOK, serve(t, newStore(defaultInfo), req).Code)
+	})
+
+	t.Run("unsatisfiable range returns 416", func(t *testing.T) {
+		t.Parallel()
+
+		for _, header := range []string{"bytes=20-", "bytes=-0"} {
+			req := httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil)
+			req.Header.Set("Range", header)
+
+			w := serve(t, newStore(defaultInfo), req)
+			require.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code, header)
+			require.Equal(t, "bytes */20", w.Header().Get("Content-Range"), header)
+			require.Empty(t, w.Body.String(), header)
+		}
+	})
+
+	t.Run("HEAD sends headers only", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(defaultInfo)
+		store.getFn = func(context.Context, string) (io.ReadCloser, error) {
+			t.Error("Get must not be called for HEAD")
+			return nil, storage.ErrNotFound
+		}
+		w := serve(t, store, httptest.NewRequest(http.MethodHead, "/files/videos/demo.mp4", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "20", w.Header().Get("Content-Length"))
+		require.Empty(t, w.Body.String())
+	})
+
+	t.Run("missing file is 404", func(t *testing.T) {
+		t.Parallel()
+
+		w := serve(t, newStore(defaultInfo), httptest.NewRequest(http.MethodGet, "/files/nope.txt", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("forbidden file is 403", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStorage{
+			headFn: func(context.Context, string) (*storage.FileInfo, error) {
+				return nil, storage.ErrAccessDenied
+			},
+		}
+		w := serve(t, store, httptest.NewRequest(http.MethodGet, "/files/secret.pdf", nil))
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("unknown content type falls back to octet-stream", func(t *testing.T) {
+		t.Parallel()
+
+		info := *defaultInfo
+		info.ContentType = ""
+		w := serve(t, newStore(&info), httptest.NewRequest(http.MethodGet, "/files/videos/demo.mp4", nil))
+		require.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	})
+}
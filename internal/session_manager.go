@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dmitrymomot/forge/pkg/clientip"
@@ -51,17 +54,42 @@ const (
 	FingerprintReject
 )
 
+// SessionLimitStrategy determines what happens when a user signs in while
+// already holding the maximum number of sessions set by WithSessionMaxPerUser.
+type SessionLimitStrategy int
+
+const (
+	// SessionEvictOldest signs the user in and deletes their oldest sessions
+	// until the limit is met.
+	SessionEvictOldest SessionLimitStrategy = iota
+	// SessionRejectNew refuses the login with session.ErrTooManySessions.
+	SessionRejectNew
+)
+
+// SessionAuthEvent describes the outcome of AuthenticateSession.
+// It is passed to the hook set by WithSessionOnAuthenticate.
+type SessionAuthEvent struct {
+	Evicted   []string // IDs of sessions deleted to honor the per-user limit
+	UserID    string
+	SessionID string // Authenticated session; empty when Rejected
+	Rejected  bool   // Login refused with session.ErrTooManySessions
+}
+
 // SessionManager handles session lifecycle and cookie management.
 type SessionManager struct {
 	store                 session.Store
 	logger                *slog.Logger
+	onAuthenticate        func(Context, SessionAuthEvent)
+	onBulkDestroy         func(ctx context.Context, userID string, count int)
 	cookieName            string
 	domain                string
 	path                  string
 	maxAge                int
+	maxPerUser            int
 	sameSite              http.SameSite
 	fingerprintMode       FingerprintMode
 	fingerprintStrictness FingerprintStrictness
+	limitStrategy         SessionLimitStrategy
 	secure                bool
 	httpOnly              bool
 }
@@ -159,6 +187,42 @@ func WithSessionFingerprint(mode FingerprintMode, strictness FingerprintStrictne
 	}
 }
 
+// WithSessionMaxPerUser limits how many sessions one user may hold at once.
+// AuthenticateSession enforces the limit according to WithSessionLimitStrategy,
+// evicting the oldest sessions by default. Anonymous and expired sessions are
+// not counted. Zero or negative means unlimited.
+func WithSessionMaxPerUser(n int) SessionOption {
+	return func(sm *SessionManager) {
+		sm.maxPerUser = max(n, 0)
+	}
+}
+
+// WithSessionLimitStrategy sets what happens when a login would exceed
+// WithSessionMaxPerUser: SessionEvictOldest (default) or SessionRejectNew.
+func WithSessionLimitStrategy(strategy SessionLimitStrategy) SessionOption {
+	return func(sm *SessionManager) {
+		sm.limitStrategy = strategy
+	}
+}
+
+// WithSessionOnAuthenticate registers a hook called after every
+// AuthenticateSession decision, including rejected logins and evictions,
+// for example to notify the user or write an audit log.
+func WithSessionOnAuthenticate(fn func(c Context, e SessionAuthEvent)) SessionOption {
+	return func(sm *SessionManager) {
+		sm.onAuthenticate = fn
+	}
+}
+
+// WithSessionOnBulkDestroy registers a hook called after DeleteAllForUser
+// purges a user's sessions, with the number deleted, for example to record
+// the purge in an audit log. It runs once per purge, not per session.
+func WithSessionOnBulkDestroy(fn func(ctx context.Context, userID string, count int)) SessionOption {
+	return func(sm *SessionManager) {
+		sm.onBulkDestroy = fn
+	}
+}
+
 // SetLogger sets the logger for session events. Called by App after initialization.
 func (sm *SessionManager) SetLogger(l *slog.Logger) {
 	if l != nil {
@@ -281,6 +345,95 @@ func (sm *SessionManager) DeleteSession(w http.ResponseWriter) {
 	http.SetCookie(w, cookie)
 }
 
+// DeleteAllForUser deletes every session userID holds, on all devices, and
+// returns how many there were. Use it when an account is deleted or its
+// credentials are compromised. Sessions are counted with ListByUserID before
+// the store's DeleteByUserID removes them, so one created concurrently is
+// deleted but may not be counted. The WithSessionOnBulkDestroy hook runs
+// after a successful purge, even when the count is zero.
+func (sm *SessionManager) DeleteAllForUser(ctx context.Context, userID string) (int, error) {
+	all, err := sm.store.ListByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("list user sessions: %w", err)
+	}
+	if err := sm.store.DeleteByUserID(ctx, userID); err != nil {
+		return 0, fmt.Errorf("delete user sessions: %w", err)
+	}
+	if sm.onBulkDestroy != nil {
+		sm.onBulkDestroy(ctx, userID, len(all))
+	}
+	return len(all), nil
+}
+
+// checkSessionLimit returns session.ErrTooManySessions when the reject
+// strategy is configured and userID already holds the maximum number of
+// active sessions, not counting currentID.
+func (sm *SessionManager) checkSessionLimit(ctx context.Context, userID, currentID string) error {
+	if sm.maxPerUser == 0 || sm.limitStrategy != SessionRejectNew {
+		return nil
+	}
+	others, err := sm.otherSessions(ctx, userID, currentID)
+	if err != nil {
+		return err
+	}
+	if len(others) >= sm.maxPerUser {
+		return session.ErrTooManySessions
+	}
+	return nil
+}
+
+// trimSessions deletes userID's oldest active sessions so that, together with
+// keepID, at most maxPerUser remain. It runs after every login, whatever the
+// strategy, so concurrent logins that raced past checkSessionLimit still
+// converge on the limit. Sessions already deleted by a concurrent login are
+// skipped. Returns the IDs it deleted.
+func (sm *SessionManager) trimSessions(ctx context.Context, userID, keepID string) ([]string, error) {
+	if sm.maxPerUser == 0 {
+		return nil, nil
+	}
+	others, err := sm.otherSessions(ctx, userID, keepID)
+	if err != nil {
+		return nil, err
+	}
+	if len(others) < sm.maxPerUser {
+		return nil, nil
+	}
+
+	// Newest first; ULID IDs break ties between sessions created in the same instant.
+	slices.SortFunc(others, func(a, b *session.Session) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	var evicted []string
+	var errs []error
+	for _, s := range others[sm.maxPerUser-1:] {
+		if err := sm.store.Delete(ctx, s.ID); err != nil && !errors.Is(err, session.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("delete session %s: %w", s.ID, err))
+			continue
+		}
+		evicted = append(evicted, s.ID)
+	}
+	return evicted, errors.Join(errs...)
+}
+
+// otherSessions lists userID's unexpired sessions other than excludeID.
+func (sm *SessionManager) otherSessions(ctx context.Context, userID, excludeID string) ([]*session.Session, error) {
+	all, err := sm.store.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user sessions: %w", err)
+	}
+	others := make([]*session.Session, 0, len(all))
+	for _, s := range all {
+		if s.ID != excludeID && !s.IsExpired() {
+			others = append(others, s)
+		}
+	}
+	return others, nil
+}
+
 // Store returns the underlying session store.
 func (sm *SessionManager) Store() session.Store {
 	return sm.store
@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// StackFormat controls how FormatPanicStack renders a call stack.
+type StackFormat struct {
+	Depth       int  // Max frames to include; 0 means all
+	TrimPaths   bool // Show files as <import path>/<file> instead of absolute paths
+	SourceLines int  // Source lines shown around the panicking line; 0 disables
+}
+
+// FormatPanicStack formats the calling goroutine's stack, starting at the
+// frame that panicked. Call it from the deferred function that recovered;
+// outside a panic the stack starts at the caller.
+//
+// Each frame is rendered as the function name followed by an indented
+// file:line, like a Go traceback. With SourceLines set, the panicking frame
+// is followed by the surrounding source read from disk, so enable it only
+// where sources are available and logs are not shared, e.g. in development.
+func FormatPanicStack(f StackFormat) string {
+	frames := panicFrames()
+	if f.Depth > 0 && len(frames) > f.Depth {
+		frames = frames[:f.Depth]
+	}
+
+	var b strings.Builder
+	for i, frame := range frames {
+		file := frame.File
+		if f.TrimPaths {
+			file = trimFramePath(frame.Function, file)
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, file, frame.Line)
+		if i == 0 && f.SourceLines > 0 {
+			writeSourceContext(&b, frame.File, frame.Line, f.SourceLines)
+		}
+	}
+	return b.String()
+}
+
+// panicFrames returns the goroutine's frames below runtime.gopanic,
+// skipping runtime helpers such as runtime.sigpanic that raised it.
+func panicFrames() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(3, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, len(pcs)*2)
+	}
+
+	var frames []runtime.Frame
+	it := runtime.CallersFrames(pcs)
+	for {
+		frame, more := it.Next()
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+
+	for i, frame := range frames {
+		if frame.Function != "runtime.gopanic" {
+			continue
+		}
+		rest := frames[i+1:]
+		for len(rest) > 1 && strings.HasPrefix(rest[0].Function, "runtime.") {
+			rest = rest[1:]
+		}
+		return rest
+	}
+	return frames
+}
+
+// trimFramePath replaces the directory of file with the import path of the
+// package that declares function, dropping GOPATH, module cache, and
+// checkout locations from the trace.
+//
+// The linker escapes dots in the last element of the import path as %2e,
+// as in "gopkg.in/yaml%2ev3.(*decoder).unmarshal", so the path ends at the
+// first dot after the last slash and is unescaped afterwards. If the result
+// does not name the file's directory, with any module cache @version
+// suffix removed, file is returned unchanged rather than guessed at. Package
+// main is named after no directory and is kept as "main".
+func trimFramePath(function, file string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return file
+	}
+	pkg, err := url.PathUnescape(function[:slash+1+dot])
+	if err != nil {
+		return file
+	}
+
+	name := strings.TrimSuffix(path.Base(pkg), "_test")
+	dir, _, _ := strings.Cut(path.Base(path.Dir(file)), "@")
+	if pkg != "main" && name != dir {
+		return file
+	}
+	return pkg + "/" + path.Base(file)
+}
+
+// writeSourceContext writes up to n lines on each side of line from file,
+// marking line itself. Unreadable files are skipped silently.
+func writeSourceContext(b *strings.Builder, file string, line, n int) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer fh.Close()
+
+	scanner := bufio.NewScanner(fh)
+	for num := 1; scanner.Scan() && num <= line+n; num++ {
+		if num < line-n {
+			continue
+		}
+		marker := " "
+		if num == line {
+			marker = ">"
+		}
+		fmt.Fprintf(b, "\t%s %4d | %s\n", marker, num, scanner.Text())
+	}
+}
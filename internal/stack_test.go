@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrimFramePath(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		function string
+		file     string
+		want     string
+	}{
+		"module package": {
+			function: "github.com/dmitrymomot/forge/internal.(*App).serve",
+			file:     "/home/dev/src/forge/internal/app.go",
+			want:     "github.com/dmitrymomot/forge/internal/app.go",
+		},
+		"external test package": {
+			function: "github.com/dmitrymomot/forge/middlewares_test.TestRecover.func1",
+			file:     "/home/dev/src/forge/middlewares/recover_test.go",
+			want:     "github.com/dmitrymomot/forge/middlewares_test/recover_test.go",
+		},
+		"escaped dotted last element": {
+			function: "gopkg.in/yaml%2ev3.(*decoder).unmarshal",
+			file:     "/go/pkg/mod/gopkg.in/yaml.v3@v3.0.1/decode.go",
+			want:     "gopkg.in/yaml.v3/decode.go",
+		},
+		"versioned module path": {
+			function: "example.com/foo%2ev2.Handler.func1",
+			file:     "/go/pkg/mod/example.com/foo.v2@v2.1.0/handler.go",
+			want:     "example.com/foo.v2/handler.go",
+		},
+		"unescaped dotted last element falls back": {
+			function: "gopkg.in/yaml.v3.(*decoder).unmarshal",
+			file:     "/go/pkg/mod/gopkg.in/yaml.v3@v3.0.1/decode.go",
+			want:     "/go/pkg/mod/gopkg.in/yaml.v3@v3.0.1/decode.go",
+		},
+		"standard library": {
+			function: "net/http.(*conn).serve",
+			file:     "/usr/local/go/src/net/http/server.go",
+			want:     "net/http/server.go",
+		},
+		"main package": {
+			function: "main.main",
+			file:     "/home/dev/app/main.go",
+			want:     "main/main.go",
+		},
+		"no package": {
+			function: "unknown",
+			file:     "/tmp/x.go",
+			want:     "/tmp/x.go",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, trimFramePath(tc.function, tc.file))
+		})
+	}
+}
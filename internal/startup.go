@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// startupGate rejects requests with 503 while the server runs its startup
+// hooks, so nothing is served before migrations and workers are up. Health
+// checks pass through, and readiness reports "starting" until the gate opens.
+type startupGate struct {
+	apps     []*App
+	starting atomic.Bool
+}
+
+func newStartupGate(apps []*App) *startupGate {
+	return &startupGate{apps: apps}
+}
+
+// close marks startup as in progress for the gate and its apps.
+func (g *startupGate) close() { g.set(true) }
+
+// open marks startup as finished for the gate and its apps.
+func (g *startupGate) open() { g.set(false) }
+
+func (g *startupGate) set(starting bool) {
+	g.starting.Store(starting)
+	for _, app := range g.apps {
+		app.starting.Store(starting)
+	}
+}
+
+// middleware rejects requests other than health checks until the gate opens.
+func (g *startupGate) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.starting.Load() && !g.isHealthRequest(r) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(defaultRetryAfter/time.Second)))
+			http.Error(w, "Server is starting, please retry later", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isHealthRequest reports whether r targets a health endpoint of any app.
+func (g *startupGate) isHealthRequest(r *http.Request) bool {
+	for _, app := range g.apps {
+		if app.isHealthRequest(r) {
+			return true
+		}
+	}
+	return false
+}
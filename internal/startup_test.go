@@ -0,0 +1,143 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// freeAddr returns a loopback address with a port that was free when checked.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return addr
+}
+
+// getURL performs a GET and returns the status code and body.
+func getURL(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestStartupGate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("readiness reports starting until hooks finish", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(
+			internal.WithHealthChecks(),
+			internal.WithHandlers(routesFunc(func(r internal.Router) {
+				r.GET("/items", func(c internal.Context) error {
+					return c.String(http.StatusOK, "items")
+				})
+			})),
+		)
+
+		addr := freeAddr(t)
+		base := "http://" + addr
+		ctx, cancel := context.WithCancel(context.Background())
+		hookStarted := make(chan struct{})
+		release := make(chan struct{})
+		var order []string
+
+		done := make(chan error, 1)
+		go func() {
+			done <- app.Run(addr,
+				internal.WithContext(ctx),
+				internal.StartupHook(func(context.Context) error {
+					order = append(order, "first")
+					close(hookStarted)
+					<-release
+					return nil
+				}),
+				internal.StartupHook(func(context.Context) error {
+					order = append(order, "second")
+					return nil
+				}),
+			)
+		}()
+		<-hookStarted
+
+		code, body := getURL(t, base+"/health/ready")
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, "Starting", body)
+
+		code, body = getURL(t, base+"/health/ready?format=json")
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.JSONEq(t, `{"status":"starting"}`, body)
+
+		code, _ = getURL(t, base+"/health/live")
+		require.Equal(t, http.StatusOK, code)
+
+		resp, err := http.Get(base + "/items")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+		close(release)
+		require.Eventually(t, func() bool {
+			code, _ := getURL(t, base+"/health/ready")
+			return code == http.StatusOK
+		}, time.Second, 10*time.Millisecond)
+
+		code, body = getURL(t, base+"/items")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "items", body)
+		require.Equal(t, []string{"first", "second"}, order)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	t.Run("failed hook aborts startup and releases the port", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New()
+		addr := freeAddr(t)
+		hookErr := errors.New("migration failed")
+		called := false
+
+		err := app.Run(addr,
+			internal.StartupHook(func(context.Context) error { return hookErr }),
+			internal.StartupHook(func(context.Context) error {
+				called = true
+				return nil
+			}),
+		)
+		require.ErrorIs(t, err, hookErr)
+		require.False(t, called)
+
+		ln, err := net.Listen("tcp", addr)
+		require.NoError(t, err)
+		require.NoError(t, ln.Close())
+	})
+
+	t.Run("router without Run is not gated", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(internal.WithHealthChecks())
+		w := serveRecorder(app.Router(), httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
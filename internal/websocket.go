@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// Default WebSocket limits.
+const (
+	defaultWSMaxMessageSize = 32 << 10 // 32KB, same as the underlying library
+)
+
+// wsConfig holds WebSocket upgrade configuration.
+type wsConfig struct {
+	allowOriginFunc func(origin string) bool
+	allowOrigins    []string
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	maxMessageSize  int64
+}
+
+// WSOption configures a WebSocket upgrade.
+type WSOption func(*wsConfig)
+
+// WithWSAllowOrigins sets the origins allowed to open a WebSocket.
+// Origins are matched exactly, as in the CORS middleware. Use "*" to allow
+// all origins. Without origin options only same-host requests are accepted.
+func WithWSAllowOrigins(origins ...string) WSOption {
+	return func(cfg *wsConfig) {
+		cfg.allowOrigins = origins
+	}
+}
+
+// WithWSAllowOriginFunc sets a dynamic origin validator.
+// When set, it completely overrides WithWSAllowOrigins.
+func WithWSAllowOriginFunc(fn func(origin string) bool) WSOption {
+	return func(cfg *wsConfig) {
+		cfg.allowOriginFunc = fn
+	}
+}
+
+// WithWSReadTimeout limits how long ReadJSON waits for a message.
+// Zero means no limit beyond the connection lifetime.
+func WithWSReadTimeout(d time.Duration) WSOption {
+	return func(cfg *wsConfig) {
+		cfg.readTimeout = d
+	}
+}
+
+// WithWSWriteTimeout limits how long WriteJSON may take.
+// Zero means no limit beyond the connection lifetime.
+func WithWSWriteTimeout(d time.Duration) WSOption {
+	return func(cfg *wsConfig) {
+		cfg.writeTimeout = d
+	}
+}
+
+// WithWSMaxMessageSize sets the maximum size in bytes of a received message.
+// Larger messages close the connection. Defaults to 32KB.
+func WithWSMaxMessageSize(n int64) WSOption {
+	return func(cfg *wsConfig) {
+		cfg.maxMessageSize = n
+	}
+}
+
+// originAllowed reports whether origin passes the configured origin checks.
+// Requests without an Origin header come from non-browser clients and are allowed.
+func (cfg *wsConfig) originAllowed(origin string) bool {
+	if origin == "" {
+		return true
+	}
+	if cfg.allowOriginFunc != nil {
+		return cfg.allowOriginFunc(origin)
+	}
+	return slices.Contains(cfg.allowOrigins, "*") || slices.Contains(cfg.allowOrigins, origin)
+}
+
+// WSConn is a WebSocket connection created by Context.Upgrade.
+// Its lifetime is bound to the request context: the connection closes when
+// the handler returns, the client goes away, or the server shuts down.
+type WSConn struct {
+	conn         *websocket.Conn
+	ctx          context.Context
+	cancel       context.CancelFunc
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+// Context returns a context that is canceled when the connection closes.
+func (ws *WSConn) Context() context.Context {
+	return ws.ctx
+}
+
+// ReadJSON reads the next message and decodes it as JSON into v.
+func (ws *WSConn) ReadJSON(v any) error {
+	ctx, cancel := withOptionalTimeout(ws.ctx, ws.readTimeout)
+	defer cancel()
+	if err := wsjson.Read(ctx, ws.conn, v); err != nil {
+		return fmt.Errorf("websocket: read: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON encodes v as JSON and sends it as a text message.
+func (ws *WSConn) WriteJSON(v any) error {
+	ctx, cancel := withOptionalTimeout(ws.ctx, ws.writeTimeout)
+	defer cancel()
+	if err := wsjson.Write(ctx, ws.conn, v); err != nil {
+		return fmt.Errorf("websocket: write: %w", err)
+	}
+	return nil
+}
+
+// Close performs a normal closing handshake and releases the connection.
+func (ws *WSConn) Close() error {
+	defer ws.cancel()
+	return ws.conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// watch closes the connection when its context is done. Server shutdown
+// closes it with StatusGoingAway so clients know to reconnect.
+func (ws *WSConn) watch(shutdown <-chan struct{}) {
+	select {
+	case <-shutdown:
+		_ = ws.conn.Close(websocket.StatusGoingAway, "server shutting down")
+		ws.cancel()
+	case <-ws.ctx.Done():
+		_ = ws.conn.CloseNow()
+	}
+}
+
+// withOptionalTimeout applies d to ctx when d is positive.
+func withOptionalTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,180 @@
+package internal_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+type wsMessage struct {
+	Text string `json:"text"`
+}
+
+// wsServer starts a test server whose GET /ws route upgrades with opts and
+// echoes JSON messages until the client disconnects.
+func wsServer(t *testing.T, appOpts []internal.Option, opts ...internal.WSOption) *httptest.Server {
+	t.Helper()
+
+	appOpts = append(appOpts, internal.WithHandlers(routesFunc(func(r internal.Router) {
+		r.GET("/ws", func(c internal.Context) error {
+			ws, err := c.Upgrade(opts...)
+			if err != nil {
+				return err
+			}
+			defer ws.Close()
+
+			for {
+				var msg wsMessage
+				if err := ws.ReadJSON(&msg); err != nil {
+					return nil
+				}
+				if err := ws.WriteJSON(wsMessage{Text: "echo: " + msg.Text}); err != nil {
+					return nil
+				}
+			}
+		})
+	})))
+
+	ts := httptest.NewServer(internal.New(appOpts...).Router())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func wsURL(ts *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+}
+
+func TestContextUpgrade(t *testing.T) {
+	t.Parallel()
+
+	t.Run("echoes json messages", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, _, err := websocket.Dial(ctx, wsURL(ts), nil)
+		require.NoError(t, err)
+		defer conn.CloseNow()
+
+		require.NoError(t, wsjson.Write(ctx, conn, wsMessage{Text: "hi"}))
+		var got wsMessage
+		require.NoError(t, wsjson.Read(ctx, conn, &got))
+		require.Equal(t, "echo: hi", got.Text)
+
+		require.NoError(t, conn.Close(websocket.StatusNormalClosure, ""))
+	})
+
+	t.Run("upgrade works for htmx requests", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, resp, err := websocket.Dial(ctx, wsURL(ts), &websocket.DialOptions{
+			HTTPHeader: http.Header{"Hx-Request": {"true"}},
+		})
+		require.NoError(t, err)
+		defer conn.CloseNow()
+		require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	})
+
+	t.Run("rejects disallowed origin with 403", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil, internal.WithWSAllowOrigins("https://app.example.com"))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, resp, err := websocket.Dial(ctx, wsURL(ts), &websocket.DialOptions{
+			HTTPHeader: http.Header{"Origin": {"https://evil.example.com"}},
+		})
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("allows configured origin", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil, internal.WithWSAllowOriginFunc(func(origin string) bool {
+			return origin == "https://app.example.com"
+		}))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, _, err := websocket.Dial(ctx, wsURL(ts), &websocket.DialOptions{
+			HTTPHeader: http.Header{"Origin": {"https://app.example.com"}},
+		})
+		require.NoError(t, err)
+		conn.CloseNow()
+	})
+
+	t.Run("rejects cross origin by default", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, resp, err := websocket.Dial(ctx, wsURL(ts), &websocket.DialOptions{
+			HTTPHeader: http.Header{"Origin": {"https://evil.example.com"}},
+		})
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("oversized message closes connection", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil, internal.WithWSMaxMessageSize(16))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, _, err := websocket.Dial(ctx, wsURL(ts), nil)
+		require.NoError(t, err)
+		defer conn.CloseNow()
+
+		require.NoError(t, wsjson.Write(ctx, conn, wsMessage{Text: strings.Repeat("x", 64)}))
+		var got wsMessage
+		err = wsjson.Read(ctx, conn, &got)
+		require.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+	})
+
+	t.Run("read timeout ends the handler", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil, internal.WithWSReadTimeout(50*time.Millisecond))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, _, err := websocket.Dial(ctx, wsURL(ts), nil)
+		require.NoError(t, err)
+		defer conn.CloseNow()
+
+		_, _, err = conn.Read(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("plain request is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		ts := wsServer(t, nil)
+		resp, err := http.Get(ts.URL + "/ws")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+	})
+}
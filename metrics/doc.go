@@ -0,0 +1,41 @@
+// Package metrics exports Prometheus metrics for Forge applications.
+//
+// It lives outside the root forge package so that applications which do not
+// use Prometheus never import or link the client library. Import it only
+// where metrics are wanted:
+//
+//	import "github.com/dmitrymomot/forge/metrics"
+//
+//	app := forge.New(
+//	    metrics.WithMetrics(),
+//	    forge.WithMiddleware(middlewares.RequestID()),
+//	    forge.WithHandlers(handlers.NewPages(repo)),
+//	)
+//
+// WithMetrics registers GET /metrics and instruments every request with a
+// request counter, a duration histogram, and an in-flight gauge. Counter and
+// histogram are labeled by method, route pattern, and status. The route label
+// comes from chi's RouteContext, so /users/42 and /users/7 are both reported
+// as /users/{id} and label cardinality stays bounded.
+//
+// # Custom Collectors
+//
+// Register application collectors with WithCollectors, or pass a shared
+// registry with WithRegistry and register collectors on it directly:
+//
+//	signups := prometheus.NewCounter(prometheus.CounterOpts{
+//	    Name: "signups_total",
+//	    Help: "Completed signups.",
+//	})
+//
+//	app := forge.New(
+//	    metrics.WithMetrics(
+//	        metrics.WithNamespace("myapp"),
+//	        metrics.WithCollectors(signups),
+//	    ),
+//	)
+//
+// Each WithMetrics call creates its own registry unless WithRegistry is set.
+// Sharing one registry between apps requires distinct namespaces, because the
+// same collector cannot be registered twice.
+package metrics
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// DefaultPath is the default metrics endpoint path.
+const DefaultPath = "/metrics"
+
+// unmatchedRoute labels requests that matched no route, keeping
+// cardinality bounded when clients probe random paths.
+const unmatchedRoute = "unmatched"
+
+// Config configures Prometheus metrics.
+type Config struct {
+	// Registry receives the HTTP collectors and serves the endpoint.
+	// Defaults to a new registry with Go runtime and process collectors.
+	Registry *prometheus.Registry
+
+	// Path is the endpoint path. Defaults to DefaultPath.
+	Path string
+
+	// Namespace prefixes every metric name (e.g. "myapp_http_requests_total").
+	Namespace string
+
+	// Buckets are the request duration histogram buckets in seconds.
+	// Defaults to prometheus.DefBuckets.
+	Buckets []float64
+
+	// Collectors are extra collectors registered with Registry.
+	Collectors []prometheus.Collector
+}
+
+// Option configures Config.
+type Option func(*Config)
+
+// WithRegistry sets the registry used for collectors and the endpoint.
+// Use it to share a registry between apps or with other exporters.
+func WithRegistry(reg *prometheus.Registry) Option {
+	return func(cfg *Config) {
+		cfg.Registry = reg
+	}
+}
+
+// WithPath sets the metrics endpoint path.
+func WithPath(path string) Option {
+	return func(cfg *Config) {
+		cfg.Path = path
+	}
+}
+
+// WithNamespace sets the metric name prefix.
+func WithNamespace(namespace string) Option {
+	return func(cfg *Config) {
+		cfg.Namespace = namespace
+	}
+}
+
+// WithBuckets sets the request duration histogram buckets in seconds.
+func WithBuckets(buckets ...float64) Option {
+	return func(cfg *Config) {
+		cfg.Buckets = buckets
+	}
+}
+
+// WithCollectors registers custom collectors alongside the HTTP metrics.
+func WithCollectors(cs ...prometheus.Collector) Option {
+	return func(cfg *Config) {
+		cfg.Collectors = append(cfg.Collectors, cs...)
+	}
+}
+
+// WithMetrics returns an app option that exposes Prometheus metrics and
+// instruments every request with:
+//
+//   - http_requests_total: counter by method, route, and status
+//   - http_request_duration_seconds: histogram by method, route, and status
+//   - http_requests_in_flight: gauge by method
+//
+// The route label is the chi route pattern (e.g. "/users/{id}"), never the
+// raw path. Requests that match no route are labeled "unmatched".
+// Place it before other middleware options so durations cover them.
+// It panics if a collector cannot be registered.
+func WithMetrics(opts ...Option) internal.Option {
+	cfg := &Config{
+		Path:    DefaultPath,
+		Buckets: prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.Registry == nil {
+		cfg.Registry = prometheus.NewRegistry()
+		cfg.Registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+	}
+
+	m := newHTTPMetrics(cfg)
+	cfg.Registry.MustRegister(m.requests, m.duration, m.inFlight)
+	cfg.Registry.MustRegister(cfg.Collectors...)
+
+	endpoint := promhttp.HandlerFor(cfg.Registry, promhttp.HandlerOpts{})
+
+	return func(a *internal.App) {
+		internal.WithMiddleware(m.middleware)(a)
+		internal.WithHandlers(metricsHandler{path: cfg.Path, endpoint: endpoint})(a)
+	}
+}
+
+// httpMetrics holds the request collectors.
+type httpMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+func newHTTPMetrics(cfg *Config) *httpMetrics {
+	labels := []string{"method", "route", "status"}
+	return &httpMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request duration in seconds.",
+			Buckets:   cfg.Buckets,
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: cfg.Namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests currently being served.",
+		}, []string{"method"}),
+	}
+}
+
+func (m *httpMetrics) middleware(next internal.HandlerFunc) internal.HandlerFunc {
+	return func(c internal.Context) error {
+		method := c.Request().Method
+		inFlight := m.inFlight.WithLabelValues(method)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		err := next(c)
+
+		// The route pattern is only known once chi has routed the request
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(c.Request().Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+
+		// Handlers that write nothing get an implicit 200 from net/http
+		status := c.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		code := strconv.Itoa(status)
+
+		m.requests.WithLabelValues(method, route, code).Inc()
+		m.duration.WithLabelValues(method, route, code).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// metricsHandler registers the metrics endpoint.
+type metricsHandler struct {
+	endpoint http.Handler
+	path     string
+}
+
+func (h metricsHandler) Routes(r internal.Router) {
+	r.GET(h.path, func(c internal.Context) error {
+		h.endpoint.ServeHTTP(c.Response(), c.Request())
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/metrics"
+)
+
+type routes func(r internal.Router)
+
+func (f routes) Routes(r internal.Router) { f(r) }
+
+func newApp(reg *prometheus.Registry, opts ...metrics.Option) *internal.App {
+	opts = append(opts, metrics.WithRegistry(reg))
+	return internal.New(
+		metrics.WithMetrics(opts...),
+		internal.WithHandlers(routes(func(r internal.Router) {
+			r.GET("/users/{id}", func(c internal.Context) error {
+				return c.String(http.StatusOK, c.Param("id"))
+			})
+		})),
+	)
+}
+
+func get(t *testing.T, h http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts requests by route pattern", func(t *testing.T) {
+		t.Parallel()
+
+		reg := prometheus.NewRegistry()
+		app := newApp(reg)
+
+		get(t, app.Router(), http.MethodGet, "/users/1")
+		get(t, app.Router(), http.MethodGet, "/users/2")
+
+		expected := `
+# HELP http_requests_total Total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",route="/users/{id}",status="200"} 2
+`
+		require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "http_requests_total"))
+	})
+
+	t.Run("labels status from response", func(t *testing.T) {
+		t.Parallel()
+
+		reg := prometheus.NewRegistry()
+		app := newApp(reg)
+
+		get(t, app.Router(), http.MethodGet, "/missing/path")
+
+		expected := `
+# HELP http_requests_total Total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",route="unmatched",status="404"} 1
+`
+		require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "http_requests_total"))
+	})
+
+	t.Run("records duration histogram", func(t *testing.T) {
+		t.Parallel()
+
+		reg := prometheus.NewRegistry()
+		app := newApp(reg)
+
+		get(t, app.Router(), http.MethodGet, "/users/1")
+
+		count, err := testutil.GatherAndCount(reg, "http_request_duration_seconds")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("serves metrics endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		reg := prometheus.NewRegistry()
+		app := newApp(reg, metrics.WithPath("/internal/metrics"))
+
+		get(t, app.Router(), http.MethodGet, "/users/1")
+		w := get(t, app.Router(), http.MethodGet, "/internal/metrics")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		body, err := io.ReadAll(w.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `http_requests_total{method="GET",route="/users/{id}",status="200"} 1`)
+		require.Contains(t, string(body), "http_requests_in_flight")
+	})
+
+	t.Run("applies namespace and custom collectors", func(t *testing.T) {
+		t.Parallel()
+
+		signups := prometheus.NewCounter(prometheus.CounterOpts{Name: "signups_total", Help: "Signups."})
+		signups.Add(3)
+
+		reg := prometheus.NewRegistry()
+		app := newApp(reg, metrics.WithNamespace("myapp"), metrics.WithCollectors(signups))
+
+		get(t, app.Router(), http.MethodGet, "/users/1")
+
+		count, err := testutil.GatherAndCount(reg, "myapp_http_requests_total", "signups_total")
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("default registry includes runtime collectors", func(t *testing.T) {
+		t.Parallel()
+
+		app := internal.New(metrics.WithMetrics())
+		w := get(t, app.Router(), http.MethodGet, metrics.DefaultPath)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "go_goroutines")
+	})
+}
@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// BodyLimit returns middleware that caps request bodies at n bytes. The body
+// is wrapped with http.MaxBytesReader, and the limit replaces
+// binder.DefaultMaxJSONSize for c.BindJSON, c.BindValidated, and c.RawBody,
+// so oversized bodies fail with a 413 *HTTPError wrapping binder.ErrBodyTooLarge.
+// Multipart uploads through c.Bind and c.FormFile fail the same way, wrapping
+// binder.ErrUploadTooLarge.
+// Non-positive values leave the request untouched.
+func BodyLimit(n int64) internal.Middleware {
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			if n <= 0 {
+				return next(c)
+			}
+			r := c.Request()
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(c.Response(), r.Body, n)
+			}
+			internal.SetBodyLimit(c, n)
+			return next(c)
+		}
+	}
+}
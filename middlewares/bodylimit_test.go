@@ -0,0 +1,175 @@
+package middlewares_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+	"github.com/dmitrymomot/forge/pkg/binder"
+)
+
+func TestBodyLimit(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	serve := func(t *testing.T, mw internal.Middleware, body string) (int, error) {
+		t.Helper()
+		var bindErr error
+		app := internal.New(
+			internal.WithMiddleware(mw),
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				bindErr = err
+				if httpErr := internal.AsHTTPError(err); httpErr != nil {
+					return c.NoContent(httpErr.Code)
+				}
+				return c.NoContent(http.StatusInternalServerError)
+			}),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				r.POST("/", func(c internal.Context) error {
+					var p payload
+					if _, err := c.BindJSON(&p); err != nil {
+						return err
+					}
+					return c.NoContent(http.StatusNoContent)
+				})
+			})),
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w.Code, bindErr
+	}
+
+	t.Run("body within limit binds", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, middlewares.BodyLimit(64), `{"name":"Alice"}`)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, code)
+	})
+
+	t.Run("oversized body returns 413", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, middlewares.BodyLimit(8), `{"name":"Alice"}`)
+		require.ErrorIs(t, err, binder.ErrBodyTooLarge)
+		require.Equal(t, http.StatusRequestEntityTooLarge, code)
+	})
+
+	t.Run("limit above binder default is honoured", func(t *testing.T) {
+		t.Parallel()
+
+		body := `{"name":"` + strings.Repeat("x", binder.DefaultMaxJSONSize) + `"}`
+		code, err := serve(t, middlewares.BodyLimit(2*binder.DefaultMaxJSONSize), body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, code)
+	})
+
+	t.Run("malformed body returns 400", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, middlewares.BodyLimit(64), `{"name":`)
+		require.ErrorIs(t, err, binder.ErrMalformedJSON)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestBodyLimitMultipart(t *testing.T) {
+	t.Parallel()
+
+	type upload struct {
+		Title  string                `form:"title"`
+		Avatar *multipart.FileHeader `form:"avatar"`
+	}
+
+	serve := func(t *testing.T, limit int64, fileSize int, handler internal.HandlerFunc) (int, error) {
+		t.Helper()
+		var handlerErr error
+		app := internal.New(
+			internal.WithMaxMultipartMemory(512),
+			internal.WithMiddleware(middlewares.BodyLimit(limit)),
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				handlerErr = err
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				r.POST("/", handler)
+			})),
+		)
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		require.NoError(t, mw.WriteField("title", "Avatar"))
+		part, err := mw.CreateFormFile("avatar", "a.png")
+		require.NoError(t, err)
+		_, err = part.Write(bytes.Repeat([]byte("x"), fileSize))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w.Code, handlerErr
+	}
+
+	bind := func(c internal.Context) error {
+		var u upload
+		if _, err := c.Bind(&u); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, u.Avatar.Filename)
+	}
+	formFile := func(c internal.Context) error {
+		_, fh, err := c.FormFile("avatar")
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, fh.Filename)
+	}
+
+	t.Run("Bind accepts upload within limit that spills to disk", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, 8<<10, 4<<10, bind)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Bind rejects oversized upload with 413", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, 1<<10, 4<<10, bind)
+		require.ErrorIs(t, err, binder.ErrUploadTooLarge)
+		require.ErrorIs(t, err, binder.ErrBodyTooLarge)
+		require.Equal(t, http.StatusRequestEntityTooLarge, code)
+	})
+
+	t.Run("FormFile accepts upload within limit", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, 8<<10, 4<<10, formFile)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("FormFile rejects oversized upload with 413", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := serve(t, 1<<10, 4<<10, formFile)
+		require.ErrorIs(t, err, binder.ErrUploadTooLarge)
+		require.Equal(t, http.StatusRequestEntityTooLarge, code)
+	})
+}
@@ -0,0 +1,277 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// DefaultCoalesceMaxSize is the largest response Coalesce shares with waiting
+// requests by default.
+const DefaultCoalesceMaxSize = 1 << 20 // 1MB
+
+// coalesceVaryHeaders are the request headers that change the response of
+// an otherwise identical request, so they are part of CoalesceRequestKey.
+var coalesceVaryHeaders = []string{"Accept", "Accept-Encoding", "Accept-Language", "HX-Request"}
+
+// CoalesceConfig configures the coalescing middleware.
+type CoalesceConfig struct {
+	// Key returns the key under which concurrent requests share a response.
+	// An empty key runs the request on its own. The default is
+	// CoalesceRequestKey for anonymous requests and "" for requests carrying
+	// a Cookie or Authorization header.
+	Key func(c internal.Context) string
+
+	// MaxSize is the largest response body that is shared (default: 1MB).
+	// Waiters on a larger response run the handler themselves.
+	MaxSize int
+}
+
+// CoalesceOption configures CoalesceConfig.
+type CoalesceOption func(*CoalesceConfig)
+
+// WithCoalesceKey sets the function that decides which requests share a
+// response. Include the user's identity for authenticated routes:
+//
+//	middlewares.WithCoalesceKey(func(c forge.Context) string {
+//	    return c.UserID() + "|" + middlewares.CoalesceRequestKey(c)
+//	})
+func WithCoalesceKey(fn func(c internal.Context) string) CoalesceOption {
+	return func(cfg *CoalesceConfig) {
+		cfg.Key = fn
+	}
+}
+
+// WithCoalesceMaxSize sets the largest response body that is shared.
+func WithCoalesceMaxSize(n int) CoalesceOption {
+	return func(cfg *CoalesceConfig) {
+		cfg.MaxSize = n
+	}
+}
+
+// CoalesceRequestKey identifies a request by method, path, query, and the
+// Accept, Accept-Encoding, Accept-Language, and HX-Request headers. It does
+// not look at credentials; combine it with the user's identity when the
+// response depends on who asks.
+func CoalesceRequestKey(c internal.Context) string {
+	r := c.Request()
+	var b strings.Builder
+	b.WriteString(r.Method)
+	b.WriteByte(' ')
+	b.WriteString(r.URL.RequestURI())
+	for _, h := range coalesceVaryHeaders {
+		b.WriteByte('\n')
+		b.WriteString(r.Header.Get(h))
+	}
+	return b.String()
+}
+
+func defaultCoalesceKey(c internal.Context) string {
+	h := c.Request().Header
+	if h.Get("Cookie") != "" || h.Get("Authorization") != "" {
+		return ""
+	}
+	return CoalesceRequestKey(c)
+}
+
+// Coalesce returns middleware that lets concurrent identical GET and HEAD
+// requests share one handler run. The first request runs the handler as
+// usual; requests with the same key that arrive while it is in flight wait
+// for it and receive a copy of its status, headers, and body. It protects
+// expensive pages from a burst of identical requests, such as a popular
+// dashboard right after its cache expires:
+//
+//	r.GET("/stats", h.stats, middlewares.Coalesce())
+//
+// Only requests in flight at the same time are merged; nothing is cached
+// afterwards. Waiters receive only the headers the handler set, so headers
+// written by middleware before Coalesce, such as X-Request-ID, stay their
+// own; Set-Cookie headers are never copied. If the handler returns an error,
+// every waiter returns the same error to the error handler, with an
+// *HTTPError copied to carry the waiter's request ID. This needs Coalesce in
+// the route's own middleware, as above; behind r.With the error is rendered
+// before Coalesce sees it, and waiters get the leader's error response as
+// is. Waiters run the handler themselves when the response was larger than
+// MaxSize, was flushed (streamed with c.Stream or c.Flush), or the first
+// request was canceled. Waiters still wait for the first run to finish, so do not
+// coalesce long-lived streams such as server-sent events.
+//
+// By default requests with a Cookie or Authorization header are not
+// coalesced, since their responses may be private. Use WithCoalesceKey to
+// coalesce them per user.
+func Coalesce(opts ...CoalesceOption) internal.Middleware {
+	cfg := &CoalesceConfig{
+		Key:     defaultCoalesceKey,
+		MaxSize: DefaultCoalesceMaxSize,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultCoalesceMaxSize
+	}
+
+	var group singleflight.Group
+
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			method := c.Request().Method
+			if method != http.MethodGet && method != http.MethodHead {
+				return next(c)
+			}
+			key := cfg.Key(c)
+			if key == "" {
+				return next(c)
+			}
+
+			leader := false
+			v, _, _ := group.Do(key, func() (any, error) {
+				leader = true
+				return runCoalesced(c, next, cfg.MaxSize), nil
+			})
+			res := v.(*coalescedResponse)
+			if leader {
+				return res.err
+			}
+			if !res.shareable {
+				return next(c)
+			}
+			return res.replay(c)
+		}
+	}
+}
+
+// coalescedResponse is the outcome of the handler run waiters share.
+type coalescedResponse struct {
+	err error
+	// httpErr is a snapshot of the *HTTPError in err, taken before the
+	// leader's error handler can modify it.
+	httpErr   *internal.HTTPError
+	shareable bool
+	written   bool
+	status    int
+	header    http.Header
+	body      []byte
+}
+
+// runCoalesced runs next for the leading request, which is served normally,
+// and records its response for the waiters.
+func runCoalesced(c internal.Context, next internal.HandlerFunc, maxSize int) *coalescedResponse {
+	rw := c.ResponseWriter()
+	if rw == nil {
+		return &coalescedResponse{err: next(c)}
+	}
+
+	capture := &coalesceCapture{max: maxSize}
+	rw.OnWrite(capture.write)
+	// Headers set by outer middleware belong to the leader alone
+	before := rw.Header().Clone()
+
+	err := next(c)
+
+	body, overflow := capture.result()
+	res := &coalescedResponse{
+		err:       err,
+		shareable: !overflow && !rw.Flushed() && !errors.Is(err, context.Canceled),
+		written:   rw.Written(),
+		status:    rw.Status(),
+		header:    addedHeaders(before, rw.Header()),
+		body:      body,
+	}
+	if httpErr := internal.AsHTTPError(err); httpErr != nil {
+		snapshot := *httpErr
+		res.httpErr = &snapshot
+	}
+	res.header.Del("Set-Cookie")
+	return res
+}
+
+// addedHeaders returns the headers in after that are missing from before or
+// have different values.
+func addedHeaders(before, after http.Header) http.Header {
+	added := make(http.Header, len(after))
+	for k, v := range after {
+		if !slices.Equal(before[k], v) {
+			added[k] = slices.Clone(v)
+		}
+	}
+	return added
+}
+
+// replay writes the shared response, or returns the shared error, for a
+// waiting request.
+func (r *coalescedResponse) replay(c internal.Context) error {
+	if r.err != nil {
+		return r.waiterError(c)
+	}
+	if !r.written {
+		return nil
+	}
+	h := c.Response().Header()
+	for k, v := range r.header {
+		h[k] = append([]string(nil), v...)
+	}
+	c.Response().WriteHeader(r.status)
+	if len(r.body) > 0 {
+		_, err := c.Response().Write(r.body)
+		return err
+	}
+	return nil
+}
+
+// waiterError returns the shared error for a waiting request. An *HTTPError
+// is copied with the waiter's request ID; errors.As finds the copy first,
+// while errors.Is still sees the leader's whole error chain.
+func (r *coalescedResponse) waiterError(c internal.Context) error {
+	if r.httpErr == nil {
+		return r.err
+	}
+	httpErr := *r.httpErr
+	httpErr.RequestID = c.RequestID()
+	return &coalescedError{httpErr: &httpErr, err: r.err}
+}
+
+// coalescedError pairs a waiter's copy of an *HTTPError with the leader's
+// original error.
+type coalescedError struct {
+	httpErr *internal.HTTPError
+	err     error
+}
+
+func (e *coalescedError) Error() string   { return e.err.Error() }
+func (e *coalescedError) Unwrap() []error { return []error{e.httpErr, e.err} }
+
+// coalesceCapture copies the leader's response body up to max bytes.
+type coalesceCapture struct {
+	mu       sync.Mutex
+	max      int
+	buf      []byte
+	overflow bool
+}
+
+func (w *coalesceCapture) write(b []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.overflow {
+		return
+	}
+	if len(w.buf)+len(b) > w.max {
+		w.overflow = true
+		w.buf = nil
+		return
+	}
+	w.buf = append(w.buf, b...)
+}
+
+func (w *coalesceCapture) result() ([]byte, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf, w.overflow
+}
@@ -0,0 +1,280 @@
+package middlewares_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+)
+
+func TestCoalesce(t *testing.T) {
+	t.Parallel()
+
+	// gate blocks handler runs until release is closed, so tests can pile up
+	// concurrent requests behind the first one.
+	type gate struct {
+		calls   atomic.Int32
+		release chan struct{}
+	}
+	newGate := func() *gate { return &gate{release: make(chan struct{})} }
+
+	newApp := func(g *gate, h internal.HandlerFunc, opts ...middlewares.CoalesceOption) http.Handler {
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				handler := func(c internal.Context) error {
+					g.calls.Add(1)
+					<-g.release
+					return h(c)
+				}
+				r.With(middlewares.Coalesce(opts...)).GET("/", handler)
+				r.With(middlewares.Coalesce(opts...)).POST("/", handler)
+			})),
+		)
+		return app.Router()
+	}
+
+	// serveAll sends every request concurrently. The first one is sent alone
+	// and the rest once it is inside the handler; release opens the gate
+	// after wantCalls handler runs started and the others had time to queue.
+	serveAll := func(t *testing.T, h http.Handler, g *gate, wantCalls int32, reqs ...*http.Request) []*httptest.ResponseRecorder {
+		t.Helper()
+		recs := make([]*httptest.ResponseRecorder, len(reqs))
+		var wg sync.WaitGroup
+		serve := func(i int) {
+			defer wg.Done()
+			recs[i] = httptest.NewRecorder()
+			h.ServeHTTP(recs[i], reqs[i])
+		}
+
+		wg.Add(1)
+		go serve(0)
+		require.Eventually(t, func() bool { return g.calls.Load() >= 1 }, time.Second, time.Millisecond)
+		for i := 1; i < len(reqs); i++ {
+			wg.Add(1)
+			go serve(i)
+		}
+		require.Eventually(t, func() bool { return g.calls.Load() >= wantCalls }, time.Second, time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		close(g.release)
+		wg.Wait()
+		return recs
+	}
+
+	get := func(path string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, path, nil)
+	}
+
+	t.Run("concurrent identical GETs share one run", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			c.SetCookie("visitor", "1", 0)
+			c.SetHeader("X-Report", "daily")
+			return c.String(http.StatusAccepted, "expensive report")
+		})
+
+		recs := serveAll(t, h, g, 1, get("/?day=1"), get("/?day=1"), get("/?day=1"), get("/?day=1"))
+
+		require.Equal(t, int32(1), g.calls.Load())
+		for i, w := range recs {
+			require.Equal(t, http.StatusAccepted, w.Code)
+			require.Equal(t, "expensive report", w.Body.String())
+			require.Equal(t, "daily", w.Header().Get("X-Report"))
+			if i > 0 {
+				require.Empty(t, w.Header().Values("Set-Cookie"), "cookies must not be shared")
+			}
+		}
+		require.NotEmpty(t, recs[0].Header().Values("Set-Cookie"))
+	})
+
+	t.Run("different queries run separately", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			return c.String(http.StatusOK, c.Query("day"))
+		})
+
+		recs := serveAll(t, h, g, 2, get("/?day=1"), get("/?day=2"))
+
+		require.Equal(t, int32(2), g.calls.Load())
+		require.Equal(t, "1", recs[0].Body.String())
+		require.Equal(t, "2", recs[1].Body.String())
+	})
+
+	t.Run("POST is never coalesced", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			return c.NoContent(http.StatusCreated)
+		})
+
+		recs := serveAll(t, h, g, 2,
+			httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a")),
+			httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a")),
+		)
+
+		require.Equal(t, int32(2), g.calls.Load())
+		for _, w := range recs {
+			require.Equal(t, http.StatusCreated, w.Code)
+		}
+	})
+
+	t.Run("requests with credentials are not coalesced by default", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			return c.String(http.StatusOK, c.Request().Header.Get("Authorization"))
+		})
+
+		alice, bob := get("/"), get("/")
+		alice.Header.Set("Authorization", "Bearer alice")
+		bob.Header.Set("Authorization", "Bearer bob")
+		recs := serveAll(t, h, g, 2, alice, bob)
+
+		require.Equal(t, int32(2), g.calls.Load())
+		require.Equal(t, "Bearer alice", recs[0].Body.String())
+		require.Equal(t, "Bearer bob", recs[1].Body.String())
+	})
+
+	t.Run("custom key coalesces per user", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			return c.String(http.StatusOK, c.Request().Header.Get("X-User"))
+		}, middlewares.WithCoalesceKey(func(c internal.Context) string {
+			return c.Request().Header.Get("X-User") + "|" + middlewares.CoalesceRequestKey(c)
+		}))
+
+		user := func(id string) *http.Request {
+			req := get("/")
+			req.Header.Set("X-User", id)
+			return req
+		}
+		recs := serveAll(t, h, g, 2, user("alice"), user("bob"), user("alice"), user("bob"))
+
+		require.Equal(t, int32(2), g.calls.Load())
+		require.Equal(t, "alice", recs[0].Body.String())
+		require.Equal(t, "bob", recs[1].Body.String())
+		require.Equal(t, "alice", recs[2].Body.String())
+		require.Equal(t, "bob", recs[3].Body.String())
+	})
+
+	t.Run("handler error is shared", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			return internal.ErrServiceUnavailable("backend down", internal.WithError(errors.New("timeout")))
+		})
+
+		recs := serveAll(t, h, g, 1, get("/"), get("/"), get("/"))
+
+		require.Equal(t, int32(1), g.calls.Load())
+		for _, w := range recs {
+			require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		}
+	})
+
+	t.Run("waiters keep their own request ID", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				return c.JSONError(err)
+			}),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				// Route-level middleware shares the handler chain, so the
+				// error reaches Coalesce instead of being rendered inside it
+				r.GET("/", func(c internal.Context) error {
+					g.calls.Add(1)
+					<-g.release
+					c.SetHeader("X-Report", "daily")
+					if c.Query("fail") != "" {
+						return c.Error(http.StatusNotFound, "missing")
+					}
+					return c.String(http.StatusOK, "report")
+				}, middlewares.RequestID(), middlewares.Coalesce())
+			})),
+		)
+		withID := func(path, id string) *http.Request {
+			req := get(path)
+			req.Header.Set("X-Request-ID", id)
+			return req
+		}
+
+		recs := serveAll(t, app.Router(), g, 1, withID("/", "req-a"), withID("/", "req-b"))
+
+		require.Equal(t, int32(1), g.calls.Load())
+		for i, id := range []string{"req-a", "req-b"} {
+			require.Equal(t, "report", recs[i].Body.String())
+			require.Equal(t, "daily", recs[i].Header().Get("X-Report"))
+			require.Equal(t, id, recs[i].Header().Get("X-Request-ID"))
+		}
+
+		g = newGate()
+		recs = serveAll(t, app.Router(), g, 1, withID("/?fail=1", "req-a"), withID("/?fail=1", "req-b"))
+
+		require.Equal(t, int32(1), g.calls.Load())
+		for i, id := range []string{"req-a", "req-b"} {
+			require.Equal(t, http.StatusNotFound, recs[i].Code)
+			require.Contains(t, recs[i].Body.String(), `"request_id":"`+id+`"`)
+			require.Equal(t, id, recs[i].Header().Get("X-Request-ID"))
+		}
+	})
+
+	t.Run("oversized response makes waiters run the handler", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		body := strings.Repeat("x", 64)
+		h := newApp(g, func(c internal.Context) error {
+			return c.String(http.StatusOK, body)
+		}, middlewares.WithCoalesceMaxSize(16))
+
+		recs := serveAll(t, h, g, 1, get("/"), get("/"), get("/"))
+
+		require.Equal(t, int32(3), g.calls.Load())
+		for _, w := range recs {
+			require.Equal(t, body, w.Body.String())
+		}
+	})
+	t.Run("flushed response makes waiters run the handler", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGate()
+		h := newApp(g, func(c internal.Context) error {
+			c.SetTrailer("X-Status", "done")
+			return c.Stream(http.StatusOK, "text/plain", func(w io.Writer) error {
+				_, err := io.WriteString(w, "chunk")
+				return err
+			})
+		})
+
+		recs := serveAll(t, h, g, 1, get("/"), get("/"))
+
+		require.Equal(t, int32(2), g.calls.Load())
+		for _, w := range recs {
+			require.Equal(t, "chunk", w.Body.String())
+			require.True(t, w.Flushed)
+		}
+	})
+}
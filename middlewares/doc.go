@@ -22,10 +22,15 @@
 //	    ),
 //	)
 //
+// Handlers read the ID with c.RequestID(). Errors from c.Error and bodies
+// written by c.JSONError include it as request_id automatically.
+//
 // # Recover
 //
 // Recover middleware catches panics and converts them to typed errors.
 // The PanicError can be handled by the global ErrorHandler.
+// The App also recovers panics on its own, outside all middleware, as a
+// last resort; Recover runs inside that net and handles panics first.
 //
 //	app := forge.New(
 //	    forge.WithMiddleware(
@@ -34,13 +39,27 @@
 //	    forge.WithErrorHandler(func(c forge.Context, err error) error {
 //	        if forge.IsPanicError(err) {
 //	            pe, _ := forge.AsPanicError(err)
-//	            c.LogError("panic", "value", pe.Value, "stack", string(pe.Stack))
+//	            c.LogError("panic", "value", pe.Value, "stack", pe.FormattedStack)
 //	            return c.Error(500, "Internal Server Error")
 //	        }
 //	        return c.Error(500, err.Error())
 //	    }),
 //	)
 //
+// PanicError.Stack holds the raw runtime stack and PanicError.FormattedStack
+// the frames from the panic site down, which is what Recover logs. Keep the
+// formatted stack short and free of build paths in production, and add source
+// context in development:
+//
+//	opts := []middlewares.RecoverOption{
+//	    middlewares.WithRecoverStackDepth(16),
+//	    middlewares.WithRecoverTrimPaths(),
+//	}
+//	if cfg.Debug {
+//	    opts = append(opts, middlewares.WithRecoverSource(3))
+//	}
+//	middlewares.Recover(opts...)
+//
 // # Timeout
 //
 // Timeout middleware enforces request timeouts and returns typed TimeoutError.
@@ -58,6 +77,18 @@
 //	    }),
 //	)
 //
+// Routes that legitimately need longer, such as report generation, override the
+// global timeout with TimeoutFor. The override replaces the global timeout rather
+// than stacking with it, and GetTimeoutContext returns a context with the new deadline:
+//
+//	r.With(middlewares.TimeoutFor(60*time.Second)).GET("/reports/{id}", h.report)
+//
+// forge.WithRequestTimeout sets an app-wide deadline on the request context
+// without this middleware. Both can be used together; Timeout and TimeoutFor
+// derive from the request context, so the shortest deadline wins. Routes
+// that stream or upgrade to websockets lift the app deadline with
+// forge.SkipRequestTimeout.
+//
 // # CORS
 //
 // CORS middleware handles Cross-Origin Resource Sharing headers.
@@ -151,6 +182,160 @@
 //	    ),
 //	)
 //
+// Issue tokens at login with IssueJWT, using the same service the middleware
+// validates with. By default the middleware reads only the Authorization
+// header. For browser logins, store the token with c.SetJWTCookie and add
+// WithJWTCookieFallback, which reads that same cookie when the header is
+// missing (forge.WithJWTCookieName changes its name for both). Cookies are
+// sent on cross-site requests, so pair the fallback with CSRF protection:
+//
+//	token, err := middlewares.IssueJWT(jwtSvc, MyClaims{Role: user.Role}, time.Hour)
+//	if err != nil {
+//	    return err
+//	}
+//	c.SetJWTCookie(token, int(time.Hour.Seconds()))
+//
+//	r.Use(middlewares.JWT[MyClaims](jwtSvc, middlewares.WithJWTCookieFallback()))
+//
+// The fallback also applies after a custom extractor chain; equivalently,
+// end the chain with forge.FromJWTCookie. A plain forge.FromCookie source
+// does not read the cookie c.SetJWTCookie writes unless given the same
+// name. For API clients, IssueJWTPair returns
+// an access and refresh token pair in the OAuth token response format. Sign
+// refresh tokens with a second jwt.Service so they cannot pass the
+// middleware, and verify them in the refresh handler:
+//
+//	pair, err := middlewares.IssueJWTPair(
+//	    jwtSvc, MyClaims{Role: user.Role}, 15*time.Minute,
+//	    refreshSvc, jwt.StandardClaims{Subject: user.ID}, 30*24*time.Hour,
+//	)
+//	return c.JSON(http.StatusOK, pair)
+//
+// # Signed URLs
+//
+// VerifySignedURL protects routes reached through links built with
+// c.SignedURL, such as magic-link sign-in and expiring downloads. Requests
+// with a missing, tampered, or expired signature get 403 Forbidden:
+//
+//	signer, _ := forge.NewURLSigner(os.Getenv("COOKIE_SECRET"))
+//
+//	r.GET("/downloads/{id}", h.download, middlewares.VerifySignedURL(signer))
+//
+//	// Elsewhere, with forge.WithURLSigner(signer) configured:
+//	link, err := c.SignedURL("/downloads/42", time.Now().Add(24*time.Hour), nil)
+//
+// # Webhooks
+//
+// WebhookVerify checks provider signatures before the handler runs, using
+// constant-time comparison. The body is read with c.RawBody, so the handler
+// gets the exact verified bytes. Missing, invalid, and stale signatures get
+// 400 Bad Request:
+//
+//	r.With(middlewares.WebhookVerify(
+//	    middlewares.WithStripeScheme(os.Getenv("STRIPE_WEBHOOK_SECRET")),
+//	)).POST("/webhooks/stripe", h.stripe)
+//
+//	r.With(middlewares.WebhookVerify(
+//	    middlewares.WithGitHubScheme(os.Getenv("GITHUB_WEBHOOK_SECRET")),
+//	)).POST("/webhooks/github", h.github)
+//
+// WithHMACScheme covers providers that send a hex HMAC of the body in a
+// header. Stripe timestamps older than five minutes are rejected; change
+// the window with WithWebhookTolerance. The scheme options panic on an empty
+// secret, so an unset environment variable fails at startup instead of
+// accepting requests signed with an empty key.
+//
+// # Body Limit
+//
+// BodyLimit caps request bodies with http.MaxBytesReader and sets the limit
+// used by c.BindJSON, c.BindValidated, and c.RawBody in place of the 1MB
+// default. Oversized JSON bodies fail with a 413 *HTTPError wrapping
+// binder.ErrBodyTooLarge; malformed ones with a 400 wrapping
+// binder.ErrMalformedJSON:
+//
+//	r.POST("/imports", h.importData, middlewares.BodyLimit(10<<20))
+//
+// It is also the upload cap: multipart bodies over the limit make c.Bind and
+// c.FormFile fail with a 413 wrapping binder.ErrUploadTooLarge. Multipart
+// parsing keeps up to forge.WithMaxMultipartMemory (10MB by default) in
+// memory and writes larger files to temporary files, so without BodyLimit
+// an upload is bounded only by disk space:
+//
+//	r.POST("/avatar", h.uploadAvatar, middlewares.BodyLimit(5<<20))
+//
+//	func (h *Handler) uploadAvatar(c forge.Context) error {
+//	    _, fh, err := c.FormFile("avatar")
+//	    if errors.Is(err, forge.ErrUploadTooLarge) {
+//	        return c.Error(http.StatusRequestEntityTooLarge, "Avatar must be under 5MB")
+//	    }
+//	    ...
+//	}
+//
+// # HTTPS Redirect
+//
+// RedirectHTTPS sends plain-HTTP requests to the same URL over HTTPS.
+// Behind a TLS-terminating proxy, X-Forwarded-Proto decides the scheme, but
+// only when the request comes from a trusted proxy (DefaultTrustedProxies,
+// the loopback and private ranges, unless WithHTTPSTrustedProxies is set):
+//
+//	forge.WithMiddleware(
+//	    middlewares.RedirectHTTPS(
+//	        middlewares.WithHTTPSTrustedProxies("10.0.0.0/8"),
+//	        middlewares.WithHTTPSExempt("/.well-known/acme-challenge/"),
+//	    ),
+//	)
+//
+// Health check paths configured with WithHealthChecks are mounted on the
+// router, so exempt them too if the load balancer probes over HTTP.
+//
+// # Body Dump
+//
+// DumpBody logs request and response bodies for debugging client
+// integrations. It buffers both bodies in memory, so enable it only on the
+// routes under investigation and remove it afterwards. JSON and form fields
+// such as password and token are redacted; add more with WithDumpRedactKeys:
+//
+//	r.POST("/webhooks/partner", h.partnerWebhook,
+//	    middlewares.DumpBody(
+//	        middlewares.WithDumpMaxSize(16<<10),
+//	        middlewares.WithDumpRedactKeys("card_number"),
+//	    ),
+//	)
+//
+// # Coalesce
+//
+// Coalesce merges concurrent identical GET and HEAD requests into one handler
+// run and copies the response to every waiter, so a burst of requests for
+// the same expensive page hits the backend once. Requests with a Cookie or
+// Authorization header are left alone unless a key function scopes them to
+// the user:
+//
+//	r.GET("/public/stats", h.stats, middlewares.Coalesce())
+//
+//	r.GET("/dashboard", h.dashboard, middlewares.Coalesce(
+//	    middlewares.WithCoalesceKey(func(c forge.Context) string {
+//	        return c.UserID() + "|" + middlewares.CoalesceRequestKey(c)
+//	    }),
+//	))
+//
+// Waiters keep their own request ID and other headers set before Coalesce.
+// Pass Coalesce as route middleware, as above, so handler errors reach it
+// and each waiter gets an error carrying its own request ID.
+//
+// # Language Switcher
+//
+// I18n resolves the language from the "lang" cookie, then Accept-Language.
+// LanguageSwitcher is the handler behind a language picker: it checks the
+// requested language against the available ones, stores it in that cookie,
+// and redirects to the return path, falling back to "/" for URLs that point
+// to another host:
+//
+//	func (h *Handler) Routes(r forge.Router) {
+//	    r.GET("/set-lang", middlewares.LanguageSwitcher(h.i18n.Languages()))
+//	}
+//
+//	// <a href="/set-lang?lang=de&return=/dashboard">Deutsch</a>
+//
 // # Recommended Middleware Order
 //
 // Apply middlewares in this order for best results:
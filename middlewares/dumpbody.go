@@ -0,0 +1,253 @@
+package middlewares
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// DefaultDumpMaxSize is the default number of body bytes DumpBody logs.
+const DefaultDumpMaxSize = 4 << 10 // 4KB
+
+// dumpCaptureLimit caps how much of a response DumpBody keeps in memory.
+// JSON and form bodies are redacted before truncation, which needs the whole
+// body; larger ones are omitted rather than logged unredacted.
+const dumpCaptureLimit = 1 << 20 // 1MB
+
+// DefaultDumpRedactKeys are the JSON and form fields DumpBody always redacts.
+var DefaultDumpRedactKeys = []string{
+	"password", "token", "access_token", "refresh_token", "id_token",
+	"secret", "client_secret", "api_key", "authorization",
+}
+
+// DumpBodyConfig configures the body dump middleware.
+type DumpBodyConfig struct {
+	// MaxSize is the number of bytes of each body that is logged (default: 4KB).
+	MaxSize int
+
+	// RedactKeys lists JSON object keys and form fields, matched ignoring
+	// case, whose values are replaced with "[REDACTED]" at any depth.
+	RedactKeys []string
+
+	// Match selects the requests to dump. Nil dumps every request the
+	// middleware sees.
+	Match func(c internal.Context) bool
+}
+
+// DumpBodyOption configures DumpBodyConfig.
+type DumpBodyOption func(*DumpBodyConfig)
+
+// WithDumpMaxSize sets how many bytes of each body are logged.
+func WithDumpMaxSize(n int) DumpBodyOption {
+	return func(cfg *DumpBodyConfig) {
+		cfg.MaxSize = n
+	}
+}
+
+// WithDumpRedactKeys adds fields to redact on top of DefaultDumpRedactKeys.
+func WithDumpRedactKeys(keys ...string) DumpBodyOption {
+	return func(cfg *DumpBodyConfig) {
+		cfg.RedactKeys = append(cfg.RedactKeys, keys...)
+	}
+}
+
+// WithDumpMatch limits dumping to requests for which fn returns true.
+func WithDumpMatch(fn func(c internal.Context) bool) DumpBodyOption {
+	return func(cfg *DumpBodyConfig) {
+		cfg.Match = fn
+	}
+}
+
+// DumpBody returns middleware that logs request and response bodies, for
+// debugging client integrations without a proxy. It is meant to be enabled
+// temporarily on the routes under investigation:
+//
+//	r.With(middlewares.DumpBody()).POST("/webhooks/stripe", h.stripeWebhook)
+//
+// Warning: DumpBody buffers bodies in memory. The request body is read with
+// c.RawBody, so it is subject to the body limit and handlers can still read
+// it; the response is kept up to 1MB. Do not leave it on busy routes or
+// routes that stream large files.
+//
+// Each dumped request produces one "http body dump" entry at Info level,
+// after the response is sent. JSON and form bodies have RedactKeys replaced
+// with "[REDACTED]" before truncation to MaxSize; other text bodies are
+// logged as is and binary bodies only by size. Headers are not logged.
+func DumpBody(opts ...DumpBodyOption) internal.Middleware {
+	cfg := &DumpBodyConfig{
+		MaxSize:    DefaultDumpMaxSize,
+		RedactKeys: append([]string(nil), DefaultDumpRedactKeys...),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultDumpMaxSize
+	}
+	redact := make(map[string]bool, len(cfg.RedactKeys))
+	for _, k := range cfg.RedactKeys {
+		redact[strings.ToLower(k)] = true
+	}
+
+	var warnOnce sync.Once
+
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			if cfg.Match != nil && !cfg.Match(c) {
+				return next(c)
+			}
+			warnOnce.Do(func() {
+				c.Logger().Warn("DumpBody is enabled: request and response bodies are buffered and logged")
+			})
+
+			// A body that cannot be read is the handler's to reject; the
+			// dump is a debugging aid and must not change the response.
+			reqBody, err := c.RawBody()
+			if err != nil {
+				c.LogWarn("DumpBody: request body not dumped", "error", err)
+				return next(c)
+			}
+			reqType := c.Request().Header.Get("Content-Type")
+
+			resp := &dumpCapture{}
+			if rw, ok := c.Response().(*internal.ResponseWriter); ok {
+				rw.OnWrite(resp.write)
+			}
+
+			c.Defer(func() {
+				status := http.StatusOK
+				if rw, ok := c.Response().(*internal.ResponseWriter); ok {
+					status = rw.Status()
+				}
+				respBody, respSize := resp.snapshot()
+				c.Logger().InfoContext(c.Context(), "http body dump",
+					slog.String("method", c.Request().Method),
+					slog.String("path", c.Request().URL.Path),
+					slog.Int("status", status),
+					slog.Group("request",
+						slog.Int("size", len(reqBody)),
+						slog.String("body", formatDumpBody(reqType, reqBody, false, cfg.MaxSize, redact)),
+					),
+					slog.Group("response",
+						slog.Int64("size", respSize),
+						slog.String("body", formatDumpBody(c.Response().Header().Get("Content-Type"), respBody, respSize > int64(len(respBody)), cfg.MaxSize, redact)),
+					),
+				)
+			})
+
+			return next(c)
+		}
+	}
+}
+
+// dumpCapture keeps the first dumpCaptureLimit bytes of a response body.
+type dumpCapture struct {
+	mu   sync.Mutex
+	buf  []byte
+	size int64
+}
+
+func (d *dumpCapture) write(b []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.size += int64(len(b))
+	if room := dumpCaptureLimit - len(d.buf); room > 0 {
+		d.buf = append(d.buf, b[:min(len(b), room)]...)
+	}
+}
+
+// snapshot returns the captured bytes and the full size of the body.
+func (d *dumpCapture) snapshot() ([]byte, int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buf, d.size
+}
+
+// formatDumpBody renders body for the log: redacted if it is JSON or a
+// form, truncated to maxSize, or summarized if it is binary. partial means
+// body is only the beginning of a larger one.
+func formatDumpBody(contentType string, body []byte, partial bool, maxSize int, redact map[string]bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var out string
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		// UseNumber keeps large integers such as IDs exact.
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v any
+		if partial || dec.Decode(&v) != nil {
+			return "[unparseable JSON omitted]"
+		}
+		redacted, err := json.Marshal(redactJSON(v, redact))
+		if err != nil {
+			return "[unparseable JSON omitted]"
+		}
+		out = string(redacted)
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if partial || err != nil {
+			return "[unparseable form omitted]"
+		}
+		for k := range values {
+			if redact[strings.ToLower(k)] {
+				values[k] = []string{redactedValue}
+			}
+		}
+		out = values.Encode()
+	case isTextMediaType(mediaType):
+		out = string(body)
+	default:
+		return fmt.Sprintf("[binary %s, %d bytes]", cmp.Or(mediaType, "unknown type"), len(body))
+	}
+
+	if len(out) > maxSize {
+		return out[:maxSize] + "…[truncated]"
+	}
+	return out
+}
+
+// redactedValue replaces redacted fields in dumped bodies.
+const redactedValue = "[REDACTED]"
+
+// redactJSON replaces the values of redacted keys in decoded JSON, at any depth.
+func redactJSON(v any, redact map[string]bool) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if redact[strings.ToLower(k)] {
+				t[k] = redactedValue
+			} else {
+				t[k] = redactJSON(val, redact)
+			}
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = redactJSON(val, redact)
+		}
+	}
+	return v
+}
+
+func isTextMediaType(mediaType string) bool {
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		mediaType == "application/xml",
+		strings.HasSuffix(mediaType, "+xml"),
+		mediaType == "application/javascript":
+		return true
+	}
+	return false
+}
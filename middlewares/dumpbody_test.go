@@ -0,0 +1,136 @@
+package middlewares_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+	"github.com/dmitrymomot/forge/pkg/logger"
+)
+
+func TestDumpBody(t *testing.T) {
+	t.Parallel()
+
+	serve := func(t *testing.T, req *http.Request, h internal.HandlerFunc, opts ...middlewares.DumpBodyOption) (*httptest.ResponseRecorder, *logger.RecordCapture) {
+		t.Helper()
+		log, capture := logger.NewTestLogger()
+		app := internal.New(
+			internal.WithCustomLogger(log),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				r.With(middlewares.DumpBody(opts...)).POST("/", h)
+				r.With(middlewares.DumpBody(opts...)).GET("/", h)
+			})),
+		)
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w, capture
+	}
+
+	jsonRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("logs redacted request and response bodies", func(t *testing.T) {
+		t.Parallel()
+
+		var handlerSaw string
+		w, capture := serve(t, jsonRequest(`{"email":"a@example.com","Password":"hunter2","nested":{"token":"t","id":12345678901234567}}`),
+			func(c internal.Context) error {
+				b, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return err
+				}
+				handlerSaw = string(b)
+				return c.JSON(http.StatusCreated, map[string]any{"id": 1, "access_token": "secret-value"})
+			})
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Contains(t, handlerSaw, "hunter2", "handler must still read the original body")
+		require.True(t, capture.Contains(slog.LevelWarn, "DumpBody is enabled: request and response bodies are buffered and logged"))
+		require.True(t, capture.Contains(slog.LevelInfo, "http body dump"))
+
+		attrs := capture.Records()[1].Attrs
+		require.Equal(t, "POST", attrs["method"])
+		require.Equal(t, int64(http.StatusCreated), attrs["status"])
+		require.JSONEq(t, `{"email":"a@example.com","Password":"[REDACTED]","nested":{"token":"[REDACTED]","id":12345678901234567}}`, attrs["request.body"].(string))
+		require.JSONEq(t, `{"id":1,"access_token":"[REDACTED]"}`, attrs["response.body"].(string))
+		require.Equal(t, int64(w.Body.Len()), attrs["response.size"])
+	})
+
+	t.Run("redacts form fields and custom keys", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("user=bob&password=pw&pin=1234"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		_, capture := serve(t, req, func(c internal.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		}, middlewares.WithDumpRedactKeys("pin"))
+
+		require.Equal(t, "password=%5BREDACTED%5D&pin=%5BREDACTED%5D&user=bob", capture.Attr("http body dump", "request.body"))
+		require.Equal(t, "", capture.Attr("http body dump", "response.body"))
+	})
+
+	t.Run("truncates to max size", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		_, capture := serve(t, req, func(c internal.Context) error {
+			return c.String(http.StatusOK, strings.Repeat("x", 100))
+		}, middlewares.WithDumpMaxSize(10))
+
+		require.Equal(t, "xxxxxxxxxx…[truncated]", capture.Attr("http body dump", "response.body"))
+		require.Equal(t, int64(100), capture.Attr("http body dump", "response.size"))
+	})
+
+	t.Run("summarizes binary bodies and omits invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		_, capture := serve(t, jsonRequest(`{"password":`), func(c internal.Context) error {
+			c.SetHeader("Content-Type", "image/png")
+			_, err := c.Response().Write([]byte{0x89, 'P', 'N', 'G'})
+			return err
+		})
+
+		require.Equal(t, "[unparseable JSON omitted]", capture.Attr("http body dump", "request.body"))
+		require.Equal(t, "[binary image/png, 4 bytes]", capture.Attr("http body dump", "response.body"))
+	})
+
+	t.Run("unreadable body is passed on without a dump", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", iotest.ErrReader(errors.New("connection reset")))
+		var called bool
+		w, capture := serve(t, req, func(c internal.Context) error {
+			called = true
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		require.True(t, called)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.True(t, capture.Contains(slog.LevelWarn, "DumpBody: request body not dumped"))
+		require.False(t, capture.Contains(slog.LevelInfo, "http body dump"))
+	})
+
+	t.Run("skips requests that do not match", func(t *testing.T) {
+		t.Parallel()
+
+		_, capture := serve(t, jsonRequest(`{}`), func(c internal.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		}, middlewares.WithDumpMatch(func(c internal.Context) bool {
+			return c.Request().Method == http.MethodGet
+		}))
+
+		require.Empty(t, capture.Records())
+	})
+}
@@ -2,30 +2,17 @@ package middlewares
 
 import (
 	"errors"
-	"fmt"
-	"time"
+
+	"github.com/dmitrymomot/forge/internal"
 )
 
 // PanicError represents a recovered panic.
-type PanicError struct {
-	Value any    // The panic value
-	Stack []byte // Stack trace (nil if disabled)
-}
-
-// Error implements the error interface.
-func (e *PanicError) Error() string {
-	return fmt.Sprintf("panic: %v", e.Value)
-}
+// It is the same type the App's built-in panic safety net returns.
+type PanicError = internal.PanicError
 
 // TimeoutError represents a request timeout.
-type TimeoutError struct {
-	Duration time.Duration // The timeout that was exceeded
-}
-
-// Error implements the error interface.
-func (e *TimeoutError) Error() string {
-	return fmt.Sprintf("request timeout after %s", e.Duration)
-}
+// It is the same type the App returns when WithRequestTimeout is exceeded.
+type TimeoutError = internal.TimeoutError
 
 // IsPanicError returns true if the error is a PanicError.
 func IsPanicError(err error) bool {
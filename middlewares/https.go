@@ -0,0 +1,168 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// DefaultTrustedProxies are the networks whose X-Forwarded-Proto header is
+// trusted by default: loopback and private ranges, where TLS-terminating
+// proxies and load balancers usually run.
+var DefaultTrustedProxies = []string{
+	"127.0.0.0/8",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+}
+
+// HTTPSConfig configures the RedirectHTTPS middleware.
+type HTTPSConfig struct {
+	TrustedProxies []netip.Prefix // Peers allowed to set X-Forwarded-Proto
+	Exempt         []string       // Paths served over plain HTTP
+	Permanent      bool           // 301/308 instead of 302/307 (default: true)
+}
+
+// HTTPSOption configures HTTPSConfig.
+type HTTPSOption func(*HTTPSConfig)
+
+// WithHTTPSPermanent selects permanent (301) or temporary (302) redirects.
+// Use temporary redirects while rolling out HTTPS, since browsers cache 301s.
+func WithHTTPSPermanent(permanent bool) HTTPSOption {
+	return func(cfg *HTTPSConfig) {
+		cfg.Permanent = permanent
+	}
+}
+
+// WithHTTPSExempt serves the given paths, and everything below them, over
+// plain HTTP. Use it for ACME challenges and load balancer health checks:
+//
+//	middlewares.WithHTTPSExempt("/.well-known/acme-challenge/", "/health")
+func WithHTTPSExempt(paths ...string) HTTPSOption {
+	return func(cfg *HTTPSConfig) {
+		cfg.Exempt = append(cfg.Exempt, paths...)
+	}
+}
+
+// WithHTTPSTrustedProxies replaces DefaultTrustedProxies with the given
+// CIDRs or single IPs. X-Forwarded-Proto is ignored from any other peer.
+// Panics if an entry cannot be parsed.
+func WithHTTPSTrustedProxies(proxies ...string) HTTPSOption {
+	return func(cfg *HTTPSConfig) {
+		cfg.TrustedProxies = mustParsePrefixes(proxies)
+	}
+}
+
+// RedirectHTTPS returns middleware that redirects plain-HTTP requests to the
+// same host, path, and query over HTTPS.
+//
+// A request counts as HTTPS if it arrived over TLS, or if X-Forwarded-Proto
+// says so and the direct peer (RemoteAddr) is a trusted proxy. Clients
+// cannot skip the redirect by sending the header themselves.
+//
+// GET and HEAD requests get 301 (or 302 with WithHTTPSPermanent(false)).
+// Other methods get 308 or 307 so the method and body are preserved.
+func RedirectHTTPS(opts ...HTTPSOption) internal.Middleware {
+	cfg := &HTTPSConfig{
+		TrustedProxies: mustParsePrefixes(DefaultTrustedProxies),
+		Permanent:      true,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			r := c.Request()
+			if isHTTPS(r, cfg.TrustedProxies) || isExemptPath(r.URL.Path, cfg.Exempt) {
+				return next(c)
+			}
+
+			http.Redirect(c.Response(), r, "https://"+r.Host+r.URL.RequestURI(), redirectStatus(r.Method, cfg.Permanent))
+			return nil
+		}
+	}
+}
+
+func isHTTPS(r *http.Request, trusted []netip.Prefix) bool {
+	if r.TLS != nil {
+		return true
+	}
+
+	proto := r.Header.Get("X-Forwarded-Proto")
+	if proto == "" || !isTrustedPeer(r.RemoteAddr, trusted) {
+		return false
+	}
+	// Proxy chains may append values; the first one is the client-facing scheme
+	proto, _, _ = strings.Cut(proto, ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
+
+func isTrustedPeer(remoteAddr string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(remoteAddr)
+	if err != nil {
+		ap, err := netip.ParseAddrPort(remoteAddr)
+		if err != nil {
+			return false
+		}
+		addr = ap.Addr()
+	}
+	addr = addr.Unmap()
+
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isExemptPath(path string, exempt []string) bool {
+	for _, p := range exempt {
+		base := strings.TrimSuffix(p, "/")
+		if path == p || path == base || strings.HasPrefix(path, base+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func redirectStatus(method string, permanent bool) int {
+	safe := method == http.MethodGet || method == http.MethodHead
+	switch {
+	case permanent && safe:
+		return http.StatusMovedPermanently
+	case permanent:
+		return http.StatusPermanentRedirect
+	case safe:
+		return http.StatusFound
+	default:
+		return http.StatusTemporaryRedirect
+	}
+}
+
+func mustParsePrefixes(entries []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, e := range entries {
+		if strings.Contains(e, "/") {
+			p, err := netip.ParsePrefix(e)
+			if err != nil {
+				panic(fmt.Sprintf("middlewares: invalid trusted proxy %q: %v", e, err))
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(e)
+		if err != nil {
+			panic(fmt.Sprintf("middlewares: invalid trusted proxy %q: %v", e, err))
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes
+}
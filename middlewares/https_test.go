@@ -0,0 +1,138 @@
+package middlewares_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+)
+
+func TestRedirectHTTPS(t *testing.T) {
+	t.Parallel()
+
+	newApp := func(opts ...middlewares.HTTPSOption) *internal.App {
+		return internal.New(
+			internal.WithMiddleware(middlewares.RedirectHTTPS(opts...)),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				ok := func(c internal.Context) error { return c.NoContent(http.StatusNoContent) }
+				r.GET("/*", ok)
+				r.POST("/*", ok)
+			})),
+		)
+	}
+
+	serve := func(app *internal.App, req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("redirects plain http preserving host, path and query", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "http://app.example.com/orders/42?tab=items", nil)
+		w := serve(newApp(), req)
+
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, "https://app.example.com/orders/42?tab=items", w.Header().Get("Location"))
+	})
+
+	t.Run("passes TLS requests through", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "https://app.example.com/", nil)
+		req.TLS = &tls.ConnectionState{}
+		require.Equal(t, http.StatusNoContent, serve(newApp(), req).Code)
+	})
+
+	t.Run("trusts forwarded proto from a trusted proxy", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.5:41000"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		require.Equal(t, http.StatusNoContent, serve(newApp(), req).Code)
+	})
+
+	t.Run("uses the first forwarded proto value", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.5:41000"
+		req.Header.Set("X-Forwarded-Proto", "http, https")
+		require.Equal(t, http.StatusMovedPermanently, serve(newApp(), req).Code)
+	})
+
+	t.Run("ignores forwarded proto from untrusted peers", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "203.0.113.7:41000"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		require.Equal(t, http.StatusMovedPermanently, serve(newApp(), req).Code)
+	})
+
+	t.Run("custom trusted proxies replace the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		app := newApp(middlewares.WithHTTPSTrustedProxies("203.0.113.0/24"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "203.0.113.7:41000"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		require.Equal(t, http.StatusNoContent, serve(app, req).Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.5:41000"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		require.Equal(t, http.StatusMovedPermanently, serve(app, req).Code)
+	})
+
+	t.Run("temporary redirects", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		require.Equal(t, http.StatusFound, serve(newApp(middlewares.WithHTTPSPermanent(false)), req).Code)
+	})
+
+	t.Run("preserves method for non-GET requests", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("{}"))
+		require.Equal(t, http.StatusPermanentRedirect, serve(newApp(), req).Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("{}"))
+		require.Equal(t, http.StatusTemporaryRedirect, serve(newApp(middlewares.WithHTTPSPermanent(false)), req).Code)
+	})
+
+	t.Run("exempt paths are served over http", func(t *testing.T) {
+		t.Parallel()
+
+		app := newApp(middlewares.WithHTTPSExempt("/.well-known/acme-challenge/", "/health"))
+
+		for path, want := range map[string]int{
+			"/.well-known/acme-challenge/token": http.StatusNoContent,
+			"/health":                           http.StatusNoContent,
+			"/health/ready":                     http.StatusNoContent,
+			"/healthz":                          http.StatusMovedPermanently,
+			"/.well-known/security.txt":         http.StatusMovedPermanently,
+		} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			require.Equal(t, want, serve(app, req).Code, path)
+		}
+	})
+
+	t.Run("panics on invalid trusted proxy", func(t *testing.T) {
+		t.Parallel()
+
+		require.Panics(t, func() {
+			middlewares.RedirectHTTPS(middlewares.WithHTTPSTrustedProxies("not-an-ip"))
+		})
+	})
+}
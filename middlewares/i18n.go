@@ -10,6 +10,7 @@ type I18nConfig struct {
 	FormatMap     map[string]*i18n.LocaleFormat
 	DefaultFormat *i18n.LocaleFormat
 	Namespace     string
+	CookieName    string
 	Extractor     internal.Extractor
 	extractorSet  bool
 }
@@ -24,6 +25,14 @@ func WithI18nNamespace(ns string) I18nOption {
 	}
 }
 
+// WithI18nCookieName sets the language cookie read by the default extractor
+// (default: DefaultLanguageCookie). Ignored with WithI18nExtractor.
+func WithI18nCookieName(name string) I18nOption {
+	return func(cfg *I18nConfig) {
+		cfg.CookieName = name
+	}
+}
+
 // WithI18nExtractor sets a custom language extractor chain.
 func WithI18nExtractor(ext internal.Extractor) I18nOption {
 	return func(cfg *I18nConfig) {
@@ -59,18 +68,31 @@ func FromAcceptLanguage(available []string) internal.ExtractorSource {
 	}
 }
 
+// FromLanguageCookie returns an ExtractorSource that reads the language from
+// the named cookie, as set by LanguageSwitcher. Values that are not one of
+// the available languages are ignored.
+func FromLanguageCookie(name string, available []string) internal.ExtractorSource {
+	return func(c internal.Context) (string, bool) {
+		value, err := c.Cookie(name)
+		if err != nil {
+			return "", false
+		}
+		return matchLanguage(value, available)
+	}
+}
+
 // I18n returns middleware that resolves the user's language, creates a Translator,
 // and stores both in the request context.
 func I18n(svc *i18n.I18n, opts ...I18nOption) internal.Middleware {
-	cfg := &I18nConfig{}
+	cfg := &I18nConfig{CookieName: DefaultLanguageCookie}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	// Default extractor: cookie → accept-language
+	// Default extractor: language cookie → accept-language
 	if !cfg.extractorSet {
 		cfg.Extractor = internal.NewExtractor(
-			internal.FromCookie("lang"),
+			FromLanguageCookie(cfg.CookieName, svc.Languages()),
 			FromAcceptLanguage(svc.Languages()),
 		)
 	}
@@ -107,6 +107,44 @@ func TestI18nMiddleware(t *testing.T) {
 		require.Equal(t, "pl", gotLang)
 	})
 
+	t.Run("default extractor ignores unsupported cookie language", func(t *testing.T) {
+		t.Parallel()
+		svc := newI18nService(t)
+		mw := middlewares.I18n(svc)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "lang", Value: "xx"})
+		r.Header.Set("Accept-Language", "de")
+		c := newTestContext(httptest.NewRecorder(), r)
+
+		var gotLang string
+		err := mw(func(c internal.Context) error {
+			gotLang = middlewares.GetLanguage(c)
+			return nil
+		})(c)
+		require.NoError(t, err)
+		require.Equal(t, "de", gotLang)
+	})
+
+	t.Run("custom cookie name", func(t *testing.T) {
+		t.Parallel()
+		svc := newI18nService(t)
+		mw := middlewares.I18n(svc, middlewares.WithI18nCookieName("locale"))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "lang", Value: "de"})
+		r.AddCookie(&http.Cookie{Name: "locale", Value: "PL"})
+		c := newTestContext(httptest.NewRecorder(), r)
+
+		var gotLang string
+		err := mw(func(c internal.Context) error {
+			gotLang = middlewares.GetLanguage(c)
+			return nil
+		})(c)
+		require.NoError(t, err)
+		require.Equal(t, "pl", gotLang)
+	})
+
 	t.Run("default extractor falls back to accept-language when no cookie", func(t *testing.T) {
 		t.Parallel()
 		svc := newI18nService(t)
@@ -1,7 +1,10 @@
 package middlewares
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/dmitrymomot/forge/internal"
 	"github.com/dmitrymomot/forge/pkg/jwt"
@@ -9,8 +12,9 @@ import (
 
 // JWTConfig configures the JWT middleware.
 type JWTConfig struct {
-	Extractor    internal.Extractor
-	extractorSet bool
+	Extractor      internal.Extractor
+	extractorSet   bool
+	cookieFallback bool
 }
 
 // JWTOption configures JWTConfig.
@@ -24,20 +28,38 @@ func WithJWTExtractor(ext internal.Extractor) JWTOption {
 	}
 }
 
+// WithJWTCookieFallback makes the middleware read the cookie c.SetJWTCookie
+// writes when the extractor chain finds no token, so tokens issued at a
+// browser login are accepted. It is the same as adding forge.FromJWTCookie
+// as the last source of the chain.
+//
+// Only enable it together with CSRF protection: browsers attach the cookie
+// to cross-site requests, unlike the Authorization header.
+func WithJWTCookieFallback() JWTOption {
+	return func(cfg *JWTConfig) {
+		cfg.cookieFallback = true
+	}
+}
+
 // JWT returns middleware that extracts a JWT from the request, validates it,
 // and stores the parsed claims in the context.
 // T is the claims type to parse into (e.g., jwt.StandardClaims or a custom struct).
+//
+// By default the token is read from the Authorization Bearer header only.
+// Use WithJWTCookieFallback to also accept the cookie written by
+// c.SetJWTCookie.
 func JWT[T any](svc *jwt.Service, opts ...JWTOption) internal.Middleware {
 	cfg := &JWTConfig{}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	// Default extractor: Bearer token from Authorization header
+	// Default extractor: Bearer token
 	if !cfg.extractorSet {
-		cfg.Extractor = internal.NewExtractor(
-			internal.FromBearerToken(),
-		)
+		cfg.Extractor = internal.NewExtractor(internal.FromBearerToken())
+	}
+	if cfg.cookieFallback {
+		cfg.Extractor = internal.NewExtractor(cfg.Extractor.Extract, internal.FromJWTCookie())
 	}
 
 	return func(next internal.HandlerFunc) internal.HandlerFunc {
@@ -75,3 +97,71 @@ func GetJWTClaims[T any](c internal.Context) *T {
 	}
 	return v
 }
+
+// IssueJWT signs claims with svc, setting the iat claim to now and, for a
+// positive ttl, the exp claim to now+ttl. claims must encode to a JSON
+// object; exp and iat values already in it are replaced. Sign with the same
+// service the JWT middleware validates with. To keep the token in a cookie,
+// store it with c.SetJWTCookie and enable WithJWTCookieFallback on the
+// middleware:
+//
+//	token, err := middlewares.IssueJWT(jwtSvc, jwt.StandardClaims{Subject: user.ID}, time.Hour)
+//	if err != nil {
+//	    return err
+//	}
+//	c.SetJWTCookie(token, int(time.Hour.Seconds()))
+func IssueJWT[T any](svc *jwt.Service, claims T, ttl time.Duration) (string, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("issue jwt: marshal claims: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return "", errors.New("issue jwt: claims must encode to a JSON object")
+	}
+
+	now := time.Now()
+	fields["iat"] = json.RawMessage(fmt.Sprint(now.Unix()))
+	if ttl > 0 {
+		fields["exp"] = json.RawMessage(fmt.Sprint(now.Add(ttl).Unix()))
+	}
+
+	return svc.Generate(fields)
+}
+
+// JWTPair is an access token with the refresh token used to renew it.
+// Its JSON form follows the OAuth 2.0 token response (RFC 6749 Section 5.1).
+type JWTPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+
+	// ExpiresIn is the access token lifetime in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// IssueJWTPair issues a short-lived access token and a long-lived refresh
+// token with IssueJWT. Sign refresh tokens with a separate service (its own
+// key), so a refresh token is never accepted by the JWT middleware as an
+// access token; the refresh endpoint parses it with refreshSvc.Parse before
+// issuing a new pair.
+func IssueJWTPair[A, R any](
+	accessSvc *jwt.Service, access A, accessTTL time.Duration,
+	refreshSvc *jwt.Service, refresh R, refreshTTL time.Duration,
+) (*JWTPair, error) {
+	accessToken, err := IssueJWT(accessSvc, access, accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := IssueJWT(refreshSvc, refresh, refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &JWTPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(accessTTL / time.Second),
+	}, nil
+}
@@ -285,3 +285,121 @@ func TestGetJWTClaims(t *testing.T) {
 		require.Nil(t, got)
 	})
 }
+
+func TestIssueJWT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sets iat and exp", func(t *testing.T) {
+		t.Parallel()
+		svc := newJWTService(t)
+
+		before := time.Now().Unix()
+		token, err := middlewares.IssueJWT(svc, customClaims{UserID: 7, Role: "admin"}, time.Hour)
+		require.NoError(t, err)
+
+		var got customClaims
+		require.NoError(t, svc.Parse(token, &got))
+		require.Equal(t, 7, got.UserID)
+		require.Equal(t, "admin", got.Role)
+		require.GreaterOrEqual(t, got.IssuedAt, before)
+		require.Equal(t, got.IssuedAt+3600, got.ExpiresAt)
+	})
+
+	t.Run("zero ttl leaves exp unset", func(t *testing.T) {
+		t.Parallel()
+		svc := newJWTService(t)
+
+		token, err := middlewares.IssueJWT(svc, jwt.StandardClaims{Subject: "user-1"}, 0)
+		require.NoError(t, err)
+
+		var got jwt.StandardClaims
+		require.NoError(t, svc.Parse(token, &got))
+		require.Equal(t, "user-1", got.Subject)
+		require.Zero(t, got.ExpiresAt)
+		require.NotZero(t, got.IssuedAt)
+	})
+
+	t.Run("rejects non-object claims", func(t *testing.T) {
+		t.Parallel()
+		svc := newJWTService(t)
+
+		_, err := middlewares.IssueJWT(svc, "user-1", time.Hour)
+		require.Error(t, err)
+	})
+
+	t.Run("cookie token is accepted only with the cookie fallback", func(t *testing.T) {
+		t.Parallel()
+		svc := newJWTService(t)
+
+		token, err := middlewares.IssueJWT(svc, jwt.StandardClaims{Subject: "user-cookie"}, time.Hour)
+		require.NoError(t, err)
+
+		login := httptest.NewRecorder()
+		newTestContext(login, httptest.NewRequest(http.MethodPost, "/login", nil)).SetJWTCookie(token, 3600)
+		newRequest := func() internal.Context {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, ck := range login.Result().Cookies() {
+				r.AddCookie(ck)
+			}
+			return newTestContext(httptest.NewRecorder(), r)
+		}
+
+		var gotClaims *jwt.StandardClaims
+		next := func(c internal.Context) error {
+			gotClaims = middlewares.GetJWTClaims[jwt.StandardClaims](c)
+			return nil
+		}
+
+		err = middlewares.JWT[jwt.StandardClaims](svc)(next)(newRequest())
+		var httpErr *internal.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+		require.Nil(t, gotClaims)
+
+		handler := middlewares.JWT[jwt.StandardClaims](svc, middlewares.WithJWTCookieFallback())(next)
+		require.NoError(t, handler(newRequest()))
+		require.NotNil(t, gotClaims)
+		require.Equal(t, "user-cookie", gotClaims.Subject)
+	})
+}
+
+func TestIssueJWTPair(t *testing.T) {
+	t.Parallel()
+
+	accessSvc := newJWTService(t)
+	refreshSvc, err := jwt.NewFromString("refresh-secret-key-at-least-32-bytes")
+	require.NoError(t, err)
+
+	pair, err := middlewares.IssueJWTPair(
+		accessSvc, jwt.StandardClaims{Subject: "user-1"}, 15*time.Minute,
+		refreshSvc, jwt.StandardClaims{Subject: "user-1", ID: "refresh-1"}, 30*24*time.Hour,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer", pair.TokenType)
+	require.Equal(t, int64(900), pair.ExpiresIn)
+
+	var refresh jwt.StandardClaims
+	require.NoError(t, refreshSvc.Parse(pair.RefreshToken, &refresh))
+	require.Equal(t, "refresh-1", refresh.ID)
+
+	t.Run("access token passes middleware", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+		handler := middlewares.JWT[jwt.StandardClaims](accessSvc)(func(c internal.Context) error { return nil })
+		require.NoError(t, handler(newTestContext(httptest.NewRecorder(), r)))
+	})
+
+	t.Run("refresh token is rejected by middleware", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+pair.RefreshToken)
+		handler := middlewares.JWT[jwt.StandardClaims](accessSvc)(func(c internal.Context) error { return nil })
+
+		var httpErr *internal.HTTPError
+		require.ErrorAs(t, handler(newTestContext(httptest.NewRecorder(), r)), &httpErr)
+		require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+	})
+}
@@ -0,0 +1,112 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+const (
+	// DefaultLanguageCookie is the cookie LanguageSwitcher sets and the
+	// default I18n extractor reads.
+	DefaultLanguageCookie = "lang"
+
+	// DefaultLanguageCookieMaxAge keeps the chosen language for a year.
+	DefaultLanguageCookieMaxAge = 365 * 24 * 60 * 60
+)
+
+// LanguageSwitcherConfig configures the LanguageSwitcher handler.
+type LanguageSwitcherConfig struct {
+	CookieName    string
+	DefaultReturn string
+	MaxAge        int
+}
+
+// LanguageSwitcherOption configures LanguageSwitcherConfig.
+type LanguageSwitcherOption func(*LanguageSwitcherConfig)
+
+// WithLanguageCookieName sets the cookie the language is stored in
+// (default: DefaultLanguageCookie). Pass the same name to WithI18nCookieName.
+func WithLanguageCookieName(name string) LanguageSwitcherOption {
+	return func(cfg *LanguageSwitcherConfig) {
+		cfg.CookieName = name
+	}
+}
+
+// WithLanguageCookieMaxAge sets the cookie lifetime in seconds
+// (default: DefaultLanguageCookieMaxAge).
+func WithLanguageCookieMaxAge(seconds int) LanguageSwitcherOption {
+	return func(cfg *LanguageSwitcherConfig) {
+		cfg.MaxAge = seconds
+	}
+}
+
+// WithLanguageDefaultReturn sets where to redirect when the request has no
+// usable return URL (default: "/").
+func WithLanguageDefaultReturn(path string) LanguageSwitcherOption {
+	return func(cfg *LanguageSwitcherConfig) {
+		cfg.DefaultReturn = path
+	}
+}
+
+// LanguageSwitcher returns a handler that stores the language chosen by the
+// user and redirects back. It reads the lang and return query parameters:
+//
+//	r.GET("/set-lang", middlewares.LanguageSwitcher(svc.Languages()))
+//
+//	// <a href="/set-lang?lang=de&return=/dashboard">Deutsch</a>
+//
+// The language must be one of available (case-insensitive); otherwise the
+// handler returns a 400 *HTTPError. The return URL must be a path on the same
+// host; absolute and protocol-relative URLs are replaced with the default
+// return path to prevent open redirects. The I18n middleware's default
+// extractor reads the cookie before Accept-Language.
+func LanguageSwitcher(available []string, opts ...LanguageSwitcherOption) internal.HandlerFunc {
+	cfg := &LanguageSwitcherConfig{
+		CookieName:    DefaultLanguageCookie,
+		DefaultReturn: "/",
+		MaxAge:        DefaultLanguageCookieMaxAge,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c internal.Context) error {
+		lang, ok := matchLanguage(c.Query("lang"), available)
+		if !ok {
+			return internal.ErrBadRequest("Unsupported language")
+		}
+
+		c.SetCookie(cfg.CookieName, lang, cfg.MaxAge)
+		return c.Redirect(http.StatusSeeOther, localReturnURL(c.Query("return"), cfg.DefaultReturn))
+	}
+}
+
+// matchLanguage returns the entry of available equal to lang, ignoring case.
+func matchLanguage(lang string, available []string) (string, bool) {
+	if lang == "" {
+		return "", false
+	}
+	for _, a := range available {
+		if strings.EqualFold(a, lang) {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// localReturnURL returns raw if it is a path on the current host, or
+// fallback otherwise. Browsers treat backslashes as slashes, so "/\evil.com"
+// is as unsafe as "//evil.com".
+func localReturnURL(raw, fallback string) string {
+	if !strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "//") || strings.ContainsAny(raw, "\\\r\n\t") {
+		return fallback
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return fallback
+	}
+	return raw
+}
@@ -0,0 +1,101 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+)
+
+func TestLanguageSwitcher(t *testing.T) {
+	t.Parallel()
+
+	available := []string{"en", "de", "pt-BR"}
+
+	switchLang := func(t *testing.T, h internal.HandlerFunc, lang, ret string) (*httptest.ResponseRecorder, error) {
+		t.Helper()
+		q := url.Values{}
+		q.Set("lang", lang)
+		if ret != "" {
+			q.Set("return", ret)
+		}
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/set-lang?"+q.Encode(), nil)
+		return w, h(newTestContext(w, r))
+	}
+
+	t.Run("sets cookie and redirects back", func(t *testing.T) {
+		t.Parallel()
+
+		w, err := switchLang(t, middlewares.LanguageSwitcher(available), "de", "/dashboard?tab=2")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusSeeOther, w.Code)
+		require.Equal(t, "/dashboard?tab=2", w.Header().Get("Location"))
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, middlewares.DefaultLanguageCookie, cookies[0].Name)
+		require.Equal(t, "de", cookies[0].Value)
+		require.Equal(t, middlewares.DefaultLanguageCookieMaxAge, cookies[0].MaxAge)
+	})
+
+	t.Run("matches language case-insensitively", func(t *testing.T) {
+		t.Parallel()
+
+		w, err := switchLang(t, middlewares.LanguageSwitcher(available), "pt-br", "/")
+		require.NoError(t, err)
+		require.Equal(t, "pt-BR", w.Result().Cookies()[0].Value)
+	})
+
+	t.Run("rejects unsupported language", func(t *testing.T) {
+		t.Parallel()
+
+		for _, lang := range []string{"", "fr"} {
+			w, err := switchLang(t, middlewares.LanguageSwitcher(available), lang, "/")
+			var httpErr *internal.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			require.Equal(t, http.StatusBadRequest, httpErr.Code)
+			require.Empty(t, w.Result().Cookies())
+		}
+	})
+
+	t.Run("rejects open redirects", func(t *testing.T) {
+		t.Parallel()
+
+		for _, ret := range []string{
+			"https://evil.com/",
+			"//evil.com",
+			"/\\evil.com",
+			"javascript:alert(1)",
+			"dashboard",
+			"/ok\r\nSet-Cookie: x=y",
+			"",
+		} {
+			w, err := switchLang(t, middlewares.LanguageSwitcher(available), "en", ret)
+			require.NoError(t, err)
+			require.Equal(t, "/", w.Header().Get("Location"), "return %q", ret)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+
+		h := middlewares.LanguageSwitcher(available,
+			middlewares.WithLanguageCookieName("locale"),
+			middlewares.WithLanguageCookieMaxAge(3600),
+			middlewares.WithLanguageDefaultReturn("/home"),
+		)
+		w, err := switchLang(t, h, "en", "//evil.com")
+		require.NoError(t, err)
+		require.Equal(t, "/home", w.Header().Get("Location"))
+
+		cookie := w.Result().Cookies()[0]
+		require.Equal(t, "locale", cookie.Name)
+		require.Equal(t, 3600, cookie.MaxAge)
+	})
+}
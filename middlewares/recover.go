@@ -13,6 +13,9 @@ const DefaultStackSize = 4096
 // RecoverConfig configures the recover middleware.
 type RecoverConfig struct {
 	StackSize         int  // Max stack trace size (default: 4096)
+	StackDepth        int  // Max frames in the formatted stack (default: 0, all)
+	SourceLines       int  // Source lines around the panicking line (default: 0, none)
+	TrimPaths         bool // Show files relative to their import path
 	DisablePrintStack bool // Disable stack trace in logs
 }
 
@@ -26,6 +29,36 @@ func WithRecoverStackSize(size int) RecoverOption {
 	}
 }
 
+// WithRecoverStackDepth limits the formatted stack to the n frames closest
+// to the panic. The raw stack is still bounded only by the stack size.
+func WithRecoverStackDepth(n int) RecoverOption {
+	return func(cfg *RecoverConfig) {
+		cfg.StackDepth = n
+	}
+}
+
+// WithRecoverTrimPaths shows files in the formatted stack by import path,
+// e.g. github.com/acme/app/handlers/user.go, instead of absolute paths that
+// expose GOPATH, the module cache, or the build machine's checkout.
+func WithRecoverTrimPaths() RecoverOption {
+	return func(cfg *RecoverConfig) {
+		cfg.TrimPaths = true
+	}
+}
+
+// WithRecoverSource includes n lines of source on each side of the
+// panicking line in the formatted stack. Sources are read from disk when
+// the panic happens, so enable it behind a debug flag only:
+//
+//	if cfg.Debug {
+//	    opts = append(opts, middlewares.WithRecoverSource(3))
+//	}
+func WithRecoverSource(n int) RecoverOption {
+	return func(cfg *RecoverConfig) {
+		cfg.SourceLines = n
+	}
+}
+
 // WithRecoverDisablePrintStack disables including stack trace in logs.
 func WithRecoverDisablePrintStack() RecoverOption {
 	return func(cfg *RecoverConfig) {
@@ -35,6 +68,8 @@ func WithRecoverDisablePrintStack() RecoverOption {
 
 // Recover returns middleware that recovers from panics.
 // It logs the panic and returns a PanicError to be handled by the global ErrorHandler.
+// The log carries the formatted stack, which starts at the panicking frame;
+// the PanicError holds both it and the raw runtime stack.
 // Request ID is automatically included via RequestIDExtractor() if configured.
 func Recover(opts ...RecoverOption) internal.Middleware {
 	cfg := &RecoverConfig{
@@ -50,21 +85,28 @@ func Recover(opts ...RecoverOption) internal.Middleware {
 			defer func() {
 				if r := recover(); r != nil {
 					var stack []byte
+					var formatted string
 					if !cfg.DisablePrintStack {
 						stack = make([]byte, cfg.StackSize)
 						n := runtime.Stack(stack, false)
 						stack = stack[:n]
+						formatted = internal.FormatPanicStack(internal.StackFormat{
+							Depth:       cfg.StackDepth,
+							TrimPaths:   cfg.TrimPaths,
+							SourceLines: cfg.SourceLines,
+						})
 					}
 
 					if cfg.DisablePrintStack {
 						c.LogError("panic recovered", "panic", r)
 					} else {
-						c.LogError("panic recovered", "panic", r, "stack", string(stack))
+						c.LogError("panic recovered", "panic", r, "stack", formatted)
 					}
 
 					err = &PanicError{
-						Value: r,
-						Stack: stack,
+						Value:          r,
+						Stack:          stack,
+						FormattedStack: formatted,
 					}
 				}
 			}()
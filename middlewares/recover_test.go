@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -433,3 +434,96 @@ func TestRecover_NestedPanic(t *testing.T) {
 		require.Contains(t, string(pe.Stack), "middlewares_test")
 	})
 }
+
+func TestRecover_FormattedStack(t *testing.T) {
+	t.Parallel()
+
+	// recoverPanic runs fn through Recover and returns the PanicError.
+	recoverPanic := func(t *testing.T, fn func(), opts ...middlewares.RecoverOption) *middlewares.PanicError {
+		t.Helper()
+
+		ctx := newTestContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		handler := middlewares.Recover(opts...)(func(c internal.Context) error {
+			fn()
+			return nil
+		})
+
+		pe, ok := middlewares.AsPanicError(handler(ctx))
+		require.True(t, ok)
+		return pe
+	}
+
+	// frameCount counts function lines; file and source lines are indented.
+	frameCount := func(stack string) int {
+		n := 0
+		for line := range strings.Lines(stack) {
+			if !strings.HasPrefix(line, "\t") {
+				n++
+			}
+		}
+		return n
+	}
+
+	t.Run("starts at the panicking frame", func(t *testing.T) {
+		t.Parallel()
+
+		pe := recoverPanic(t, func() { panic("boom") })
+		first, _, _ := strings.Cut(pe.FormattedStack, "\n")
+		require.Contains(t, first, "TestRecover_FormattedStack")
+		require.NotContains(t, pe.FormattedStack, "runtime.gopanic")
+		require.NotEmpty(t, pe.Stack)
+	})
+
+	t.Run("skips runtime frames for runtime errors", func(t *testing.T) {
+		t.Parallel()
+
+		pe := recoverPanic(t, func() {
+			var m map[string]int
+			m["x"] = 1
+		})
+		first, _, _ := strings.Cut(pe.FormattedStack, "\n")
+		require.Contains(t, first, "TestRecover_FormattedStack")
+	})
+
+	t.Run("limits depth", func(t *testing.T) {
+		t.Parallel()
+
+		full := recoverPanic(t, func() { panic("boom") })
+		require.Greater(t, frameCount(full.FormattedStack), 2)
+
+		pe := recoverPanic(t, func() { panic("boom") }, middlewares.WithRecoverStackDepth(2))
+		require.Equal(t, 2, frameCount(pe.FormattedStack))
+	})
+
+	t.Run("trims paths to import paths", func(t *testing.T) {
+		t.Parallel()
+
+		_, file, _, ok := runtime.Caller(0)
+		require.True(t, ok)
+
+		full := recoverPanic(t, func() { panic("boom") })
+		require.Contains(t, full.FormattedStack, file)
+
+		pe := recoverPanic(t, func() { panic("boom") }, middlewares.WithRecoverTrimPaths())
+		require.NotContains(t, pe.FormattedStack, file)
+		require.Contains(t, pe.FormattedStack, "\tgithub.com/dmitrymomot/forge/middlewares_test/recover_test.go:")
+	})
+
+	t.Run("includes source context", func(t *testing.T) {
+		t.Parallel()
+
+		pe := recoverPanic(t, func() { panic("source context panic") }, middlewares.WithRecoverSource(1))
+		require.Contains(t, pe.FormattedStack, `pe := recoverPanic(t, func() { panic("source context panic") }`)
+		require.Contains(t, pe.FormattedStack, "\t> ")
+
+		plain := recoverPanic(t, func() { panic("boom") })
+		require.NotContains(t, plain.FormattedStack, " | ")
+	})
+
+	t.Run("disabled with print stack", func(t *testing.T) {
+		t.Parallel()
+
+		pe := recoverPanic(t, func() { panic("boom") }, middlewares.WithRecoverDisablePrintStack())
+		require.Empty(t, pe.FormattedStack)
+	})
+}
@@ -9,9 +9,6 @@ import (
 	"github.com/dmitrymomot/forge/pkg/logger"
 )
 
-// requestIDKey is the context key for storing the request ID.
-type requestIDKey struct{}
-
 // DefaultRequestIDHeaders are the headers checked (in order) for an existing request ID.
 var DefaultRequestIDHeaders = []string{"X-Request-ID", "X-Correlation-ID"}
 
@@ -75,7 +72,7 @@ func RequestID(opts ...RequestIDOption) internal.Middleware {
 				reqID = cfg.Generator()
 			}
 
-			c.Set(requestIDKey{}, reqID)
+			c.Set(internal.RequestIDKey{}, reqID)
 			c.SetHeader(cfg.ResponseHeader, reqID)
 
 			return next(c)
@@ -85,18 +82,16 @@ func RequestID(opts ...RequestIDOption) internal.Middleware {
 
 // GetRequestID extracts the request ID from the context.
 // Returns an empty string if no request ID is set.
+// It is equivalent to c.RequestID().
 func GetRequestID(c internal.Context) string {
-	if v, ok := c.Get(requestIDKey{}).(string); ok {
-		return v
-	}
-	return ""
+	return c.RequestID()
 }
 
 // RequestIDExtractor returns a ContextExtractor for use with WithLogger.
 // Automatically adds "request_id" to all log entries.
 func RequestIDExtractor() logger.ContextExtractor {
 	return func(ctx context.Context) (slog.Attr, bool) {
-		if v, ok := ctx.Value(requestIDKey{}).(string); ok && v != "" {
+		if v, ok := ctx.Value(internal.RequestIDKey{}).(string); ok && v != "" {
 			return slog.String("request_id", v), true
 		}
 		return slog.Attr{}, false
@@ -1,6 +1,7 @@
 package middlewares_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -95,6 +96,27 @@ func TestRequestIDExtractor(t *testing.T) {
 		require.Equal(t, "request_id", attr.Key)
 		require.NotEmpty(t, attr.Value.String())
 	})
+
+	t.Run("finds request ID in background context", func(t *testing.T) {
+		t.Parallel()
+
+		reqCtx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(reqCtx)
+		rec := httptest.NewRecorder()
+		ctx := newTestContext(rec, req)
+
+		handler := middlewares.RequestID()(func(c internal.Context) error {
+			return nil
+		})
+		require.NoError(t, handler(ctx))
+
+		bg := ctx.BackgroundContext()
+		cancel()
+
+		attr, ok := middlewares.RequestIDExtractor()(bg)
+		require.True(t, ok)
+		require.Equal(t, middlewares.GetRequestID(ctx), attr.Value.String())
+	})
 }
 
 func TestRequestID_CustomOptions(t *testing.T) {
@@ -324,3 +346,28 @@ func TestRequestIDExtractor_EdgeCases(t *testing.T) {
 		require.False(t, ok)
 	})
 }
+
+func TestRequestID_ErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	app := internal.New(
+		internal.WithMiddleware(middlewares.RequestID()),
+		internal.WithErrorHandler(func(c internal.Context, err error) error {
+			return c.JSONError(err)
+		}),
+		internal.WithHandlers(routes(func(r internal.Router) {
+			r.GET("/missing", func(c internal.Context) error {
+				return internal.ErrNotFound("not found")
+			})
+		})),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("X-Request-ID", "req-abc")
+	rec := httptest.NewRecorder()
+	app.Router().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "req-abc", rec.Header().Get("X-Request-ID"))
+	require.Contains(t, rec.Body.String(), `"request_id":"req-abc"`)
+}
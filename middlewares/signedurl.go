@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
+)
+
+// VerifySignedURL returns middleware that rejects requests whose URL was not
+// produced by signer or has expired, with 403 Forbidden. Apply it to routes
+// reached through c.SignedURL links, such as magic-link sign-in or downloads.
+func VerifySignedURL(signer *signedurl.Signer) internal.Middleware {
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			r := c.Request()
+			if err := signer.Verify(r.URL.Path, r.URL.Query()); err != nil {
+				if errors.Is(err, signedurl.ErrExpired) {
+					return internal.ErrForbidden("link has expired", internal.WithError(err))
+				}
+				return internal.ErrForbidden("invalid link", internal.WithError(err))
+			}
+			return next(c)
+		}
+	}
+}
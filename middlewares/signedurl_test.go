@@ -0,0 +1,62 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+	"github.com/dmitrymomot/forge/pkg/signedurl"
+)
+
+func TestVerifySignedURL(t *testing.T) {
+	t.Parallel()
+
+	signer, err := signedurl.New("test-secret-key-at-least-32-bytes!")
+	require.NoError(t, err)
+
+	call := func(t *testing.T, target string) (bool, error) {
+		t.Helper()
+		called := false
+		handler := middlewares.VerifySignedURL(signer)(func(c internal.Context) error {
+			called = true
+			return nil
+		})
+		c := newTestContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+		return called, handler(c)
+	}
+
+	t.Run("valid signature passes", func(t *testing.T) {
+		t.Parallel()
+
+		target := signer.Sign("/download/42", time.Now().Add(time.Hour), url.Values{"user": {"7"}})
+		called, err := call(t, target)
+		require.NoError(t, err)
+		require.True(t, called)
+	})
+
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{"missing signature", "/download/42"},
+		{"tampered path", "/download/43?" + signer.Sign("/download/42", time.Now().Add(time.Hour), nil)[len("/download/42?"):]},
+		{"expired", signer.Sign("/download/42", time.Now().Add(-time.Minute), nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			called, err := call(t, tt.target)
+			require.False(t, called)
+			httpErr := internal.AsHTTPError(err)
+			require.NotNil(t, httpErr)
+			require.Equal(t, http.StatusForbidden, httpErr.Code)
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"log/slog"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 
 	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/pkg/cookie"
 	"github.com/dmitrymomot/forge/pkg/htmx"
 	"github.com/dmitrymomot/forge/pkg/i18n"
 	"github.com/dmitrymomot/forge/pkg/job"
@@ -37,7 +39,11 @@ func newTestContext(w http.ResponseWriter, r *http.Request) *testContext {
 func (c *testContext) Request() *http.Request        { return c.request }
 func (c *testContext) Response() http.ResponseWriter { return c.response }
 func (c *testContext) Context() context.Context      { return c.request.Context() }
-func (c *testContext) Param(name string) string      { return "" }
+func (c *testContext) BackgroundContext() context.Context {
+	return context.WithoutCancel(c.request.Context())
+}
+func (c *testContext) Param(name string) string           { return "" }
+func (c *testContext) ParamOK(name string) (string, bool) { return "", false }
 
 func (c *testContext) Query(name string) string {
 	return c.request.URL.Query().Get(name)
@@ -51,23 +57,44 @@ func (c *testContext) QueryDefault(name, defaultValue string) string {
 	return v
 }
 
-func (c *testContext) Domain() string               { return c.request.Host }
-func (c *testContext) Subdomain() string            { return "" }
-func (c *testContext) Header(name string) string    { return c.request.Header.Get(name) }
-func (c *testContext) SetHeader(name, value string) { c.response.Header().Set(name, value) }
-func (c *testContext) JSON(code int, v any) error   { c.response.WriteHeader(code); return nil }
+func (c *testContext) Domain() string                                                     { return c.request.Host }
+func (c *testContext) Subdomain() string                                                  { return "" }
+func (c *testContext) Header(name string) string                                          { return c.request.Header.Get(name) }
+func (c *testContext) SetHeader(name, value string)                                       { c.response.Header().Set(name, value) }
+func (c *testContext) JSON(code int, v any) error                                         { c.response.WriteHeader(code); return nil }
+func (c *testContext) JSONPretty(code int, v any) error                                   { c.response.WriteHeader(code); return nil }
+func (c *testContext) JSONError(err error) error                                          { return nil }
+func (c *testContext) ValidationResponse(code int, errs validator.ValidationErrors) error { return nil }
 func (c *testContext) String(code int, s string) error {
 	c.response.WriteHeader(code)
 	_, err := c.response.Write([]byte(s))
 	return err
 }
 func (c *testContext) NoContent(code int) error { c.response.WriteHeader(code); return nil }
+func (c *testContext) Stream(code int, contentType string, fn func(w io.Writer) error) error {
+	c.response.Header().Set("Content-Type", contentType)
+	c.response.WriteHeader(code)
+	return fn(c.response)
+}
+func (c *testContext) StreamJSON(code int, ch <-chan any) error {
+	c.response.WriteHeader(code)
+	return nil
+}
+func (c *testContext) Flush() error                  { return nil }
+func (c *testContext) SetTrailer(name, value string) {}
+func (c *testContext) Trailer(name string) string    { return "" }
 func (c *testContext) Redirect(code int, url string) error {
 	http.Redirect(c.response, c.request, url, code)
 	return nil
 }
+func (c *testContext) Upgrade(opts ...internal.WSOption) (*internal.WSConn, error) {
+	return nil, nil
+}
 func (c *testContext) IsHTMX() bool                      { return htmx.IsHTMX(c.request) }
+func (c *testContext) Defer(fn func())                   {}
 func (c *testContext) Written() bool                     { return false }
+func (c *testContext) Status() int                       { return 0 }
+func (c *testContext) BytesWritten() int                 { return 0 }
 func (c *testContext) Logger() *slog.Logger              { return slog.Default() }
 func (c *testContext) LogDebug(msg string, attrs ...any) {}
 func (c *testContext) LogInfo(msg string, attrs ...any)  {}
@@ -94,9 +121,27 @@ func (c *testContext) RenderPartial(code int, fullPage, partial internal.Compone
 	return c.Render(code, fullPage)
 }
 
-func (c *testContext) Bind(v any) (validator.ValidationErrors, error)      { return nil, nil }
-func (c *testContext) BindQuery(v any) (validator.ValidationErrors, error) { return nil, nil }
-func (c *testContext) BindJSON(v any) (validator.ValidationErrors, error)  { return nil, nil }
+func (c *testContext) RenderOOB(main internal.Component, oob ...internal.Component) error {
+	return c.Render(http.StatusOK, main)
+}
+
+func (c *testContext) RenderFragments(code int, layout internal.Component, fragments map[string]internal.Component, opts ...htmx.RenderOption) error {
+	return c.Render(code, layout)
+}
+
+func (c *testContext) RenderToString(component internal.Component) (string, error) { return "", nil }
+func (c *testContext) Bind(v any) (validator.ValidationErrors, error)              { return nil, nil }
+func (c *testContext) BindQuery(v any) (validator.ValidationErrors, error)         { return nil, nil }
+func (c *testContext) BindJSON(v any) (validator.ValidationErrors, error)          { return nil, nil }
+func (c *testContext) BindAuto(v any) (validator.ValidationErrors, error)          { return nil, nil }
+func (c *testContext) BindPagination(opts ...internal.PaginationOption) (internal.Pagination, error) {
+	return internal.Pagination{}, nil
+}
+func (c *testContext) RawBody() ([]byte, error)  { return nil, nil }
+func (c *testContext) BindValidated(v any) error { return nil }
+func (c *testContext) BindMergePatch(current any) (any, []string, error) {
+	return nil, nil, nil
+}
 
 func (c *testContext) Set(key, value any) {
 	c.values[key] = value
@@ -109,6 +154,11 @@ func (c *testContext) Get(key any) any {
 	return c.values[key]
 }
 
+func (c *testContext) RequestID() string {
+	id, _ := c.values[internal.RequestIDKey{}].(string)
+	return id
+}
+
 func (c *testContext) Cookie(name string) (string, error) {
 	cookie, err := c.request.Cookie(name)
 	if err != nil {
@@ -117,6 +167,15 @@ func (c *testContext) Cookie(name string) (string, error) {
 	return cookie.Value, nil
 }
 
+func (c *testContext) Cookies() []*http.Cookie {
+	return c.request.Cookies()
+}
+
+func (c *testContext) HasCookie(name string) bool {
+	_, err := c.request.Cookie(name)
+	return err == nil
+}
+
 func (c *testContext) SetCookie(name, value string, maxAge int) {
 	http.SetCookie(c.response, &http.Cookie{
 		Name:   name,
@@ -132,12 +191,23 @@ func (c *testContext) DeleteCookie(name string) {
 	})
 }
 
-func (c *testContext) CookieSigned(name string) (string, error)                          { return "", nil }
-func (c *testContext) SetCookieSigned(name, value string, maxAge int) error              { return nil }
-func (c *testContext) CookieEncrypted(name string) (string, error)                       { return "", nil }
-func (c *testContext) SetCookieEncrypted(name, value string, maxAge int) error           { return nil }
+func (c *testContext) CookieSigned(name string) (string, error)                { return "", nil }
+func (c *testContext) SetCookieSigned(name, value string, maxAge int) error    { return nil }
+func (c *testContext) CookieEncrypted(name string) (string, error)             { return "", nil }
+func (c *testContext) SetCookieEncrypted(name, value string, maxAge int) error { return nil }
+func (c *testContext) SetCookieRaw(ck *http.Cookie, p cookie.Protection) error { return nil }
+func (c *testContext) JWTCookie() (string, error) {
+	return c.Cookie(internal.DefaultJWTCookie)
+}
+func (c *testContext) SetJWTCookie(token string, maxAge int) {
+	c.SetCookie(internal.DefaultJWTCookie, token, maxAge)
+}
 func (c *testContext) Flash(key string, dest any) error                                  { return nil }
 func (c *testContext) SetFlash(key string, value any) error                              { return nil }
+func (c *testContext) FlashSuccess(msg string)                                           {}
+func (c *testContext) FlashError(msg string)                                             {}
+func (c *testContext) FlashWarning(msg string)                                           {}
+func (c *testContext) Flashes() ([]internal.FlashMessage, error)                         { return nil, nil }
 func (c *testContext) Session() (*session.Session, error)                                { return nil, nil }
 func (c *testContext) InitSession() error                                                { return nil }
 func (c *testContext) AuthenticateSession(userID string) error                           { return nil }
@@ -145,8 +215,13 @@ func (c *testContext) SessionValue(key string) (any, error)
 func (c *testContext) SetSessionValue(key string, val any) error                         { return nil }
 func (c *testContext) DeleteSessionValue(key string) error                               { return nil }
 func (c *testContext) DestroySession() error                                             { return nil }
+func (c *testContext) DestroyUserSessions(userID string) (int, error)                    { return 0, nil }
 func (c *testContext) ResponseWriter() *internal.ResponseWriter                          { return nil }
 func (c *testContext) Enqueue(name string, payload any, opts ...job.EnqueueOption) error { return nil }
+func (c *testContext) EnqueueWithID(name string, payload any, opts ...job.EnqueueOption) (int64, error) {
+	return 0, nil
+}
+func (c *testContext) JobStatus(id int64) (*job.JobStatus, error) { return nil, nil }
 func (c *testContext) EnqueueTx(tx pgx.Tx, name string, payload any, opts ...job.EnqueueOption) error {
 	return nil
 }
@@ -156,7 +231,10 @@ func (c *testContext) Upload(r io.Reader, size int64, opts ...storage.Option) (*
 }
 func (c *testContext) Download(key string) (io.ReadCloser, error)                    { return nil, nil }
 func (c *testContext) DeleteFile(key string) error                                   { return nil }
+func (c *testContext) FileInfo(key string) (*storage.FileInfo, error)                { return nil, nil }
+func (c *testContext) FileExists(key string) (bool, error)                           { return false, nil }
 func (c *testContext) FileURL(key string, opts ...storage.URLOption) (string, error) { return "", nil }
+func (c *testContext) ServeFile(key string, opts ...internal.ServeFileOption) error  { return nil }
 func (c *testContext) T(key string, _ ...i18n.M) string                              { return key }
 func (c *testContext) Tn(key string, _ int, _ ...i18n.M) string                      { return key }
 func (c *testContext) Language() string                                              { return "" }
@@ -168,6 +246,15 @@ func (c *testContext) FormatTime(t time.Time) string
 func (c *testContext) FormatDateTime(datetime time.Time) string {
 	return datetime.Format("2006-01-02 15:04:05")
 }
+
+func (c *testContext) SignedURL(path string, expiry time.Time, params url.Values) (string, error) {
+	return "", nil
+}
+
+func (c *testContext) SetLastModified(t time.Time)       {}
+func (c *testContext) NotModifiedSince(t time.Time) bool { return false }
+func (c *testContext) CheckETag(etag string) bool        { return false }
+
 func (c *testContext) Deadline() (time.Time, bool)             { return c.request.Context().Deadline() }
 func (c *testContext) Done() <-chan struct{}                   { return c.request.Context().Done() }
 func (c *testContext) Err() error                              { return c.request.Context().Err() }
@@ -3,6 +3,7 @@ package middlewares
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/dmitrymomot/forge/internal"
@@ -25,10 +26,15 @@ func Timeout(timeout time.Duration) internal.Middleware {
 
 	return func(next internal.HandlerFunc) internal.HandlerFunc {
 		return func(c internal.Context) error {
-			ctx, cancel := context.WithTimeout(c.Context(), timeout)
-			defer cancel()
+			budget := &timeoutBudget{
+				parent:  c.Context(),
+				start:   time.Now(),
+				changed: make(chan struct{}, 1),
+			}
+			defer budget.stop()
 
-			c.Set(timeoutContextKey{}, ctx)
+			c.Set(timeoutContextKey{}, budget.replace(timeout))
+			c.Set(timeoutBudgetKey{}, budget)
 
 			// Capture logger before spawning goroutine (not safe to access c.Logger() from timeout goroutine)
 			logger := c.Logger()
@@ -38,23 +44,107 @@ func Timeout(timeout time.Duration) internal.Middleware {
 				done <- next(c)
 			}()
 
-			select {
-			case err := <-done:
-				return err
-			case <-ctx.Done():
-				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-					logger.WarnContext(ctx, "request timeout", "timeout", timeout.String())
-					return &TimeoutError{Duration: timeout}
+			for {
+				ctx, d := budget.current()
+				select {
+				case err := <-done:
+					return err
+				case <-budget.changed:
+					// TimeoutFor replaced the deadline; wait on the new context.
+				case <-ctx.Done():
+					if cur, _ := budget.current(); cur != ctx {
+						continue
+					}
+					if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						logger.WarnContext(ctx, "request timeout", "timeout", d.String())
+						return &TimeoutError{Duration: d}
+					}
+					return ctx.Err()
 				}
-				return ctx.Err()
 			}
 		}
 	}
 }
 
+// TimeoutFor returns middleware that overrides the timeout for specific routes.
+// Inside a Timeout middleware it replaces the enclosing timeout rather than
+// stacking with it: the route gets timeout measured from when the request
+// entered Timeout, and GetTimeoutContext returns a context with that deadline.
+// Without an enclosing Timeout it behaves like Timeout(timeout).
+//
+//	r.With(middlewares.TimeoutFor(60*time.Second)).GET("/reports/{id}", h.report)
+func TimeoutFor(timeout time.Duration) internal.Middleware {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		standalone := Timeout(timeout)(next)
+
+		return func(c internal.Context) error {
+			budget, ok := c.Get(timeoutBudgetKey{}).(*timeoutBudget)
+			if !ok {
+				return standalone(c)
+			}
+
+			c.Set(timeoutContextKey{}, budget.replace(timeout))
+			return next(c)
+		}
+	}
+}
+
 // timeoutContextKey is used to store the timeout context.
 type timeoutContextKey struct{}
 
+// timeoutBudgetKey is used to store the timeout budget.
+type timeoutBudgetKey struct{}
+
+// timeoutBudget is the deadline shared between Timeout and TimeoutFor,
+// letting a route-level override move the enclosing deadline.
+type timeoutBudget struct {
+	parent  context.Context
+	start   time.Time
+	changed chan struct{}
+
+	mu      sync.Mutex
+	ctx     context.Context
+	timeout time.Duration
+	cancels []context.CancelFunc
+}
+
+// replace sets the timeout to d, measured from start, and returns a context
+// with the new deadline. The enclosing Timeout is woken to wait on it.
+func (b *timeoutBudget) replace(d time.Duration) context.Context {
+	ctx, cancel := context.WithDeadline(b.parent, b.start.Add(d))
+
+	b.mu.Lock()
+	b.ctx = ctx
+	b.timeout = d
+	b.cancels = append(b.cancels, cancel)
+	b.mu.Unlock()
+
+	select {
+	case b.changed <- struct{}{}:
+	default:
+	}
+	return ctx
+}
+
+func (b *timeoutBudget) current() (context.Context, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ctx, b.timeout
+}
+
+// stop releases every context created by replace.
+func (b *timeoutBudget) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, cancel := range b.cancels {
+		cancel()
+	}
+}
+
 // GetTimeoutContext retrieves the timeout context if available.
 // This allows handlers to check for cancellation via ctx.Done().
 func GetTimeoutContext(c internal.Context) context.Context {
@@ -320,3 +320,124 @@ func TestTimeout_ConcurrentRequests(t *testing.T) {
 		require.True(t, middlewares.IsTimeoutError(err2))
 	})
 }
+
+func TestTimeoutFor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("longer override survives past the global timeout", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := newTestContext(rec, req)
+
+		var ctxErr error
+		handler := middlewares.Timeout(20 * time.Millisecond)(
+			middlewares.TimeoutFor(500 * time.Millisecond)(func(c internal.Context) error {
+				time.Sleep(60 * time.Millisecond)
+				ctxErr = middlewares.GetTimeoutContext(c).Err()
+				return nil
+			}),
+		)
+
+		require.NoError(t, handler(ctx))
+		require.NoError(t, ctxErr)
+	})
+
+	t.Run("shorter override replaces the global timeout", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := newTestContext(rec, req)
+
+		handler := middlewares.Timeout(time.Second)(
+			middlewares.TimeoutFor(20 * time.Millisecond)(func(c internal.Context) error {
+				time.Sleep(200 * time.Millisecond)
+				return nil
+			}),
+		)
+
+		err := handler(ctx)
+		te, ok := middlewares.AsTimeoutError(err)
+		require.True(t, ok)
+		require.Equal(t, 20*time.Millisecond, te.Duration)
+	})
+
+	t.Run("override is measured from the start of the request", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := newTestContext(rec, req)
+
+		start := time.Now()
+		var deadline time.Time
+		handler := middlewares.Timeout(20 * time.Millisecond)(func(c internal.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return middlewares.TimeoutFor(time.Second)(func(c internal.Context) error {
+				deadline, _ = middlewares.GetTimeoutContext(c).Deadline()
+				return nil
+			})(c)
+		})
+
+		require.NoError(t, handler(ctx))
+		require.WithinDuration(t, start.Add(time.Second), deadline, 5*time.Millisecond)
+	})
+
+	t.Run("acts as Timeout without an enclosing Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := newTestContext(rec, req)
+
+		handler := middlewares.TimeoutFor(20 * time.Millisecond)(func(c internal.Context) error {
+			time.Sleep(200 * time.Millisecond)
+			return nil
+		})
+
+		require.True(t, middlewares.IsTimeoutError(handler(ctx)))
+	})
+
+	t.Run("applies per route through the router", func(t *testing.T) {
+		t.Parallel()
+
+		slow := func(c internal.Context) error {
+			select {
+			case <-time.After(60 * time.Millisecond):
+				return c.String(http.StatusOK, "done")
+			case <-middlewares.GetTimeoutContext(c).Done():
+				return nil
+			}
+		}
+
+		app := internal.New(
+			internal.WithMiddleware(middlewares.Timeout(20*time.Millisecond)),
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				if middlewares.IsTimeoutError(err) {
+					return c.String(http.StatusGatewayTimeout, "timeout")
+				}
+				return err
+			}),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				r.GET("/fast", slow)
+				r.With(middlewares.TimeoutFor(time.Second)).GET("/report", slow)
+			})),
+		)
+
+		rec := httptest.NewRecorder()
+		app.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/report", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "done", rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		app.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+		require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	})
+}
+
+// routes adapts a function to the Handler interface.
+type routes func(r internal.Router)
+
+func (f routes) Routes(r internal.Router) { f(r) }
@@ -0,0 +1,225 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmitrymomot/forge/internal"
+)
+
+// DefaultWebhookTolerance is how old a timestamped webhook signature may be
+// before WebhookVerify rejects it. It matches Stripe's own default.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// Webhook verification errors, wrapped in the 400 *HTTPError WebhookVerify
+// returns.
+var (
+	// ErrWebhookSignatureMissing is returned when no configured scheme's
+	// signature header is present.
+	ErrWebhookSignatureMissing = errors.New("webhook: missing signature")
+
+	// ErrWebhookSignatureInvalid is returned when the signature header is
+	// malformed or does not match the body.
+	ErrWebhookSignatureInvalid = errors.New("webhook: invalid signature")
+
+	// ErrWebhookTimestampStale is returned when a timestamped signature is
+	// older, or further in the future, than the tolerance.
+	ErrWebhookTimestampStale = errors.New("webhook: stale timestamp")
+)
+
+// WebhookScheme verifies one provider's signature. Header names the header
+// carrying the signature; Verify checks its value against the raw body.
+type WebhookScheme struct {
+	// Verify returns nil when signature is valid for body. now is the time
+	// timestamped schemes compare against, and tolerance the allowed skew.
+	Verify func(signature string, body []byte, now time.Time, tolerance time.Duration) error
+
+	// Header is the request header holding the signature.
+	Header string
+}
+
+// WebhookVerifyConfig configures the webhook verification middleware.
+type WebhookVerifyConfig struct {
+	// Schemes are tried in order; the first whose header is present on the
+	// request verifies it.
+	Schemes []WebhookScheme
+
+	// Tolerance is the maximum age of a timestamped signature
+	// (default: DefaultWebhookTolerance).
+	Tolerance time.Duration
+}
+
+// WebhookVerifyOption configures WebhookVerifyConfig.
+type WebhookVerifyOption func(*WebhookVerifyConfig)
+
+// WithWebhookScheme adds a custom signature scheme.
+func WithWebhookScheme(s WebhookScheme) WebhookVerifyOption {
+	return func(cfg *WebhookVerifyConfig) {
+		cfg.Schemes = append(cfg.Schemes, s)
+	}
+}
+
+// WithHMACScheme adds a scheme that expects the hex-encoded HMAC of the raw
+// body in header, computed with secret and algo (for example sha256.New).
+// A "name=" prefix on the header value, as in "sha256=<hex>", is ignored.
+// Panics if secret is empty.
+func WithHMACScheme(header, secret string, algo func() hash.Hash) WebhookVerifyOption {
+	requireWebhookSecret("WithHMACScheme", secret)
+	return hmacScheme(header, secret, algo)
+}
+
+// hmacScheme builds the scheme behind WithHMACScheme and WithGitHubScheme.
+func hmacScheme(header, secret string, algo func() hash.Hash) WebhookVerifyOption {
+	return WithWebhookScheme(WebhookScheme{
+		Header: header,
+		Verify: func(signature string, body []byte, _ time.Time, _ time.Duration) error {
+			if _, hexSig, ok := strings.Cut(signature, "="); ok {
+				signature = hexSig
+			}
+			if !validHMAC(algo, secret, body, signature) {
+				return ErrWebhookSignatureInvalid
+			}
+			return nil
+		},
+	})
+}
+
+// WithGitHubScheme adds GitHub's scheme: the HMAC-SHA256 of the body in the
+// X-Hub-Signature-256 header, prefixed with "sha256=". Panics if secret is
+// empty.
+func WithGitHubScheme(secret string) WebhookVerifyOption {
+	requireWebhookSecret("WithGitHubScheme", secret)
+	return hmacScheme("X-Hub-Signature-256", secret, sha256.New)
+}
+
+// WithStripeScheme adds Stripe's scheme: the Stripe-Signature header holds a
+// timestamp and one or more v1 signatures, each the HMAC-SHA256 of
+// "<timestamp>.<body>". Timestamps outside the tolerance are rejected, which
+// stops replays of captured requests. Panics if secret is empty.
+func WithStripeScheme(secret string) WebhookVerifyOption {
+	requireWebhookSecret("WithStripeScheme", secret)
+	return WithWebhookScheme(WebhookScheme{
+		Header: "Stripe-Signature",
+		Verify: func(signature string, body []byte, now time.Time, tolerance time.Duration) error {
+			var ts string
+			var sigs []string
+			for part := range strings.SplitSeq(signature, ",") {
+				k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+				switch k {
+				case "t":
+					ts = v
+				case "v1":
+					sigs = append(sigs, v)
+				}
+			}
+			sec, err := strconv.ParseInt(ts, 10, 64)
+			if err != nil || len(sigs) == 0 {
+				return ErrWebhookSignatureInvalid
+			}
+			if d := now.Sub(time.Unix(sec, 0)); d > tolerance || d < -tolerance {
+				return ErrWebhookTimestampStale
+			}
+
+			payload := make([]byte, 0, len(ts)+1+len(body))
+			payload = append(append(append(payload, ts...), '.'), body...)
+			for _, sig := range sigs {
+				if validHMAC(sha256.New, secret, payload, sig) {
+					return nil
+				}
+			}
+			return ErrWebhookSignatureInvalid
+		},
+	})
+}
+
+// WithWebhookTolerance sets how old a timestamped signature may be.
+func WithWebhookTolerance(d time.Duration) WebhookVerifyOption {
+	return func(cfg *WebhookVerifyConfig) {
+		cfg.Tolerance = d
+	}
+}
+
+// WebhookVerify returns middleware that verifies the signature of incoming
+// webhooks before the handler runs. The raw body is read with c.RawBody, so
+// it is subject to the body limit and the handler reads the verified bytes
+// from c.RawBody or c.BindJSON. Requests without a signature, with an
+// invalid one, or with a stale timestamp are rejected with 400 Bad Request
+// wrapping ErrWebhookSignatureMissing, ErrWebhookSignatureInvalid, or
+// ErrWebhookTimestampStale.
+//
+// WebhookVerify panics if no scheme is configured.
+func WebhookVerify(opts ...WebhookVerifyOption) internal.Middleware {
+	cfg := &WebhookVerifyConfig{
+		Tolerance: DefaultWebhookTolerance,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if len(cfg.Schemes) == 0 {
+		panic("middlewares: WebhookVerify requires at least one scheme")
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = DefaultWebhookTolerance
+	}
+
+	return func(next internal.HandlerFunc) internal.HandlerFunc {
+		return func(c internal.Context) error {
+			if err := verifyWebhook(c, cfg); err != nil {
+				var httpErr *internal.HTTPError
+				if errors.As(err, &httpErr) {
+					return err
+				}
+				return internal.ErrBadRequest("Invalid webhook signature", internal.WithError(err))
+			}
+			return next(c)
+		}
+	}
+}
+
+// verifyWebhook checks the request against the first scheme whose header
+// is present.
+func verifyWebhook(c internal.Context, cfg *WebhookVerifyConfig) error {
+	for _, s := range cfg.Schemes {
+		signature := c.Request().Header.Get(s.Header)
+		if signature == "" {
+			continue
+		}
+		body, err := c.RawBody()
+		if err != nil {
+			return err
+		}
+		if err := s.Verify(signature, body, time.Now(), cfg.Tolerance); err != nil {
+			return fmt.Errorf("verify %s: %w", s.Header, err)
+		}
+		return nil
+	}
+	return ErrWebhookSignatureMissing
+}
+
+// requireWebhookSecret panics if secret is empty. Anyone can sign with an
+// empty key, so a secret missing from the environment would otherwise let
+// every forged request through.
+func requireWebhookSecret(fn, secret string) {
+	if secret == "" {
+		panic("middlewares: " + fn + " requires a secret")
+	}
+}
+
+// validHMAC reports whether hexSig is the HMAC of payload, compared in
+// constant time.
+func validHMAC(algo func() hash.Hash, secret string, payload []byte, hexSig string) bool {
+	got, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(algo, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), got)
+}
@@ -0,0 +1,147 @@
+package middlewares_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/internal"
+	"github.com/dmitrymomot/forge/middlewares"
+)
+
+func TestWebhookVerify(t *testing.T) {
+	t.Parallel()
+
+	const secret = "whsec_test"
+	const body = `{"type":"invoice.paid"}`
+
+	sign := func(payload string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(payload))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	serve := func(t *testing.T, headers map[string]string, opts ...middlewares.WebhookVerifyOption) (*httptest.ResponseRecorder, string, error) {
+		t.Helper()
+		var handlerErr error
+		var handlerSaw string
+		app := internal.New(
+			internal.WithErrorHandler(func(c internal.Context, err error) error {
+				handlerErr = err
+				var httpErr *internal.HTTPError
+				if errors.As(err, &httpErr) {
+					return c.NoContent(httpErr.Code)
+				}
+				return c.NoContent(http.StatusInternalServerError)
+			}),
+			internal.WithHandlers(routes(func(r internal.Router) {
+				r.With(middlewares.WebhookVerify(opts...)).POST("/", func(c internal.Context) error {
+					b, err := c.RawBody()
+					if err != nil {
+						return err
+					}
+					handlerSaw = string(b)
+					return c.NoContent(http.StatusNoContent)
+				})
+			})),
+		)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		app.Router().ServeHTTP(w, req)
+		return w, handlerSaw, handlerErr
+	}
+
+	t.Run("github signature passes and body stays readable", func(t *testing.T) {
+		t.Parallel()
+
+		w, saw, _ := serve(t, map[string]string{"X-Hub-Signature-256": "sha256=" + sign(body)},
+			middlewares.WithGitHubScheme(secret))
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, body, saw)
+	})
+
+	t.Run("github signature mismatch is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		w, _, err := serve(t, map[string]string{"X-Hub-Signature-256": "sha256=" + sign("other")},
+			middlewares.WithGitHubScheme(secret))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.ErrorIs(t, err, middlewares.ErrWebhookSignatureInvalid)
+	})
+
+	t.Run("missing signature is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		w, _, err := serve(t, nil, middlewares.WithGitHubScheme(secret))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.ErrorIs(t, err, middlewares.ErrWebhookSignatureMissing)
+	})
+
+	t.Run("stripe signature passes", func(t *testing.T) {
+		t.Parallel()
+
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		header := fmt.Sprintf("t=%s,v1=%s,v1=%s", ts, sign("rotated"), sign(ts+"."+body))
+		w, saw, _ := serve(t, map[string]string{"Stripe-Signature": header}, middlewares.WithStripeScheme(secret))
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, body, saw)
+	})
+
+	t.Run("stale stripe timestamp is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		ts := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+		header := fmt.Sprintf("t=%s,v1=%s", ts, sign(ts+"."+body))
+		w, _, err := serve(t, map[string]string{"Stripe-Signature": header}, middlewares.WithStripeScheme(secret))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.ErrorIs(t, err, middlewares.ErrWebhookTimestampStale)
+	})
+
+	t.Run("tolerance is configurable", func(t *testing.T) {
+		t.Parallel()
+
+		ts := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+		header := fmt.Sprintf("t=%s,v1=%s", ts, sign(ts+"."+body))
+		w, _, _ := serve(t, map[string]string{"Stripe-Signature": header},
+			middlewares.WithStripeScheme(secret), middlewares.WithWebhookTolerance(time.Hour))
+		require.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("generic hmac scheme uses the given algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		mac := hmac.New(sha512.New, []byte(secret))
+		mac.Write([]byte(body))
+		w, _, _ := serve(t, map[string]string{"X-Signature": hex.EncodeToString(mac.Sum(nil))},
+			middlewares.WithHMACScheme("X-Signature", secret, sha512.New))
+		require.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("panics without a scheme", func(t *testing.T) {
+		t.Parallel()
+
+		require.Panics(t, func() { middlewares.WebhookVerify() })
+	})
+
+	t.Run("schemes panic without a secret", func(t *testing.T) {
+		t.Parallel()
+
+		require.Panics(t, func() { middlewares.WithHMACScheme("X-Signature", "", sha512.New) })
+		require.Panics(t, func() { middlewares.WithGitHubScheme("") })
+		require.Panics(t, func() { middlewares.WithStripeScheme("") })
+	})
+}
@@ -52,10 +52,26 @@
 //		// req is now populated from JSON body
 //	}
 //
+// Bodies over the limit fail with ErrBodyTooLarge, whether cut off by the
+// binder itself or by an http.MaxBytesReader wrapped around r.Body. Raise or
+// lower the binder's limit with WithMaxJSONSize. Syntax errors, type
+// mismatches, unknown fields, and trailing data fail with ErrMalformedJSON.
+// Both also match ErrFailedToParseJSON:
+//
+//	err := binder.JSON(binder.WithMaxJSONSize(64<<10))(r, &req)
+//	switch {
+//	case errors.Is(err, binder.ErrBodyTooLarge):
+//		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+//	case errors.Is(err, binder.ErrMalformedJSON):
+//		http.Error(w, "malformed JSON", http.StatusBadRequest)
+//	}
+//
 // # Form Binding
 //
 // Form binding handles both URL-encoded forms and multipart forms with file uploads.
-// It supports comprehensive struct tags and type conversion:
+// File fields (*multipart.FileHeader and []*multipart.FileHeader) can be declared
+// with either the `form` or the `file` tag. It supports comprehensive struct tags
+// and type conversion:
 //
 //	type UploadRequest struct {
 //		Title       string                  `form:"title"`
@@ -63,7 +79,7 @@
 //		Tags        []string                `form:"tags"`     // Multi-value support
 //		IsPublic    bool                    `form:"public"`   // String to bool conversion
 //		Priority    int                     `form:"priority"` // String to int conversion
-//		Avatar      *multipart.FileHeader   `file:"avatar"`   // Single file upload
+//		Avatar      *multipart.FileHeader   `form:"avatar"`   // Single file upload
 //		Attachments []*multipart.FileHeader `file:"files"`    // Multiple file uploads
 //		Internal    string                  `form:"-"`        // Ignored field
 //	}
@@ -111,6 +127,24 @@
 //		// req is populated from query parameters
 //	}
 //
+// # Collection Parameters
+//
+// Query and form binders populate collection fields from these shapes:
+//
+//	type ListRequest struct {
+//		Tags    []string            `query:"tag"`    // ?tag=a&tag=b or ?tag=a,b
+//		IDs     []int               `query:"id"`     // ?id=1&id=2
+//		Filter  map[string]string   `query:"filter"` // ?filter[status]=open&filter[owner]=me
+//		Ranges  map[string]int      `query:"min"`    // ?min[price]=10
+//		AnyOf   map[string][]string `query:"any"`    // ?any[label]=bug&any[label]=ui
+//	}
+//
+// Map keys must have a string kind. Nested brackets (filter[a][b]) and empty
+// keys (filter[]) are ignored, and a map stays nil when no bracketed parameter
+// matches. Element values use the same conversion rules as scalar fields, and
+// conversion errors name both the field and the offending key. JSON bodies bind
+// the same field types through encoding/json.
+//
 // # Path Parameter Binding
 //
 // Path parameter binding extracts values from URL path segments using
@@ -233,8 +267,12 @@
 //		switch {
 //		case errors.Is(err, binder.ErrUnsupportedMediaType):
 //			// Handle unsupported media type
+//		case errors.Is(err, binder.ErrBodyTooLarge):
+//			// Handle oversized body
+//		case errors.Is(err, binder.ErrMalformedJSON):
+//			// Handle invalid JSON
 //		case errors.Is(err, binder.ErrFailedToParseJSON):
-//			// Handle JSON parsing error
+//			// Handle other JSON read errors
 //		case errors.Is(err, binder.ErrFailedToParseForm):
 //			// Handle form parsing error
 //		case errors.Is(err, binder.ErrFailedToParseQuery):
@@ -1,6 +1,9 @@
 package binder
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error variables define common binding failures that can occur during request processing.
 var (
@@ -12,6 +15,20 @@ var (
 	// or doesn't match the target struct schema.
 	ErrFailedToParseJSON = errors.New("failed to parse JSON request body")
 
+	// ErrMalformedJSON indicates the request body is not valid JSON or does not
+	// fit the target struct, as opposed to parseable data that fails validation.
+	// Errors wrapping it also wrap ErrFailedToParseJSON.
+	ErrMalformedJSON = errors.New("malformed JSON")
+
+	// ErrBodyTooLarge indicates the request body exceeds the size limit, either
+	// the binder's own or one set by http.MaxBytesReader.
+	// Errors wrapping it also wrap ErrFailedToParseJSON or ErrUploadTooLarge.
+	ErrBodyTooLarge = errors.New("request body too large")
+
+	// ErrUploadTooLarge indicates a multipart/form-data body exceeds the size
+	// limit. Errors wrapping it also wrap ErrBodyTooLarge.
+	ErrUploadTooLarge = fmt.Errorf("upload too large: %w", ErrBodyTooLarge)
+
 	// ErrFailedToParseForm indicates form data parsing failed due to malformed
 	// multipart boundaries or invalid URL-encoded data.
 	ErrFailedToParseForm = errors.New("failed to parse form data")
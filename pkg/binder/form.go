@@ -1,6 +1,7 @@
 package binder
 
 import (
+	"errors"
 	"fmt"
 	"mime"
 	"mime/multipart"
@@ -13,6 +14,25 @@ import (
 // DefaultMaxMemory is the default maximum memory used for parsing multipart forms (10MB).
 const DefaultMaxMemory = 10 << 20 // 10 MB
 
+// FormOption configures the Form binder.
+type FormOption func(*formOptions)
+
+type formOptions struct {
+	maxMemory int64
+}
+
+// WithMaxMemory sets how many bytes of a multipart form are kept in memory.
+// File parts beyond it are written to temporary files on disk, so it does
+// not limit the upload size; use http.MaxBytesReader for that.
+// Non-positive values keep DefaultMaxMemory.
+func WithMaxMemory(n int64) FormOption {
+	return func(o *formOptions) {
+		if n > 0 {
+			o.maxMemory = n
+		}
+	}
+}
+
 // Form creates a unified binder for both form data and file uploads.
 // It handles application/x-www-form-urlencoded and multipart/form-data content types.
 //
@@ -25,19 +45,29 @@ const DefaultMaxMemory = 10 << 20 // 10 MB
 // Supported types for form fields:
 //   - Basic types: string, int, int64, uint, uint64, float32, float64, bool
 //   - Slices of basic types for multi-value fields
+//   - Maps with string keys from bracket notation (filter[status]=open)
 //   - Pointers for optional fields
 //
 // Supported types for file fields:
 //   - *multipart.FileHeader - single file
 //   - []*multipart.FileHeader - multiple files
 //
+// File fields may use either the `file` tag or the `form` tag. A file field
+// tagged with `form` that receives a plain text value (the request was not
+// multipart/form-data) fails with ErrUnsupportedMediaType.
+//
+// Parse errors wrap ErrFailedToParseForm together with the underlying error.
+// A multipart body cut off by an http.MaxBytesReader, or whose non-file fields
+// exceed the memory limit plus 10MB, also wraps ErrUploadTooLarge and
+// ErrBodyTooLarge.
+//
 // Example:
 //
 //	type UploadRequest struct {
 //		Title    string                  `form:"title"`
 //		Category string                  `form:"category"`
 //		Tags     []string                `form:"tags"`     // Multi-value field
-//		Avatar   *multipart.FileHeader   `file:"avatar"`   // Optional file
+//		Avatar   *multipart.FileHeader   `form:"avatar"`   // Optional file
 //		Gallery  []*multipart.FileHeader `file:"gallery"`  // Multiple files
 //		Internal string                  `form:"-"`        // Skipped
 //	}
@@ -61,7 +91,12 @@ const DefaultMaxMemory = 10 << 20 // 10 MB
 //	}
 //
 //	http.HandleFunc("/upload", uploadHandler)
-func Form() Binder {
+func Form(opts ...FormOption) Binder {
+	o := &formOptions{maxMemory: DefaultMaxMemory}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return func(r *http.Request, v any) error {
 		contentType := r.Header.Get("Content-Type")
 		if contentType == "" {
@@ -78,7 +113,7 @@ func Form() Binder {
 		switch {
 		case mediaType == "application/x-www-form-urlencoded":
 			if err := r.ParseForm(); err != nil {
-				return fmt.Errorf("%w: %v", ErrFailedToParseForm, err)
+				return fmt.Errorf("%w: %w", ErrFailedToParseForm, err)
 			}
 			values = r.Form
 
@@ -98,9 +133,12 @@ func Form() Binder {
 				return fmt.Errorf("%w: invalid boundary parameter", ErrFailedToParseForm)
 			}
 
-			// Use DefaultMaxMemory for multipart parsing; larger files spill to disk
-			if err := r.ParseMultipartForm(DefaultMaxMemory); err != nil {
-				return fmt.Errorf("%w: %v", ErrFailedToParseForm, err)
+			// Parts beyond maxMemory spill to disk.
+			if err := r.ParseMultipartForm(o.maxMemory); err != nil {
+				if IsUploadTooLarge(err) {
+					return fmt.Errorf("%w: %w: %w", ErrFailedToParseForm, ErrUploadTooLarge, err)
+				}
+				return fmt.Errorf("%w: %w", ErrFailedToParseForm, err)
 			}
 
 			if r.MultipartForm != nil {
@@ -119,6 +157,14 @@ func Form() Binder {
 	}
 }
 
+// IsUploadTooLarge reports whether err from parsing a multipart form means
+// the body was too large: cut off by an http.MaxBytesReader, or holding more
+// non-file data than the parser accepts.
+func IsUploadTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr) || errors.Is(err, multipart.ErrMessageTooLarge) || errors.Is(err, ErrUploadTooLarge)
+}
+
 // bindFormAndFiles binds both form values and files to a struct.
 func bindFormAndFiles(v any, values map[string][]string, files map[string][]*multipart.FileHeader, bindErr error) error {
 	rv := reflect.ValueOf(v)
@@ -164,7 +210,15 @@ func bindFormAndFiles(v any, values map[string][]string, files map[string][]*mul
 				continue
 			}
 
-			if fieldValues, exists := values[paramName]; exists && len(fieldValues) > 0 {
+			if isFileType(fieldType.Type) {
+				if err := bindFormFile(field, fieldType, paramName, values, files, bindErr); err != nil {
+					return err
+				}
+			} else if fieldType.Type.Kind() == reflect.Map {
+				if err := setMapValue(field, fieldType.Type, paramName, values); err != nil {
+					return fmt.Errorf("%w: field %s: %v", bindErr, fieldType.Name, err)
+				}
+			} else if fieldValues, exists := values[paramName]; exists && len(fieldValues) > 0 {
 				if err := setFieldValue(field, fieldType.Type, fieldValues); err != nil {
 					return fmt.Errorf("%w: field %s: %v", bindErr, fieldType.Name, err)
 				}
@@ -184,6 +238,33 @@ func bindFormAndFiles(v any, values map[string][]string, files map[string][]*mul
 	return nil
 }
 
+// isFileType reports whether t is *multipart.FileHeader or []*multipart.FileHeader.
+func isFileType(t reflect.Type) bool {
+	fileType := reflect.TypeFor[*multipart.FileHeader]()
+	return t == fileType || (t.Kind() == reflect.Slice && t.Elem() == fileType)
+}
+
+// bindFormFile binds uploaded files to a file-typed field declared with a form tag.
+// A text value submitted under a file field's name means the request was not
+// multipart, which is reported as ErrUnsupportedMediaType instead of silently
+// leaving the field empty.
+func bindFormFile(field reflect.Value, fieldType reflect.StructField, paramName string, values map[string][]string, files map[string][]*multipart.FileHeader, bindErr error) error {
+	if fileHeaders, exists := files[paramName]; exists && len(fileHeaders) > 0 {
+		if err := setFileField(field, fieldType.Type, fileHeaders); err != nil {
+			return fmt.Errorf("%w: field %s: %v", bindErr, fieldType.Name, err)
+		}
+		return nil
+	}
+
+	if files == nil {
+		if vals, exists := values[paramName]; exists && len(vals) > 0 {
+			return fmt.Errorf("%w: field %s: file uploads require multipart/form-data", ErrUnsupportedMediaType, fieldType.Name)
+		}
+	}
+
+	return nil
+}
+
 // setFileField sets file values to struct fields.
 func setFileField(field reflect.Value, fieldType reflect.Type, fileHeaders []*multipart.FileHeader) error {
 	// Apply security sanitization to prevent path traversal attacks
@@ -3,6 +3,7 @@ package binder_test
 import (
 	"bytes"
 	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -694,6 +695,26 @@ func TestForm(t *testing.T) {
 		assert.Equal(t, "Test", result.Name)
 		assert.Equal(t, 25, result.Age)
 	})
+
+	t.Run("bracket notation into map", func(t *testing.T) {
+		t.Parallel()
+		type settingsForm struct {
+			Settings map[string]string `form:"settings"`
+		}
+
+		formData := url.Values{
+			"settings[theme]": {"dark"},
+			"settings[lang]":  {"en"},
+		}
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var result settingsForm
+		err := binder.Form()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"theme": "dark", "lang": "en"}, result.Settings)
+	})
 }
 
 func TestFormWithFiles(t *testing.T) {
@@ -918,6 +939,107 @@ func TestFormWithFiles(t *testing.T) {
 	})
 }
 
+func TestFormWithFormTaggedFiles(t *testing.T) {
+	t.Parallel()
+	type profileForm struct {
+		Name        string                  `form:"name"`
+		Avatar      *multipart.FileHeader   `form:"avatar"`
+		Attachments []*multipart.FileHeader `form:"attachments"`
+	}
+
+	t.Run("binds text and files in one call", func(t *testing.T) {
+		t.Parallel()
+		body, contentType := createMultipartFormWithFiles(t,
+			map[string]string{"name": "Jane"},
+			map[string][]fileData{
+				"avatar": {{filename: "me.png", content: []byte("png")}},
+				"attachments": {
+					{filename: "a.txt", content: []byte("a")},
+					{filename: "b.txt", content: []byte("b")},
+				},
+			},
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/profile", body)
+		req.Header.Set("Content-Type", contentType)
+
+		var result profileForm
+		err := binder.Form()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", result.Name)
+		require.NotNil(t, result.Avatar)
+		assert.Equal(t, "me.png", result.Avatar.Filename)
+		require.Len(t, result.Attachments, 2)
+		assert.Equal(t, "a.txt", result.Attachments[0].Filename)
+	})
+
+	t.Run("missing file leaves field nil", func(t *testing.T) {
+		t.Parallel()
+		body, contentType := createMultipartFormWithFiles(t, map[string]string{"name": "Jane"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/profile", body)
+		req.Header.Set("Content-Type", contentType)
+
+		var result profileForm
+		err := binder.Form()(req, &result)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Avatar)
+		assert.Nil(t, result.Attachments)
+	})
+
+	t.Run("url-encoded body without file values binds text", func(t *testing.T) {
+		t.Parallel()
+		formData := url.Values{"name": {"Jane"}}
+		req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var result profileForm
+		err := binder.Form()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", result.Name)
+		assert.Nil(t, result.Avatar)
+	})
+
+	t.Run("url-encoded value for file field is rejected", func(t *testing.T) {
+		t.Parallel()
+		formData := url.Values{"name": {"Jane"}, "avatar": {"me.png"}}
+		req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var result profileForm
+		err := binder.Form()(req, &result)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, binder.ErrUnsupportedMediaType)
+		assert.Contains(t, err.Error(), "Avatar")
+	})
+
+	t.Run("body limit surfaces MaxBytesError", func(t *testing.T) {
+		t.Parallel()
+		body, contentType := createMultipartFormWithFiles(t,
+			map[string]string{"name": "Jane"},
+			map[string][]fileData{
+				"avatar": {{filename: "big.bin", content: bytes.Repeat([]byte("x"), 4096)}},
+			},
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/profile", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 1024)
+
+		var result profileForm
+		err := binder.Form()(req, &result)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, binder.ErrFailedToParseForm)
+		var maxErr *http.MaxBytesError
+		assert.ErrorAs(t, err, &maxErr)
+	})
+}
+
 // Helper types and functions for file tests
 
 type fileData struct {
@@ -950,3 +1072,69 @@ func createMultipartFormWithFiles(t *testing.T, fields map[string]string, files
 
 	return body, writer.FormDataContentType()
 }
+
+func TestFormUploadLimits(t *testing.T) {
+	t.Parallel()
+
+	type uploadForm struct {
+		Title  string                `form:"title"`
+		Avatar *multipart.FileHeader `file:"avatar"`
+	}
+
+	newRequest := func(t *testing.T, size int) *http.Request {
+		body, contentType := createMultipartFormWithFiles(t,
+			map[string]string{"title": "Upload"},
+			map[string][]fileData{"avatar": {{filename: "a.bin", content: bytes.Repeat([]byte("x"), size)}}},
+		)
+		req := httptest.NewRequest(http.MethodPost, "/upload", body)
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("body over MaxBytesReader limit", func(t *testing.T) {
+		t.Parallel()
+
+		req := newRequest(t, 4096)
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 1024)
+
+		var result uploadForm
+		err := binder.Form()(req, &result)
+		require.ErrorIs(t, err, binder.ErrFailedToParseForm)
+		require.ErrorIs(t, err, binder.ErrUploadTooLarge)
+		require.ErrorIs(t, err, binder.ErrBodyTooLarge)
+		require.True(t, binder.IsUploadTooLarge(err))
+	})
+
+	t.Run("files over max memory spill to disk", func(t *testing.T) {
+		t.Parallel()
+
+		req := newRequest(t, 4096)
+
+		var result uploadForm
+		err := binder.Form(binder.WithMaxMemory(1024))(req, &result)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = req.MultipartForm.RemoveAll() })
+
+		require.Equal(t, "Upload", result.Title)
+		require.NotNil(t, result.Avatar)
+		f, err := result.Avatar.Open()
+		require.NoError(t, err)
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.Len(t, data, 4096)
+	})
+
+	t.Run("other parse errors are not too large", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("garbage"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+
+		var result uploadForm
+		err := binder.Form()(req, &result)
+		require.ErrorIs(t, err, binder.ErrFailedToParseForm)
+		require.NotErrorIs(t, err, binder.ErrUploadTooLarge)
+		require.False(t, binder.IsUploadTooLarge(err))
+	})
+}
@@ -2,6 +2,7 @@ package binder
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,23 @@ import (
 // DefaultMaxJSONSize is the default maximum size for JSON request bodies (1MB).
 const DefaultMaxJSONSize = 1 << 20 // 1 MB
 
+// JSONOption configures the JSON binder.
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	maxSize int64
+}
+
+// WithMaxJSONSize sets the maximum JSON body size in bytes.
+// Non-positive values keep DefaultMaxJSONSize.
+func WithMaxJSONSize(n int64) JSONOption {
+	return func(o *jsonOptions) {
+		if n > 0 {
+			o.maxSize = n
+		}
+	}
+}
+
 // JSON creates a JSON binder function.
 //
 // Example:
@@ -27,7 +45,17 @@ const DefaultMaxJSONSize = 1 << 20 // 1 MB
 //	}
 //
 //	http.HandleFunc("/users", createUserHandler)
-func JSON() Binder {
+//
+// Bodies over the size limit, including those cut off by an
+// http.MaxBytesReader, fail with ErrBodyTooLarge. Bodies that are not valid
+// JSON or do not fit v fail with ErrMalformedJSON. Both also match
+// ErrFailedToParseJSON.
+func JSON(opts ...JSONOption) Binder {
+	o := &jsonOptions{maxSize: DefaultMaxJSONSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return func(r *http.Request, v any) error {
 		// Fail fast if request context is already cancelled to avoid processing doomed requests
 		ctx := r.Context()
@@ -53,39 +81,35 @@ func JSON() Binder {
 		}
 
 		// Read entire body with +1 byte to detect oversized requests efficiently
-		limitedReader := io.LimitReader(r.Body, DefaultMaxJSONSize+1)
+		limitedReader := io.LimitReader(r.Body, o.maxSize+1)
 		body, err := io.ReadAll(limitedReader)
 		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return fmt.Errorf("%w (%w): max %d bytes", ErrFailedToParseJSON, ErrBodyTooLarge, maxErr.Limit)
+			}
 			return fmt.Errorf("%w: failed to read request body: %v", ErrFailedToParseJSON, err)
 		}
 
 		// Reject requests exceeding size limit to prevent DoS attacks
-		if len(body) > DefaultMaxJSONSize {
-			return fmt.Errorf("%w: request body too large (max %d bytes)", ErrFailedToParseJSON, DefaultMaxJSONSize)
+		if int64(len(body)) > o.maxSize {
+			return fmt.Errorf("%w (%w): max %d bytes", ErrFailedToParseJSON, ErrBodyTooLarge, o.maxSize)
 		}
 
 		decoder := json.NewDecoder(strings.NewReader(string(body)))
 		decoder.DisallowUnknownFields() // Strict mode prevents typos and unexpected fields
 
 		if err := decoder.Decode(v); err != nil {
-			switch {
-			case strings.Contains(err.Error(), "cannot unmarshal"):
-				return fmt.Errorf("%w: %v", ErrFailedToParseJSON, err)
-			case strings.Contains(err.Error(), "unexpected end of JSON"):
-				return fmt.Errorf("%w: %v", ErrFailedToParseJSON, err)
-			case strings.Contains(err.Error(), "invalid character"):
-				return fmt.Errorf("%w: %v", ErrFailedToParseJSON, err)
-			case err == io.EOF:
-				return fmt.Errorf("%w: empty body", ErrFailedToParseJSON)
-			default:
-				return fmt.Errorf("%w: %v", ErrFailedToParseJSON, err)
+			if err == io.EOF {
+				return fmt.Errorf("%w (%w): empty body", ErrFailedToParseJSON, ErrMalformedJSON)
 			}
+			return fmt.Errorf("%w (%w): %v", ErrFailedToParseJSON, ErrMalformedJSON, err)
 		}
 
 		// Verify no trailing data exists after valid JSON to prevent injection attacks
 		var extra json.RawMessage
 		if err := decoder.Decode(&extra); err != io.EOF {
-			return fmt.Errorf("%w: unexpected data after JSON object", ErrFailedToParseJSON)
+			return fmt.Errorf("%w (%w): unexpected data after JSON object", ErrFailedToParseJSON, ErrMalformedJSON)
 		}
 
 		// Apply security sanitization to prevent XSS and injection attacks
@@ -170,6 +170,48 @@ func TestJSON(t *testing.T) {
 		assert.Contains(t, err.Error(), "unexpected data after JSON object")
 	})
 
+	t.Run("malformed JSON is distinguishable", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"name":`))
+		req.Header.Set("Content-Type", "application/json")
+
+		var result testStruct
+		err := binder.JSON()(req, &result)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, binder.ErrMalformedJSON))
+		assert.False(t, errors.Is(err, binder.ErrBodyTooLarge))
+	})
+
+	t.Run("custom max size", func(t *testing.T) {
+		t.Parallel()
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"name":"Too long for the limit"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		var result testStruct
+		err := binder.JSON(binder.WithMaxJSONSize(10))(req, &result)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, binder.ErrBodyTooLarge))
+		assert.True(t, errors.Is(err, binder.ErrFailedToParseJSON))
+		assert.Contains(t, err.Error(), "max 10 bytes")
+	})
+
+	t.Run("MaxBytesReader limit is reported as body too large", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(`{"name":"Too long for the limit"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Body = http.MaxBytesReader(w, req.Body, 10)
+
+		var result testStruct
+		err := binder.JSON()(req, &result)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, binder.ErrBodyTooLarge))
+		assert.False(t, errors.Is(err, binder.ErrMalformedJSON))
+	})
+
 	t.Run("null values", func(t *testing.T) {
 		t.Parallel()
 		jsonData := `{"name":null,"age":null,"email":null}`
@@ -14,17 +14,19 @@ import (
 // Supported types:
 //   - Basic types: string, int, int64, uint, uint64, float32, float64, bool
 //   - Slices of basic types for multi-value parameters
+//   - Maps with string keys from bracket notation (?filter[status]=open)
 //   - Pointers for optional fields
 //
 // Example:
 //
 //	type SearchRequest struct {
-//		Query    string   `query:"q"`
-//		Page     int      `query:"page"`
-//		PageSize int      `query:"page_size"`
-//		Tags     []string `query:"tags"`     // ?tags=go&tags=web or ?tags=go,web
-//		Active   *bool    `query:"active"`   // Optional
-//		Internal string   `query:"-"`        // Skipped
+//		Query    string            `query:"q"`
+//		Page     int               `query:"page"`
+//		PageSize int               `query:"page_size"`
+//		Tags     []string          `query:"tags"`   // ?tags=go&tags=web or ?tags=go,web
+//		Active   *bool             `query:"active"` // Optional
+//		Filter   map[string]string `query:"filter"` // ?filter[status]=open
+//		Internal string            `query:"-"`      // Skipped
 //	}
 //
 //	func searchHandler(w http.ResponseWriter, r *http.Request) {
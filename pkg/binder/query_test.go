@@ -478,4 +478,114 @@ func TestQuery(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, []string{"go", "web", "api"}, result.Tags)
 	})
+
+	t.Run("repeated parameters into int slice", func(t *testing.T) {
+		t.Parallel()
+		type sliceStruct struct {
+			IDs []int `query:"id"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test?id=1&id=2&id=3", nil)
+
+		var result sliceStruct
+		err := binder.Query()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, result.IDs)
+	})
+
+	t.Run("bracket notation into map", func(t *testing.T) {
+		t.Parallel()
+		type filterStruct struct {
+			Filter map[string]string `query:"filter"`
+			Page   int               `query:"page"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test?filter%5Bstatus%5D=open&filter[owner]=me&page=2", nil)
+
+		var result filterStruct
+		err := binder.Query()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"status": "open", "owner": "me"}, result.Filter)
+		assert.Equal(t, 2, result.Page)
+	})
+
+	t.Run("map with typed and slice values", func(t *testing.T) {
+		t.Parallel()
+		type filterStruct struct {
+			Min   map[string]int      `query:"min"`
+			AnyOf map[string][]string `query:"any"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test?min[price]=10&min[qty]=2&any[label]=bug&any[label]=ui", nil)
+
+		var result filterStruct
+		err := binder.Query()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"price": 10, "qty": 2}, result.Min)
+		assert.Equal(t, map[string][]string{"label": {"bug", "ui"}}, result.AnyOf)
+	})
+
+	t.Run("map stays nil without bracketed parameters", func(t *testing.T) {
+		t.Parallel()
+		type filterStruct struct {
+			Filter map[string]string `query:"filter"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test?filter=open&other[status]=x", nil)
+
+		var result filterStruct
+		err := binder.Query()(req, &result)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Filter)
+	})
+
+	t.Run("map ignores nested and empty keys", func(t *testing.T) {
+		t.Parallel()
+		type filterStruct struct {
+			Filter map[string]string `query:"filter"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test?filter[a][b]=x&filter[]=y&filter[ok]=z", nil)
+
+		var result filterStruct
+		err := binder.Query()(req, &result)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ok": "z"}, result.Filter)
+	})
+
+	t.Run("map value conversion error names key", func(t *testing.T) {
+		t.Parallel()
+		type filterStruct struct {
+			Min map[string]int `query:"min"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test?min[price]=abc", nil)
+
+		var result filterStruct
+		err := binder.Query()(req, &result)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, binder.ErrFailedToParseQuery)
+		assert.Contains(t, err.Error(), `"price"`)
+	})
+
+	t.Run("map with non-string key is rejected", func(t *testing.T) {
+		t.Parallel()
+		type filterStruct struct {
+			Bad map[int]string `query:"bad"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		var result filterStruct
+		err := binder.Query()(req, &result)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, binder.ErrFailedToParseQuery)
+	})
 }
@@ -40,6 +40,13 @@ func bindToStruct(v any, tagName string, values map[string][]string, bindErr err
 			continue
 		}
 
+		if fieldType.Type.Kind() == reflect.Map {
+			if err := setMapValue(field, fieldType.Type, paramName, values); err != nil {
+				return fmt.Errorf("%w: field %s: %v", bindErr, fieldType.Name, err)
+			}
+			continue
+		}
+
 		fieldValues, exists := values[paramName]
 		if !exists || len(fieldValues) == 0 {
 			continue // No value provided, leave as zero value
@@ -167,6 +174,47 @@ func setSliceValue(field reflect.Value, fieldType reflect.Type, values []string)
 	return nil
 }
 
+// setMapValue populates a map field from bracket-notation parameters.
+// For paramName "filter", the parameters filter[status]=open and filter[owner]=me
+// produce map[status:open owner:me]. Map keys must have a string kind; values use
+// the same conversion as struct fields, so map[string][]string collects repeated
+// parameters. Nested brackets (filter[a][b]) and empty keys (filter[]) are ignored.
+// The map is only allocated when at least one matching parameter is present.
+func setMapValue(field reflect.Value, fieldType reflect.Type, paramName string, values map[string][]string) error {
+	keyType := fieldType.Key()
+	if keyType.Kind() != reflect.String {
+		return fmt.Errorf("unsupported map key type %s", keyType.Kind())
+	}
+	elemType := fieldType.Elem()
+	prefix := paramName + "["
+
+	for name, vals := range values {
+		if len(vals) == 0 {
+			continue
+		}
+		key, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		key, ok = strings.CutSuffix(key, "]")
+		if !ok || key == "" || strings.ContainsAny(key, "[]") {
+			continue
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if err := setFieldValue(elem, elemType, vals); err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(fieldType))
+		}
+		field.SetMapIndex(reflect.ValueOf(sanitizeStringValue(key)).Convert(keyType), elem)
+	}
+
+	return nil
+}
+
 // sanitizeStringValue removes dangerous characters that could be used in injection attacks.
 // It prevents CRLF injection, null byte attacks, and filters invalid Unicode sequences.
 func sanitizeStringValue(value string) string {
@@ -23,12 +23,26 @@ type Cache[V any] interface {
 	// Set stores a value with the given TTL.
 	Set(ctx context.Context, key string, value V, ttl time.Duration) error
 
+	// SetIfAbsent stores a value only if the key does not exist or has
+	// expired, and reports whether it was stored.
+	SetIfAbsent(ctx context.Context, key string, value V, ttl time.Duration) (bool, error)
+
+	// CompareAndSwap replaces the value of key with newValue only if the
+	// current value equals oldValue, and reports whether it was replaced.
+	// Values are equal when their serialized forms match (JSON for Memory,
+	// the configured Marshaler for Redis). A missing key never matches.
+	CompareAndSwap(ctx context.Context, key string, oldValue, newValue V, ttl time.Duration) (bool, error)
+
 	// Delete removes a key from the cache.
 	Delete(ctx context.Context, key string) error
 
 	// Has checks whether a key exists and has not expired.
 	Has(ctx context.Context, key string) (bool, error)
 
+	// TTL returns the remaining time-to-live of key, or 0 if it never expires.
+	// Returns ErrNotFound if the key does not exist or has expired.
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
 	// Clear removes all entries from the cache.
 	Clear(ctx context.Context) error
 
@@ -73,15 +87,22 @@ type getOrSetResult[V any] struct {
 // GetOrSet with the same key concurrently, fn is called only once.
 //
 // The callback returns the value, a TTL for caching, and an error.
+// The TTL goes through SetIfAbsent, so a configured TTL jitter applies to it.
 // If fn returns an error, the value is not cached and the error is returned.
+//
+// On a miss the result is cached with SetIfAbsent, so a value another writer
+// stored while fn ran is not overwritten by the possibly older computed one.
+// If Get failed for another reason, such as an entry that no longer
+// unmarshals, the result is cached with Set so it replaces that entry.
 func GetOrSet[V any](ctx context.Context, c Cache[V], key string, fn func(ctx context.Context) (V, time.Duration, error)) (V, error) {
 	// Fast path: try cache first.
-	if v, err := c.Get(ctx, key); err == nil {
+	v, getErr := c.Get(ctx, key)
+	if getErr == nil {
 		return v, nil
 	}
 
 	// Slow path: use singleflight to deduplicate concurrent misses.
-	v, err, _ := sfGroup.Do(key, func() (any, error) {
+	res, err, _ := sfGroup.Do(key, func() (any, error) {
 		val, ttl, err := fn(ctx)
 		if err != nil {
 			return nil, err
@@ -93,10 +114,14 @@ func GetOrSet[V any](ctx context.Context, c Cache[V], key string, fn func(ctx co
 		return zero, err
 	}
 
-	r := v.(getOrSetResult[V])
+	r := res.(getOrSetResult[V])
 
 	// Best-effort cache the result.
-	_ = c.Set(ctx, key, r.val, r.ttl)
+	if errors.Is(getErr, ErrNotFound) {
+		_, _ = c.SetIfAbsent(ctx, key, r.val, r.ttl)
+	} else {
+		_ = c.Set(ctx, key, r.val, r.ttl)
+	}
 
 	return r.val, nil
 }
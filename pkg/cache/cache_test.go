@@ -3,6 +3,7 @@ package cache_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -251,6 +252,228 @@ func TestMemory_Has(t *testing.T) {
 	})
 }
 
+// --- Memory: TTL ---
+
+func TestMemory_TTL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns remaining time", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "value", time.Minute))
+
+		ttl, err := c.TTL(ctx, "key")
+		require.NoError(t, err)
+		require.Greater(t, ttl, 59*time.Second)
+		require.LessOrEqual(t, ttl, time.Minute)
+	})
+
+	t.Run("returns zero for key that never expires", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "value", -1))
+
+		ttl, err := c.TTL(ctx, "key")
+		require.NoError(t, err)
+		require.Zero(t, ttl)
+	})
+
+	t.Run("returns ErrNotFound for missing key", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		_, err := c.TTL(context.Background(), "missing")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("returns ErrNotFound for expired key", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string](cache.WithCleanupInterval(0))
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "value", time.Millisecond))
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, err := c.TTL(ctx, "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+
+		has, err := c.Has(ctx, "key")
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+}
+
+// --- Memory: SetIfAbsent ---
+
+func TestMemory_SetIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores missing key", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		ok, err := c.SetIfAbsent(ctx, "key", "value", time.Minute)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, "value", val)
+	})
+
+	t.Run("keeps existing value", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "fresh", time.Minute))
+
+		ok, err := c.SetIfAbsent(ctx, "key", "stale", time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, "fresh", val)
+	})
+
+	t.Run("replaces expired value", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string](cache.WithCleanupInterval(0))
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "old", time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+
+		ok, err := c.SetIfAbsent(ctx, "key", "new", time.Minute)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("returns ErrClosed after close", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		require.NoError(t, c.Close())
+
+		_, err := c.SetIfAbsent(context.Background(), "key", "value", time.Minute)
+		require.ErrorIs(t, err, cache.ErrClosed)
+	})
+}
+
+// --- Memory: CompareAndSwap ---
+
+func TestMemory_CompareAndSwap(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name  string   `json:"name"`
+		Roles []string `json:"roles"`
+	}
+
+	t.Run("swaps matching value", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[user]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", user{Name: "alice", Roles: []string{"admin"}}, time.Minute))
+
+		ok, err := c.CompareAndSwap(ctx, "key", user{Name: "alice", Roles: []string{"admin"}}, user{Name: "bob"}, time.Minute)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, "bob", val.Name)
+	})
+
+	t.Run("keeps value that changed", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", 2, time.Minute))
+
+		ok, err := c.CompareAndSwap(ctx, "key", 1, 3, time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, 2, val)
+	})
+
+	t.Run("missing key never matches", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int]()
+		defer c.Close()
+
+		ctx := context.Background()
+		ok, err := c.CompareAndSwap(ctx, "key", 0, 1, time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		has, err := c.Has(ctx, "key")
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("concurrent increments are not lost", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "counter", 0, time.Minute))
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Go(func() {
+				for {
+					cur, err := c.Get(ctx, "counter")
+					require.NoError(t, err)
+					ok, err := c.CompareAndSwap(ctx, "counter", cur, cur+1, time.Minute)
+					require.NoError(t, err)
+					if ok {
+						return
+					}
+				}
+			})
+		}
+		wg.Wait()
+
+		val, err := c.Get(ctx, "counter")
+		require.NoError(t, err)
+		require.Equal(t, 20, val)
+	})
+}
+
 // --- Memory: Clear ---
 
 func TestMemory_Clear(t *testing.T) {
@@ -414,6 +637,122 @@ func TestMemory_MaxEntries(t *testing.T) {
 	})
 }
 
+// --- Memory: LFU ---
+
+func TestMemory_LFU(t *testing.T) {
+	t.Parallel()
+
+	// churn reads a hot key a few times, then writes one-shot keys through
+	// a cache of three entries, and reports whether the hot key survived.
+	churn := func(t *testing.T, policy cache.EvictionPolicy) bool {
+		t.Helper()
+
+		c := cache.NewMemory[int](cache.WithMaxEntries(3), cache.WithEvictionPolicy(policy))
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "hot", 1, time.Minute))
+		for range 5 {
+			_, err := c.Get(ctx, "hot")
+			require.NoError(t, err)
+		}
+		for i := range 10 {
+			require.NoError(t, c.Set(ctx, fmt.Sprintf("once-%d", i), i, time.Minute))
+		}
+
+		has, err := c.Has(ctx, "hot")
+		require.NoError(t, err)
+		return has
+	}
+
+	t.Run("retains hot keys that LRU evicts", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, churn(t, cache.PolicyLRU), "LRU should evict the hot key")
+		require.True(t, churn(t, cache.PolicyLFU), "LFU should keep the hot key")
+	})
+
+	t.Run("breaks frequency ties by recency", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int](cache.WithMaxEntries(2), cache.WithEvictionPolicy(cache.PolicyLFU))
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "a", 1, time.Minute))
+		require.NoError(t, c.Set(ctx, "b", 2, time.Minute))
+		require.NoError(t, c.Set(ctx, "c", 3, time.Minute))
+
+		has, err := c.Has(ctx, "a")
+		require.NoError(t, err)
+		require.False(t, has, "a is the oldest of the equally used keys")
+
+		has, err = c.Has(ctx, "b")
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("aging lets a newly hot key overtake a formerly hot one", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int](cache.WithMaxEntries(2), cache.WithEvictionPolicy(cache.PolicyLFU))
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "old", 1, time.Minute))
+		for range 1000 {
+			_, err := c.Get(ctx, "old")
+			require.NoError(t, err)
+		}
+		require.NoError(t, c.Set(ctx, "new", 2, time.Minute))
+		for range 300 {
+			_, err := c.Get(ctx, "new")
+			require.NoError(t, err)
+		}
+
+		// Without aging "old" (1001 uses) would outrank "new" (301 uses).
+		require.NoError(t, c.Set(ctx, "next", 3, time.Minute))
+
+		has, err := c.Has(ctx, "old")
+		require.NoError(t, err)
+		require.False(t, has, "old hotness should have decayed")
+
+		has, err = c.Has(ctx, "new")
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("calls the evict callback and supports delete and clear", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int](cache.WithMaxEntries(2), cache.WithEvictionPolicy(cache.PolicyLFU))
+		defer c.Close()
+
+		var evicted []string
+		c.SetEvictCallback(func(key string, _ int) {
+			evicted = append(evicted, key)
+		})
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "a", 1, time.Minute))
+		require.NoError(t, c.Set(ctx, "b", 2, time.Minute))
+		_, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		require.NoError(t, c.Set(ctx, "c", 3, time.Minute))
+		require.Equal(t, []string{"b"}, evicted)
+
+		require.NoError(t, c.Delete(ctx, "a"))
+		require.NoError(t, c.Set(ctx, "d", 4, time.Minute))
+		require.Equal(t, []string{"b", "a"}, evicted)
+
+		require.NoError(t, c.Clear(ctx))
+		require.NoError(t, c.Set(ctx, "e", 5, time.Minute))
+		val, err := c.Get(ctx, "e")
+		require.NoError(t, err)
+		require.Equal(t, 5, val)
+	})
+}
+
 // --- Memory: Eviction Callback ---
 
 func TestMemory_EvictCallback(t *testing.T) {
@@ -556,9 +895,62 @@ func TestMemory_ConcurrentAccess(t *testing.T) {
 
 // --- GetOrSet ---
 
+// unreadableCache reports ErrUnmarshal for the keys in bad, as Redis does for
+// entries written in an old format, until they are overwritten with Set.
+type unreadableCache struct {
+	*cache.Memory[string]
+	bad map[string]bool
+	mu  sync.Mutex
+}
+
+func (c *unreadableCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	bad := c.bad[key]
+	c.mu.Unlock()
+	if bad {
+		return "", cache.ErrUnmarshal
+	}
+	return c.Memory.Get(ctx, key)
+}
+
+func (c *unreadableCache) Set(ctx context.Context, key string, v string, ttl time.Duration) error {
+	c.mu.Lock()
+	delete(c.bad, key)
+	c.mu.Unlock()
+	return c.Memory.Set(ctx, key, v, ttl)
+}
+
 func TestGetOrSet(t *testing.T) {
 	t.Parallel()
 
+	t.Run("overwrites an unreadable entry", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c := &unreadableCache{Memory: cache.NewMemory[string](), bad: map[string]bool{"key": true, "other": true}}
+		defer c.Close()
+		require.NoError(t, c.Memory.Set(ctx, "key", "corrupt", time.Minute))
+		require.NoError(t, c.Memory.Set(ctx, "other", "corrupt", time.Minute))
+
+		val, err := cache.GetOrSet(ctx, c, "key", func(_ context.Context) (string, time.Duration, error) {
+			return "fresh", time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "fresh", val)
+
+		got, err := cache.GetOrSetMany(ctx, c, []string{"other"}, func(_ context.Context, _ []string) (map[string]string, time.Duration, error) {
+			return map[string]string{"other": "fresh"}, time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"other": "fresh"}, got)
+
+		for _, key := range []string{"key", "other"} {
+			cached, err := c.Get(ctx, key)
+			require.NoError(t, err)
+			require.Equal(t, "fresh", cached, key)
+		}
+	})
+
 	t.Run("returns cached value on hit", func(t *testing.T) {
 		t.Parallel()
 
@@ -614,6 +1006,25 @@ func TestGetOrSet(t *testing.T) {
 		require.ErrorIs(t, err, cache.ErrNotFound)
 	})
 
+	t.Run("does not overwrite value set while computing", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		val, err := cache.GetOrSet(ctx, c, "race", func(ctx context.Context) (string, time.Duration, error) {
+			require.NoError(t, c.Set(ctx, "race", "fresh", time.Minute))
+			return "stale", time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "stale", val)
+
+		cached, err := c.Get(ctx, "race")
+		require.NoError(t, err)
+		require.Equal(t, "fresh", cached)
+	})
+
 	t.Run("deduplicates concurrent calls", func(t *testing.T) {
 		t.Parallel()
 
@@ -646,6 +1057,335 @@ func TestGetOrSet(t *testing.T) {
 	})
 }
 
+// --- GetOrSetSWR ---
+
+func TestGetOrSetSWR(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loads on miss and serves fresh value", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		ctx := context.Background()
+		var calls atomic.Int64
+		loader := func(_ context.Context) (string, error) {
+			calls.Add(1)
+			return "v1", nil
+		}
+
+		for range 3 {
+			val, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, loader)
+			require.NoError(t, err)
+			require.Equal(t, "v1", val)
+		}
+		require.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("serves stale value and refreshes in background", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", cache.SWREntry[string]{
+			Value:      "old",
+			SoftExpiry: time.Now().Add(-time.Second),
+		}, time.Minute))
+
+		release := make(chan struct{})
+		var calls atomic.Int64
+		loader := func(_ context.Context) (string, error) {
+			calls.Add(1)
+			<-release
+			return "new", nil
+		}
+
+		for range 5 {
+			val, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, loader)
+			require.NoError(t, err)
+			require.Equal(t, "old", val)
+		}
+		close(release)
+
+		require.Eventually(t, func() bool {
+			entry, err := c.Get(ctx, "key")
+			return err == nil && entry.Value == "new"
+		}, time.Second, 5*time.Millisecond)
+		require.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("background refresh outlives request context", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		require.NoError(t, c.Set(context.Background(), "key", cache.SWREntry[string]{
+			Value:      "old",
+			SoftExpiry: time.Now().Add(-time.Second),
+		}, time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		val, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, func(ctx context.Context) (string, error) {
+			cancel()
+			return "new", ctx.Err()
+		})
+		require.NoError(t, err)
+		require.Equal(t, "old", val)
+
+		require.Eventually(t, func() bool {
+			entry, err := c.Get(context.Background(), "key")
+			return err == nil && entry.Value == "new"
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("failed refresh keeps stale value", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", cache.SWREntry[string]{
+			Value:      "old",
+			SoftExpiry: time.Now().Add(-time.Second),
+		}, time.Minute))
+
+		done := make(chan struct{})
+		val, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, func(_ context.Context) (string, error) {
+			defer close(done)
+			return "", errors.New("upstream down")
+		})
+		require.NoError(t, err)
+		require.Equal(t, "old", val)
+
+		<-done
+		entry, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, "old", entry.Value)
+	})
+
+	t.Run("blocks to load after hard expiry", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		ctx := context.Background()
+		_, err := cache.GetOrSetSWR(ctx, c, "key", 10*time.Millisecond, 10*time.Millisecond, func(_ context.Context) (string, error) {
+			return "v1", nil
+		})
+		require.NoError(t, err)
+
+		time.Sleep(30 * time.Millisecond)
+
+		val, err := cache.GetOrSetSWR(ctx, c, "key", 10*time.Millisecond, 10*time.Millisecond, func(_ context.Context) (string, error) {
+			return "v2", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "v2", val)
+	})
+
+	t.Run("returns loader error on miss", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		ctx := context.Background()
+		testErr := errors.New("load failed")
+		_, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, func(_ context.Context) (string, error) {
+			return "", testErr
+		})
+		require.ErrorIs(t, err, testErr)
+
+		_, err = c.Get(ctx, "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("loader panic becomes an error", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[cache.SWREntry[string]]()
+		defer c.Close()
+
+		ctx := context.Background()
+		_, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, func(_ context.Context) (string, error) {
+			panic("boom")
+		})
+		require.ErrorContains(t, err, "boom")
+
+		// A background refresh that panics keeps serving the stale value.
+		require.NoError(t, c.Set(ctx, "key", cache.SWREntry[string]{
+			Value:      "old",
+			SoftExpiry: time.Now().Add(-time.Second),
+		}, time.Minute))
+		done := make(chan struct{})
+		val, err := cache.GetOrSetSWR(ctx, c, "key", time.Minute, time.Minute, func(_ context.Context) (string, error) {
+			defer close(done)
+			panic("boom")
+		})
+		require.NoError(t, err)
+		require.Equal(t, "old", val)
+		<-done
+	})
+
+	t.Run("caches sharing a key do not share loads", func(t *testing.T) {
+		t.Parallel()
+
+		strs := cache.NewMemory[cache.SWREntry[string]]()
+		defer strs.Close()
+		ints := cache.NewMemory[cache.SWREntry[int]]()
+		defer ints.Close()
+
+		ctx := context.Background()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		errc := make(chan error, 1)
+		go func() {
+			_, err := cache.GetOrSetSWR(ctx, strs, "shared-swr-key", time.Minute, time.Minute, func(_ context.Context) (string, error) {
+				close(started)
+				<-release
+				return "s", nil
+			})
+			errc <- err
+		}()
+		<-started
+
+		n, err := cache.GetOrSetSWR(ctx, ints, "shared-swr-key", time.Minute, time.Minute, func(_ context.Context) (int, error) {
+			return 42, nil
+		})
+		close(release)
+		require.NoError(t, err)
+		require.Equal(t, 42, n)
+		require.NoError(t, <-errc)
+	})
+}
+
+// --- GetOrSetMany ---
+
+func TestGetOrSetMany(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loads only missing keys in one call", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "a", "cached-a", time.Minute))
+
+		var calls int
+		got, err := cache.GetOrSetMany(ctx, c, []string{"a", "b", "c", "b"}, func(_ context.Context, missing []string) (map[string]string, time.Duration, error) {
+			calls++
+			require.Equal(t, []string{"b", "c"}, missing)
+			return map[string]string{"b": "loaded-b", "c": "loaded-c"}, time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, calls)
+		require.Equal(t, map[string]string{"a": "cached-a", "b": "loaded-b", "c": "loaded-c"}, got)
+
+		// Loaded values are back-filled.
+		cached, err := c.Get(ctx, "c")
+		require.NoError(t, err)
+		require.Equal(t, "loaded-c", cached)
+	})
+
+	t.Run("skips loader on full hit", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "a", "cached-a", time.Minute))
+
+		got, err := cache.GetOrSetMany(ctx, c, []string{"a"}, func(_ context.Context, _ []string) (map[string]string, time.Duration, error) {
+			t.Fatal("loader should not be called on full hit")
+			return nil, 0, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"a": "cached-a"}, got)
+	})
+
+	t.Run("omits keys the loader did not find", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		got, err := cache.GetOrSetMany(ctx, c, []string{"a", "gone"}, func(_ context.Context, _ []string) (map[string]string, time.Duration, error) {
+			return map[string]string{"a": "loaded-a"}, time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"a": "loaded-a"}, got)
+
+		has, err := c.Has(ctx, "gone")
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("returns loader error and caches nothing", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[string]()
+		defer c.Close()
+
+		ctx := context.Background()
+		testErr := errors.New("query failed")
+		_, err := cache.GetOrSetMany(ctx, c, []string{"a"}, func(_ context.Context, _ []string) (map[string]string, time.Duration, error) {
+			return map[string]string{"a": "partial"}, time.Minute, testErr
+		})
+		require.ErrorIs(t, err, testErr)
+
+		_, err = c.Get(ctx, "a")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("deduplicates keys across concurrent calls", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewMemory[int]()
+		defer c.Close()
+
+		ctx := context.Background()
+		var mu sync.Mutex
+		loads := map[string]int{}
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Go(func() {
+				got, err := cache.GetOrSetMany(ctx, c, []string{"many:x", "many:y"}, func(_ context.Context, missing []string) (map[string]int, time.Duration, error) {
+					mu.Lock()
+					for _, k := range missing {
+						loads[k]++
+					}
+					mu.Unlock()
+					time.Sleep(10 * time.Millisecond) // Simulate slow query.
+					out := make(map[string]int, len(missing))
+					for _, k := range missing {
+						out[k] = len(k)
+					}
+					return out, time.Minute, nil
+				})
+				require.NoError(t, err)
+				require.Equal(t, map[string]int{"many:x": 6, "many:y": 6}, got)
+			})
+		}
+		wg.Wait()
+
+		// As with GetOrSet, a call that arrives after the first load finished
+		// but before its back-fill is visible may load again.
+		require.LessOrEqual(t, loads["many:x"], 2)
+		require.LessOrEqual(t, loads["many:y"], 2)
+	})
+}
+
 // --- JSON Marshaler ---
 
 func TestJsonMarshaler(t *testing.T) {
@@ -711,3 +1451,69 @@ func TestMemoryOptions(t *testing.T) {
 		require.Equal(t, "value", val)
 	})
 }
+
+// --- Noop ---
+
+func TestNoop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("always misses", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewNoop[string]()
+		ctx := context.Background()
+
+		require.NoError(t, c.Set(ctx, "key", "value", time.Minute))
+
+		val, err := c.Get(ctx, "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+		require.Empty(t, val)
+
+		has, err := c.Has(ctx, "key")
+		require.NoError(t, err)
+		require.False(t, has)
+
+		ok, err := c.SetIfAbsent(ctx, "key", "value", time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = c.CompareAndSwap(ctx, "key", "", "value", time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		_, err = c.TTL(ctx, "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("delete clear and close succeed", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewNoop[int]()
+		ctx := context.Background()
+
+		require.NoError(t, c.Delete(ctx, "key"))
+		require.NoError(t, c.Clear(ctx))
+		require.NoError(t, c.Close())
+	})
+
+	t.Run("GetOrSet always computes", func(t *testing.T) {
+		t.Parallel()
+
+		c := cache.NewNoop[int]()
+		ctx := context.Background()
+
+		calls := 0
+		fn := func(context.Context) (int, time.Duration, error) {
+			calls++
+			return calls, time.Minute, nil
+		}
+
+		v, err := cache.GetOrSet(ctx, c, "key", fn)
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+
+		v, err = cache.GetOrSet(ctx, c, "key", fn)
+		require.NoError(t, err)
+		require.Equal(t, 2, v)
+	})
+}
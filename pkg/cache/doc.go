@@ -9,8 +9,11 @@
 //
 //   - Get(ctx, key) (V, error) — retrieve a value
 //   - Set(ctx, key, value, ttl) error — store a value with TTL
+//   - SetIfAbsent(ctx, key, value, ttl) (bool, error) — store only if missing
+//   - CompareAndSwap(ctx, key, old, new, ttl) (bool, error) — replace only if unchanged
 //   - Delete(ctx, key) error — remove a key
 //   - Has(ctx, key) (bool, error) — check existence
+//   - TTL(ctx, key) (time.Duration, error) — remaining lifetime, 0 if it never expires
 //   - Clear(ctx) error — remove all entries
 //   - Close() error — release resources
 //
@@ -19,6 +22,15 @@
 //   - Zero: use the cache's configured default TTL (1 hour by default)
 //   - Negative: item never expires
 //
+// TTL reports how long an entry has left, for refreshing it before it
+// expires or for inspecting a cache from an admin page. Keys that never
+// expire report 0; missing and expired keys return ErrNotFound:
+//
+//	ttl, err := c.TTL(ctx, "user:42")
+//	if err == nil && ttl > 0 && ttl < time.Minute {
+//	    go refreshUser(context.WithoutCancel(ctx), 42)
+//	}
+//
 // # In-Memory Cache
 //
 // Use [NewMemory] for single-process applications or testing.
@@ -35,6 +47,17 @@
 //	c.Set(ctx, "greeting", "hello", 0)   // uses default TTL
 //	val, err := c.Get(ctx, "greeting")   // val = "hello"
 //
+// At capacity the least recently used entry is evicted. When a few hot keys
+// share the cache with many one-shot keys, LRU lets the one-shots push the
+// hot keys out; [PolicyLFU] evicts the least frequently used entry instead.
+// Access counts are halved periodically, so keys that stop being read lose
+// their standing:
+//
+//	c := cache.NewMemory[[]byte](
+//	    cache.WithMaxEntries(10000),
+//	    cache.WithEvictionPolicy(cache.PolicyLFU),
+//	)
+//
 // # Eviction Callbacks
 //
 // The in-memory cache supports eviction callbacks for resource cleanup:
@@ -46,7 +69,7 @@
 //	    conn.Close()
 //	})
 //
-// The callback is triggered on LRU eviction, TTL expiration cleanup,
+// The callback is triggered on LRU or LFU eviction, TTL expiration cleanup,
 // manual deletion, and clearing.
 //
 // # Redis Cache
@@ -68,6 +91,13 @@
 // a different serialization format (msgpack, protobuf, etc.).
 // If nil, JSON is used.
 //
+// # Disabled Cache
+//
+// Use [NewNoop] to disable caching without changing call sites. Every Get
+// misses and writes are discarded, so [GetOrSet] always computes:
+//
+//	var c cache.Cache[User] = cache.NewNoop[User]()
+//
 // # Cache Stampede Prevention
 //
 // Use the standalone [GetOrSet] function to prevent cache stampedes.
@@ -78,6 +108,69 @@
 //	    return user, 5 * time.Minute, err
 //	})
 //
+// [GetOrSetMany] does the same for a batch of keys. The loader is called once
+// with only the keys missing from the cache, so a cold list view makes one
+// query instead of one per item. Keys already being loaded by a concurrent
+// call are awaited rather than loaded again:
+//
+//	users, err := cache.GetOrSetMany(ctx, c, ids, func(ctx context.Context, missing []string) (map[string]User, time.Duration, error) {
+//	    found, err := repo.FindUsers(ctx, missing)
+//	    return found, 5 * time.Minute, err
+//	})
+//
+// Keys the loader does not return are left out of the result and not cached.
+//
+// Keys written together, such as during a bulk cache warm, also expire
+// together. [WithTTLJitter] and [WithRedisTTLJitter] randomize each entry's
+// TTL at Set time, including TTLs returned to [GetOrSet], to spread refreshes:
+//
+//	c := cache.NewMemory[User](cache.WithTTLJitter(0.1)) // TTL ±10%
+//
+// Entries that never expire are unaffected, and jitter never makes a TTL
+// non-positive.
+//
+// # Conditional Writes
+//
+// [Cache.SetIfAbsent] stores a value only if the key is missing (SET NX on
+// Redis), and [Cache.CompareAndSwap] replaces a value only if it still equals
+// the one read earlier (a Lua script on Redis). Both report whether they
+// wrote. [GetOrSet] and [GetOrSetMany] back-fill with SetIfAbsent, so a
+// value another writer stored during the load is not replaced by an older
+// one; an entry that fails to unmarshal is overwritten instead. Use
+// CompareAndSwap for read-modify-write updates:
+//
+//	for {
+//	    cart, err := c.Get(ctx, key)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    ok, err := c.CompareAndSwap(ctx, key, cart, cart.With(item), time.Hour)
+//	    if err != nil || ok {
+//	        return err
+//	    }
+//	}
+//
+// Values are compared by their serialized form: JSON for the in-memory
+// cache, the configured [Marshaler] for Redis.
+//
+// # Stale-While-Revalidate
+//
+// [GetOrSetSWR] keeps hot keys off the loader's critical path. A value is
+// served as-is while fresh, served stale while a single background refresh
+// replaces it, and loaded synchronously only once fully expired. The cache
+// stores [SWREntry] envelopes carrying the soft expiry next to the value:
+//
+//	c := cache.NewMemory[cache.SWREntry[Stats]]()
+//
+//	stats, err := cache.GetOrSetSWR(ctx, c, "stats", time.Minute, 10*time.Minute, func(ctx context.Context) (Stats, error) {
+//	    return repo.LoadStats(ctx)
+//	})
+//
+// The tradeoff is consistency: for up to fresh+stale after a write to the
+// source, readers may see the old value, and with Redis each process
+// refreshes independently. Use it for data where bounded staleness is
+// acceptable, and [Cache.Delete] the key when a change must show at once.
+//
 // # Error Handling
 //
 // The package defines sentinel errors:
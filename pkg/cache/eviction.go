@@ -0,0 +1,193 @@
+package cache
+
+import "container/list"
+
+// EvictionPolicy selects which entry the memory cache evicts when it
+// reaches its maximum entry count.
+type EvictionPolicy int
+
+const (
+	// PolicyLRU evicts the least recently used entry. It is the default.
+	PolicyLRU EvictionPolicy = iota
+
+	// PolicyLFU evicts the least frequently used entry, breaking ties by
+	// recency. Access counts are halved periodically, so keys that were hot
+	// long ago do not stay pinned forever.
+	PolicyLFU
+)
+
+// lfuAgingFactor controls how often LFU counts decay: every time the cache
+// has seen lfuAgingFactor accesses per stored entry, all counts are halved.
+const lfuAgingFactor = 10
+
+// lfuMinAgingPeriod keeps tiny caches from aging on almost every access.
+const lfuMinAgingPeriod = 64
+
+// evictionPolicy orders entries for eviction. Implementations are not
+// safe for concurrent use; Memory calls them with its mutex held.
+type evictionPolicy[V any] interface {
+	// add starts tracking a new entry.
+	add(e *entry[V])
+	// touch records an access to a tracked entry.
+	touch(e *entry[V])
+	// remove stops tracking an entry.
+	remove(e *entry[V])
+	// victim returns the entry to evict next, or nil if none is tracked.
+	victim() *entry[V]
+	// reset drops every tracked entry.
+	reset()
+}
+
+func newEvictionPolicy[V any](p EvictionPolicy) evictionPolicy[V] {
+	if p == PolicyLFU {
+		return newLFU[V]()
+	}
+	return newLRU[V]()
+}
+
+// lru keeps entries in a doubly-linked list with the most recently used at
+// the front, for O(1) touch and eviction from the back.
+type lru[V any] struct {
+	order *list.List
+}
+
+func newLRU[V any]() *lru[V] {
+	return &lru[V]{order: list.New()}
+}
+
+func (p *lru[V]) add(e *entry[V]) {
+	e.elem = p.order.PushFront(e)
+}
+
+func (p *lru[V]) touch(e *entry[V]) {
+	p.order.MoveToFront(e.elem)
+}
+
+func (p *lru[V]) remove(e *entry[V]) {
+	p.order.Remove(e.elem)
+	e.elem = nil
+}
+
+func (p *lru[V]) victim() *entry[V] {
+	if back := p.order.Back(); back != nil {
+		return back.Value.(*entry[V])
+	}
+	return nil
+}
+
+func (p *lru[V]) reset() {
+	p.order.Init()
+}
+
+// lfuBucket holds the entries sharing one access count, most recently
+// used at the front.
+type lfuBucket struct {
+	entries *list.List
+	freq    uint32
+}
+
+// lfu implements O(1) LFU with frequency buckets: buckets is ordered by
+// ascending count, so the victim is the back entry of the front bucket.
+// Each entry points at its bucket (e.bucket) and at its element within
+// that bucket's list (e.elem).
+type lfu[V any] struct {
+	buckets *list.List
+	// accesses counts adds and touches since counts were last halved.
+	accesses int
+	size     int
+}
+
+func newLFU[V any]() *lfu[V] {
+	return &lfu[V]{buckets: list.New()}
+}
+
+func (p *lfu[V]) add(e *entry[V]) {
+	p.size++
+	front := p.buckets.Front()
+	if front == nil || front.Value.(*lfuBucket).freq != 1 {
+		front = p.buckets.PushFront(&lfuBucket{freq: 1, entries: list.New()})
+	}
+	p.place(e, front)
+	p.tick()
+}
+
+func (p *lfu[V]) touch(e *entry[V]) {
+	cur := e.bucket
+	b := cur.Value.(*lfuBucket)
+	freq := b.freq + 1
+
+	next := cur.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != freq {
+		next = p.buckets.InsertAfter(&lfuBucket{freq: freq, entries: list.New()}, cur)
+	}
+	p.unplace(e)
+	p.place(e, next)
+	p.tick()
+}
+
+func (p *lfu[V]) remove(e *entry[V]) {
+	p.unplace(e)
+	p.size--
+}
+
+func (p *lfu[V]) victim() *entry[V] {
+	front := p.buckets.Front()
+	if front == nil {
+		return nil
+	}
+	return front.Value.(*lfuBucket).entries.Back().Value.(*entry[V])
+}
+
+func (p *lfu[V]) reset() {
+	p.buckets.Init()
+	p.accesses = 0
+	p.size = 0
+}
+
+// place puts e at the front of bucket.
+func (p *lfu[V]) place(e *entry[V], bucket *list.Element) {
+	e.bucket = bucket
+	e.elem = bucket.Value.(*lfuBucket).entries.PushFront(e)
+}
+
+// unplace takes e out of its bucket, dropping the bucket once empty.
+func (p *lfu[V]) unplace(e *entry[V]) {
+	b := e.bucket.Value.(*lfuBucket)
+	b.entries.Remove(e.elem)
+	if b.entries.Len() == 0 {
+		p.buckets.Remove(e.bucket)
+	}
+	e.bucket, e.elem = nil, nil
+}
+
+// tick counts an access and halves every count once the aging period has
+// passed. The period grows with the cache, keeping aging amortized O(1).
+func (p *lfu[V]) tick() {
+	p.accesses++
+	if p.accesses < max(p.size*lfuAgingFactor, lfuMinAgingPeriod) {
+		return
+	}
+	p.accesses = 0
+	p.age()
+}
+
+// age halves every bucket's count, merging buckets whose counts become
+// equal. Buckets are visited from least to most used, and entries of a more
+// used bucket go in front of the ones already merged, so within a merged
+// bucket the formerly less used entries are still evicted first.
+func (p *lfu[V]) age() {
+	aged := list.New()
+	for el := p.buckets.Front(); el != nil; el = el.Next() {
+		b := el.Value.(*lfuBucket)
+		freq := max(b.freq/2, 1)
+
+		target := aged.Back()
+		if target == nil || target.Value.(*lfuBucket).freq != freq {
+			target = aged.PushBack(&lfuBucket{freq: freq, entries: list.New()})
+		}
+		for en := b.entries.Back(); en != nil; en = en.Prev() {
+			p.place(en.Value.(*entry[V]), target)
+		}
+	}
+	p.buckets = aged
+}
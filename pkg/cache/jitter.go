@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// jitterTTL randomizes ttl by up to ±fraction of its length. Non-positive
+// TTLs are returned unchanged, and the result is never below one nanosecond,
+// so jitter cannot turn an expiring entry into one that never expires.
+func jitterTTL(ttl time.Duration, fraction float64) time.Duration {
+	if ttl <= 0 || fraction <= 0 {
+		return ttl
+	}
+	delta := (rand.Float64()*2 - 1) * fraction * float64(ttl)
+	return max(ttl+time.Duration(delta), 1)
+}
+
+// clampJitter limits a jitter fraction to [0, 1].
+func clampJitter(fraction float64) float64 {
+	return min(max(fraction, 0), 1)
+}
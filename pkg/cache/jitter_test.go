@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitterTTL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stays within fraction", func(t *testing.T) {
+		t.Parallel()
+
+		ttl := time.Minute
+		for range 1000 {
+			got := jitterTTL(ttl, 0.1)
+			require.GreaterOrEqual(t, got, 54*time.Second)
+			require.LessOrEqual(t, got, 66*time.Second)
+		}
+	})
+
+	t.Run("spreads values", func(t *testing.T) {
+		t.Parallel()
+
+		seen := make(map[time.Duration]struct{})
+		for range 100 {
+			seen[jitterTTL(time.Hour, 0.1)] = struct{}{}
+		}
+		require.Greater(t, len(seen), 1)
+	})
+
+	t.Run("leaves non-positive TTLs and zero fraction unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, time.Duration(-1), jitterTTL(-1, 0.5))
+		require.Equal(t, time.Duration(0), jitterTTL(0, 0.5))
+		require.Equal(t, time.Minute, jitterTTL(time.Minute, 0))
+	})
+
+	t.Run("never returns a non-positive TTL", func(t *testing.T) {
+		t.Parallel()
+
+		for range 1000 {
+			require.Positive(t, jitterTTL(time.Nanosecond, 1))
+		}
+	})
+}
+
+func TestWithTTLJitterClampsFraction(t *testing.T) {
+	t.Parallel()
+
+	o := defaultMemoryOptions()
+	WithTTLJitter(2)(o)
+	require.Equal(t, 1.0, o.ttlJitter)
+
+	r := defaultRedisOptions()
+	WithRedisTTLJitter(-0.5)(r)
+	require.Zero(t, r.ttlJitter)
+}
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// batchFlights tracks keys being loaded by GetOrSetMany so concurrent batches
+// that overlap wait for the in-flight load instead of loading a key twice.
+// It plays the role sfGroup plays for GetOrSet, but lets a caller claim many
+// keys at once and learn which ones it now owns.
+var batchFlights = struct {
+	m  map[string]*batchFlight
+	mu sync.Mutex
+}{m: make(map[string]*batchFlight)}
+
+// batchFlight is one key's pending load. val, found, and err are written
+// before done is closed and are read-only afterwards.
+type batchFlight struct {
+	val   any
+	err   error
+	done  chan struct{}
+	found bool
+}
+
+// GetOrSetMany retrieves values for keys, calling loader once with only the
+// keys missing from the cache. It is the batch counterpart of GetOrSet for
+// list views that would otherwise make one load per key.
+//
+// The loader returns the values it found, a TTL for caching them, and an
+// error. Keys absent from the loader's map are not cached and are omitted
+// from the result. Loaded values are written back with SetIfAbsent, so a
+// configured TTL jitter applies and values stored meanwhile by other writers
+// are kept. Keys whose Get failed with an error other than ErrNotFound, such
+// as entries that no longer unmarshal, are written with Set to replace them.
+//
+// Each key is loaded at most once across concurrent callers: keys another
+// GetOrSetMany call is already loading are awaited rather than passed to
+// loader. If the loader fails, its error is returned to every caller waiting
+// on those keys and nothing is cached.
+func GetOrSetMany[V any](ctx context.Context, c Cache[V], keys []string, loader func(ctx context.Context, missing []string) (map[string]V, time.Duration, error)) (map[string]V, error) {
+	result := make(map[string]V, len(keys))
+
+	// Fast path: collect hits, deduplicating keys.
+	var missing []string
+	seen := make(map[string]struct{}, len(keys))
+	unreadable := make(map[string]bool)
+	for _, key := range keys {
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		v, err := c.Get(ctx, key)
+		if err == nil {
+			result[key] = v
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) {
+			unreadable[key] = true
+		}
+		missing = append(missing, key)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	// Claim keys nobody is loading; wait on the rest.
+	var ownedKeys []string
+	owned := make(map[string]*batchFlight, len(missing))
+	waiting := make(map[string]*batchFlight)
+	batchFlights.mu.Lock()
+	for _, key := range missing {
+		if f, ok := batchFlights.m[key]; ok {
+			waiting[key] = f
+			continue
+		}
+		f := &batchFlight{done: make(chan struct{})}
+		batchFlights.m[key] = f
+		owned[key] = f
+		ownedKeys = append(ownedKeys, key)
+	}
+	batchFlights.mu.Unlock()
+
+	if len(ownedKeys) > 0 {
+		if err := loadOwned(ctx, c, ownedKeys, owned, unreadable, loader, result); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, f := range waiting {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if f.err != nil {
+			return nil, f.err
+		}
+		if v, ok := f.val.(V); ok && f.found {
+			result[key] = v
+		}
+	}
+
+	return result, nil
+}
+
+// loadOwned calls loader for the owned keys, in request order, back-fills the
+// cache, and resolves the flights so waiting callers can proceed. Unreadable
+// keys are overwritten rather than set only if absent.
+func loadOwned[V any](ctx context.Context, c Cache[V], keys []string, owned map[string]*batchFlight, unreadable map[string]bool, loader func(ctx context.Context, missing []string) (map[string]V, time.Duration, error), result map[string]V) (err error) {
+	var loaded map[string]V
+	var ttl time.Duration
+
+	// Resolve flights even if loader panics, so waiters are never stuck;
+	// they see the keys as not found.
+	defer func() {
+		batchFlights.mu.Lock()
+		for key := range owned {
+			delete(batchFlights.m, key)
+		}
+		batchFlights.mu.Unlock()
+
+		for key, f := range owned {
+			if err != nil {
+				f.err = err
+			} else if v, ok := loaded[key]; ok {
+				f.val, f.found = v, true
+			}
+			close(f.done)
+		}
+	}()
+
+	loaded, ttl, err = loader(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	for key := range owned {
+		v, ok := loaded[key]
+		if !ok {
+			continue
+		}
+		// Best-effort cache the result.
+		if unreadable[key] {
+			_ = c.Set(ctx, key, v, ttl)
+		} else {
+			_, _ = c.SetIfAbsent(ctx, key, v, ttl)
+		}
+		result[key] = v
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"bytes"
 	"container/list"
 	"context"
 	"sync"
@@ -8,9 +9,12 @@ import (
 )
 
 // entry holds a cached value with its expiration time and key.
+// elem and bucket are owned by the eviction policy.
 type entry[V any] struct {
 	expiresAt time.Time // zero value = never expires
 	value     V
+	elem      *list.Element
+	bucket    *list.Element // LFU only: the entry's frequency bucket
 	key       string
 }
 
@@ -23,14 +27,14 @@ func (e *entry[V]) isExpired() bool {
 }
 
 // Memory is an in-memory cache with TTL-based expiration and optional
-// LRU eviction when a maximum entry count is configured.
+// LRU or LFU eviction when a maximum entry count is configured.
 //
-// It uses a hash map for O(1) lookups and a doubly-linked list for O(1)
-// LRU eviction ordering. The most recently accessed items are at the
-// front of the list; the least recently used are at the back.
+// It uses a hash map for O(1) lookups. LRU keeps a doubly-linked list with
+// the most recently accessed items at the front; LFU keeps frequency
+// buckets. Both evict in O(1).
 type Memory[V any] struct {
-	items    map[string]*list.Element
-	eviction *list.List
+	items    map[string]*entry[V]
+	eviction evictionPolicy[V]
 	opts     *memoryOptions
 	onEvict  func(key string, value V)
 	done     chan struct{}
@@ -55,8 +59,8 @@ func NewMemory[V any](opts ...MemoryOption) *Memory[V] {
 	}
 
 	m := &Memory[V]{
-		items:    make(map[string]*list.Element),
-		eviction: list.New(),
+		items:    make(map[string]*entry[V]),
+		eviction: newEvictionPolicy[V](o.evictionPolicy),
 		opts:     o,
 		done:     make(chan struct{}),
 	}
@@ -69,7 +73,7 @@ func NewMemory[V any](opts ...MemoryOption) *Memory[V] {
 }
 
 // SetEvictCallback sets a callback function that is called when items
-// are evicted from the cache. This includes LRU eviction, TTL expiration
+// are evicted from the cache. This includes LRU or LFU eviction, TTL expiration
 // cleanup, manual deletion, and clearing.
 func (m *Memory[V]) SetEvictCallback(fn func(key string, value V)) {
 	m.mu.Lock()
@@ -79,27 +83,18 @@ func (m *Memory[V]) SetEvictCallback(fn func(key string, value V)) {
 
 // Get retrieves a value by key.
 // Returns ErrNotFound if the key does not exist or has expired.
-// Accessing a key marks it as recently used for LRU purposes.
+// Accessing a key counts as a use for eviction purposes.
 func (m *Memory[V]) Get(_ context.Context, key string) (V, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	elem, ok := m.items[key]
+	e, ok := m.live(key)
 	if !ok {
 		var zero V
 		return zero, ErrNotFound
 	}
 
-	e := elem.Value.(*entry[V])
-
-	if e.isExpired() {
-		m.removeElement(elem)
-		var zero V
-		return zero, ErrNotFound
-	}
-
-	// Move to front: mark as recently used.
-	m.eviction.MoveToFront(elem)
+	m.eviction.touch(e)
 
 	return e.value, nil
 }
@@ -115,10 +110,85 @@ func (m *Memory[V]) Set(_ context.Context, key string, value V, ttl time.Duratio
 		return ErrClosed
 	}
 
+	m.set(key, value, ttl)
+	return nil
+}
+
+// SetIfAbsent stores a value only if the key does not exist or has expired,
+// and reports whether it was stored.
+func (m *Memory[V]) SetIfAbsent(_ context.Context, key string, value V, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return false, ErrClosed
+	}
+
+	if _, ok := m.live(key); ok {
+		return false, nil
+	}
+
+	m.set(key, value, ttl)
+	return true, nil
+}
+
+// CompareAndSwap replaces the value of key with newValue only if the current
+// value has the same JSON encoding as oldValue, and reports whether it was
+// replaced.
+func (m *Memory[V]) CompareAndSwap(_ context.Context, key string, oldValue, newValue V, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return false, ErrClosed
+	}
+
+	e, ok := m.live(key)
+	if !ok {
+		return false, nil
+	}
+
+	var jm jsonMarshaler[V]
+	current, err := jm.Marshal(e.value)
+	if err != nil {
+		return false, err
+	}
+	expected, err := jm.Marshal(oldValue)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(current, expected) {
+		return false, nil
+	}
+
+	m.set(key, newValue, ttl)
+	return true, nil
+}
+
+// live returns the unexpired entry for key, removing it if it has expired.
+// Caller must hold the mutex.
+func (m *Memory[V]) live(key string) (*entry[V], bool) {
+	e, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if e.isExpired() {
+		m.removeEntry(e)
+		return nil, false
+	}
+
+	return e, true
+}
+
+// set stores a value, resolving the TTL and evicting an entry if the
+// cache is full. Caller must hold the mutex.
+func (m *Memory[V]) set(key string, value V, ttl time.Duration) {
 	// Resolve TTL.
 	if ttl == 0 {
 		ttl = m.opts.defaultTTL
 	}
+	ttl = jitterTTL(ttl, m.opts.ttlJitter)
 
 	var expiresAt time.Time
 	if ttl > 0 {
@@ -127,25 +197,21 @@ func (m *Memory[V]) Set(_ context.Context, key string, value V, ttl time.Duratio
 	// ttl < 0: expiresAt stays zero (never expires)
 
 	// Update existing entry.
-	if elem, ok := m.items[key]; ok {
-		e := elem.Value.(*entry[V])
+	if e, ok := m.items[key]; ok {
 		e.value = value
 		e.expiresAt = expiresAt
-		m.eviction.MoveToFront(elem)
-		return nil
+		m.eviction.touch(e)
+		return
 	}
 
-	// Evict LRU entry if at capacity.
+	// Evict an entry if at capacity.
 	if m.opts.maxEntries > 0 && len(m.items) >= m.opts.maxEntries {
-		m.evictOldest()
+		m.evictOne()
 	}
 
-	// Insert new entry at front.
 	e := &entry[V]{key: key, value: value, expiresAt: expiresAt}
-	elem := m.eviction.PushFront(e)
-	m.items[key] = elem
-
-	return nil
+	m.eviction.add(e)
+	m.items[key] = e
 }
 
 // Delete removes a key from the cache.
@@ -157,8 +223,8 @@ func (m *Memory[V]) Delete(_ context.Context, key string) error {
 		return ErrClosed
 	}
 
-	if elem, ok := m.items[key]; ok {
-		m.removeElement(elem)
+	if e, ok := m.items[key]; ok {
+		m.removeEntry(e)
 	}
 
 	return nil
@@ -169,18 +235,32 @@ func (m *Memory[V]) Has(_ context.Context, key string) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	elem, ok := m.items[key]
+	_, ok := m.live(key)
+	return ok, nil
+}
+
+// TTL returns the remaining time-to-live of key, computed from its stored
+// expiry, or 0 if it never expires. Returns ErrNotFound if the key does not
+// exist or has expired. It does not count as a use for eviction.
+func (m *Memory[V]) TTL(_ context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.live(key)
 	if !ok {
-		return false, nil
+		return 0, ErrNotFound
 	}
-
-	e := elem.Value.(*entry[V])
-	if e.isExpired() {
-		m.removeElement(elem)
-		return false, nil
+	if e.expiresAt.IsZero() {
+		return 0, nil
 	}
 
-	return true, nil
+	// An entry expiring this instant counts as expired.
+	remaining := time.Until(e.expiresAt)
+	if remaining <= 0 {
+		m.removeEntry(e)
+		return 0, ErrNotFound
+	}
+	return remaining, nil
 }
 
 // Clear removes all entries from the cache.
@@ -193,14 +273,13 @@ func (m *Memory[V]) Clear(_ context.Context) error {
 	}
 
 	if m.onEvict != nil {
-		for _, elem := range m.items {
-			e := elem.Value.(*entry[V])
+		for _, e := range m.items {
 			m.onEvict(e.key, e.value)
 		}
 	}
 
-	m.items = make(map[string]*list.Element)
-	m.eviction.Init()
+	m.items = make(map[string]*entry[V])
+	m.eviction.reset()
 
 	return nil
 }
@@ -236,36 +315,31 @@ func (m *Memory[V]) janitor() {
 	}
 }
 
-// deleteExpired removes all expired entries from back to front.
+// deleteExpired removes all expired entries.
 func (m *Memory[V]) deleteExpired() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	now := time.Now()
-	for elem := m.eviction.Back(); elem != nil; {
-		e := elem.Value.(*entry[V])
-		prev := elem.Prev()
+	for _, e := range m.items {
 		if !e.expiresAt.IsZero() && now.After(e.expiresAt) {
-			m.removeElement(elem)
+			m.removeEntry(e)
 		}
-		elem = prev
 	}
 }
 
-// evictOldest removes the least recently used entry.
+// evictOne removes the entry chosen by the eviction policy.
 // Caller must hold the mutex.
-func (m *Memory[V]) evictOldest() {
-	elem := m.eviction.Back()
-	if elem != nil {
-		m.removeElement(elem)
+func (m *Memory[V]) evictOne() {
+	if e := m.eviction.victim(); e != nil {
+		m.removeEntry(e)
 	}
 }
 
-// removeElement removes a specific element and triggers the eviction callback.
+// removeEntry removes a specific entry and triggers the eviction callback.
 // Caller must hold the mutex.
-func (m *Memory[V]) removeElement(elem *list.Element) {
-	m.eviction.Remove(elem)
-	e := elem.Value.(*entry[V])
+func (m *Memory[V]) removeEntry(e *entry[V]) {
+	m.eviction.remove(e)
 	delete(m.items, e.key)
 
 	if m.onEvict != nil {
@@ -9,6 +9,8 @@ type memoryOptions struct {
 	defaultTTL      time.Duration
 	cleanupInterval time.Duration
 	maxEntries      int
+	ttlJitter       float64
+	evictionPolicy  EvictionPolicy
 }
 
 func defaultMemoryOptions() *memoryOptions {
@@ -37,8 +39,21 @@ func WithCleanupInterval(d time.Duration) MemoryOption {
 	}
 }
 
+// WithTTLJitter randomizes each entry's effective TTL by up to ±fraction
+// (0.1 = ±10%) at Set time, so entries written together, such as during a
+// bulk cache warm, do not all expire at once. The fraction is clamped to [0, 1].
+// Entries that never expire are unaffected, and jitter never makes a TTL
+// non-positive.
+// Default: 0 (no jitter).
+func WithTTLJitter(fraction float64) MemoryOption {
+	return func(o *memoryOptions) {
+		o.ttlJitter = clampJitter(fraction)
+	}
+}
+
 // WithMaxEntries sets the maximum number of entries in the cache.
-// When the limit is reached, the least recently used entry is evicted.
+// When the limit is reached, an entry is evicted according to the
+// eviction policy (least recently used by default).
 // Zero means unlimited.
 // Default: 0 (unlimited).
 func WithMaxEntries(n int) MemoryOption {
@@ -46,3 +61,13 @@ func WithMaxEntries(n int) MemoryOption {
 		o.maxEntries = n
 	}
 }
+
+// WithEvictionPolicy selects the entry evicted when WithMaxEntries is
+// reached. Use PolicyLFU when a small set of hot keys shares the cache with
+// many one-shot keys, which would push the hot keys out under LRU.
+// Default: PolicyLRU.
+func WithEvictionPolicy(p EvictionPolicy) MemoryOption {
+	return func(o *memoryOptions) {
+		o.evictionPolicy = p
+	}
+}
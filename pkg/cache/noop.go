@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Noop is a cache that stores nothing. Every Get misses, so [GetOrSet]
+// always computes the value. Use it to disable caching in tests or behind
+// a feature flag without changing call sites.
+type Noop[V any] struct{}
+
+// NewNoop creates a cache that always misses.
+//
+// Example:
+//
+//	var c cache.Cache[User] = cache.NewNoop[User]()
+//	if cfg.CacheEnabled {
+//	    c = cache.NewMemory[User]()
+//	}
+func NewNoop[V any]() *Noop[V] {
+	return &Noop[V]{}
+}
+
+// Get always returns ErrNotFound.
+func (n *Noop[V]) Get(_ context.Context, _ string) (V, error) {
+	var zero V
+	return zero, ErrNotFound
+}
+
+// Set discards the value.
+func (n *Noop[V]) Set(_ context.Context, _ string, _ V, _ time.Duration) error {
+	return nil
+}
+
+// SetIfAbsent discards the value and reports false.
+func (n *Noop[V]) SetIfAbsent(_ context.Context, _ string, _ V, _ time.Duration) (bool, error) {
+	return false, nil
+}
+
+// CompareAndSwap reports false, as no key ever exists.
+func (n *Noop[V]) CompareAndSwap(_ context.Context, _ string, _, _ V, _ time.Duration) (bool, error) {
+	return false, nil
+}
+
+// Delete is a no-op.
+func (n *Noop[V]) Delete(_ context.Context, _ string) error {
+	return nil
+}
+
+// Has always returns false.
+func (n *Noop[V]) Has(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+// TTL always returns ErrNotFound.
+func (n *Noop[V]) TTL(_ context.Context, _ string) (time.Duration, error) {
+	return 0, ErrNotFound
+}
+
+// Clear is a no-op.
+func (n *Noop[V]) Clear(_ context.Context) error {
+	return nil
+}
+
+// Close is a no-op.
+func (n *Noop[V]) Close() error {
+	return nil
+}
+
+var _ Cache[any] = (*Noop[any])(nil)
@@ -76,16 +76,57 @@ func (r *Redis[V]) Set(ctx context.Context, key string, value V, ttl time.Durati
 		return err
 	}
 
-	// Resolve TTL.
-	if ttl == 0 {
-		ttl = r.opts.defaultTTL
+	return r.client.Set(ctx, r.prefixedKey(key), data, r.resolveTTL(ttl)).Err()
+}
+
+// SetIfAbsent stores a value only if the key does not exist, using SET NX,
+// and reports whether it was stored.
+func (r *Redis[V]) SetIfAbsent(ctx context.Context, key string, value V, ttl time.Duration) (bool, error) {
+	data, err := r.marshaler.Marshal(value)
+	if err != nil {
+		return false, err
 	}
 
-	// Redis interprets 0 as no expiration.
-	// For negative TTL (our "never expires" semantic), pass 0 to Redis.
-	redisTTL := max(ttl, 0)
+	return r.client.SetNX(ctx, r.prefixedKey(key), data, r.resolveTTL(ttl)).Result()
+}
+
+// casScript sets KEYS[1] to ARGV[2] if its current value is ARGV[1].
+// ARGV[3] is the TTL in milliseconds; 0 means no expiration.
+var casScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// CompareAndSwap replaces the value of key with newValue only if the stored
+// bytes equal the marshaled oldValue, and reports whether it was replaced.
+// The check and write run atomically in a Lua script.
+func (r *Redis[V]) CompareAndSwap(ctx context.Context, key string, oldValue, newValue V, ttl time.Duration) (bool, error) {
+	expected, err := r.marshaler.Marshal(oldValue)
+	if err != nil {
+		return false, err
+	}
+	data, err := r.marshaler.Marshal(newValue)
+	if err != nil {
+		return false, err
+	}
+
+	var ttlMs int64
+	if ttl := r.resolveTTL(ttl); ttl > 0 {
+		ttlMs = max(ttl.Milliseconds(), 1)
+	}
 
-	return r.client.Set(ctx, r.prefixedKey(key), data, redisTTL).Err()
+	n, err := casScript.Run(ctx, r.client, []string{r.prefixedKey(key)}, expected, data, ttlMs).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
 }
 
 // Delete removes a key from Redis.
@@ -102,6 +143,24 @@ func (r *Redis[V]) Has(ctx context.Context, key string) (bool, error) {
 	return n > 0, nil
 }
 
+// TTL returns the remaining time-to-live of key using PTTL, or 0 if it has
+// no expiration. Returns ErrNotFound if the key does not exist.
+func (r *Redis[V]) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, r.prefixedKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// PTTL reports -2 for a missing key and -1 for a key without expiration.
+	switch {
+	case ttl == -2:
+		return 0, ErrNotFound
+	case ttl < 0:
+		return 0, nil
+	}
+	return ttl, nil
+}
+
 // Clear removes all cache entries.
 // If a prefix is configured, only keys matching the prefix are removed using SCAN.
 // If no prefix is configured, FLUSHDB is used.
@@ -118,6 +177,18 @@ func (r *Redis[V]) Close() error {
 	return nil
 }
 
+// resolveTTL applies the default TTL and jitter and converts the result to
+// Redis semantics, where 0 means no expiration.
+func (r *Redis[V]) resolveTTL(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		ttl = r.opts.defaultTTL
+	}
+	ttl = jitterTTL(ttl, r.opts.ttlJitter)
+
+	// For negative TTL (our "never expires" semantic), pass 0 to Redis.
+	return max(ttl, 0)
+}
+
 // prefixedKey returns the full Redis key with prefix.
 func (r *Redis[V]) prefixedKey(key string) string {
 	if r.opts.prefix == "" {
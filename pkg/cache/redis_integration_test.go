@@ -239,6 +239,163 @@ func TestRedis_Has(t *testing.T) {
 	})
 }
 
+// --- Redis: TTL ---
+
+func TestRedis_TTL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns remaining time", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[string](client, nil, cache.WithPrefix("test-ttl"))
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "value", time.Minute))
+
+		ttl, err := c.TTL(ctx, "key")
+		require.NoError(t, err)
+		require.Greater(t, ttl, 59*time.Second)
+		require.LessOrEqual(t, ttl, time.Minute)
+	})
+
+	t.Run("returns zero for key that never expires", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[string](client, nil, cache.WithPrefix("test-ttl-persist"))
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "value", -1))
+
+		ttl, err := c.TTL(ctx, "key")
+		require.NoError(t, err)
+		require.Zero(t, ttl)
+	})
+
+	t.Run("returns ErrNotFound for missing key", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[string](client, nil, cache.WithPrefix("test-ttl-miss"))
+
+		_, err := c.TTL(context.Background(), "missing")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("returns ErrNotFound for expired key", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[string](client, nil, cache.WithPrefix("test-ttl-expired"))
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "value", 50*time.Millisecond))
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err := c.TTL(ctx, "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+}
+
+// --- Redis: SetIfAbsent ---
+
+func TestRedis_SetIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores missing key", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[string](client, nil, cache.WithPrefix("test-setnx"))
+
+		ctx := context.Background()
+		ok, err := c.SetIfAbsent(ctx, "key", "value", time.Minute)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, "value", val)
+	})
+
+	t.Run("keeps existing value", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[string](client, nil, cache.WithPrefix("test-setnx-exists"))
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", "fresh", time.Minute))
+
+		ok, err := c.SetIfAbsent(ctx, "key", "stale", time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, "fresh", val)
+	})
+}
+
+// --- Redis: CompareAndSwap ---
+
+func TestRedis_CompareAndSwap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("swaps matching value with TTL", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[int](client, nil, cache.WithPrefix("test-cas"))
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", 1, -1))
+
+		ok, err := c.CompareAndSwap(ctx, "key", 1, 2, time.Minute)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, 2, val)
+
+		ttl, err := client.PTTL(ctx, "test-cas:key").Result()
+		require.NoError(t, err)
+		require.Greater(t, ttl, time.Duration(0))
+	})
+
+	t.Run("keeps value that changed", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[int](client, nil, cache.WithPrefix("test-cas-changed"))
+
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "key", 2, time.Minute))
+
+		ok, err := c.CompareAndSwap(ctx, "key", 1, 3, time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		val, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, 2, val)
+	})
+
+	t.Run("missing key never matches", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[int](client, nil, cache.WithPrefix("test-cas-missing"))
+
+		ok, err := c.CompareAndSwap(context.Background(), "key", 0, 1, time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
 // --- Redis: Clear ---
 
 func TestRedis_Clear(t *testing.T) {
@@ -358,3 +515,48 @@ func TestRedis_CustomMarshaler(t *testing.T) {
 		require.Equal(t, "olleh", raw)
 	})
 }
+
+func TestRedis_GetOrSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("replaces an entry that does not unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[int](client, nil, cache.WithPrefix("test-getorset-corrupt"))
+
+		ctx := context.Background()
+		require.NoError(t, client.Set(ctx, "test-getorset-corrupt:key", "not-json", time.Minute).Err())
+
+		val, err := cache.GetOrSet(ctx, c, "key", func(_ context.Context) (int, time.Duration, error) {
+			return 42, time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 42, val)
+
+		cached, err := c.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, 42, cached)
+	})
+
+	t.Run("many replaces entries that do not unmarshal", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestRedisClient(t)
+		c := cache.NewRedis[int](client, nil, cache.WithPrefix("test-getorsetmany-corrupt"))
+
+		ctx := context.Background()
+		require.NoError(t, client.Set(ctx, "test-getorsetmany-corrupt:a", "not-json", time.Minute).Err())
+
+		got, err := cache.GetOrSetMany(ctx, c, []string{"a", "b"}, func(_ context.Context, missing []string) (map[string]int, time.Duration, error) {
+			require.Equal(t, []string{"a", "b"}, missing)
+			return map[string]int{"a": 1, "b": 2}, time.Minute, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+
+		cached, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, 1, cached)
+	})
+}
@@ -8,6 +8,7 @@ type RedisOption func(*redisOptions)
 type redisOptions struct {
 	prefix     string
 	defaultTTL time.Duration
+	ttlJitter  float64
 }
 
 func defaultRedisOptions() *redisOptions {
@@ -26,6 +27,18 @@ func WithRedisDefaultTTL(d time.Duration) RedisOption {
 	}
 }
 
+// WithRedisTTLJitter randomizes each entry's effective TTL by up to ±fraction
+// (0.1 = ±10%) at Set time, so entries written together, such as during a
+// bulk cache warm, do not all expire at once. The fraction is clamped to [0, 1].
+// Entries that never expire are unaffected, and jitter never makes a TTL
+// non-positive.
+// Default: 0 (no jitter).
+func WithRedisTTLJitter(fraction float64) RedisOption {
+	return func(o *redisOptions) {
+		o.ttlJitter = clampJitter(fraction)
+	}
+}
+
 // WithPrefix sets a key prefix for all cache operations.
 // Keys are stored as "{prefix}:{key}". This is useful for namespacing
 // when multiple caches share the same Redis instance.
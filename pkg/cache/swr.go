@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// swrGroup deduplicates GetOrSetSWR loads. It is separate from sfGroup so a
+// key used with both GetOrSet and GetOrSetSWR never shares a result of the
+// wrong type. Flights are keyed by cache instance and key; see swrFlightKey.
+var swrGroup singleflight.Group
+
+// swrFlightKey returns the singleflight key for key in c, so two caches
+// holding the same key, possibly of different value types, never share a
+// load. Caches are identified by address; other implementations fall back
+// to their type.
+func swrFlightKey(c any, key string) string {
+	if v := reflect.ValueOf(c); v.Kind() == reflect.Pointer {
+		return strconv.FormatUint(uint64(v.Pointer()), 16) + "/" + key
+	}
+	return fmt.Sprintf("%T/%s", c, key)
+}
+
+// SWREntry is the envelope GetOrSetSWR stores: the value plus the time after
+// which it is served stale and refreshed. Caches used with GetOrSetSWR hold
+// SWREntry[V] rather than V.
+type SWREntry[V any] struct {
+	Value      V         `json:"value"`
+	SoftExpiry time.Time `json:"soft_expiry"`
+}
+
+// GetOrSetSWR retrieves a value with stale-while-revalidate semantics:
+//
+//   - Younger than fresh: the cached value is returned.
+//   - Between fresh and fresh+stale: the cached value is returned at once and
+//     loader runs in the background to replace it.
+//   - Older than fresh+stale, or missing: loader runs and the call blocks.
+//
+// Loads for the same key of the same cache are deduplicated with
+// singleflight, so a hot key triggers one background refresh however many
+// requests see it stale. Entries are stored with a TTL of fresh+stale; a
+// configured TTL jitter applies to that hard expiry only.
+//
+// A background refresh runs with ctx's values but not its cancellation, so it
+// finishes after the request returns. Its errors are dropped and the stale
+// value keeps being served until the next attempt or hard expiry. A panic
+// in loader is returned as an error rather than crashing the refresh
+// goroutine.
+func GetOrSetSWR[V any](ctx context.Context, c Cache[SWREntry[V]], key string, fresh, stale time.Duration, loader func(ctx context.Context) (V, error)) (V, error) {
+	load := func(ctx context.Context) func() (any, error) {
+		return func() (_ any, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("cache: loader panicked: %v", r)
+				}
+			}()
+			val, err := loader(ctx)
+			if err != nil {
+				return nil, err
+			}
+			entry := SWREntry[V]{Value: val, SoftExpiry: time.Now().Add(fresh)}
+			// Best-effort cache the result.
+			_ = c.Set(ctx, key, entry, fresh+stale)
+			return entry, nil
+		}
+	}
+
+	flightKey := swrFlightKey(c, key)
+	if entry, err := c.Get(ctx, key); err == nil {
+		if time.Now().After(entry.SoftExpiry) {
+			// DoChan's channel is buffered, so the result can be ignored.
+			swrGroup.DoChan(flightKey, load(context.WithoutCancel(ctx)))
+		}
+		return entry.Value, nil
+	}
+
+	var zero V
+	v, err, _ := swrGroup.Do(flightKey, load(ctx))
+	if err != nil {
+		return zero, err
+	}
+	entry, ok := v.(SWREntry[V])
+	if !ok {
+		return zero, fmt.Errorf("cache: unexpected shared result type %T for key %q", v, key)
+	}
+	return entry.Value, nil
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -116,6 +117,28 @@ func (m *Manager) Delete(w http.ResponseWriter, name string) {
 	http.SetCookie(w, m.cookie(name, "", -1))
 }
 
+// DeleteAll removes several cookies at once, e.g. on logout.
+func (m *Manager) DeleteAll(w http.ResponseWriter, names ...string) {
+	for _, name := range names {
+		m.Delete(w, name)
+	}
+}
+
+// Names returns the names of all cookies sent with the request, in order
+// and without duplicates.
+func (m *Manager) Names(r *http.Request) []string {
+	cookies := r.Cookies()
+	names := make([]string, 0, len(cookies))
+	seen := make(map[string]bool, len(cookies))
+	for _, c := range cookies {
+		if !seen[c.Name] {
+			seen[c.Name] = true
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
 // GetSigned returns a signed cookie value.
 // Returns ErrNoSecret if no secret is configured.
 // Returns ErrBadSig if signature verification fails.
@@ -164,16 +187,7 @@ func (m *Manager) SetSigned(w http.ResponseWriter, name, value string, maxAge in
 		return ErrNoSecret
 	}
 
-	// Sign the value
-	mac := hmac.New(sha256.New, m.secret)
-	mac.Write([]byte(value))
-	sig := mac.Sum(nil)
-
-	// Format: base64(value).base64(signature)
-	encoded := base64.RawURLEncoding.EncodeToString([]byte(value)) +
-		"." + base64.RawURLEncoding.EncodeToString(sig)
-
-	http.SetCookie(w, m.cookie(name, encoded, maxAge))
+	http.SetCookie(w, m.cookie(name, m.sign(value), maxAge))
 	return nil
 }
 
@@ -210,16 +224,71 @@ func (m *Manager) SetEncrypted(w http.ResponseWriter, name, value string, maxAge
 		return ErrNoSecret
 	}
 
-	ciphertext, err := m.encrypt([]byte(value))
+	encoded, err := m.seal(value)
 	if err != nil {
 		return err
 	}
 
-	encoded := base64.RawURLEncoding.EncodeToString(ciphertext)
 	http.SetCookie(w, m.cookie(name, encoded, maxAge))
 	return nil
 }
 
+// Protection selects how SetRaw encodes a cookie's value.
+type Protection int
+
+// Protection modes. Signed and Encrypted values are read back with
+// GetSigned and GetEncrypted.
+const (
+	Plain Protection = iota
+	Signed
+	Encrypted
+)
+
+// Cookie returns a cookie with the manager's configured attributes, as Set
+// would write it. Adjust it and pass it to SetRaw for one-off attributes.
+func (m *Manager) Cookie(name, value string, maxAge int) *http.Cookie {
+	return m.cookie(name, value, maxAge)
+}
+
+// SetRaw writes c with its own attributes instead of the manager's, for
+// cases like a custom Domain, a session cookie without Max-Age, or a specific
+// Expires. An empty Path defaults to the manager's path. With Signed or
+// Encrypted, the value is protected with the manager's secret; c itself is
+// not modified.
+// Returns ErrNoSecret if protection is requested without a secret.
+// Returns an error if the resulting cookie is invalid.
+func (m *Manager) SetRaw(w http.ResponseWriter, c *http.Cookie, p Protection) error {
+	if c == nil {
+		return errors.New("cookie: nil cookie")
+	}
+	if p != Plain && m.secret == nil {
+		return ErrNoSecret
+	}
+
+	out := *c
+	if out.Path == "" {
+		out.Path = m.path
+	}
+
+	switch p {
+	case Signed:
+		out.Value = m.sign(c.Value)
+	case Encrypted:
+		encoded, err := m.seal(c.Value)
+		if err != nil {
+			return err
+		}
+		out.Value = encoded
+	}
+
+	if err := out.Valid(); err != nil {
+		return fmt.Errorf("cookie: %w", err)
+	}
+
+	http.SetCookie(w, &out)
+	return nil
+}
+
 // Flash reads and deletes a flash message.
 // Returns ErrNoSecret if no secret is configured.
 // Returns ErrNotFound if the flash cookie doesn't exist.
@@ -269,6 +338,26 @@ func (m *Manager) cookie(name, value string, maxAge int) *http.Cookie {
 	}
 }
 
+// sign returns value with its HMAC-SHA256 signature:
+// base64(value).base64(signature).
+func (m *Manager) sign(value string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(value))
+	sig := mac.Sum(nil)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(value)) +
+		"." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// seal encrypts value and encodes it for a cookie.
+func (m *Manager) seal(value string) (string, error) {
+	ciphertext, err := m.encrypt([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
 // encrypt uses AES-GCM.
 func (m *Manager) encrypt(plaintext []byte) ([]byte, error) {
 	// Derive 32-byte key from secret
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dmitrymomot/forge/pkg/cookie"
 )
@@ -92,6 +93,57 @@ func TestPlainCookies(t *testing.T) {
 	})
 }
 
+func TestCookieNames(t *testing.T) {
+	m := cookie.New()
+
+	t.Run("no cookies", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if names := m.Names(r); len(names) != 0 {
+			t.Errorf("Names() = %v, want empty", names)
+		}
+	})
+
+	t.Run("names in order without duplicates", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Cookie", "session=a; theme=dark; session=b; consent=yes")
+
+		got := m.Names(r)
+		want := []string{"session", "theme", "consent"}
+		if len(got) != len(want) {
+			t.Fatalf("Names() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Names()[%d] = %q, want %q", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func TestDeleteAll(t *testing.T) {
+	m := cookie.New(cookie.WithPath("/app"))
+
+	w := httptest.NewRecorder()
+	m.DeleteAll(w, "session", "remember", "csrf")
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 3 {
+		t.Fatalf("expected 3 cookies, got %d", len(cookies))
+	}
+	for i, name := range []string{"session", "remember", "csrf"} {
+		c := cookies[i]
+		if c.Name != name {
+			t.Errorf("cookie[%d].Name = %q, want %q", i, c.Name, name)
+		}
+		if c.MaxAge != -1 {
+			t.Errorf("cookie %q MaxAge = %d, want -1", name, c.MaxAge)
+		}
+		if c.Path != "/app" {
+			t.Errorf("cookie %q Path = %q, want /app", name, c.Path)
+		}
+	}
+}
+
 func TestSignedCookies(t *testing.T) {
 	t.Run("no secret returns error", func(t *testing.T) {
 		m := cookie.New() // no secret
@@ -389,3 +441,127 @@ func TestDefaultAttributes(t *testing.T) {
 		t.Errorf("default SameSite = %v, want %v", c.SameSite, http.SameSiteLaxMode)
 	}
 }
+
+func TestSetRaw(t *testing.T) {
+	t.Run("keeps caller attributes", func(t *testing.T) {
+		m := cookie.New(
+			cookie.WithDomain("example.com"),
+			cookie.WithSecure(true),
+			cookie.WithSameSite(http.SameSiteStrictMode),
+		)
+		expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		w := httptest.NewRecorder()
+		err := m.SetRaw(w, &http.Cookie{
+			Name:     "tracking",
+			Value:    "abc",
+			Domain:   "shop.example.com",
+			Expires:  expires,
+			SameSite: http.SameSiteNoneMode,
+			Secure:   true,
+		}, cookie.Plain)
+		if err != nil {
+			t.Fatalf("SetRaw() error: %v", err)
+		}
+
+		c := w.Result().Cookies()[0]
+		if c.Value != "abc" {
+			t.Errorf("Value = %q, want %q", c.Value, "abc")
+		}
+		if c.Domain != "shop.example.com" {
+			t.Errorf("Domain = %q, want %q", c.Domain, "shop.example.com")
+		}
+		if !c.Expires.Equal(expires) {
+			t.Errorf("Expires = %v, want %v", c.Expires, expires)
+		}
+		if c.MaxAge != 0 {
+			t.Errorf("MaxAge = %d, want 0", c.MaxAge)
+		}
+		if c.SameSite != http.SameSiteNoneMode {
+			t.Errorf("SameSite = %v, want %v", c.SameSite, http.SameSiteNoneMode)
+		}
+		if c.Path != "/" {
+			t.Errorf("Path = %q, want manager default %q", c.Path, "/")
+		}
+	})
+
+	t.Run("signed value reads back", func(t *testing.T) {
+		m := cookie.New(cookie.WithSecret(testSecret))
+		in := &http.Cookie{Name: "session", Value: "user123", Path: "/app"}
+
+		w := httptest.NewRecorder()
+		if err := m.SetRaw(w, in, cookie.Signed); err != nil {
+			t.Fatalf("SetRaw() error: %v", err)
+		}
+		if in.Value != "user123" {
+			t.Errorf("SetRaw() modified the caller's cookie: %q", in.Value)
+		}
+
+		c := w.Result().Cookies()[0]
+		if c.Path != "/app" {
+			t.Errorf("Path = %q, want %q", c.Path, "/app")
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(c)
+		val, err := m.GetSigned(r, "session")
+		if err != nil {
+			t.Fatalf("GetSigned() error: %v", err)
+		}
+		if val != "user123" {
+			t.Errorf("GetSigned() = %q, want %q", val, "user123")
+		}
+	})
+
+	t.Run("encrypted value reads back", func(t *testing.T) {
+		m := cookie.New(cookie.WithSecret(testSecret))
+
+		w := httptest.NewRecorder()
+		if err := m.SetRaw(w, &http.Cookie{Name: "prefs", Value: "dark"}, cookie.Encrypted); err != nil {
+			t.Fatalf("SetRaw() error: %v", err)
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(w.Result().Cookies()[0])
+		val, err := m.GetEncrypted(r, "prefs")
+		if err != nil {
+			t.Fatalf("GetEncrypted() error: %v", err)
+		}
+		if val != "dark" {
+			t.Errorf("GetEncrypted() = %q, want %q", val, "dark")
+		}
+	})
+
+	t.Run("protection without secret", func(t *testing.T) {
+		m := cookie.New()
+
+		err := m.SetRaw(httptest.NewRecorder(), &http.Cookie{Name: "session", Value: "x"}, cookie.Signed)
+		if !errors.Is(err, cookie.ErrNoSecret) {
+			t.Errorf("SetRaw() error = %v, want ErrNoSecret", err)
+		}
+	})
+
+	t.Run("invalid cookie", func(t *testing.T) {
+		m := cookie.New()
+		w := httptest.NewRecorder()
+
+		if err := m.SetRaw(w, nil, cookie.Plain); err == nil {
+			t.Error("SetRaw(nil) error = nil, want error")
+		}
+		if err := m.SetRaw(w, &http.Cookie{Name: "bad name", Value: "x"}, cookie.Plain); err == nil {
+			t.Error("SetRaw() with invalid name error = nil, want error")
+		}
+		if len(w.Result().Cookies()) != 0 {
+			t.Error("SetRaw() wrote an invalid cookie")
+		}
+	})
+}
+
+func TestManagerCookie(t *testing.T) {
+	m := cookie.New(cookie.WithDomain("example.com"), cookie.WithSecure(true))
+
+	c := m.Cookie("theme", "dark", 60)
+	if c.Domain != "example.com" || !c.Secure || !c.HttpOnly || c.Path != "/" || c.MaxAge != 60 {
+		t.Errorf("Cookie() = %+v, want manager defaults", c)
+	}
+}
@@ -22,6 +22,11 @@
 //		}
 //	}
 //
+// Names lists the cookies sent with a request, and DeleteAll clears several
+// cookies in one call, e.g. on logout:
+//
+//	m.DeleteAll(w, "session", "remember_me", "csrf")
+//
 // # With Secret
 //
 // Enable signing and encryption with a 32+ byte secret:
@@ -42,6 +47,19 @@
 //	err := m.SetEncrypted(w, "prefs", userPrefs, 86400)
 //	value, err := m.GetEncrypted(r, "prefs")
 //
+// # Custom Attributes
+//
+// Set and its variants apply the manager's attributes to every cookie. For a
+// one-off cookie with its own Domain, SameSite, or Expires, pass a full
+// [http.Cookie] to SetRaw; start from Cookie to keep the other defaults:
+//
+//	c := m.Cookie("sid", sessionID, 0) // no Max-Age: session cookie
+//	c.Domain = ".example.com"           // share across subdomains
+//	err := m.SetRaw(w, c, cookie.Signed)
+//
+// The [Protection] argument signs or encrypts the value with the manager's
+// secret, so GetSigned and GetEncrypted read it back as usual.
+//
 // # Flash Messages
 //
 // Flash messages are encrypted, single-read values that auto-delete after reading.
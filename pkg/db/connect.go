@@ -16,6 +16,7 @@ type Option func(*options)
 
 type options struct {
 	migrations        *embed.FS
+	migrationLock     *int64
 	logger            *slog.Logger
 	maxConns          int32
 	minConns          int32
@@ -45,6 +46,16 @@ func WithMigrations(fs embed.FS) Option {
 	}
 }
 
+// WithMigrationLock makes Open run migrations under the advisory lock key
+// (see WithAdvisoryLock), so instances starting together during a rolling
+// deploy apply them one at a time instead of racing. The others wait, then
+// find nothing left to apply.
+func WithMigrationLock(key int64) Option {
+	return func(o *options) {
+		o.migrationLock = &key
+	}
+}
+
 // WithLogger sets the logger for migrations and connection events.
 func WithLogger(log *slog.Logger) Option {
 	return func(o *options) {
@@ -136,7 +147,7 @@ func Open(ctx context.Context, connString string, opts ...Option) (*pgxpool.Pool
 	}
 
 	if o.migrations != nil {
-		if err := Migrate(ctx, pool, *o.migrations, o.logger); err != nil {
+		if err := migrate(ctx, pool, *o.migrations, o); err != nil {
 			pool.Close()
 			return nil, err
 		}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CopyFrom bulk-inserts rows into table using the PostgreSQL COPY protocol,
+// which is much faster than individual INSERTs for large imports.
+// Each row must hold one value per column, in column order. The table may be
+// schema-qualified ("analytics.events"). Returns the number of rows copied.
+func CopyFrom(ctx context.Context, pool *pgxpool.Pool, table string, columns []string, rows [][]any) (int64, error) {
+	return CopyFromSource(ctx, pool, table, columns, pgx.CopyFromRows(rows))
+}
+
+// CopyFromSource is the streaming form of CopyFrom. Rows are read from src as
+// they are sent, so imports such as large CSV files need not fit in memory.
+// A connection is acquired from the pool for the duration of the copy.
+func CopyFromSource(ctx context.Context, pool *pgxpool.Pool, table string, columns []string, src pgx.CopyFromSource) (int64, error) {
+	n, err := pool.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, src)
+	if err != nil {
+		return n, errors.Join(ErrCopyFailed, err)
+	}
+	return n, nil
+}
@@ -9,6 +9,9 @@
 //   - Automatic retry logic with exponential backoff during startup
 //   - Health check function compatible with standard health check interfaces
 //   - Database migrations using [github.com/pressly/goose/v3]
+//   - Bulk inserts via the COPY protocol
+//   - Advisory locks for work that must run on a single instance
+//   - Typed row scanning into structs with QueryOne and QueryAll
 //   - Environment-based configuration for deployment convenience
 //
 // # Configuration
@@ -91,6 +94,40 @@
 //		// Transaction was rolled back automatically
 //	}
 //
+// # Typed Queries
+//
+// [QueryOne] and [QueryAll] scan rows into structs by column name, replacing
+// hand-written Scan calls. They accept any [Querier], so the same code runs
+// on a pool or inside [WithTx]:
+//
+//	type Contact struct {
+//		ID    int64  `db:"id"`
+//		Email string `db:"email"`
+//	}
+//
+//	contact, err := db.QueryOne[Contact](ctx, pool, "SELECT id, email FROM contacts WHERE id = $1", id)
+//	if errors.Is(err, db.ErrNotFound) {
+//		// no such contact
+//	}
+//
+//	err = db.WithTx(ctx, pool, func(tx pgx.Tx) error {
+//		contacts, err := db.QueryAll[Contact](ctx, tx, "SELECT id, email FROM contacts")
+//		// ...
+//	})
+//
+// # Bulk Inserts
+//
+// [CopyFrom] loads rows with the COPY protocol, which is far faster than
+// individual INSERTs for CSV imports and backfills:
+//
+//	n, err := db.CopyFrom(ctx, pool, "contacts", []string{"email", "name"}, [][]any{
+//		{"alice@example.com", "Alice"},
+//		{"bob@example.com", "Bob"},
+//	})
+//
+// [CopyFromSource] streams rows from a [pgx.CopyFromSource] instead of a slice,
+// such as [pgx.CopyFromFunc] reading a CSV file row by row.
+//
 // # Migrations
 //
 // Run database migrations using embedded SQL files:
@@ -111,6 +148,34 @@
 //		log.Fatal(err)
 //	}
 //
+// When several instances start at once, [WithMigrationLock] makes [Open]
+// apply migrations under an advisory lock so goose runs on one at a time:
+//
+//	pool, err := db.Open(ctx, dsn,
+//		db.WithMigrations(migrations),
+//		db.WithMigrationLock(727001),
+//	)
+//
+// # Advisory Locks
+//
+// [WithAdvisoryLock] runs a function while holding a Postgres advisory lock,
+// waiting for other holders first. [TryAdvisoryLock] gives up instead of
+// waiting and reports whether the function ran:
+//
+//	const seedLock = 727002
+//
+//	err := db.WithAdvisoryLock(ctx, pool, seedLock, func() error {
+//		return seed(ctx, pool)
+//	})
+//
+//	ran, err := db.TryAdvisoryLock(ctx, pool, reportLock, func() error {
+//		return buildDailyReport(ctx, pool)
+//	})
+//
+// The lock is session-level, so a dedicated connection is checked out from
+// the pool and held until the function returns. Work inside the function
+// uses other pool connections as usual.
+//
 // # Error Handling
 //
 // The package defines sentinel errors for common failure modes:
@@ -120,6 +185,9 @@
 //   - [ErrHealthcheckFailed] - Database ping failed
 //   - [ErrSetDialect] - Migration dialect configuration error
 //   - [ErrApplyMigrations] - Migration execution failed
+//   - [ErrCopyFailed] - Bulk copy failed
+//   - [ErrNotFound] - QueryOne matched no rows (also matches [pgx.ErrNoRows])
+//   - [ErrAdvisoryLock] - Advisory lock could not be acquired
 //
 // Errors are wrapped using [errors.Join] to preserve the original error context.
 package db
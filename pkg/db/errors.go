@@ -8,4 +8,7 @@ var (
 	ErrHealthcheckFailed        = errors.New("db: healthcheck failed")
 	ErrSetDialect               = errors.New("db migrator: failed to set dialect")
 	ErrApplyMigrations          = errors.New("db migrator: failed to apply migrations")
+	ErrCopyFailed               = errors.New("db: bulk copy failed")
+	ErrNotFound                 = errors.New("db: no rows found")
+	ErrAdvisoryLock             = errors.New("db: failed to acquire advisory lock")
 )
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WithAdvisoryLock runs fn while holding the Postgres session-level advisory
+// lock key, waiting until it is free. Use it for work that must run on one
+// instance at a time, such as migrations and seeds during a rolling deploy.
+//
+// The lock belongs to a database session, so a connection is checked out from
+// the pool and held until fn returns; size the pool with that in mind. The
+// lock is released even if fn panics or ctx is canceled. If the release itself
+// fails, the connection is closed, which makes Postgres drop the lock.
+func WithAdvisoryLock(ctx context.Context, pool *pgxpool.Pool, key int64, fn func() error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return errors.Join(ErrAdvisoryLock, err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		return errors.Join(ErrAdvisoryLock, err)
+	}
+	defer unlockAdvisory(ctx, conn, key)
+
+	return fn()
+}
+
+// TryAdvisoryLock is the non-blocking form of WithAdvisoryLock. If another
+// session holds the lock it returns false without calling fn; otherwise it
+// runs fn under the lock and returns true with fn's error.
+func TryAdvisoryLock(ctx context.Context, pool *pgxpool.Pool, key int64, fn func() error) (bool, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return false, errors.Join(ErrAdvisoryLock, err)
+	}
+	defer conn.Release()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		return false, errors.Join(ErrAdvisoryLock, err)
+	}
+	if !acquired {
+		return false, nil
+	}
+	defer unlockAdvisory(ctx, conn, key)
+
+	return true, fn()
+}
+
+// unlockAdvisory releases key on conn. It ignores ctx cancellation so a
+// canceled caller does not leave the lock held on a pooled connection.
+func unlockAdvisory(ctx context.Context, conn *pgxpool.Conn, key int64) {
+	ctx = context.WithoutCancel(ctx)
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", key); err != nil {
+		// Closing the session is the only other way to free the lock; the
+		// pool discards the closed connection on Release.
+		_ = conn.Conn().Close(ctx)
+	}
+}
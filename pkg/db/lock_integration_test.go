@@ -0,0 +1,144 @@
+//go:build integration
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/db"
+)
+
+// testLockKey returns an advisory lock key no other test uses.
+func testLockKey() int64 {
+	return time.Now().UnixNano() + tableSeq.Add(1)
+}
+
+func TestWithAdvisoryLock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs fn and releases the lock", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		key := testLockKey()
+
+		called := false
+		err := db.WithAdvisoryLock(ctx, pool, key, func() error {
+			called = true
+			return nil
+		})
+		require.NoError(t, err)
+		require.True(t, called)
+
+		ran, err := db.TryAdvisoryLock(ctx, pool, key, func() error { return nil })
+		require.NoError(t, err)
+		require.True(t, ran)
+	})
+
+	t.Run("returns fn error and releases the lock", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		key := testLockKey()
+		errBoom := errors.New("boom")
+
+		err := db.WithAdvisoryLock(ctx, pool, key, func() error { return errBoom })
+		require.ErrorIs(t, err, errBoom)
+
+		ran, err := db.TryAdvisoryLock(ctx, pool, key, func() error { return nil })
+		require.NoError(t, err)
+		require.True(t, ran)
+	})
+
+	t.Run("releases the lock on panic", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		key := testLockKey()
+
+		require.Panics(t, func() {
+			_ = db.WithAdvisoryLock(ctx, pool, key, func() error { panic("boom") })
+		})
+
+		ran, err := db.TryAdvisoryLock(ctx, pool, key, func() error { return nil })
+		require.NoError(t, err)
+		require.True(t, ran)
+	})
+
+	t.Run("waits for the current holder", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		key := testLockKey()
+
+		held := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- db.WithAdvisoryLock(ctx, pool, key, func() error {
+				close(held)
+				<-release
+				return nil
+			})
+		}()
+		<-held
+
+		acquired := make(chan error, 1)
+		go func() {
+			acquired <- db.WithAdvisoryLock(ctx, pool, key, func() error { return nil })
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("lock acquired while held by another session")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(release)
+		require.NoError(t, <-done)
+		require.NoError(t, <-acquired)
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newTestPool(t)
+		key := testLockKey()
+
+		_, err := db.TryAdvisoryLock(context.Background(), pool, key, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			return db.WithAdvisoryLock(ctx, pool, key, func() error { return nil })
+		})
+		require.ErrorIs(t, err, db.ErrAdvisoryLock)
+	})
+}
+
+func TestTryAdvisoryLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pool := newTestPool(t)
+	key := testLockKey()
+
+	ran, err := db.TryAdvisoryLock(ctx, pool, key, func() error {
+		inner, err := db.TryAdvisoryLock(ctx, pool, key, func() error {
+			t.Fatal("fn called while lock held by another session")
+			return nil
+		})
+		require.NoError(t, err)
+		require.False(t, inner)
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, ran)
+}
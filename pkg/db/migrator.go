@@ -50,6 +50,35 @@ func Migrate(ctx context.Context, pool *pgxpool.Pool, migrations embed.FS, log *
 	return nil
 }
 
+// Migrator returns a function that runs Migrate on pool, for use as a
+// startup hook. Of opts, only WithLogger and WithMigrationLock apply; with a
+// migration lock, instances starting together apply migrations one at a time.
+//
+// Example:
+//
+//	app.Run(":8080", forge.StartupHook(db.Migrator(pool, migrations,
+//	    db.WithMigrationLock(4242),
+//	)))
+func Migrator(pool *pgxpool.Pool, migrations embed.FS, opts ...Option) func(context.Context) error {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(ctx context.Context) error {
+		return migrate(ctx, pool, migrations, o)
+	}
+}
+
+// migrate runs Migrate, under the migration advisory lock when one is set.
+func migrate(ctx context.Context, pool *pgxpool.Pool, migrations embed.FS, o *options) error {
+	if o.migrationLock == nil {
+		return Migrate(ctx, pool, migrations, o.logger)
+	}
+	return WithAdvisoryLock(ctx, pool, *o.migrationLock, func() error {
+		return Migrate(ctx, pool, migrations, o.logger)
+	})
+}
+
 type gooseLoggerAdapter struct {
 	log *slog.Logger
 }
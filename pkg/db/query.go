@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Querier is implemented by *pgxpool.Pool, *pgxpool.Conn, *pgx.Conn and
+// pgx.Tx, so the query helpers work both on a pool and inside WithTx.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+var (
+	_ Querier = (*pgxpool.Pool)(nil)
+	_ Querier = (pgx.Tx)(nil)
+)
+
+// QueryOne runs sql and scans the first row into T, matching result columns
+// to struct fields by name (see [pgx.RowToStructByName] for the db tag rules).
+// Returns an error wrapping both ErrNotFound and pgx.ErrNoRows when the query
+// yields no rows.
+func QueryOne[T any](ctx context.Context, q Querier, sql string, args ...any) (T, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	v, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[T])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return v, errors.Join(ErrNotFound, err)
+	}
+	return v, err
+}
+
+// QueryAll runs sql and scans every row into T, matching result columns to
+// struct fields by name. An empty result is not an error; it returns an empty
+// slice.
+func QueryAll[T any](ctx context.Context, q Querier, sql string, args ...any) ([]T, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[T])
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/db"
+)
+
+type testRow struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestQueryOne(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scans row into struct", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		table := newTestTable(t, pool)
+		_, err := db.CopyFrom(ctx, pool, table, []string{"id", "name"}, testRows(3))
+		require.NoError(t, err)
+
+		row, err := db.QueryOne[testRow](ctx, pool, "SELECT id, name FROM "+table+" WHERE id = $1", int64(2))
+		require.NoError(t, err)
+		require.Equal(t, testRow{ID: 2, Name: "name-2"}, row)
+	})
+
+	t.Run("returns ErrNotFound for no rows", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newTestPool(t)
+		table := newTestTable(t, pool)
+
+		_, err := db.QueryOne[testRow](context.Background(), pool, "SELECT id, name FROM "+table)
+		require.ErrorIs(t, err, db.ErrNotFound)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("works inside a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		table := newTestTable(t, pool)
+
+		err := db.WithTx(ctx, pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, "INSERT INTO "+table+" (id, name) VALUES (7, 'tx')"); err != nil {
+				return err
+			}
+			row, err := db.QueryOne[testRow](ctx, tx, "SELECT id, name FROM "+table+" WHERE id = 7")
+			require.NoError(t, err)
+			require.Equal(t, "tx", row.Name)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestQueryAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scans all rows", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		pool := newTestPool(t)
+		table := newTestTable(t, pool)
+		_, err := db.CopyFrom(ctx, pool, table, []string{"id", "name"}, testRows(5))
+		require.NoError(t, err)
+
+		rows, err := db.QueryAll[testRow](ctx, pool, "SELECT id, name FROM "+table+" ORDER BY id")
+		require.NoError(t, err)
+		require.Len(t, rows, 5)
+		require.Equal(t, testRow{ID: 4, Name: "name-4"}, rows[4])
+	})
+
+	t.Run("returns empty slice for no rows", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newTestPool(t)
+		table := newTestTable(t, pool)
+
+		rows, err := db.QueryAll[testRow](context.Background(), pool, "SELECT id, name FROM "+table)
+		require.NoError(t, err)
+		require.Empty(t, rows)
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newTestPool(t)
+
+		_, err := db.QueryAll[testRow](context.Background(), pool, "SELECT id, name FROM missing_table")
+		require.Error(t, err)
+	})
+}
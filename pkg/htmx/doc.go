@@ -76,6 +76,12 @@
 //	)
 //	// Components must include id and hx-swap-oob attributes
 //
+// OOBList renders a slice of components as consecutive OOB fragments and
+// fails with ErrOOBMissingID or ErrOOBMissingSwap if a fragment's root
+// element lacks the required attributes:
+//
+//	htmx.WithOOB(htmx.OOBList(newMessages...))
+//
 // # Response Headers
 //
 // The package exports constants for all HTMX response headers. Common response headers include:
@@ -6,3 +6,13 @@ import "net/http"
 func IsHTMX(r *http.Request) bool {
 	return r.Header.Get(HeaderHXRequest) == "true"
 }
+
+// Target returns the id of the element an HTMX request targets, from the
+// HX-Target header. It is empty for regular requests and for HTMX requests
+// whose target element has no id.
+func Target(r *http.Request) string {
+	if !IsHTMX(r) {
+		return ""
+	}
+	return r.Header.Get(HeaderHXTarget)
+}
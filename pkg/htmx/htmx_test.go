@@ -66,3 +66,26 @@ func TestIsHTMX(t *testing.T) {
 		assert.False(t, htmx.IsHTMX(req), "should be case-sensitive")
 	})
 }
+
+func TestTarget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns HX-Target for htmx requests", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("HX-Request", "true")
+		req.Header.Set("HX-Target", "user-list")
+
+		assert.Equal(t, "user-list", htmx.Target(req))
+	})
+
+	t.Run("ignores HX-Target on regular requests", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("HX-Target", "user-list")
+
+		assert.Empty(t, htmx.Target(req))
+	})
+}
@@ -0,0 +1,127 @@
+package htmx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrOOBMissingID is returned when an out-of-band fragment's root element
+	// has no id attribute, so htmx would have nothing to swap it into.
+	ErrOOBMissingID = errors.New("htmx: out-of-band fragment has no id")
+
+	// ErrOOBMissingSwap is returned when an out-of-band fragment's root element
+	// has no hx-swap-oob attribute, so htmx would treat it as main content.
+	ErrOOBMissingSwap = errors.New("htmx: out-of-band fragment has no hx-swap-oob attribute")
+)
+
+// OOBList returns a Renderable that renders components one after another as
+// out-of-band fragments, such as new chat messages appended to a list:
+//
+//	htmx.WithOOB(htmx.OOBList(messages...))
+//
+// Each fragment is checked with ValidateOOB before it is written, so a
+// component missing its id or hx-swap-oob attribute fails the render
+// instead of being silently ignored by htmx.
+func OOBList[T Renderable](components ...T) Renderable {
+	return oobList[T](components)
+}
+
+type oobList[T Renderable] []T
+
+func (l oobList[T]) Render(ctx context.Context, w io.Writer) error {
+	var buf bytes.Buffer
+	for i, component := range l {
+		buf.Reset()
+		if err := component.Render(ctx, &buf); err != nil {
+			return err
+		}
+		if err := ValidateOOB(buf.Bytes()); err != nil {
+			return fmt.Errorf("oob fragment %d: %w", i, err)
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateOOB checks that the root element of a rendered out-of-band fragment
+// carries the id and hx-swap-oob attributes htmx needs to swap it.
+// Leading whitespace, comments, and doctypes are skipped.
+func ValidateOOB(fragment []byte) error {
+	attrs := rootAttributes(string(fragment))
+	if _, ok := attrs["id"]; !ok {
+		return ErrOOBMissingID
+	}
+	if _, ok := attrs["hx-swap-oob"]; !ok {
+		return ErrOOBMissingSwap
+	}
+	return nil
+}
+
+// rootAttributes returns the lowercased attribute names of the first start
+// tag in s. It is a scanner for well-formed template output, not an HTML parser.
+func rootAttributes(s string) map[string]struct{} {
+	for {
+		i := strings.IndexByte(s, '<')
+		if i < 0 || i+1 >= len(s) {
+			return nil
+		}
+		s = s[i+1:]
+		switch {
+		case strings.HasPrefix(s, "!--"):
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				return nil
+			}
+			s = s[end+3:]
+			continue
+		case s[0] == '!' || s[0] == '?' || s[0] == '/':
+			continue
+		}
+		break
+	}
+
+	// Skip the tag name.
+	i := strings.IndexAny(s, " \t\r\n/>")
+	if i < 0 {
+		return nil
+	}
+	s = s[i:]
+
+	attrs := make(map[string]struct{})
+	for {
+		s = strings.TrimLeft(s, " \t\r\n/")
+		if s == "" || s[0] == '>' {
+			return attrs
+		}
+		end := strings.IndexAny(s, " \t\r\n=/>")
+		if end < 0 {
+			end = len(s)
+		}
+		attrs[strings.ToLower(s[:end])] = struct{}{}
+		s = strings.TrimLeft(s[end:], " \t\r\n")
+		if !strings.HasPrefix(s, "=") {
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t\r\n")
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			closing := strings.IndexByte(s[1:], s[0])
+			if closing < 0 {
+				return attrs
+			}
+			s = s[closing+2:]
+			continue
+		}
+		end = strings.IndexAny(s, " \t\r\n>")
+		if end < 0 {
+			return attrs
+		}
+		s = s[end:]
+	}
+}
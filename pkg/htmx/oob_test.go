@@ -0,0 +1,81 @@
+package htmx_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dmitrymomot/forge/pkg/htmx"
+)
+
+func TestOOBList(t *testing.T) {
+	t.Run("renders components in order", func(t *testing.T) {
+		list := htmx.OOBList(
+			mockComponent{content: `<li id="m1" hx-swap-oob="beforeend:#chat">one</li>`},
+			mockComponent{content: `<li id="m2" hx-swap-oob="beforeend:#chat">two</li>`},
+		)
+
+		var sb strings.Builder
+		if err := list.Render(context.Background(), &sb); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+
+		want := `<li id="m1" hx-swap-oob="beforeend:#chat">one</li><li id="m2" hx-swap-oob="beforeend:#chat">two</li>`
+		if sb.String() != want {
+			t.Errorf("Render() = %q, want %q", sb.String(), want)
+		}
+	})
+
+	t.Run("stops at an invalid fragment", func(t *testing.T) {
+		list := htmx.OOBList(
+			mockComponent{content: `<li id="m1" hx-swap-oob="true">one</li>`},
+			mockComponent{content: `<li hx-swap-oob="true">two</li>`},
+		)
+
+		var sb strings.Builder
+		err := list.Render(context.Background(), &sb)
+		if !errors.Is(err, htmx.ErrOOBMissingID) {
+			t.Fatalf("Render() error = %v, want ErrOOBMissingID", err)
+		}
+		if strings.Contains(sb.String(), "two") {
+			t.Errorf("invalid fragment was written: %q", sb.String())
+		}
+	})
+
+	t.Run("empty list renders nothing", func(t *testing.T) {
+		var sb strings.Builder
+		if err := htmx.OOBList[htmx.Renderable]().Render(context.Background(), &sb); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if sb.Len() != 0 {
+			t.Errorf("Render() = %q, want empty", sb.String())
+		}
+	})
+}
+
+func TestValidateOOB(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		want     error
+	}{
+		{"valid", `<div id="a" hx-swap-oob="true">x</div>`, nil},
+		{"leading whitespace and comment", "\n  <!-- row --><tr hx-swap-oob='outerHTML' id=row-1><td>x</td></tr>", nil},
+		{"uppercase attributes", `<DIV ID="a" HX-SWAP-OOB>x</DIV>`, nil},
+		{"quoted value containing id", `<div title="id=x" hx-swap-oob="true">x</div>`, htmx.ErrOOBMissingID},
+		{"nested id only", `<div hx-swap-oob="true"><span id="a"></span></div>`, htmx.ErrOOBMissingID},
+		{"missing swap", `<div id="a">x</div>`, htmx.ErrOOBMissingSwap},
+		{"plain text", `hello`, htmx.ErrOOBMissingID},
+		{"empty", ``, htmx.ErrOOBMissingID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := htmx.ValidateOOB([]byte(tt.fragment))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateOOB(%q) = %v, want %v", tt.fragment, err, tt.want)
+			}
+		})
+	}
+}
@@ -79,10 +79,29 @@
 //	fmt.Println(i18nInstance.Tn("en", "items", "count", 1))  // "1 item"
 //	fmt.Println(i18nInstance.Tn("en", "items", "count", 5))  // "5 items"
 //
+// As in ICU MessageFormat and i18next, exact-count keys like "=0" or "=1"
+// override the plural category for that count. They are checked before the
+// category in each language of the fallback chain:
+//
+//	"inbox": map[string]string{
+//		"=0":    "No new messages",
+//		"one":   "1 new message",
+//		"other": "{{count}} new messages",
+//	}
+//
 // # Language Fallback
 //
 // When a translation is not found in the requested language, the package
-// automatically falls back to the default language, then to the key itself.
+// automatically falls back to the base language ("de" for "de-AT"), then to
+// the default language, then to the key itself.
+//
+// WithFallbackChain replaces the base-language step with an explicit list,
+// for regional variants that share most keys with another language:
+//
+//	i18n.New(
+//		i18n.WithDefaultLanguage("en"),
+//		i18n.WithFallbackChain("pt-BR", "pt"), // pt-BR → pt → en → key
+//	)
 //
 // # Translator
 //
@@ -95,6 +114,29 @@
 //	price := translator.FormatCurrency(19.99)  // "19,99 €"
 //	date := translator.FormatDate(time.Now())   // "07.02.2026"
 //
+// ParseNumber and ParseCurrency read localized input back, so forms can both
+// display and accept amounts in the user's format:
+//
+//	amount, err := translator.ParseCurrency("1.234,56 €") // 1234.56
+//	if errors.Is(err, i18n.ErrInvalidCurrency) {
+//		// reject the form field
+//	}
+//
+// # Formatted Placeholders
+//
+// A placeholder can name a format directive after a comma, so translators
+// decide where a value goes and the Translator formats it for the locale.
+// Pass numbers and time.Time values instead of pre-formatted strings:
+//
+//	// "due": "{{total, currency}} fällig am {{due, date}}"
+//	translator.T("due", i18n.M{"total": 1234.5, "due": invoice.DueAt})
+//	// "1.234,50 € fällig am 09.03.2026"
+//
+// The directives are number, currency, and percent for numeric values and
+// date, time, and datetime for time.Time. An unknown directive or a value
+// of another type is inserted with %v, as is every directive in I18n.T,
+// which has no locale format.
+//
 // # Predefined Locale Formats
 //
 // The package includes predefined formats for common locales:
@@ -111,6 +153,16 @@
 //
 //	bestMatch := i18n.ParseAcceptLanguage("es-ES,es;q=0.9,en;q=0.8", available)
 //
+// # Validating Translations
+//
+// Validate compares every language with the default language and reports
+// missing keys, mismatched placeholders, and plural keys without the forms
+// the language's plural rule needs. Run it in a test so CI catches drift:
+//
+//	for _, issue := range i18n.Validate(inst) {
+//	    t.Error(issue) // e.g. "es app:greeting: placeholder_mismatch: missing {{name}}"
+//	}
+//
 // # Thread Safety
 //
 // The I18n struct is immutable after creation, making it safe for concurrent use
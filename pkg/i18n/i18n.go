@@ -3,7 +3,9 @@ package i18n
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -24,6 +26,10 @@ type I18n struct {
 	// Useful for detecting untranslated keys during development or monitoring gaps in translations.
 	missingKeyHandler func(lang, namespace, key string)
 
+	// Fallback chains keyed by language. After New, each chain starts with
+	// the language itself and ends with the default language.
+	fallbackChains map[string][]string
+
 	// Default/fallback language.
 	defaultLang string
 
@@ -55,6 +61,7 @@ func New(opts ...Option) (*I18n, error) {
 	}
 
 	i.languages = i.buildLanguagesList()
+	i.buildFallbackChains()
 
 	return i, nil
 }
@@ -104,6 +111,30 @@ func WithLanguages(langs ...string) Option {
 	}
 }
 
+// WithFallbackChain sets the languages tried, in order, when a key is missing
+// in lang, before the default language. For example, with
+// WithFallbackChain("pt-BR", "pt") a lookup for "pt-BR" tries "pt-BR",
+// "pt", the default language, and finally returns the key.
+// Without a chain, a regional language such as "de-AT" falls back to its
+// base language "de" automatically.
+func WithFallbackChain(lang string, chain ...string) Option {
+	return func(i *I18n) error {
+		if lang == "" {
+			return ErrEmptyLanguage
+		}
+		for _, l := range chain {
+			if l == "" {
+				return ErrEmptyLanguage
+			}
+		}
+		if i.fallbackChains == nil {
+			i.fallbackChains = make(map[string][]string)
+		}
+		i.fallbackChains[lang] = chain
+		return nil
+	}
+}
+
 // WithTranslations loads translations for a specific language and namespace.
 // The translations map can be nested; it will be flattened internally for
 // efficient lookups.
@@ -159,26 +190,23 @@ func WithMissingKeyHandler(handler func(lang, namespace, key string)) Option {
 
 // T retrieves a translation for the given language, namespace, and key.
 // Placeholders in the translation are replaced with values from the provided maps.
-// Falls back to the default language if translation is not found.
+// Falls back through the language's fallback chain (or its base language)
+// and then the default language if translation is not found.
 // Returns the key itself if no translation exists.
 func (i *I18n) T(lang, namespace, key string, placeholders ...M) string {
-	compositeKey := buildKey(lang, namespace, key)
-	if translation, exists := i.translations[compositeKey]; exists {
-		return replacePlaceholdersWithMerge(translation, placeholders...)
-	}
-
-	if base := baseLanguage(lang); base != lang {
-		baseKey := buildKey(base, namespace, key)
-		if translation, exists := i.translations[baseKey]; exists {
-			return replacePlaceholdersWithMerge(translation, placeholders...)
-		}
-	}
+	return i.translate(lang, namespace, key, nil, placeholders...)
+}
 
-	if lang != i.defaultLang && baseLanguage(lang) != i.defaultLang {
-		defaultKey := buildKey(i.defaultLang, namespace, key)
-		if translation, exists := i.translations[defaultKey]; exists {
-			return replacePlaceholdersWithMerge(translation, placeholders...)
-		}
+// translate implements T, applying placeholder format directives with lf.
+func (i *I18n) translate(lang, namespace, key string, lf *LocaleFormat, placeholders ...M) string {
+	var translation string
+	found := i.resolve(lang, func(l string) bool {
+		var exists bool
+		translation, exists = i.translations[buildKey(l, namespace, key)]
+		return exists
+	})
+	if found {
+		return replacePlaceholdersWithMerge(translation, lf, placeholders...)
 	}
 
 	if i.missingKeyHandler != nil {
@@ -190,40 +218,33 @@ func (i *I18n) T(lang, namespace, key string, placeholders ...M) string {
 
 // Tn retrieves a pluralized translation for the given count.
 // It automatically selects the appropriate plural form based on the language's plural rule
-// and injects the count as a placeholder.
+// and injects the count as a placeholder. As in ICU MessageFormat, an exact-count
+// key such as "=0" or "=1" takes precedence over the plural category.
 func (i *I18n) Tn(lang, namespace, key string, n int, placeholders ...M) string {
-	rule, exists := i.pluralRules[lang]
-	if !exists {
-		if base := baseLanguage(lang); base != lang {
-			rule, exists = i.pluralRules[base]
-		}
-		if !exists {
-			if rule, exists = i.pluralRules[i.defaultLang]; !exists {
-				rule = DefaultPluralRule
-			}
-		}
+	return i.translatePlural(lang, namespace, key, n, nil, placeholders...)
+}
+
+// translatePlural implements Tn, applying placeholder format directives with lf.
+func (i *I18n) translatePlural(lang, namespace, key string, n int, lf *LocaleFormat, placeholders ...M) string {
+	var rule PluralRule
+	if !i.resolve(lang, func(l string) bool {
+		var exists bool
+		rule, exists = i.pluralRules[l]
+		return exists
+	}) {
+		rule = DefaultPluralRule
 	}
 
 	form := rule(n)
 	pluralKey := key + "." + form
+	exactKey := key + ".=" + strconv.Itoa(n)
 
 	var translation string
-	var found bool
-
-	// Try exact language
-	found, translation = i.findPluralTranslation(lang, namespace, pluralKey, key, form)
-
-	// Try base language (e.g., "en" for "en-US")
-	if !found {
-		if base := baseLanguage(lang); base != lang {
-			found, translation = i.findPluralTranslation(base, namespace, pluralKey, key, form)
-		}
-	}
-
-	// Try default language
-	if !found && lang != i.defaultLang && baseLanguage(lang) != i.defaultLang {
-		found, translation = i.findPluralTranslation(i.defaultLang, namespace, pluralKey, key, form)
-	}
+	found := i.resolve(lang, func(l string) bool {
+		var ok bool
+		ok, translation = i.findPluralTranslation(l, namespace, exactKey, pluralKey, key, form)
+		return ok
+	})
 
 	if !found {
 		if i.missingKeyHandler != nil {
@@ -237,12 +258,16 @@ func (i *I18n) Tn(lang, namespace, key string, n int, placeholders ...M) string
 		maps.Copy(mergedPlaceholders, p)
 	}
 
-	return ReplacePlaceholders(translation, mergedPlaceholders)
+	return replacePlaceholders(translation, mergedPlaceholders, lf)
 }
 
 // findPluralTranslation tries to find a plural translation for a given language,
-// checking the exact form first, then fallback forms.
-func (i *I18n) findPluralTranslation(lang, namespace, pluralKey, key, form string) (bool, string) {
+// checking the exact-count key ("=0") first, then the plural form, then
+// fallback forms.
+func (i *I18n) findPluralTranslation(lang, namespace, exactKey, pluralKey, key, form string) (bool, string) {
+	if trans, exists := i.translations[buildKey(lang, namespace, exactKey)]; exists {
+		return true, trans
+	}
 	compositeKey := buildKey(lang, namespace, pluralKey)
 	if trans, exists := i.translations[compositeKey]; exists {
 		return true, trans
@@ -266,6 +291,45 @@ func (i *I18n) DefaultLanguage() string {
 	return i.defaultLang
 }
 
+// resolve calls find for each language in lang's fallback order until it
+// returns true. The order is lang's configured chain, or lang, its base
+// language, and the default language when no chain is set. It reports
+// whether find succeeded; the cost is O(chain length) with no allocations.
+func (i *I18n) resolve(lang string, find func(lang string) bool) bool {
+	if chain, ok := i.fallbackChains[lang]; ok {
+		for _, l := range chain {
+			if find(l) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if find(lang) {
+		return true
+	}
+	base := baseLanguage(lang)
+	if base != lang && find(base) {
+		return true
+	}
+	return lang != i.defaultLang && base != i.defaultLang && find(i.defaultLang)
+}
+
+// buildFallbackChains expands each configured chain to lang, chain...,
+// default language, dropping duplicates. It runs once the default language
+// is final, so options can be given in any order.
+func (i *I18n) buildFallbackChains() {
+	for lang, chain := range i.fallbackChains {
+		full := make([]string, 0, len(chain)+2)
+		for _, l := range slices.Concat([]string{lang}, chain, []string{i.defaultLang}) {
+			if !slices.Contains(full, l) {
+				full = append(full, l)
+			}
+		}
+		i.fallbackChains[lang] = full
+	}
+}
+
 func (i *I18n) buildLanguagesList() []string {
 	if len(i.languages) > 0 {
 		return i.languages
@@ -304,7 +368,7 @@ func flattenTranslations(data map[string]any, prefix string) map[string]string {
 	return result
 }
 
-func replacePlaceholdersWithMerge(template string, placeholders ...M) string {
+func replacePlaceholdersWithMerge(template string, lf *LocaleFormat, placeholders ...M) string {
 	if len(placeholders) == 0 {
 		return template
 	}
@@ -314,7 +378,7 @@ func replacePlaceholdersWithMerge(template string, placeholders ...M) string {
 		maps.Copy(merged, p)
 	}
 
-	return ReplacePlaceholders(template, merged)
+	return replacePlaceholders(template, merged, lf)
 }
 
 // baseLanguage strips the region from a language tag (e.g., "en-US" → "en").
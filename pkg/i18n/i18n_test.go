@@ -316,6 +316,41 @@ func TestTn(t *testing.T) {
 		require.Equal(t, "You have 0 new messages", inst.Tn("en", "general", "messages", 0))
 	})
 
+	t.Run("exact-count keys take precedence over plural category", func(t *testing.T) {
+		t.Parallel()
+		inst, err := i18n.New(
+			i18n.WithPluralRule("en", i18n.EnglishPluralRule),
+			i18n.WithTranslations("en", "general", map[string]any{
+				"inbox": map[string]any{
+					"=0":    "No new messages",
+					"=12":   "A dozen new messages",
+					"zero":  "zero category",
+					"one":   "1 new message",
+					"other": "{{count}} new messages",
+				},
+			}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "No new messages", inst.Tn("en", "general", "inbox", 0))
+		require.Equal(t, "1 new message", inst.Tn("en", "general", "inbox", 1))
+		require.Equal(t, "A dozen new messages", inst.Tn("en", "general", "inbox", 12))
+		require.Equal(t, "13 new messages", inst.Tn("en", "general", "inbox", 13))
+	})
+
+	t.Run("exact-count key matches negative counts", func(t *testing.T) {
+		t.Parallel()
+		inst, err := i18n.New(
+			i18n.WithTranslations("en", "general", map[string]any{
+				"delta": map[string]any{
+					"=-1":   "one fewer",
+					"other": "{{count}} change",
+				},
+			}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "one fewer", inst.Tn("en", "general", "delta", -1))
+	})
+
 	t.Run("injects count placeholder automatically", func(t *testing.T) {
 		t.Parallel()
 		inst := setup()
@@ -505,6 +540,77 @@ func TestBaseLanguageFallback(t *testing.T) {
 	})
 }
 
+func TestFallbackChain(t *testing.T) {
+	t.Parallel()
+
+	inst, err := i18n.New(
+		i18n.WithFallbackChain("pt-BR", "pt"),
+		i18n.WithFallbackChain("gsw", "de-CH", "de"),
+		i18n.WithDefaultLanguage("en"),
+		i18n.WithTranslations("en", "ui", map[string]any{
+			"save":   "Save",
+			"cancel": "Cancel",
+			"files":  map[string]any{"one": "{{count}} file", "other": "{{count}} files"},
+		}),
+		i18n.WithTranslations("pt", "ui", map[string]any{
+			"save":  "Salvar",
+			"files": map[string]any{"one": "{{count}} arquivo", "other": "{{count}} arquivos"},
+		}),
+		i18n.WithTranslations("pt-BR", "ui", map[string]any{
+			"save": "Gravar",
+		}),
+		i18n.WithTranslations("de", "ui", map[string]any{
+			"save":   "Speichern",
+			"cancel": "Abbrechen",
+		}),
+		i18n.WithTranslations("de-CH", "ui", map[string]any{
+			"save": "Sichern",
+		}),
+	)
+	require.NoError(t, err)
+
+	t.Run("exact language wins", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "Gravar", inst.T("pt-BR", "ui", "save"))
+	})
+
+	t.Run("walks the chain before the default", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "Abbrechen", inst.T("gsw", "ui", "cancel"))
+		require.Equal(t, "Sichern", inst.T("gsw", "ui", "save"))
+	})
+
+	t.Run("falls back to default after the chain", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "Cancel", inst.T("pt-BR", "ui", "cancel"))
+	})
+
+	t.Run("returns key when missing everywhere", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "missing", inst.T("pt-BR", "ui", "missing"))
+	})
+
+	t.Run("regional language without a chain uses its base", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "Speichern", inst.T("de-AT", "ui", "save"))
+	})
+
+	t.Run("Tn follows the chain", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "2 arquivos", inst.Tn("pt-BR", "ui", "files", 2))
+	})
+
+	t.Run("rejects empty languages", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := i18n.New(i18n.WithFallbackChain("", "pt"))
+		require.ErrorIs(t, err, i18n.ErrEmptyLanguage)
+
+		_, err = i18n.New(i18n.WithFallbackChain("pt-BR", ""))
+		require.ErrorIs(t, err, i18n.ErrEmptyLanguage)
+	})
+}
+
 func TestFlattenTranslations(t *testing.T) {
 	t.Parallel()
 
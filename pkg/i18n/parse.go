@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseNumber parses a number written with the locale's separators, the
+// inverse of FormatNumber: "1.234,56" in de-DE yields 1234.56.
+// Thousand separators are optional but, when present, must group digits in
+// threes. A locale that groups with a space also accepts the no-break spaces
+// browsers and spreadsheets often insert. Returns ErrInvalidNumber on
+// malformed input.
+func (lf *LocaleFormat) ParseNumber(s string) (float64, error) {
+	n, ok := lf.parseNumber(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+	}
+	return n, nil
+}
+
+// ParseCurrency parses an amount written the way FormatCurrency writes it,
+// such as "-$1,234.56" in en-US or "1.234,56 €" in de-DE. The currency symbol
+// is optional, but if present it must be on the locale's side of the number.
+// Returns ErrInvalidCurrency on malformed input.
+func (lf *LocaleFormat) ParseCurrency(s string) (float64, error) {
+	str := strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(str, "-")
+	if negative {
+		str = strings.TrimSpace(str[1:])
+	}
+
+	if lf.currencySymbol != "" {
+		if lf.currencyPosition == "before" {
+			str = strings.TrimSpace(strings.TrimPrefix(str, lf.currencySymbol))
+		} else {
+			str = strings.TrimSpace(strings.TrimSuffix(str, lf.currencySymbol))
+		}
+	}
+
+	// The sign belongs in front of the whole amount; reject a second one.
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+
+	n, ok := lf.parseNumber(str)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+	if negative {
+		n = -n
+	}
+	return n, nil
+}
+
+// parseNumber converts s to the canonical form strconv understands.
+func (lf *LocaleFormat) parseNumber(s string) (float64, bool) {
+	var sign string
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+
+	thousand := lf.thousandSeparator
+	if thousand == " " {
+		s = strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(s)
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, lf.decimalSeparator)
+	if hasFrac && (fracPart == "" || !isDigits(fracPart)) {
+		return 0, false
+	}
+	if intPart == "" && !hasFrac {
+		return 0, false
+	}
+
+	if thousand != "" && strings.Contains(intPart, thousand) {
+		groups := strings.Split(intPart, thousand)
+		for i, g := range groups {
+			if !isDigits(g) || (i == 0 && len(g) > 3) || (i > 0 && len(g) != 3) {
+				return 0, false
+			}
+		}
+		intPart = strings.Join(groups, "")
+	} else if intPart != "" && !isDigits(intPart) {
+		return 0, false
+	}
+
+	canonical := sign + intPart
+	if intPart == "" {
+		canonical += "0"
+	}
+	if hasFrac {
+		canonical += "." + fracPart
+	}
+
+	n, err := strconv.ParseFloat(canonical, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
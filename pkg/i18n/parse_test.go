@@ -0,0 +1,131 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/i18n"
+)
+
+func TestLocaleFormat_ParseNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format *i18n.LocaleFormat
+		input  string
+		want   float64
+	}{
+		{"en-US grouped", i18n.FormatEnUS(), "1,234.56", 1234.56},
+		{"en-US ungrouped", i18n.FormatEnUS(), "1234.5", 1234.5},
+		{"en-US negative", i18n.FormatEnUS(), "-1,234,567.89", -1234567.89},
+		{"en-US leading decimal", i18n.FormatEnUS(), ".5", 0.5},
+		{"de-DE grouped", i18n.FormatDeDE(), "1.234,56", 1234.56},
+		{"de-DE integer", i18n.FormatDeDE(), "1.234", 1234},
+		{"fr-FR space", i18n.FormatFrFR(), "1 234,56", 1234.56},
+		{"fr-FR no-break space", i18n.FormatFrFR(), "1\u00a0234,56", 1234.56},
+		{"fr-FR narrow no-break space", i18n.FormatFrFR(), "1\u202f234\u202f567", 1234567},
+		{"ja-JP", i18n.FormatJaJP(), "12,345", 12345},
+		{"surrounding whitespace", i18n.FormatEnUS(), "  42 ", 42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.format.ParseNumber(tt.input)
+			require.NoError(t, err)
+			require.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+
+	t.Run("round trips FormatNumber", func(t *testing.T) {
+		t.Parallel()
+		for _, lf := range []*i18n.LocaleFormat{i18n.FormatEnUS(), i18n.FormatDeDE(), i18n.FormatFrFR(), i18n.FormatJaJP()} {
+			for _, n := range []float64{0, 7, 1234.5, -98765.43} {
+				got, err := lf.ParseNumber(lf.FormatNumber(n))
+				require.NoError(t, err)
+				require.InDelta(t, n, got, 1e-9)
+			}
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		format *i18n.LocaleFormat
+		input  string
+	}{
+		{"empty", i18n.FormatEnUS(), ""},
+		{"letters", i18n.FormatEnUS(), "12a"},
+		{"two decimal separators", i18n.FormatEnUS(), "1.2.3"},
+		{"bad grouping", i18n.FormatEnUS(), "12,34.5"},
+		{"oversized first group", i18n.FormatEnUS(), "1234,567"},
+		{"separator in fraction", i18n.FormatDeDE(), "1,234.5"},
+		{"trailing decimal separator", i18n.FormatDeDE(), "12,"},
+		{"sign only", i18n.FormatEnUS(), "-"},
+	}
+	for _, tt := range invalid {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tt.format.ParseNumber(tt.input)
+			require.ErrorIs(t, err, i18n.ErrInvalidNumber)
+		})
+	}
+}
+
+func TestLocaleFormat_ParseCurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format *i18n.LocaleFormat
+		input  string
+		want   float64
+	}{
+		{"en-US", i18n.FormatEnUS(), "$1,234.56", 1234.56},
+		{"en-US negative", i18n.FormatEnUS(), "-$1,234.56", -1234.56},
+		{"en-US without symbol", i18n.FormatEnUS(), "99.99", 99.99},
+		{"de-DE", i18n.FormatDeDE(), "1.234,56 €", 1234.56},
+		{"de-DE negative", i18n.FormatDeDE(), "-1.234,56 €", -1234.56},
+		{"fr-FR no-break space", i18n.FormatFrFR(), "1\u00a0234,56\u00a0€", 1234.56},
+		{"ja-JP", i18n.FormatJaJP(), "¥12,345.00", 12345},
+		{"pt-BR", i18n.FormatPtBR(), "R$1.234,56", 1234.56},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.format.ParseCurrency(tt.input)
+			require.NoError(t, err)
+			require.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+
+	t.Run("round trips FormatCurrency", func(t *testing.T) {
+		t.Parallel()
+		for _, lf := range []*i18n.LocaleFormat{i18n.FormatEnUS(), i18n.FormatDeDE(), i18n.FormatFrFR(), i18n.FormatJaJP()} {
+			for _, n := range []float64{0, 7.5, 1234.56, -98765.43} {
+				got, err := lf.ParseCurrency(lf.FormatCurrency(n))
+				require.NoError(t, err)
+				require.InDelta(t, n, got, 1e-9)
+			}
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		format *i18n.LocaleFormat
+		input  string
+	}{
+		{"empty", i18n.FormatEnUS(), ""},
+		{"symbol only", i18n.FormatEnUS(), "$"},
+		{"symbol on wrong side", i18n.FormatDeDE(), "€ 12,50"},
+		{"double sign", i18n.FormatEnUS(), "-$-5"},
+		{"wrong separators", i18n.FormatDeDE(), "1,234.56 €"},
+	}
+	for _, tt := range invalid {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tt.format.ParseCurrency(tt.input)
+			require.ErrorIs(t, err, i18n.ErrInvalidCurrency)
+		})
+	}
+}
@@ -3,28 +3,127 @@ package i18n
 import (
 	"fmt"
 	"strings"
+	"time"
+)
+
+// Placeholder format directives, written after the name: {{price, currency}}.
+const (
+	FormatDirectiveNumber   = "number"
+	FormatDirectiveCurrency = "currency"
+	FormatDirectivePercent  = "percent"
+	FormatDirectiveDate     = "date"
+	FormatDirectiveTime     = "time"
+	FormatDirectiveDateTime = "datetime"
 )
 
 // ReplacePlaceholders replaces placeholders in the template string with values
 // from the provided map. Placeholders use the format {{name}}.
 // If a placeholder is not found in the map, it remains unchanged.
 //
+// A placeholder may carry a format directive, {{name, currency}}; without a
+// LocaleFormat the value is inserted as is. Translator.T applies the
+// directives with its locale format.
+//
 // Example:
 //
 //	template: "Hello, {{name}}! You have {{count}} messages."
 //	placeholders: M{"name": "John", "count": 5}
 //	returns: "Hello, John! You have 5 messages."
 func ReplacePlaceholders(template string, placeholders M) string {
+	return replacePlaceholders(template, placeholders, nil)
+}
+
+// replacePlaceholders substitutes every {{name}} and {{name, directive}} in
+// template. Directives are applied with lf when it is non-nil and the value
+// has a matching type; otherwise the value is formatted with %v.
+func replacePlaceholders(template string, placeholders M, lf *LocaleFormat) string {
 	if len(placeholders) < 1 {
 		return template
 	}
 
-	result := template
-	for key, value := range placeholders {
-		placeholder := "{{" + key + "}}"
-		replacement := fmt.Sprintf("%v", value)
-		result = strings.ReplaceAll(result, placeholder, replacement)
+	var b strings.Builder
+	rest := template
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start+2:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 2
+
+		name, directive, _ := strings.Cut(rest[start+2:end], ",")
+		value, ok := placeholders[strings.TrimSpace(name)]
+		b.WriteString(rest[:start])
+		if ok {
+			b.WriteString(formatPlaceholder(value, strings.TrimSpace(directive), lf))
+		} else {
+			b.WriteString(rest[start : end+2])
+		}
+		rest = rest[end+2:]
+	}
+	b.WriteString(rest)
+	return b.String()
+}
+
+// formatPlaceholder renders value for a placeholder with the given directive.
+func formatPlaceholder(value any, directive string, lf *LocaleFormat) string {
+	if lf == nil || directive == "" {
+		return fmt.Sprintf("%v", value)
 	}
 
-	return result
+	if t, ok := value.(time.Time); ok {
+		switch directive {
+		case FormatDirectiveDate:
+			return lf.FormatDate(t)
+		case FormatDirectiveTime:
+			return lf.FormatTime(t)
+		case FormatDirectiveDateTime:
+			return lf.FormatDateTime(t)
+		}
+	}
+	if n, ok := toFloat(value); ok {
+		switch directive {
+		case FormatDirectiveNumber:
+			return lf.FormatNumber(n)
+		case FormatDirectiveCurrency:
+			return lf.FormatCurrency(n)
+		case FormatDirectivePercent:
+			return lf.FormatPercent(n)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// toFloat converts Go's numeric types to float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
 }
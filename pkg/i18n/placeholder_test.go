@@ -89,6 +89,24 @@ func TestReplacePlaceholders(t *testing.T) {
 			placeholders: i18n.M{"user_name": "Dave", "item_count": 10},
 			expected:     "User Dave has 10 items",
 		},
+		{
+			name:         "format directive without locale inserts value as is",
+			template:     "Total: {{price, currency}}",
+			placeholders: i18n.M{"price": 19.5},
+			expected:     "Total: 19.5",
+		},
+		{
+			name:         "value containing braces is not expanded again",
+			template:     "{{a}} and {{b}}",
+			placeholders: i18n.M{"a": "{{b}}", "b": "B"},
+			expected:     "{{b}} and B",
+		},
+		{
+			name:         "unclosed placeholder",
+			template:     "Hello, {{name",
+			placeholders: i18n.M{"name": "Eve"},
+			expected:     "Hello, {{name",
+		},
 	}
 
 	for _, tt := range tests {
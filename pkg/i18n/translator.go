@@ -33,8 +33,10 @@ func NewTranslator(i18n *I18n, language, namespace string, format *LocaleFormat)
 }
 
 // T translates a key using the translator's language and namespace context.
+// Placeholders with a format directive, such as {{total, currency}} or
+// {{due, date}}, are formatted with the translator's LocaleFormat.
 func (t *Translator) T(key string, placeholders ...M) string {
-	return t.i18n.T(t.language, t.namespace, key, placeholders...)
+	return t.i18n.translate(t.language, t.namespace, key, t.format, placeholders...)
 }
 
 // TranslateMessage translates a key with a single placeholder map.
@@ -42,12 +44,13 @@ func (t *Translator) T(key string, placeholders ...M) string {
 //
 //	ve.Translate(translator.TranslateMessage)
 func (t *Translator) TranslateMessage(key string, values map[string]any) string {
-	return t.i18n.T(t.language, t.namespace, key, values)
+	return t.i18n.translate(t.language, t.namespace, key, t.format, values)
 }
 
 // Tn translates a key with pluralization using the translator's language and namespace context.
+// Format directives are applied as in T, so {{count, number}} gets thousand separators.
 func (t *Translator) Tn(key string, n int, placeholders ...M) string {
-	return t.i18n.Tn(t.language, t.namespace, key, n, placeholders...)
+	return t.i18n.translatePlural(t.language, t.namespace, key, n, t.format, placeholders...)
 }
 
 // FormatNumber formats a number with locale-specific separators.
@@ -66,6 +69,18 @@ func (t *Translator) FormatPercent(n float64) string {
 	return t.format.FormatPercent(n)
 }
 
+// ParseNumber parses a number written with locale-specific separators.
+// It is the inverse of FormatNumber.
+func (t *Translator) ParseNumber(s string) (float64, error) {
+	return t.format.ParseNumber(s)
+}
+
+// ParseCurrency parses a currency amount written with locale-specific formatting.
+// It is the inverse of FormatCurrency.
+func (t *Translator) ParseCurrency(s string) (float64, error) {
+	return t.format.ParseCurrency(s)
+}
+
 // FormatDate formats a date with locale-specific formatting.
 func (t *Translator) FormatDate(date time.Time) string {
 	return t.format.FormatDate(date)
@@ -144,3 +144,77 @@ func TestTranslatorFormatting(t *testing.T) {
 		require.Equal(t, "1,234.5", format.FormatNumber(1234.5))
 	})
 }
+
+func TestTranslatorFormatDirectives(t *testing.T) {
+	t.Parallel()
+
+	inst, err := i18n.New(
+		i18n.WithDefaultLanguage("en"),
+		i18n.WithLanguages("en", "de"),
+		i18n.WithTranslations("en", "billing", map[string]any{
+			"due":      "{{total, currency}} due on {{due, date}} at {{due, time}}",
+			"discount": "Save {{rate, percent}} on {{seats, number}} seats",
+			"mixed":    "{{name}} owes {{total, currency}}",
+			"unknown":  "{{total, fancy}} / {{name, currency}} / {{missing, date}}",
+			"invoices": map[string]any{
+				"one":   "{{count, number}} invoice",
+				"other": "{{count, number}} invoices",
+			},
+		}),
+		i18n.WithTranslations("de", "billing", map[string]any{
+			"due": "{{total, currency}} fällig am {{due, date}} um {{due, time}}",
+		}),
+	)
+	require.NoError(t, err)
+
+	due := time.Date(2026, 3, 9, 14, 30, 0, 0, time.UTC)
+
+	t.Run("formats per locale", func(t *testing.T) {
+		t.Parallel()
+
+		en := i18n.NewTranslator(inst, "en", "billing", i18n.FormatEnUS())
+		require.Equal(t, "$1,234.50 due on 03/09/2026 at 2:30 PM",
+			en.T("due", i18n.M{"total": 1234.5, "due": due}))
+
+		de := i18n.NewTranslator(inst, "de", "billing", i18n.FormatDeDE())
+		require.Equal(t, "1.234,50 € fällig am 09.03.2026 um 14:30",
+			de.T("due", i18n.M{"total": 1234.5, "due": due}))
+	})
+
+	t.Run("numbers of any type", func(t *testing.T) {
+		t.Parallel()
+
+		en := i18n.NewTranslator(inst, "en", "billing", i18n.FormatEnUS())
+		require.Equal(t, "Save 15% on 1,200 seats",
+			en.T("discount", i18n.M{"rate": float32(0.15), "seats": uint16(1200)}))
+	})
+
+	t.Run("plain placeholders are unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		en := i18n.NewTranslator(inst, "en", "billing", i18n.FormatEnUS())
+		require.Equal(t, "Acme owes $10.00", en.T("mixed", i18n.M{"name": "Acme", "total": 10}))
+	})
+
+	t.Run("unknown directive or mismatched type falls back to value", func(t *testing.T) {
+		t.Parallel()
+
+		en := i18n.NewTranslator(inst, "en", "billing", i18n.FormatEnUS())
+		require.Equal(t, "42 / Acme / {{missing, date}}",
+			en.T("unknown", i18n.M{"total": 42, "name": "Acme"}))
+	})
+
+	t.Run("plural count", func(t *testing.T) {
+		t.Parallel()
+
+		en := i18n.NewTranslator(inst, "en", "billing", i18n.FormatEnUS())
+		require.Equal(t, "2,500 invoices", en.Tn("invoices", 2500))
+		require.Equal(t, "1 invoice", en.Tn("invoices", 1))
+	})
+
+	t.Run("I18n.T has no locale and inserts values as is", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "Acme owes 10", inst.T("en", "billing", "mixed", i18n.M{"name": "Acme", "total": 10}))
+	})
+}
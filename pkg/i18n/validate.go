@@ -0,0 +1,257 @@
+package i18n
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// IssueKind classifies a ValidationIssue.
+type IssueKind string
+
+// Validation issue kinds.
+const (
+	// IssueMissingKey is a key of the default language that a language
+	// neither translates nor inherits from a non-default fallback language.
+	IssueMissingKey IssueKind = "missing_key"
+
+	// IssuePlaceholderMismatch is a translation whose placeholder names
+	// differ from the default language's.
+	IssuePlaceholderMismatch IssueKind = "placeholder_mismatch"
+
+	// IssueMissingPluralForm is a plural key without a form the language's
+	// plural rule selects.
+	IssueMissingPluralForm IssueKind = "missing_plural_form"
+)
+
+// ValidationIssue describes one translation problem found by Validate.
+type ValidationIssue struct {
+	Kind      IssueKind
+	Lang      string
+	Namespace string
+
+	// Key is the translation key; for plural keys it is the key passed to
+	// Tn, without the form suffix.
+	Key string
+
+	// Detail explains the problem, e.g. "missing {{name}}".
+	Detail string
+}
+
+// String formats the issue as "lang namespace:key: kind: detail".
+func (v ValidationIssue) String() string {
+	s := fmt.Sprintf("%s %s:%s: %s", v.Lang, v.Namespace, v.Key, v.Kind)
+	if v.Detail != "" {
+		s += ": " + v.Detail
+	}
+	return s
+}
+
+// Validate compares every language's translations with the default
+// language's and returns the problems found, sorted by language, namespace,
+// and key. It is meant to run in a unit test so CI fails when translations
+// drift:
+//
+//	func TestTranslations(t *testing.T) {
+//	    for _, issue := range i18n.Validate(app.I18n) {
+//	        t.Error(issue)
+//	    }
+//	}
+//
+// Validate reports keys missing in a language, translations whose
+// placeholders differ from the default language's, and plural keys without
+// a form the language's plural rule selects. A key inherited from a fallback
+// language other than the default (e.g. "pt-BR" from "pt") is not missing.
+// The zero form is never required: Tn falls back from it to other.
+// Placeholder names are compared without format directives, and {{count}}
+// is ignored for plural keys because Tn always provides it.
+func Validate(inst *I18n) []ValidationIssue {
+	entries := make(map[string]map[string]string) // lang -> "namespace:key" -> text
+	for composite, text := range inst.translations {
+		lang, rest, _ := strings.Cut(composite, ":")
+		if entries[lang] == nil {
+			entries[lang] = make(map[string]string)
+		}
+		entries[lang][rest] = text
+	}
+
+	langs := slices.Clone(inst.languages)
+	for lang := range entries {
+		if !slices.Contains(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+
+	grouped := make(map[string]map[string]*message, len(entries))
+	for lang, e := range entries {
+		grouped[lang] = groupMessages(e)
+	}
+	base := grouped[inst.defaultLang]
+
+	var issues []ValidationIssue
+	for _, lang := range langs {
+		own := grouped[lang]
+		for id, want := range base {
+			namespace, key, _ := strings.Cut(id, ":")
+			issue := ValidationIssue{Lang: lang, Namespace: namespace, Key: key}
+
+			got, ok := own[id]
+			if lang != inst.defaultLang && !ok {
+				if !inst.inheritsMessage(lang, grouped, id) {
+					issue.Kind = IssueMissingKey
+					issues = append(issues, issue)
+				}
+				continue
+			}
+
+			if got.plural {
+				if missing := missingPluralForms(got, inst.pluralRuleFor(lang)); len(missing) > 0 {
+					issue.Kind = IssueMissingPluralForm
+					issue.Detail = "missing " + strings.Join(missing, ", ")
+					issues = append(issues, issue)
+				}
+			}
+
+			if lang == inst.defaultLang {
+				continue
+			}
+			if detail := diffPlaceholders(want.placeholders, got.placeholders); detail != "" {
+				issue.Kind = IssuePlaceholderMismatch
+				issue.Detail = detail
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b ValidationIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.Lang, b.Lang),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Key, b.Key),
+			cmp.Compare(a.Kind, b.Kind),
+		)
+	})
+	return issues
+}
+
+// message is a translation key as Validate sees it: a plain key, or a plural
+// key with its forms.
+type message struct {
+	forms        map[string]bool
+	placeholders map[string]bool
+	plural       bool
+}
+
+// groupMessages folds the forms of plural keys ("items.one", "items.=0")
+// into one message keyed by "namespace:items".
+func groupMessages(entries map[string]string) map[string]*message {
+	msgs := make(map[string]*message, len(entries))
+	for id, text := range entries {
+		form := ""
+		if dot := strings.LastIndexByte(id, '.'); dot > 0 && isPluralForm(id[dot+1:]) {
+			id, form = id[:dot], id[dot+1:]
+		}
+
+		m := msgs[id]
+		if m == nil {
+			m = &message{forms: make(map[string]bool), placeholders: make(map[string]bool)}
+			msgs[id] = m
+		}
+		if form != "" {
+			m.plural = true
+			m.forms[form] = true
+		}
+		for _, name := range placeholderNames(text) {
+			m.placeholders[name] = true
+		}
+	}
+
+	for _, m := range msgs {
+		if m.plural {
+			delete(m.placeholders, "count")
+		}
+	}
+	return msgs
+}
+
+// isPluralForm reports whether a key segment is a plural category or an
+// exact-count form such as "=0".
+func isPluralForm(segment string) bool {
+	switch segment {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return strings.HasPrefix(segment, "=")
+}
+
+// inheritsMessage reports whether a fallback language of lang other than
+// the default language has the message id.
+func (i *I18n) inheritsMessage(lang string, grouped map[string]map[string]*message, id string) bool {
+	return i.resolve(lang, func(l string) bool {
+		if l == lang || l == i.defaultLang {
+			return false
+		}
+		_, ok := grouped[l][id]
+		return ok
+	})
+}
+
+// pluralRuleFor returns the plural rule Tn uses for lang.
+func (i *I18n) pluralRuleFor(lang string) PluralRule {
+	if rule, ok := i.pluralRules[lang]; ok {
+		return rule
+	}
+	return GetPluralRuleForLanguage(lang)
+}
+
+// missingPluralForms returns the forms rule selects that m lacks, in CLDR
+// order. The zero form is optional.
+func missingPluralForms(m *message, rule PluralRule) []string {
+	var missing []string
+	for _, form := range SupportedPluralForms(rule) {
+		if form != PluralZero && !m.forms[form] {
+			missing = append(missing, form)
+		}
+	}
+	return missing
+}
+
+// diffPlaceholders describes how got's placeholder names differ from want's,
+// or returns "" if they match.
+func diffPlaceholders(want, got map[string]bool) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		if !got[name] {
+			parts = append(parts, "missing {{"+name+"}}")
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(got)) {
+		if !want[name] {
+			parts = append(parts, "unexpected {{"+name+"}}")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// placeholderNames returns the names of the {{name}} and
+// {{name, directive}} placeholders in text.
+func placeholderNames(text string) []string {
+	var names []string
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			return names
+		}
+		end := strings.Index(text[start+2:], "}}")
+		if end < 0 {
+			return names
+		}
+		name, _, _ := strings.Cut(text[start+2:start+2+end], ",")
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+		text = text[start+2+end+2:]
+	}
+}
@@ -0,0 +1,136 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/i18n"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("consistent translations", func(t *testing.T) {
+		t.Parallel()
+
+		inst, err := i18n.New(
+			i18n.WithLanguages("en", "de"),
+			i18n.WithTranslations("en", "app", map[string]any{
+				"greeting": "Hello, {{name}}!",
+				"total":    "Total: {{amount, currency}}",
+				"items": map[string]any{
+					"one":   "One item",
+					"other": "{{count}} items",
+				},
+			}),
+			i18n.WithTranslations("de", "app", map[string]any{
+				"greeting": "Hallo, {{name}}!",
+				"total":    "Summe: {{amount}}",
+				"items": map[string]any{
+					"one":   "Ein Artikel",
+					"other": "{{count}} Artikel",
+				},
+			}),
+		)
+		require.NoError(t, err)
+
+		require.Empty(t, i18n.Validate(inst))
+	})
+
+	t.Run("reports drift", func(t *testing.T) {
+		t.Parallel()
+
+		inst, err := i18n.New(
+			i18n.WithLanguages("en", "es", "pl"),
+			i18n.WithTranslations("en", "app", map[string]any{
+				"greeting": "Hello, {{name}}!",
+				"farewell": "Bye",
+				"items": map[string]any{
+					"one":   "One item",
+					"other": "{{count}} items",
+				},
+			}),
+			i18n.WithTranslations("es", "app", map[string]any{
+				"greeting": "¡Hola, {{nombre}}!",
+				"items": map[string]any{
+					"one":   "Un artículo",
+					"many":  "{{count}} de artículos",
+					"other": "{{count}} artículos",
+				},
+			}),
+			i18n.WithTranslations("pl", "app", map[string]any{
+				"greeting": "Cześć, {{name}}!",
+				"farewell": "Pa",
+				"items": map[string]any{
+					"one":  "Jeden element",
+					"many": "{{count}} elementów",
+				},
+			}),
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, []i18n.ValidationIssue{
+			{Kind: i18n.IssueMissingKey, Lang: "es", Namespace: "app", Key: "farewell"},
+			{
+				Kind:      i18n.IssuePlaceholderMismatch,
+				Lang:      "es",
+				Namespace: "app",
+				Key:       "greeting",
+				Detail:    "missing {{name}}, unexpected {{nombre}}",
+			},
+			{
+				Kind:      i18n.IssueMissingPluralForm,
+				Lang:      "pl",
+				Namespace: "app",
+				Key:       "items",
+				Detail:    "missing few",
+			},
+		}, i18n.Validate(inst))
+	})
+
+	t.Run("fallback language covers missing keys", func(t *testing.T) {
+		t.Parallel()
+
+		inst, err := i18n.New(
+			i18n.WithLanguages("en", "pt", "pt-BR"),
+			i18n.WithTranslations("en", "app", map[string]any{"save": "Save", "cancel": "Cancel"}),
+			i18n.WithTranslations("pt", "app", map[string]any{"save": "Salvar", "cancel": "Cancelar"}),
+			i18n.WithTranslations("pt-BR", "app", map[string]any{"save": "Salvar"}),
+		)
+		require.NoError(t, err)
+
+		require.Empty(t, i18n.Validate(inst))
+	})
+
+	t.Run("checks default language plural forms", func(t *testing.T) {
+		t.Parallel()
+
+		inst, err := i18n.New(
+			i18n.WithTranslations("en", "app", map[string]any{
+				"items": map[string]any{"=0": "No items", "other": "{{count}} items"},
+			}),
+		)
+		require.NoError(t, err)
+
+		issues := i18n.Validate(inst)
+		require.Len(t, issues, 1)
+		require.Equal(t, i18n.IssueMissingPluralForm, issues[0].Kind)
+		require.Equal(t, "missing one", issues[0].Detail)
+		require.Equal(t, "en app:items: missing_plural_form: missing one", issues[0].String())
+	})
+
+	t.Run("includes languages with translations but not listed", func(t *testing.T) {
+		t.Parallel()
+
+		inst, err := i18n.New(
+			i18n.WithTranslations("en", "app", map[string]any{"title": "Title", "body": "Body"}),
+			i18n.WithTranslations("fr", "app", map[string]any{"title": "Titre"}),
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, []i18n.ValidationIssue{
+			{Kind: i18n.IssueMissingKey, Lang: "fr", Namespace: "app", Key: "body"},
+		}, i18n.Validate(inst))
+	})
+}
@@ -10,7 +10,7 @@
 //   - Scheduled/periodic tasks with cron expressions
 //   - Transactional job enqueueing (jobs only visible after commit)
 //   - Multiple named queues with configurable worker counts
-//   - Automatic retry with exponential backoff
+//   - Automatic retry with configurable backoff and per-task schedules
 //   - Job deduplication with uniqueness constraints
 //   - Priority-based job ordering
 //   - Health check integration
@@ -89,6 +89,25 @@
 //	func (t *HourlySync) Schedule() string { return "@hourly" }
 //	func (t *FrequentCheck) Schedule() string { return "@every 5m" }
 //
+// # Time Zones
+//
+// Schedules are evaluated in UTC by default. A task can implement an optional
+// TimeZone() method returning an IANA zone name to evaluate its cron expression
+// in that location instead:
+//
+//	func (t *TenantCleanup) Schedule() string { return "0 2 * * *" } // 2 AM local time
+//	func (t *TenantCleanup) TimeZone() string { return "America/New_York" }
+//
+// The zone is loaded with time.LoadLocation when the manager is created;
+// an unknown zone makes NewManager return an error.
+//
+// Schedules near daylight-saving transitions follow wall-clock time. When
+// clocks spring forward, times inside the skipped hour do not exist, so a task
+// scheduled at 2:30 AM does not run that day. When clocks fall back, the
+// repeated hour occurs twice, so a task scheduled at 1:30 AM runs twice.
+// Pick a time outside the 1–3 AM window, or keep the task in UTC, if either
+// outcome is a problem. Interval schedules (@every) are not affected.
+//
 // # App Integration
 //
 // Jobs integrate with Forge through the WithJobs option:
@@ -157,6 +176,109 @@
 //	    job.UniqueKey(userID),
 //	)
 //
+// # Retries
+//
+// A failed job is retried until it reaches its maximum attempts (see
+// MaxAttempts). River's default backoff waits attempt^4 seconds. Set a
+// different policy for all tasks with WithRetryPolicy:
+//
+//	job.WithRetryPolicy(job.ExponentialBackoff(time.Second, 10*time.Minute))
+//
+// ExponentialBackoff, FixedBackoff, and LinearBackoff cover the common cases;
+// any func(attempt int, err error) time.Duration works.
+//
+// A task can schedule its own retries by implementing an optional NextRetry
+// method, for example to honor a rate-limited API's reset time:
+//
+//	func (t *SyncCRM) NextRetry(attempt int, err error) time.Time {
+//	    var rl *crm.RateLimitError
+//	    if errors.As(err, &rl) {
+//	        return rl.ResetAt
+//	    }
+//	    return time.Time{} // fall back to the manager's policy
+//	}
+//
+// Each retry is logged at warn level with the task, attempt number, delay,
+// and error.
+//
+// To fail a job permanently, wrap ErrNoRetry in the returned error. The job
+// is cancelled instead of retried:
+//
+//	if errors.Is(err, stripe.ErrCardDeclined) {
+//	    return fmt.Errorf("charge %s: %w: %w", p.OrderID, job.ErrNoRetry, err)
+//	}
+//
+// # Draining
+//
+// Drain waits until no jobs are available or running in the manager's queues,
+// which makes integration tests deterministic:
+//
+//	require.NoError(t, manager.Enqueue(ctx, "send_welcome", payload))
+//	require.NoError(t, manager.Drain(ctx))
+//	// assert side effects
+//
+// Drain polls the River tables and returns once it observes empty queues, so
+// jobs enqueued concurrently may be missed. Use it in tests and controlled
+// shutdowns, not as backpressure.
+//
+// # Progress Reporting
+//
+// Register a long-running task with WithProgressTask to receive a Reporter
+// in Handle. Enqueue it with EnqueueWithID and poll Status with the returned
+// ID, for example from an HTMX endpoint that renders a progress bar.
+//
+// Status looks a job up by ID alone, and IDs are sequential, so it does not
+// know who may see a job. Record the ID with its owner when enqueueing and
+// check ownership before calling it, or any user can read the progress of
+// any job by guessing IDs:
+//
+//	id, err := c.EnqueueWithID("export_orders", payload)
+//	// store id with c.UserID() in the exports table, redirect to /exports/{id}
+//
+//	func (h *ExportHandler) Progress(c forge.Context) error {
+//	    id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+//	    if err != nil {
+//	        return forge.ErrNotFound("Export not found")
+//	    }
+//	    if !h.repo.ExportBelongsTo(c, id, c.UserID()) {
+//	        return forge.ErrNotFound("Export not found")
+//	    }
+//	    st, err := c.JobStatus(id)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if st.State.Finished() {
+//	        c.SetHeader("HX-Trigger", "export-done")
+//	    }
+//	    return c.Render(http.StatusOK, views.ExportProgress(st.Progress, st.Message))
+//	}
+//
+// Progress is stored in the River job's metadata, so every SetProgress call
+// is an UPDATE on river_job. Report on meaningful steps, not on every loop
+// iteration: once per batch or percentage point, and no more than about once
+// a second per job. Completed jobs report 100 regardless of the last call.
+// Status returns ErrJobNotFound once River has pruned the finished job.
+// JobStatus.Error holds the raw error of the last failed attempt, which may
+// include internal details; log it rather than showing it to users.
+//
+// # Context Propagation
+//
+// Context values such as tenant and trace IDs are lost when a job crosses
+// into a worker. Capture them at enqueue time and restore them on the worker
+// with the same keys:
+//
+//	c.Enqueue("send_email", payload, job.CaptureContext(c.Context(), tenantKey, traceKey))
+//
+//	job.NewManager(pool,
+//	    job.WithTask(tasks.NewSendEmail(mailer)),
+//	    job.WithContextPropagation(tenantKey, traceKey),
+//	)
+//
+// forge.WithJobContextPropagation captures the keys on every c.Enqueue.
+// Values are stored as JSON in the job's metadata: strings round-trip
+// unchanged, other values come back in their decoded JSON form, and a value
+// that cannot be encoded fails the enqueue.
+//
 // # Health Checks
 //
 // Add job manager health check to readiness probes:
@@ -176,6 +298,8 @@
 //   - [ErrAlreadyStarted] - Manager already running
 //   - [ErrNotStarted] - Manager not running
 //   - [ErrHealthcheckFailed] - Health check failed
+//   - [ErrNoRetry] - Returned by a task to cancel the job instead of retrying
+//   - [ErrJobNotFound] - Status was called with an unknown or pruned job ID
 //
 // # Database Migrations
 //
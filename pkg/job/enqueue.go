@@ -4,13 +4,14 @@ import "time"
 
 // enqueueConfig holds options for enqueueing a job.
 type enqueueConfig struct {
-	scheduledAt *time.Time
-	queue       string
-	uniqueKey   string
-	tags        []string
-	maxAttempts int
-	uniqueFor   time.Duration
-	priority    int
+	scheduledAt   *time.Time
+	contextValues map[string]any
+	queue         string
+	uniqueKey     string
+	tags          []string
+	maxAttempts   int
+	uniqueFor     time.Duration
+	priority      int
 }
 
 // EnqueueOption configures job enqueueing.
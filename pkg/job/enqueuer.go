@@ -73,17 +73,24 @@ func NewEnqueuer(pool *pgxpool.Pool, opts ...EnqueuerOption) (*Enqueuer, error)
 // The job will be executed by a registered task handler on a worker process.
 // Note: Task name validation happens on the worker side.
 func (e *Enqueuer) Enqueue(ctx context.Context, name string, payload any, opts ...EnqueueOption) error {
+	_, err := e.EnqueueWithID(ctx, name, payload, opts...)
+	return err
+}
+
+// EnqueueWithID is like Enqueue but also returns the job ID, which Status
+// accepts. When a unique job already exists, its ID is returned.
+func (e *Enqueuer) EnqueueWithID(ctx context.Context, name string, payload any, opts ...EnqueueOption) (int64, error) {
 	args, insertOpts, err := buildJobArgs(name, payload, opts...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	_, err = e.client.Insert(ctx, args, insertOpts)
+	res, err := e.client.Insert(ctx, args, insertOpts)
 	if err != nil {
-		return fmt.Errorf("job: enqueue: %w", err)
+		return 0, fmt.Errorf("job: enqueue: %w", err)
 	}
 
-	return nil
+	return res.Job.ID, nil
 }
 
 // EnqueueTx adds a job to the queue within a transaction.
@@ -141,6 +148,13 @@ func buildJobArgs(name string, payload any, opts ...EnqueueOption) (*forgeTaskAr
 	if len(enqCfg.tags) > 0 {
 		insertOpts.Tags = enqCfg.tags
 	}
+	if len(enqCfg.contextValues) > 0 {
+		metadata, err := encodeContextMetadata(enqCfg.contextValues)
+		if err != nil {
+			return nil, nil, err
+		}
+		insertOpts.Metadata = metadata
+	}
 	if enqCfg.uniqueFor > 0 {
 		insertOpts.UniqueOpts = river.UniqueOpts{
 			ByPeriod: enqCfg.uniqueFor,
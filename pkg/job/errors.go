@@ -27,4 +27,11 @@ var (
 	// ErrPoolRequired is returned when attempting to create a manager
 	// or enqueuer without providing a database pool.
 	ErrPoolRequired = errors.New("job: pool is required")
+
+	// ErrNoRetry can be wrapped in the error a task returns to fail the job
+	// permanently: it is cancelled instead of retried.
+	ErrNoRetry = errors.New("job: do not retry")
+
+	// ErrJobNotFound is returned by Status when no job has the given ID.
+	ErrJobNotFound = errors.New("job: not found")
 )
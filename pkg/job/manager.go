@@ -3,9 +3,12 @@ package job
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -13,12 +16,16 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/riverqueue/river/rivertype"
 	"github.com/robfig/cron/v3"
 )
 
 const (
 	defaultMaxWorkers = 100
 	defaultQueue      = river.QueueDefault
+
+	// drainPollInterval is how often Drain checks the River tables.
+	drainPollInterval = 100 * time.Millisecond
 )
 
 // Manager handles background job processing using River.
@@ -29,6 +36,7 @@ type Manager struct {
 	registry *taskRegistry
 	workers  *river.Workers
 	logger   *slog.Logger
+	queues   []string
 
 	mu      sync.Mutex
 	started bool
@@ -64,7 +72,12 @@ func NewManager(pool *pgxpool.Pool, opts ...Option) (*Manager, error) {
 
 	var periodicJobs []*river.PeriodicJob
 	for _, sched := range cfg.schedules {
-		cronSchedule, err := parseCronSchedule(sched.schedule)
+		loc, err := loadScheduleLocation(sched.timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("job: invalid time zone %q for task %q: %w", sched.timeZone, sched.name, err)
+		}
+
+		cronSchedule, err := parseCronSchedule(sched.schedule, loc)
 		if err != nil {
 			return nil, fmt.Errorf("job: invalid cron schedule %q: %w", sched.schedule, err)
 		}
@@ -84,13 +97,17 @@ func NewManager(pool *pgxpool.Pool, opts ...Option) (*Manager, error) {
 
 		cfg.registry.register(sched.name, &scheduledTaskExecutor{
 			handler: sched.handler,
+			retryAt: sched.nextRetry,
 		})
 	}
 
 	workers := river.NewWorkers()
 	river.AddWorker(workers, &forgeTaskWorker{
-		registry: cfg.registry,
-		logger:   cfg.logger,
+		pool:        pool,
+		registry:    cfg.registry,
+		logger:      cfg.logger,
+		retryPolicy: cfg.retryPolicy,
+		contextKeys: cfg.contextKeys,
 	})
 
 	// Client created immediately, allowing enqueue() before Start().
@@ -113,6 +130,7 @@ func NewManager(pool *pgxpool.Pool, opts ...Option) (*Manager, error) {
 		registry: cfg.registry,
 		workers:  workers,
 		logger:   cfg.logger,
+		queues:   slices.Sorted(maps.Keys(queues)),
 	}, nil
 }
 
@@ -158,15 +176,63 @@ func (m *Manager) Stop(ctx context.Context) error {
 	return nil
 }
 
+// Drain blocks until no jobs are available or running in the manager's queues,
+// polling the River tables, or until ctx is done. Jobs scheduled for the future
+// or waiting to retry are not waited for.
+//
+// Drain is meant for tests ("enqueue, Drain, assert side effects") and for
+// controlled shutdowns, not as backpressure. A job enqueued while Drain polls,
+// including one enqueued by a running job, is waited for only if it is seen
+// before the queues are observed empty.
+func (m *Manager) Drain(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.mu.Unlock()
+
+	if !started {
+		return ErrNotStarted
+	}
+
+	params := river.NewJobListParams().
+		Queues(m.queues...).
+		States(rivertype.JobStateAvailable, rivertype.JobStateRunning).
+		First(1)
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		res, err := m.client.JobList(ctx, params)
+		if err != nil {
+			return fmt.Errorf("job: drain: %w", err)
+		}
+		if len(res.Jobs) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("job: drain: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // Enqueue adds a job to the queue for processing.
 // The job will be executed by a registered task handler.
 // Jobs can be enqueued before Start() is called; they will be processed
 // once the manager starts.
 func (m *Manager) Enqueue(ctx context.Context, name string, payload any, opts ...EnqueueOption) error {
+	_, err := m.EnqueueWithID(ctx, name, payload, opts...)
+	return err
+}
+
+// EnqueueWithID is like Enqueue but also returns the job ID for Status.
+func (m *Manager) EnqueueWithID(ctx context.Context, name string, payload any, opts ...EnqueueOption) (int64, error) {
 	if _, ok := m.registry.get(name); !ok {
-		return fmt.Errorf("%w: %s", ErrUnknownTask, name)
+		return 0, fmt.Errorf("%w: %s", ErrUnknownTask, name)
 	}
-	return m.Enqueuer.Enqueue(ctx, name, payload, opts...)
+	return m.Enqueuer.EnqueueWithID(ctx, name, payload, opts...)
 }
 
 // EnqueueTx adds a job to the queue within a transaction.
@@ -187,6 +253,11 @@ type forgeTaskArgs struct {
 	TaskName  string          `json:"task_name"`
 	UniqueKey string          `json:"unique_key,omitempty"`
 	Payload   json.RawMessage `json:"payload,omitempty"`
+
+	// err is the error the current attempt returned. River passes the same
+	// *Job to Work and NextRetry, so this hands the error to the retry
+	// policy without outliving the attempt. It is never serialized.
+	err error
 }
 
 func (forgeTaskArgs) Kind() string {
@@ -196,8 +267,11 @@ func (forgeTaskArgs) Kind() string {
 // forgeTaskWorker processes all Forge tasks through the registry.
 type forgeTaskWorker struct {
 	river.WorkerDefaults[forgeTaskArgs]
-	registry *taskRegistry
-	logger   *slog.Logger
+	pool        *pgxpool.Pool
+	registry    *taskRegistry
+	logger      *slog.Logger
+	retryPolicy RetryPolicy
+	contextKeys []any
 }
 
 func (w *forgeTaskWorker) Work(ctx context.Context, job *river.Job[forgeTaskArgs]) error {
@@ -206,19 +280,31 @@ func (w *forgeTaskWorker) Work(ctx context.Context, job *river.Job[forgeTaskArgs
 		return fmt.Errorf("%w: %s", ErrUnknownTask, job.Args.TaskName)
 	}
 
+	ctx = restoreContext(ctx, job.Metadata, w.contextKeys)
+
 	w.logger.DebugContext(ctx, "executing task",
 		slog.String("task", job.Args.TaskName),
 		slog.Int64("job_id", job.ID),
 		slog.Int("attempt", job.Attempt),
 	)
 
-	if err := executor.Execute(ctx, job.Args.Payload); err != nil {
+	var err error
+	if re, ok := executor.(reportingExecutor); ok {
+		err = re.executeWithReporter(ctx, job.Args.Payload, &dbReporter{ctx: ctx, pool: w.pool, jobID: job.ID})
+	} else {
+		err = executor.Execute(ctx, job.Args.Payload)
+	}
+	if err != nil {
 		w.logger.ErrorContext(ctx, "task failed",
 			slog.String("task", job.Args.TaskName),
 			slog.Int64("job_id", job.ID),
 			slog.Int("attempt", job.Attempt),
 			slog.Any("error", err),
 		)
+		if errors.Is(err, ErrNoRetry) {
+			return river.JobCancel(err)
+		}
+		job.Args.err = err
 		return err
 	}
 
@@ -232,27 +318,51 @@ func (w *forgeTaskWorker) Work(ctx context.Context, job *river.Job[forgeTaskArgs
 
 type scheduledTaskExecutor struct {
 	handler scheduledHandler
+	retryAt retryFunc
 }
 
 func (e *scheduledTaskExecutor) Execute(ctx context.Context, _ json.RawMessage) error {
 	return e.handler(ctx)
 }
 
+func (e *scheduledTaskExecutor) nextRetry(attempt int, err error) time.Time {
+	if e.retryAt == nil {
+		return time.Time{}
+	}
+	return e.retryAt(attempt, err)
+}
+
+// cronScheduleAdapter evaluates a cron schedule in a fixed location.
+// River passes times in UTC; converting to the task's location before
+// computing the next run makes "0 2 * * *" mean 2 AM local time.
 type cronScheduleAdapter struct {
 	schedule cron.Schedule
+	location *time.Location
 }
 
 func (a *cronScheduleAdapter) Next(current time.Time) time.Time {
-	return a.schedule.Next(current)
+	return a.schedule.Next(current.In(a.location))
 }
 
-func parseCronSchedule(expr string) (river.PeriodicSchedule, error) {
+func parseCronSchedule(expr string, loc *time.Location) (river.PeriodicSchedule, error) {
 	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
 	schedule, err := parser.Parse(expr)
 	if err != nil {
 		return nil, err
 	}
-	return &cronScheduleAdapter{schedule: schedule}, nil
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &cronScheduleAdapter{schedule: schedule, location: loc}, nil
+}
+
+// loadScheduleLocation resolves a task's time zone name.
+// An empty name means UTC.
+func loadScheduleLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
 }
 
 // Shutdown returns a shutdown function for the job manager.
@@ -1,9 +1,11 @@
 package job
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,6 +18,22 @@ func TestNewManager_NilPool(t *testing.T) {
 	assert.Contains(t, err.Error(), "pool is required")
 }
 
+func TestManagerDrain_NotStarted(t *testing.T) {
+	t.Parallel()
+
+	// The pool connects lazily, so no database is needed to build a manager.
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/forge")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	m, err := NewManager(pool, WithQueue("email", 5), WithQueue("billing", 2))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"billing", "default", "email"}, m.queues)
+
+	err = m.Drain(context.Background())
+	require.ErrorIs(t, err, ErrNotStarted)
+}
+
 func TestParseCronSchedule_Valid(t *testing.T) {
 	t.Parallel()
 
@@ -96,7 +114,7 @@ func TestParseCronSchedule_Valid(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			schedule, err := parseCronSchedule(tt.expr)
+			schedule, err := parseCronSchedule(tt.expr, time.UTC)
 			require.NoError(t, err)
 			assert.NotNil(t, schedule)
 
@@ -130,7 +148,7 @@ func TestParseCronSchedule_Invalid(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			_, err := parseCronSchedule(tt.expr)
+			_, err := parseCronSchedule(tt.expr, time.UTC)
 			require.Error(t, err)
 		})
 	}
@@ -139,7 +157,7 @@ func TestParseCronSchedule_Invalid(t *testing.T) {
 func TestCronScheduleAdapter_Next(t *testing.T) {
 	t.Parallel()
 
-	schedule, err := parseCronSchedule("0 * * * *") // Every hour
+	schedule, err := parseCronSchedule("0 * * * *", time.UTC) // Every hour
 	require.NoError(t, err)
 
 	base := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
@@ -155,6 +173,81 @@ func TestCronScheduleAdapter_Next(t *testing.T) {
 	assert.Equal(t, expected2, next2)
 }
 
+func TestCronScheduleAdapter_NextInTimeZone(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("evaluates in location", func(t *testing.T) {
+		t.Parallel()
+
+		schedule, err := parseCronSchedule("0 2 * * *", loc)
+		require.NoError(t, err)
+
+		base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		next := schedule.Next(base)
+
+		// 2 AM EST is 07:00 UTC
+		expected := time.Date(2024, 1, 2, 7, 0, 0, 0, time.UTC)
+		assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+	})
+
+	t.Run("skips nonexistent time on spring forward", func(t *testing.T) {
+		t.Parallel()
+
+		schedule, err := parseCronSchedule("30 2 * * *", loc)
+		require.NoError(t, err)
+
+		base := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+		next := schedule.Next(base)
+
+		// 2024-03-10 02:30 does not exist in New York; next run is on the 11th.
+		expected := time.Date(2024, 3, 11, 6, 30, 0, 0, time.UTC)
+		assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+	})
+
+	t.Run("nil location defaults to UTC", func(t *testing.T) {
+		t.Parallel()
+
+		schedule, err := parseCronSchedule("0 2 * * *", nil)
+		require.NoError(t, err)
+
+		base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		next := schedule.Next(base)
+
+		expected := time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)
+		assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+	})
+}
+
+func TestLoadScheduleLocation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty defaults to UTC", func(t *testing.T) {
+		t.Parallel()
+
+		loc, err := loadScheduleLocation("")
+		require.NoError(t, err)
+		assert.Equal(t, time.UTC, loc)
+	})
+
+	t.Run("valid zone", func(t *testing.T) {
+		t.Parallel()
+
+		loc, err := loadScheduleLocation("Europe/Berlin")
+		require.NoError(t, err)
+		assert.Equal(t, "Europe/Berlin", loc.String())
+	})
+
+	t.Run("invalid zone", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := loadScheduleLocation("Mars/Olympus_Mons")
+		require.Error(t, err)
+	})
+}
+
 func TestErrors(t *testing.T) {
 	t.Parallel()
 
@@ -3,15 +3,18 @@ package job
 import (
 	"context"
 	"log/slog"
+	"time"
 )
 
 // config holds job manager configuration.
 type config struct {
-	registry   *taskRegistry
-	queues     map[string]int
-	logger     *slog.Logger
-	schedules  []scheduleConfig
-	maxWorkers int
+	registry    *taskRegistry
+	queues      map[string]int
+	logger      *slog.Logger
+	retryPolicy RetryPolicy
+	schedules   []scheduleConfig
+	contextKeys []any
+	maxWorkers  int
 }
 
 // newConfig creates a config with defaults.
@@ -26,14 +29,25 @@ func newConfig() *config {
 //
 //nolint:betteralign
 type scheduleConfig struct {
-	handler  scheduledHandler
-	name     string
-	schedule string
+	handler   scheduledHandler
+	nextRetry retryFunc
+	name      string
+	schedule  string
+	timeZone  string
 }
 
 // scheduledHandler is a function type for scheduled task handlers.
 type scheduledHandler func(context.Context) error
 
+// retryFunc is a task's optional NextRetry method.
+type retryFunc func(attempt int, err error) time.Time
+
+// retryMethod is the optional method a task implements to schedule its
+// own retries.
+type retryMethod interface {
+	NextRetry(attempt int, err error) time.Time
+}
+
 // Option configures the job manager.
 type Option func(*config)
 
@@ -41,6 +55,10 @@ type Option func(*config)
 // The task must implement Name() and Handle(ctx, P) methods.
 // The payload type P is inferred from the Handle method signature.
 //
+// Tasks may optionally implement NextRetry(attempt int, err error) time.Time
+// to schedule their own retries; returning the zero time defers to the
+// manager's RetryPolicy. Scheduled tasks may implement it too.
+//
 // Example:
 //
 //	type SendWelcome struct {
@@ -63,10 +81,41 @@ func WithTask[P any, T interface {
 	}
 }
 
+// WithProgressTask registers a task whose Handle also receives a Reporter
+// for recording progress, which Status reads back. It otherwise behaves like
+// WithTask, including the optional NextRetry method.
+//
+// Example:
+//
+//	func (t *ExportOrders) Name() string { return "export_orders" }
+//	func (t *ExportOrders) Handle(ctx context.Context, p ExportPayload, r job.Reporter) error {
+//	    for i, batch := range batches {
+//	        // ... write batch ...
+//	        _ = r.SetProgress((i+1)*100/len(batches), "Exporting orders")
+//	    }
+//	    return nil
+//	}
+//
+//	job.WithProgressTask(tasks.NewExportOrders(repo))
+func WithProgressTask[P any, T interface {
+	Name() string
+	Handle(context.Context, P, Reporter) error
+}](task T) Option {
+	return func(c *config) {
+		wrapper := newProgressTaskWrapper[P, T](task)
+		c.registry.register(task.Name(), wrapper)
+	}
+}
+
 // WithScheduledTask registers a periodic task using structural typing.
 // The task must implement Name(), Schedule(), and Handle(ctx) methods.
 // Schedule() should return a cron expression (5 fields: min hour day month weekday).
 //
+// Tasks may optionally implement TimeZone() string returning an IANA zone name
+// (e.g. "America/New_York"). The cron expression is then evaluated in that
+// location instead of UTC. The zone is loaded when the manager is created,
+// and an invalid zone makes NewManager fail.
+//
 // Example:
 //
 //	type CleanupSessions struct {
@@ -86,11 +135,18 @@ func WithScheduledTask[T interface {
 	Handle(context.Context) error
 }](task T) Option {
 	return func(c *config) {
-		c.schedules = append(c.schedules, scheduleConfig{
+		sched := scheduleConfig{
 			name:     task.Name(),
 			schedule: task.Schedule(),
 			handler:  task.Handle,
-		})
+		}
+		if tz, ok := any(task).(interface{ TimeZone() string }); ok {
+			sched.timeZone = tz.TimeZone()
+		}
+		if r, ok := any(task).(retryMethod); ok {
+			sched.nextRetry = r.NextRetry
+		}
+		c.schedules = append(c.schedules, sched)
 	}
 }
 
@@ -123,6 +179,19 @@ func WithLogger(l *slog.Logger) Option {
 	}
 }
 
+// WithRetryPolicy sets the backoff used for failed jobs whose task does not
+// schedule its own retries with NextRetry. If not set, River's default
+// exponential backoff (attempt^4 seconds) applies.
+//
+// Example:
+//
+//	job.WithRetryPolicy(job.ExponentialBackoff(time.Second, 10*time.Minute))
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *config) {
+		c.retryPolicy = p
+	}
+}
+
 // WithMaxWorkers sets the default maximum number of workers.
 // This applies to the default queue and any queue without explicit worker count.
 // Defaults to 100 if not set.
@@ -62,6 +62,41 @@ func TestWithScheduledTask(t *testing.T) {
 	assert.NotNil(t, cfg.schedules[0].handler)
 }
 
+// zonedScheduledTestTask implements the scheduled task interface with a time zone.
+type zonedScheduledTestTask struct {
+	scheduledTestTask
+}
+
+func (t *zonedScheduledTestTask) TimeZone() string { return "America/New_York" }
+
+func TestWithScheduledTask_TimeZone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("captures time zone when implemented", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newConfig()
+
+		task := &zonedScheduledTestTask{scheduledTestTask{schedule: "0 2 * * *"}}
+		WithScheduledTask[*zonedScheduledTestTask](task)(cfg)
+
+		require.Len(t, cfg.schedules, 1)
+		assert.Equal(t, "America/New_York", cfg.schedules[0].timeZone)
+	})
+
+	t.Run("empty time zone when absent", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newConfig()
+
+		task := &scheduledTestTask{schedule: "0 2 * * *"}
+		WithScheduledTask[*scheduledTestTask](task)(cfg)
+
+		require.Len(t, cfg.schedules, 1)
+		assert.Empty(t, cfg.schedules[0].timeZone)
+	})
+}
+
 func TestWithQueue(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,221 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// progressMetadataKey is the River job metadata key progress is stored under.
+const progressMetadataKey = "forge_progress"
+
+// Reporter records the progress of a running job so it can be read back with
+// Status. Each SetProgress call is one database write; see the package
+// documentation for how often to call it.
+type Reporter interface {
+	// SetProgress stores percent (clamped to 0-100) and a short message,
+	// replacing the previous report.
+	SetProgress(percent int, message string) error
+}
+
+// JobState is the lifecycle state of a job.
+type JobState string
+
+// Job states, matching River's.
+const (
+	StateAvailable JobState = "available"
+	StateScheduled JobState = "scheduled"
+	StatePending   JobState = "pending"
+	StateRunning   JobState = "running"
+	StateRetryable JobState = "retryable"
+	StateCompleted JobState = "completed"
+	StateCancelled JobState = "cancelled"
+	StateDiscarded JobState = "discarded"
+)
+
+// Finished reports whether the job will not run again: it completed, was
+// cancelled, or exhausted its attempts. Polling endpoints stop here.
+func (s JobState) Finished() bool {
+	return s == StateCompleted || s == StateCancelled || s == StateDiscarded
+}
+
+// JobStatus is a snapshot of a job's state and last reported progress.
+type JobStatus struct {
+	ScheduledAt time.Time
+	CreatedAt   time.Time
+
+	// FinishedAt is set once the job is completed, cancelled, or discarded.
+	FinishedAt *time.Time
+
+	// ProgressAt is when progress was last reported, or nil if never.
+	ProgressAt *time.Time
+
+	// Task is the registered task name.
+	Task  string
+	State JobState
+
+	// Message is the message of the last progress report.
+	Message string
+
+	// Error is the error of the most recent failed attempt, or empty. It is
+	// the handler's raw error text, so do not render it to end users.
+	Error string
+
+	ID int64
+
+	// Progress is the last reported percentage. Completed jobs report 100.
+	Progress    int
+	Attempt     int
+	MaxAttempts int
+}
+
+// Status returns the state and last reported progress of the job with the
+// given ID. Returns ErrJobNotFound if the job does not exist, including after
+// River has pruned it. Any job can be read by ID, so callers serving users
+// must check that the job belongs to them first.
+func (e *Enqueuer) Status(ctx context.Context, jobID int64) (*JobStatus, error) {
+	row, err := e.client.JobGet(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, river.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrJobNotFound, jobID)
+		}
+		return nil, fmt.Errorf("job: status: %w", err)
+	}
+	return statusFromRow(row)
+}
+
+// progressRecord is the JSON stored under progressMetadataKey.
+type progressRecord struct {
+	At      time.Time `json:"at"`
+	Message string    `json:"message,omitempty"`
+	Percent int       `json:"percent"`
+}
+
+// statusFromRow converts a River job row to a JobStatus.
+func statusFromRow(row *rivertype.JobRow) (*JobStatus, error) {
+	st := &JobStatus{
+		ID:          row.ID,
+		State:       JobState(row.State),
+		Attempt:     row.Attempt,
+		MaxAttempts: row.MaxAttempts,
+		CreatedAt:   row.CreatedAt,
+		ScheduledAt: row.ScheduledAt,
+		FinishedAt:  row.FinalizedAt,
+	}
+
+	var args forgeTaskArgs
+	if err := json.Unmarshal(row.EncodedArgs, &args); err == nil {
+		st.Task = args.TaskName
+	}
+
+	if n := len(row.Errors); n > 0 {
+		st.Error = row.Errors[n-1].Error
+	}
+
+	if len(row.Metadata) > 0 {
+		var meta struct {
+			Progress *progressRecord `json:"forge_progress"`
+		}
+		if err := json.Unmarshal(row.Metadata, &meta); err != nil {
+			return nil, fmt.Errorf("job: status: decode metadata: %w", err)
+		}
+		if p := meta.Progress; p != nil {
+			st.Progress = p.Percent
+			st.Message = p.Message
+			at := p.At
+			st.ProgressAt = &at
+		}
+	}
+
+	if st.State == StateCompleted {
+		st.Progress = 100
+	}
+
+	return st, nil
+}
+
+// progressTaskWrapper implements taskExecutor for tasks whose Handle takes a
+// Reporter.
+type progressTaskWrapper[P any, T interface {
+	Name() string
+	Handle(context.Context, P, Reporter) error
+}] struct {
+	task    T
+	retryAt retryFunc
+}
+
+// Execute runs the task without persisting progress.
+func (w *progressTaskWrapper[P, T]) Execute(ctx context.Context, raw json.RawMessage) error {
+	return w.executeWithReporter(ctx, raw, noopReporter{})
+}
+
+func (w *progressTaskWrapper[P, T]) executeWithReporter(ctx context.Context, raw json.RawMessage, r Reporter) error {
+	var payload P
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return errors.Join(ErrInvalidPayload, err)
+		}
+	}
+	return w.task.Handle(ctx, payload, r)
+}
+
+func (w *progressTaskWrapper[P, T]) nextRetry(attempt int, err error) time.Time {
+	if w.retryAt == nil {
+		return time.Time{}
+	}
+	return w.retryAt(attempt, err)
+}
+
+func newProgressTaskWrapper[P any, T interface {
+	Name() string
+	Handle(context.Context, P, Reporter) error
+}](task T) *progressTaskWrapper[P, T] {
+	w := &progressTaskWrapper[P, T]{task: task}
+	if r, ok := any(task).(retryMethod); ok {
+		w.retryAt = r.NextRetry
+	}
+	return w
+}
+
+// reportingExecutor is implemented by executors whose task accepts a Reporter.
+type reportingExecutor interface {
+	executeWithReporter(ctx context.Context, payload json.RawMessage, r Reporter) error
+}
+
+// updateProgressSQL merges a progress record into the job's metadata.
+// River merges its own metadata updates on completion, so the key survives.
+const updateProgressSQL = `UPDATE river_job SET metadata = jsonb_set(metadata, '{` + progressMetadataKey + `}', $2::jsonb) WHERE id = $1`
+
+// dbReporter persists progress to the running job's row.
+type dbReporter struct {
+	ctx   context.Context
+	pool  *pgxpool.Pool
+	jobID int64
+}
+
+func (r *dbReporter) SetProgress(percent int, message string) error {
+	data, err := json.Marshal(progressRecord{
+		Percent: min(max(percent, 0), 100),
+		Message: message,
+		At:      time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("job: set progress: %w", err)
+	}
+	if _, err := r.pool.Exec(r.ctx, updateProgressSQL, r.jobID, data); err != nil {
+		return fmt.Errorf("job: set progress: %w", err)
+	}
+	return nil
+}
+
+// noopReporter discards progress. It is used when a task runs outside a
+// worker, where there is no job row to update.
+type noopReporter struct{}
+
+func (noopReporter) SetProgress(int, string) error { return nil }
@@ -0,0 +1,172 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+)
+
+type progressTask struct {
+	payload testPayload
+	steps   int
+}
+
+func (t *progressTask) Name() string { return "progress_task" }
+
+func (t *progressTask) Handle(_ context.Context, p testPayload, r Reporter) error {
+	t.payload = p
+	for i := 1; i <= t.steps; i++ {
+		if err := r.SetProgress(i*100/t.steps, "step"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type recordingReporter struct {
+	percents []int
+}
+
+func (r *recordingReporter) SetProgress(percent int, _ string) error {
+	r.percents = append(r.percents, percent)
+	return nil
+}
+
+func TestProgressTaskWrapper(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passes reporter to handler", func(t *testing.T) {
+		t.Parallel()
+
+		task := &progressTask{steps: 4}
+		w := newProgressTaskWrapper[testPayload, *progressTask](task)
+		rep := &recordingReporter{}
+
+		err := w.executeWithReporter(context.Background(), json.RawMessage(`{"message":"hi","count":1}`), rep)
+		require.NoError(t, err)
+		require.Equal(t, testPayload{Message: "hi", Count: 1}, task.payload)
+		require.Equal(t, []int{25, 50, 75, 100}, rep.percents)
+	})
+
+	t.Run("execute without reporter discards progress", func(t *testing.T) {
+		t.Parallel()
+
+		task := &progressTask{steps: 2}
+		w := newProgressTaskWrapper[testPayload, *progressTask](task)
+
+		require.NoError(t, w.Execute(context.Background(), nil))
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		t.Parallel()
+
+		w := newProgressTaskWrapper[testPayload, *progressTask](&progressTask{})
+
+		err := w.Execute(context.Background(), json.RawMessage(`{invalid`))
+		require.ErrorIs(t, err, ErrInvalidPayload)
+	})
+
+	t.Run("registered with WithProgressTask", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newConfig()
+		WithProgressTask(&progressTask{})(cfg)
+
+		executor, ok := cfg.registry.get("progress_task")
+		require.True(t, ok)
+		_, ok = executor.(reportingExecutor)
+		require.True(t, ok)
+	})
+}
+
+func TestJobStateFinished(t *testing.T) {
+	t.Parallel()
+
+	for _, st := range []JobState{StateCompleted, StateCancelled, StateDiscarded} {
+		require.True(t, st.Finished(), st)
+	}
+	for _, st := range []JobState{StateAvailable, StateScheduled, StatePending, StateRunning, StateRetryable} {
+		require.False(t, st.Finished(), st)
+	}
+}
+
+func TestStatusFromRow(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	reported := created.Add(time.Minute)
+
+	newRow := func(state rivertype.JobState, metadata string) *rivertype.JobRow {
+		return &rivertype.JobRow{
+			ID:          42,
+			State:       state,
+			Attempt:     2,
+			MaxAttempts: 5,
+			CreatedAt:   created,
+			ScheduledAt: created,
+			EncodedArgs: []byte(`{"task_name":"export_orders"}`),
+			Metadata:    []byte(metadata),
+			Errors: []rivertype.AttemptError{
+				{Attempt: 1, Error: "timeout"},
+			},
+		}
+	}
+
+	t.Run("running with progress", func(t *testing.T) {
+		t.Parallel()
+
+		st, err := statusFromRow(newRow(rivertype.JobStateRunning,
+			`{"forge_progress":{"percent":40,"message":"Exporting","at":"`+reported.Format(time.RFC3339)+`"}}`))
+		require.NoError(t, err)
+		require.Equal(t, &JobStatus{
+			ID:          42,
+			Task:        "export_orders",
+			State:       StateRunning,
+			Progress:    40,
+			Message:     "Exporting",
+			ProgressAt:  &reported,
+			Error:       "timeout",
+			Attempt:     2,
+			MaxAttempts: 5,
+			CreatedAt:   created,
+			ScheduledAt: created,
+		}, st)
+	})
+
+	t.Run("no progress reported", func(t *testing.T) {
+		t.Parallel()
+
+		st, err := statusFromRow(newRow(rivertype.JobStateAvailable, `{}`))
+		require.NoError(t, err)
+		require.Zero(t, st.Progress)
+		require.Empty(t, st.Message)
+		require.Nil(t, st.ProgressAt)
+	})
+
+	t.Run("completed reports 100", func(t *testing.T) {
+		t.Parallel()
+
+		st, err := statusFromRow(newRow(rivertype.JobStateCompleted,
+			`{"forge_progress":{"percent":90,"message":"Almost","at":"`+reported.Format(time.RFC3339)+`"}}`))
+		require.NoError(t, err)
+		require.Equal(t, 100, st.Progress)
+		require.Equal(t, "Almost", st.Message)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := statusFromRow(newRow(rivertype.JobStateRunning, `{invalid`))
+		require.Error(t, err)
+	})
+}
+
+func TestNoopReporter(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, noopReporter{}.SetProgress(50, "ignored"))
+}
@@ -0,0 +1,103 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// contextMetadataKey is the River job metadata key propagated context values
+// are stored under.
+const contextMetadataKey = "forge_context"
+
+// CaptureContext copies the values stored in ctx under keys into the job's
+// metadata, so a manager configured with WithContextPropagation and the same
+// keys can restore them into the context Handle receives. Keys without a
+// value in ctx are skipped.
+//
+// Values are stored as JSON. Strings round-trip unchanged; other values are
+// restored as their decoded JSON form (float64, bool, map[string]any,
+// []any), so prefer string values such as tenant and trace IDs. A value that
+// cannot be encoded makes the enqueue fail.
+//
+// Keys are matched across processes by their type name and value, so both
+// sides must use the same key variables. Two unexported struct{} key types
+// with the same name in packages with the same name collide.
+//
+// Example:
+//
+//	c.Enqueue("send_email", payload, job.CaptureContext(c.Context(), tenantKey, traceKey))
+func CaptureContext(ctx context.Context, keys ...any) EnqueueOption {
+	return func(c *enqueueConfig) {
+		for _, key := range keys {
+			v := ctx.Value(key)
+			if v == nil {
+				continue
+			}
+			if c.contextValues == nil {
+				c.contextValues = make(map[string]any, len(keys))
+			}
+			c.contextValues[contextKeyName(key)] = v
+		}
+	}
+}
+
+// WithContextPropagation restores context values captured with
+// CaptureContext into the context passed to Handle. Only the listed keys are
+// restored; values captured under other keys stay in the job's metadata.
+// See CaptureContext for the serialization constraints.
+//
+// Example:
+//
+//	job.WithContextPropagation(tenantKey, traceKey)
+func WithContextPropagation(keys ...any) Option {
+	return func(c *config) {
+		c.contextKeys = append(c.contextKeys, keys...)
+	}
+}
+
+// contextKeyName returns the name a context key is stored under. Including
+// the type keeps typed string keys from colliding with plain strings.
+func contextKeyName(key any) string {
+	return fmt.Sprintf("%T:%v", key, key)
+}
+
+// encodeContextMetadata builds the River metadata object holding the
+// captured context values. It returns nil when nothing was captured.
+func encodeContextMetadata(values map[string]any) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(map[string]any{contextMetadataKey: values})
+	if err != nil {
+		return nil, fmt.Errorf("job: marshal context values: %w", err)
+	}
+	return b, nil
+}
+
+// restoreContext returns ctx with the values captured under keys in the
+// job's metadata. Metadata that cannot be decoded leaves ctx unchanged:
+// missing tracing context must not fail the job.
+func restoreContext(ctx context.Context, metadata []byte, keys []any) context.Context {
+	if len(keys) == 0 || len(metadata) == 0 {
+		return ctx
+	}
+	var meta struct {
+		Values map[string]json.RawMessage `json:"forge_context"`
+	}
+	if err := json.Unmarshal(metadata, &meta); err != nil || len(meta.Values) == 0 {
+		return ctx
+	}
+	for _, key := range keys {
+		raw, ok := meta.Values[contextKeyName(key)]
+		if !ok {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil || v == nil {
+			continue
+		}
+		ctx = context.WithValue(ctx, key, v)
+	}
+	return ctx
+}
@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+type traceKey string
+
+func TestContextPropagation(t *testing.T) {
+	t.Parallel()
+
+	const traceID traceKey = "trace_id"
+
+	t.Run("round-trips values through job metadata", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+		ctx = context.WithValue(ctx, traceID, "abc123")
+
+		_, opts, err := buildJobArgs("task", nil, CaptureContext(ctx, tenantKey{}, traceID))
+		require.NoError(t, err)
+		require.NotEmpty(t, opts.Metadata)
+
+		restored := restoreContext(context.Background(), opts.Metadata, []any{tenantKey{}, traceID})
+		require.Equal(t, "acme", restored.Value(tenantKey{}))
+		require.Equal(t, "abc123", restored.Value(traceID))
+	})
+
+	t.Run("typed key does not match plain string", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), traceID, "abc123")
+		_, opts, err := buildJobArgs("task", nil, CaptureContext(ctx, traceID))
+		require.NoError(t, err)
+
+		restored := restoreContext(context.Background(), opts.Metadata, []any{"trace_id"})
+		require.Nil(t, restored.Value("trace_id"))
+	})
+
+	t.Run("restores only registered keys", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+		ctx = context.WithValue(ctx, traceID, "abc123")
+		_, opts, err := buildJobArgs("task", nil, CaptureContext(ctx, tenantKey{}, traceID))
+		require.NoError(t, err)
+
+		restored := restoreContext(context.Background(), opts.Metadata, []any{tenantKey{}})
+		require.Equal(t, "acme", restored.Value(tenantKey{}))
+		require.Nil(t, restored.Value(traceID))
+	})
+
+	t.Run("missing values add no metadata", func(t *testing.T) {
+		t.Parallel()
+
+		_, opts, err := buildJobArgs("task", nil, CaptureContext(context.Background(), tenantKey{}))
+		require.NoError(t, err)
+		require.Nil(t, opts.Metadata)
+	})
+
+	t.Run("non-string values come back decoded", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), tenantKey{}, 42)
+		_, opts, err := buildJobArgs("task", nil, CaptureContext(ctx, tenantKey{}))
+		require.NoError(t, err)
+
+		restored := restoreContext(context.Background(), opts.Metadata, []any{tenantKey{}})
+		require.InDelta(t, 42.0, restored.Value(tenantKey{}), 0)
+	})
+
+	t.Run("unencodable value fails enqueue", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), tenantKey{}, make(chan int))
+		_, _, err := buildJobArgs("task", nil, CaptureContext(ctx, tenantKey{}))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid metadata leaves context unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		require.Equal(t, ctx, restoreContext(ctx, []byte("not json"), []any{tenantKey{}}))
+	})
+}
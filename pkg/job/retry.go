@@ -0,0 +1,85 @@
+package job
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+)
+
+// RetryPolicy returns how long to wait before retrying a job whose attempt
+// failed with err. Attempts are numbered from 1. err is nil when the attempt
+// panicked instead of returning an error.
+type RetryPolicy func(attempt int, err error) time.Duration
+
+// ExponentialBackoff doubles the delay after each attempt, starting at base
+// and never exceeding max: base, 2*base, 4*base, ... max.
+func ExponentialBackoff(base, max time.Duration) RetryPolicy {
+	return func(attempt int, _ error) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
+// FixedBackoff waits d before every retry.
+func FixedBackoff(d time.Duration) RetryPolicy {
+	return func(int, error) time.Duration {
+		return d
+	}
+}
+
+// LinearBackoff waits step times the attempt number, capped at max:
+// step, 2*step, 3*step, ... max. A non-positive max means no cap.
+func LinearBackoff(step, max time.Duration) RetryPolicy {
+	return func(attempt int, _ error) time.Duration {
+		d := step * time.Duration(attempt)
+		if max > 0 {
+			d = min(d, max)
+		}
+		return d
+	}
+}
+
+// retryScheduler is implemented by executors whose task defines
+// NextRetry(attempt int, err error) time.Time.
+type retryScheduler interface {
+	nextRetry(attempt int, err error) time.Time
+}
+
+// defaultRetryPolicy is River's own schedule, used when neither the task nor
+// WithRetryPolicy decides. Computing it here rather than leaving it to River
+// lets the worker log the actual delay.
+var defaultRetryPolicy = &river.DefaultClientRetryPolicy{}
+
+// NextRetry schedules the retry of a failed job: the task's own NextRetry
+// wins, then the manager's RetryPolicy, then River's default backoff.
+// River only calls it when the job will be retried.
+func (w *forgeTaskWorker) NextRetry(job *river.Job[forgeTaskArgs]) time.Time {
+	err := job.Args.err
+	now := time.Now()
+
+	var next time.Time
+	if executor, ok := w.registry.get(job.Args.TaskName); ok {
+		if rs, ok := executor.(retryScheduler); ok {
+			next = rs.nextRetry(job.Attempt, err)
+		}
+	}
+	if next.IsZero() && w.retryPolicy != nil {
+		next = now.Add(w.retryPolicy(job.Attempt, err))
+	}
+	if next.IsZero() {
+		next = defaultRetryPolicy.NextRetry(job.JobRow)
+	}
+
+	w.logger.Warn("task retry scheduled",
+		slog.String("task", job.Args.TaskName),
+		slog.Int64("job_id", job.ID),
+		slog.Int("attempt", job.Attempt),
+		slog.Duration("delay", next.Sub(now).Round(time.Millisecond)),
+		slog.Any("error", err),
+	)
+	return next
+}
@@ -0,0 +1,159 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/logger"
+)
+
+type retryingTask struct {
+	testTask
+	delay   time.Duration
+	gotErr  error
+	attempt int
+}
+
+func (t *retryingTask) NextRetry(attempt int, err error) time.Time {
+	t.attempt, t.gotErr = attempt, err
+	if t.delay == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(t.delay)
+}
+
+func TestRetryPolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exponential doubles up to cap", func(t *testing.T) {
+		t.Parallel()
+
+		p := ExponentialBackoff(time.Second, 10*time.Second)
+		var got []time.Duration
+		for attempt := 1; attempt <= 6; attempt++ {
+			got = append(got, p(attempt, nil))
+		}
+		require.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second,
+		}, got)
+		require.Equal(t, 10*time.Second, p(1000, nil))
+	})
+
+	t.Run("fixed", func(t *testing.T) {
+		t.Parallel()
+
+		p := FixedBackoff(30 * time.Second)
+		require.Equal(t, 30*time.Second, p(1, nil))
+		require.Equal(t, 30*time.Second, p(7, nil))
+	})
+
+	t.Run("linear with and without cap", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, 15*time.Second, LinearBackoff(5*time.Second, 0)(3, nil))
+		require.Equal(t, 12*time.Second, LinearBackoff(5*time.Second, 12*time.Second)(3, nil))
+	})
+}
+
+func TestForgeTaskWorker_NextRetry(t *testing.T) {
+	t.Parallel()
+
+	newJob := func(name string, err error) *river.Job[forgeTaskArgs] {
+		return &river.Job[forgeTaskArgs]{
+			JobRow: &rivertype.JobRow{ID: 7, Attempt: 2},
+			Args:   forgeTaskArgs{TaskName: name, err: err},
+		}
+	}
+
+	t.Run("task NextRetry wins", func(t *testing.T) {
+		t.Parallel()
+
+		task := &retryingTask{testTask: testTask{name: "sync"}, delay: time.Hour}
+		registry := newTaskRegistry()
+		registry.register("sync", newTaskWrapper[testPayload](task))
+		log, capture := logger.NewTestLogger()
+		w := &forgeTaskWorker{registry: registry, logger: log, retryPolicy: FixedBackoff(time.Second)}
+
+		taskErr := errors.New("rate limited")
+		next := w.NextRetry(newJob("sync", taskErr))
+
+		require.WithinDuration(t, time.Now().Add(time.Hour), next, time.Minute)
+		require.Equal(t, 2, task.attempt)
+		require.ErrorIs(t, task.gotErr, taskErr)
+
+		require.True(t, capture.Contains(slog.LevelWarn, "task retry scheduled"))
+		require.Equal(t, "sync", capture.Attr("task retry scheduled", "task"))
+		require.Equal(t, int64(2), capture.Attr("task retry scheduled", "attempt"))
+		require.Equal(t, taskErr, capture.Attr("task retry scheduled", "error"))
+		delay, ok := capture.Attr("task retry scheduled", "delay").(time.Duration)
+		require.True(t, ok)
+		require.InDelta(t, time.Hour, delay, float64(time.Minute))
+	})
+
+	t.Run("zero time falls back to retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		task := &retryingTask{testTask: testTask{name: "sync"}}
+		registry := newTaskRegistry()
+		registry.register("sync", newTaskWrapper[testPayload](task))
+		w := &forgeTaskWorker{registry: registry, logger: slog.New(slog.DiscardHandler), retryPolicy: FixedBackoff(time.Minute)}
+
+		next := w.NextRetry(newJob("sync", errors.New("boom")))
+		require.WithinDuration(t, time.Now().Add(time.Minute), next, 5*time.Second)
+	})
+
+	t.Run("River default without policy", func(t *testing.T) {
+		t.Parallel()
+
+		registry := newTaskRegistry()
+		registry.register("plain", newTaskWrapper[testPayload](&testTask{name: "plain"}))
+		w := &forgeTaskWorker{registry: registry, logger: slog.New(slog.DiscardHandler)}
+
+		// River's default is attempt^4 seconds with jitter, so 1s for the
+		// first failure recorded on the job.
+		next := w.NextRetry(newJob("plain", errors.New("boom")))
+		require.WithinDuration(t, time.Now().Add(time.Second), next, time.Second)
+	})
+}
+
+func TestForgeTaskWorker_NoRetry(t *testing.T) {
+	t.Parallel()
+
+	taskErr := fmt.Errorf("card declined: %w", ErrNoRetry)
+	registry := newTaskRegistry()
+	registry.register("charge", newTaskWrapper[testPayload](&testTask{name: "charge", err: taskErr}))
+	w := &forgeTaskWorker{registry: registry, logger: slog.New(slog.DiscardHandler)}
+
+	err := w.Work(context.Background(), &river.Job[forgeTaskArgs]{
+		JobRow: &rivertype.JobRow{ID: 1, Attempt: 1},
+		Args:   forgeTaskArgs{TaskName: "charge"},
+	})
+
+	var cancelErr *rivertype.JobCancelError
+	require.ErrorAs(t, err, &cancelErr)
+	require.ErrorIs(t, err, ErrNoRetry)
+}
+
+func TestForgeTaskWorker_RecordsAttemptError(t *testing.T) {
+	t.Parallel()
+
+	taskErr := errors.New("timeout")
+	registry := newTaskRegistry()
+	registry.register("sync", newTaskWrapper[testPayload](&testTask{name: "sync", err: taskErr}))
+	w := &forgeTaskWorker{registry: registry, logger: slog.New(slog.DiscardHandler)}
+
+	job := &river.Job[forgeTaskArgs]{
+		JobRow: &rivertype.JobRow{ID: 1, Attempt: 1},
+		Args:   forgeTaskArgs{TaskName: "sync"},
+	}
+	require.ErrorIs(t, w.Work(context.Background(), job), taskErr)
+	require.ErrorIs(t, job.Args.err, taskErr)
+}
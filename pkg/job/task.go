@@ -7,6 +7,7 @@ import (
 	"maps"
 	"slices"
 	"sync"
+	"time"
 )
 
 // taskExecutor is the internal interface for type-erased task execution.
@@ -53,7 +54,8 @@ type taskWrapper[P any, T interface {
 	Name() string
 	Handle(context.Context, P) error
 }] struct {
-	task T
+	task    T
+	retryAt retryFunc
 }
 
 func (w *taskWrapper[P, T]) Execute(ctx context.Context, raw json.RawMessage) error {
@@ -66,9 +68,20 @@ func (w *taskWrapper[P, T]) Execute(ctx context.Context, raw json.RawMessage) er
 	return w.task.Handle(ctx, payload)
 }
 
+func (w *taskWrapper[P, T]) nextRetry(attempt int, err error) time.Time {
+	if w.retryAt == nil {
+		return time.Time{}
+	}
+	return w.retryAt(attempt, err)
+}
+
 func newTaskWrapper[P any, T interface {
 	Name() string
 	Handle(context.Context, P) error
 }](task T) *taskWrapper[P, T] {
-	return &taskWrapper[P, T]{task: task}
+	w := &taskWrapper[P, T]{task: task}
+	if r, ok := any(task).(retryMethod); ok {
+		w.retryAt = r.NextRetry
+	}
+	return w
 }
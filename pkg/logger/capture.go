@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Record is a captured log entry. Attrs holds resolved attribute values keyed
+// by name; attributes inside groups use dot-separated keys ("http.status").
+type Record struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]any
+}
+
+// RecordCapture collects log records written by a logger from NewTestLogger.
+// It is safe for concurrent use.
+type RecordCapture struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewTestLogger creates a logger that captures records in memory at every level
+// instead of writing them out. Context extractors run as they do in New, so
+// tests can assert on injected attributes such as request IDs.
+func NewTestLogger(extractors ...ContextExtractor) (*slog.Logger, *RecordCapture) {
+	capture := &RecordCapture{}
+	h := &captureHandler{capture: capture}
+	return slog.New(NewLogHandlerDecorator(h, extractors...)), capture
+}
+
+// Records returns a copy of the captured records in logging order.
+func (c *RecordCapture) Records() []Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Record, len(c.records))
+	copy(out, c.records)
+	return out
+}
+
+// Contains reports whether a record with the given level and message was logged.
+func (c *RecordCapture) Contains(level slog.Level, msg string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.records {
+		if r.Level == level && r.Message == msg {
+			return true
+		}
+	}
+	return false
+}
+
+// Attr returns the value of attribute key on the first record with the given
+// message, or nil if there is no such record or attribute.
+func (c *RecordCapture) Attr(msg, key string) any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.records {
+		if r.Message == msg {
+			return r.Attrs[key]
+		}
+	}
+	return nil
+}
+
+func (c *RecordCapture) add(r Record) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, r)
+}
+
+// captureHandler is the slog.Handler behind NewTestLogger. Attributes added
+// with WithAttrs are flattened eagerly so Handle only merges the record's own.
+type captureHandler struct {
+	capture *RecordCapture
+	attrs   map[string]any
+	prefix  string
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *captureHandler) Handle(_ context.Context, rec slog.Record) error {
+	attrs := make(map[string]any, len(h.attrs)+rec.NumAttrs())
+	maps.Copy(attrs, h.attrs)
+	rec.Attrs(func(a slog.Attr) bool {
+		flattenAttr(attrs, h.prefix, a)
+		return true
+	})
+	h.capture.add(Record{
+		Time:    rec.Time,
+		Level:   rec.Level,
+		Message: rec.Message,
+		Attrs:   attrs,
+	})
+	return nil
+}
+
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := &captureHandler{
+		capture: h.capture,
+		attrs:   maps.Clone(h.attrs),
+		prefix:  h.prefix,
+	}
+	if next.attrs == nil {
+		next.attrs = make(map[string]any, len(attrs))
+	}
+	for _, a := range attrs {
+		flattenAttr(next.attrs, h.prefix, a)
+	}
+	return next
+}
+
+func (h *captureHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &captureHandler{
+		capture: h.capture,
+		attrs:   h.attrs,
+		prefix:  h.prefix + name + ".",
+	}
+}
+
+// flattenAttr stores a under prefix+key, expanding groups into dotted keys.
+// Empty attributes are dropped and groups with an empty key are inlined,
+// matching the built-in handlers.
+func flattenAttr(dst map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			flattenAttr(dst, prefix, ga)
+		}
+		return
+	}
+	dst[prefix+a.Key] = a.Value.Any()
+}
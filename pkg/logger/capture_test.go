@@ -0,0 +1,64 @@
+package logger_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/logger"
+)
+
+type requestIDKey struct{}
+
+func TestNewTestLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("captures records at every level", func(t *testing.T) {
+		t.Parallel()
+
+		log, capture := logger.NewTestLogger()
+		log.Debug("debug message")
+		log.Warn("slow query", "duration_ms", 1200)
+
+		records := capture.Records()
+		require.Len(t, records, 2)
+		require.Equal(t, slog.LevelDebug, records[0].Level)
+		require.True(t, capture.Contains(slog.LevelWarn, "slow query"))
+		require.False(t, capture.Contains(slog.LevelError, "slow query"))
+		require.Equal(t, int64(1200), capture.Attr("slow query", "duration_ms"))
+		require.Nil(t, capture.Attr("missing", "duration_ms"))
+	})
+
+	t.Run("runs context extractors", func(t *testing.T) {
+		t.Parallel()
+
+		extractor := func(ctx context.Context) (slog.Attr, bool) {
+			id, ok := ctx.Value(requestIDKey{}).(string)
+			return slog.String("request_id", id), ok
+		}
+		log, capture := logger.NewTestLogger(extractor)
+
+		ctx := context.WithValue(context.Background(), requestIDKey{}, "req-123")
+		log.InfoContext(ctx, "handled")
+		log.Info("no request")
+
+		require.Equal(t, "req-123", capture.Attr("handled", "request_id"))
+		require.Nil(t, capture.Attr("no request", "request_id"))
+	})
+
+	t.Run("flattens groups and logger attributes", func(t *testing.T) {
+		t.Parallel()
+
+		log, capture := logger.NewTestLogger()
+		log.With("component", "billing").
+			WithGroup("http").
+			Info("request", "status", 200, slog.Group("client", "ip", "10.0.0.1"))
+
+		attrs := capture.Records()[0].Attrs
+		require.Equal(t, "billing", attrs["component"])
+		require.Equal(t, int64(200), attrs["http.status"])
+		require.Equal(t, "10.0.0.1", attrs["http.client.ip"])
+	})
+}
@@ -10,9 +10,12 @@ type ContextExtractor func(ctx context.Context) (slog.Attr, bool)
 
 // LogHandlerDecorator wraps a slog.Handler and injects context-extracted attributes during logging.
 // Extraction occurs per-log-call to capture fresh request-scoped values (e.g., request IDs).
+// When redaction is enabled (see WithRedaction), sensitive attributes are
+// replaced before the record or handler attributes reach the wrapped handler.
 type LogHandlerDecorator struct {
 	next       slog.Handler
 	extractors []ContextExtractor
+	redact     func(key string) bool
 }
 
 // NewLogHandlerDecorator creates a new decorated handler with context extractors.
@@ -31,25 +34,46 @@ func (h *LogHandlerDecorator) Enabled(ctx context.Context, level slog.Level) boo
 	return h.next.Enabled(ctx, level)
 }
 
-// Handle extracts context attributes and delegates to the underlying handler.
+// Handle extracts context attributes, redacts sensitive ones if enabled, and
+// delegates to the underlying handler.
 func (h *LogHandlerDecorator) Handle(ctx context.Context, rec slog.Record) error {
-	if len(h.extractors) == 0 {
-		return h.next.Handle(ctx, rec)
-	}
-
 	for _, ex := range h.extractors {
 		if attr, ok := ex(ctx); ok {
 			rec.AddAttrs(attr)
 		}
 	}
+	if h.redact != nil {
+		rec = h.redactRecord(rec)
+	}
 	return h.next.Handle(ctx, rec)
 }
 
+// redactRecord returns rec with sensitive attributes replaced. Records
+// cannot have attributes removed, so a changed record is rebuilt.
+func (h *LogHandlerDecorator) redactRecord(rec slog.Record) slog.Record {
+	attrs := make([]slog.Attr, 0, rec.NumAttrs())
+	rec.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	attrs, changed := redactAttrs(attrs, h.redact)
+	if !changed {
+		return rec
+	}
+	out := slog.NewRecord(rec.Time, rec.Level, rec.Message, rec.PC)
+	out.AddAttrs(attrs...)
+	return out
+}
+
 // WithAttrs creates a new decorated handler with additional static attributes.
 func (h *LogHandlerDecorator) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if h.redact != nil {
+		attrs, _ = redactAttrs(attrs, h.redact)
+	}
 	return &LogHandlerDecorator{
 		next:       h.next.WithAttrs(attrs),
 		extractors: h.extractors,
+		redact:     h.redact,
 	}
 }
 
@@ -58,5 +82,6 @@ func (h *LogHandlerDecorator) WithGroup(name string) slog.Handler {
 	return &LogHandlerDecorator{
 		next:       h.next.WithGroup(name),
 		extractors: h.extractors,
+		redact:     h.redact,
 	}
 }
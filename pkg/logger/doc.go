@@ -76,6 +76,41 @@
 //
 // This allows using context extractors with any handler implementation.
 //
+// # Redaction
+//
+// WithRedaction replaces the values of sensitive attributes with "[REDACTED]"
+// in the decorator, before records reach stdout or Sentry. Keys containing
+// password, token, secret, authorization, or set-cookie are always redacted,
+// ignoring case; pass more keys to extend the list:
+//
+//	log := logger.WithRedaction(logger.New(requestIDExtractor), "api_key", "ssn")
+//
+//	log.Info("login", "user", "alice", "password", pw)
+//	// Output: {"level":"INFO","msg":"login","user":"alice","password":"[REDACTED]"}
+//
+// Groups are inspected at every level, and a sensitive group key redacts the
+// whole group. WithRedactionFunc takes a custom predicate instead:
+//
+//	log = logger.WithRedactionFunc(log, func(key string) bool {
+//		return strings.HasPrefix(key, "pii_")
+//	})
+//
+// Only attributes are inspected: log structs through slog.LogValuer, returning
+// a group, rather than passing them whole with slog.Any.
+//
+// # Testing
+//
+// NewTestLogger returns a logger that records entries in memory, with the
+// same context extractors as production, so tests can assert on what was logged:
+//
+//	log, capture := logger.NewTestLogger(requestIDExtractor)
+//	svc := NewService(log)
+//
+//	svc.Charge(ctx, invoice)
+//
+//	require.True(t, capture.Contains(slog.LevelWarn, "payment retried"))
+//	require.Equal(t, "abc-123", capture.Attr("payment retried", "request_id"))
+//
 // # Architecture
 //
 // The package uses several design patterns:
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted attributes.
+const RedactedValue = "[REDACTED]"
+
+// defaultRedactedKeys are always redacted by WithRedaction.
+var defaultRedactedKeys = []string{"password", "token", "secret", "authorization", "set-cookie"}
+
+// WithRedaction returns a logger that replaces the values of sensitive
+// attributes with "[REDACTED]" before records reach any destination,
+// including Sentry. A key is sensitive when it contains, ignoring case, one
+// of password, token, secret, authorization, set-cookie, or one of keys, so
+// "access_token" and "X-Auth-Token" are caught too. Attributes nested in
+// groups are checked at every level; a sensitive group key redacts the whole
+// group.
+//
+// Redaction sees attributes, not the fields of arbitrary values: a struct
+// logged with slog.Any is only redacted if it implements slog.LogValuer and
+// returns a group.
+//
+// If l was built by this package, redaction runs in its existing decorator,
+// after context extractors; otherwise l's handler is wrapped. Attributes
+// already added to l with With are not revisited, so apply redaction first.
+func WithRedaction(l *slog.Logger, keys ...string) *slog.Logger {
+	match := make([]string, 0, len(defaultRedactedKeys)+len(keys))
+	match = append(match, defaultRedactedKeys...)
+	for _, k := range keys {
+		if k != "" {
+			match = append(match, strings.ToLower(k))
+		}
+	}
+	return WithRedactionFunc(l, func(key string) bool {
+		key = strings.ToLower(key)
+		for _, m := range match {
+			if strings.Contains(key, m) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WithRedactionFunc is like WithRedaction but redacts the attributes whose
+// key satisfies fn. It replaces any redaction configured earlier on l.
+func WithRedactionFunc(l *slog.Logger, fn func(key string) bool) *slog.Logger {
+	var d LogHandlerDecorator
+	if existing, ok := l.Handler().(*LogHandlerDecorator); ok {
+		d = *existing
+	} else {
+		d.next = l.Handler()
+	}
+	d.redact = fn
+	return slog.New(&d)
+}
+
+// redactAttrs returns attrs with sensitive values replaced and whether
+// anything changed. The slice is copied only when something does.
+func redactAttrs(attrs []slog.Attr, redact func(string) bool) ([]slog.Attr, bool) {
+	var out []slog.Attr
+	for i, a := range attrs {
+		r, changed := redactAttr(a, redact)
+		if changed && out == nil {
+			out = make([]slog.Attr, len(attrs))
+			copy(out, attrs[:i])
+		}
+		if out != nil {
+			out[i] = r
+		}
+	}
+	if out == nil {
+		return attrs, false
+	}
+	return out, true
+}
+
+// redactAttr redacts a if its key is sensitive, or the sensitive attributes
+// inside it if it is a group. LogValuers are resolved first so the groups
+// they return are inspected too.
+func redactAttr(a slog.Attr, redact func(string) bool) (slog.Attr, bool) {
+	if redact(a.Key) {
+		return slog.String(a.Key, RedactedValue), true
+	}
+	resolved := false
+	if a.Value.Kind() == slog.KindLogValuer {
+		a.Value = a.Value.Resolve()
+		resolved = true
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return a, resolved
+	}
+	group, changed := redactAttrs(a.Value.Group(), redact)
+	if !changed {
+		return a, resolved
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(group...)}, true
+}
@@ -0,0 +1,125 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/logger"
+)
+
+type credentials struct {
+	User     string
+	Password string
+}
+
+func (c credentials) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("user", c.User), slog.String("password", c.Password))
+}
+
+func TestWithRedaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("redacts default keys case-insensitively", func(t *testing.T) {
+		t.Parallel()
+
+		log, capture := logger.NewTestLogger()
+		log = logger.WithRedaction(log)
+		log.Info("login",
+			"user", "alice",
+			"Password", "hunter2",
+			"access_token", "abc",
+			"Authorization", "Bearer xyz",
+			"Set-Cookie", "sid=1",
+			"client_secret", "s3cr3t",
+		)
+
+		attrs := capture.Records()[0].Attrs
+		require.Equal(t, "alice", attrs["user"])
+		for _, key := range []string{"Password", "access_token", "Authorization", "Set-Cookie", "client_secret"} {
+			require.Equal(t, logger.RedactedValue, attrs[key], key)
+		}
+	})
+
+	t.Run("redacts extra keys", func(t *testing.T) {
+		t.Parallel()
+
+		log, capture := logger.NewTestLogger()
+		logger.WithRedaction(log, "ssn").Info("user", "SSN", "123-45-6789", "token", "t")
+
+		require.Equal(t, logger.RedactedValue, capture.Attr("user", "SSN"))
+		require.Equal(t, logger.RedactedValue, capture.Attr("user", "token"))
+	})
+
+	t.Run("redacts nested groups and log valuers", func(t *testing.T) {
+		t.Parallel()
+
+		log, capture := logger.NewTestLogger()
+		log = logger.WithRedaction(log)
+		log.Info("request",
+			slog.Group("http",
+				slog.String("method", "POST"),
+				slog.Group("headers", slog.String("authorization", "Bearer xyz"), slog.String("accept", "*/*")),
+			),
+			slog.Any("creds", credentials{User: "bob", Password: "pw"}),
+			slog.Group("secrets", slog.String("db", "postgres://u:p@host")),
+		)
+
+		attrs := capture.Records()[0].Attrs
+		require.Equal(t, "POST", attrs["http.method"])
+		require.Equal(t, logger.RedactedValue, attrs["http.headers.authorization"])
+		require.Equal(t, "*/*", attrs["http.headers.accept"])
+		require.Equal(t, "bob", attrs["creds.user"])
+		require.Equal(t, logger.RedactedValue, attrs["creds.password"])
+		require.Equal(t, logger.RedactedValue, attrs["secrets"])
+		require.NotContains(t, attrs, "secrets.db")
+	})
+
+	t.Run("redacts logger attributes and extracted attributes", func(t *testing.T) {
+		t.Parallel()
+
+		extractor := func(context.Context) (slog.Attr, bool) {
+			return slog.String("session_token", "from-context"), true
+		}
+		log, capture := logger.NewTestLogger(extractor)
+		log = logger.WithRedaction(log).With("api_token", "static").WithGroup("job")
+		log.Info("run", "password", "x", "id", 7)
+
+		attrs := capture.Records()[0].Attrs
+		require.Equal(t, logger.RedactedValue, attrs["api_token"])
+		require.Equal(t, logger.RedactedValue, attrs["job.session_token"])
+		require.Equal(t, logger.RedactedValue, attrs["job.password"])
+		require.Equal(t, int64(7), attrs["job.id"])
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		t.Parallel()
+
+		log, capture := logger.NewTestLogger()
+		log = logger.WithRedactionFunc(log, func(key string) bool {
+			return strings.HasPrefix(key, "pii_")
+		})
+		log.Info("signup", "pii_email", "a@example.com", "password", "kept")
+
+		require.Equal(t, logger.RedactedValue, capture.Attr("signup", "pii_email"))
+		require.Equal(t, "kept", capture.Attr("signup", "password"))
+	})
+
+	t.Run("wraps foreign handlers", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		log := logger.WithRedaction(slog.New(slog.NewJSONHandler(&buf, nil)))
+		log.Info("auth", "token", "abc", "user", "alice")
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		require.Equal(t, logger.RedactedValue, out["token"])
+		require.Equal(t, "alice", out["user"])
+	})
+}
@@ -5,4 +5,9 @@ package mailer
 type Config struct {
 	FallbackSubject string `env:"MAILER_FALLBACK_SUBJECT" envDefault:"Notification"`
 	DefaultLayout   string `env:"MAILER_DEFAULT_LAYOUT" envDefault:"base.html"`
+
+	// UnsubscribeURL is the default one-click unsubscribe URL for Send.
+	// It is a template executed with SendParams.Data, so it can be
+	// per-recipient: "https://app.example.com/unsubscribe?token={{.Token}}".
+	UnsubscribeURL string `env:"MAILER_UNSUBSCRIBE_URL"`
 }
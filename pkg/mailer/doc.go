@@ -69,6 +69,22 @@
 //
 // Subject fields support Go template syntax ({{.Variable}}) for dynamic subjects.
 //
+// # Partials
+//
+// Shared fragments such as a header or footer live under the partials
+// directory (RendererConfig.PartialDir, "partials" by default, relative to
+// TemplateDir). They are parsed when the renderer is created and included by
+// path without the extension:
+//
+//	{{template "partials/header" .}}
+//
+//	Your order has shipped.
+//
+//	{{template "partials/footer" .}}
+//
+// Including a partial that does not exist returns ErrTemplateNotFound naming
+// the missing partial.
+//
 // # Sending Emails
 //
 // Mailer provides two methods for sending emails:
@@ -79,6 +95,24 @@
 // SendParams supports optional overrides for subject, layout, sender, reply-to,
 // CC, BCC, and attachments.
 //
+// # Unsubscribe Headers
+//
+// Marketing emails should carry one-click unsubscribe headers. Set
+// Email.UnsubscribeURL, SendParams.UnsubscribeURL, or Config.UnsubscribeURL;
+// senders emit List-Unsubscribe and List-Unsubscribe-Post via Email.AllHeaders.
+// The URL must be absolute HTTPS. With Send it is a template over Data, so it
+// can be per-recipient:
+//
+//	err := m.Send(ctx, mailer.SendParams{
+//		To:             user.Email,
+//		Template:       "newsletter.md",
+//		Data:           data,
+//		UnsubscribeURL: "https://app.example.com/unsubscribe?token={{.Token}}",
+//	})
+//
+// Config.UnsubscribeURL applies to every Send, so set it only on a mailer
+// dedicated to marketing mail, not on one that sends transactional email.
+//
 // # Email Tags
 //
 // The Email type supports provider-specific tags for categorization:
@@ -134,9 +168,10 @@
 //   - ErrNoRecipient: No recipient specified
 //   - ErrNoSubject: No subject provided
 //   - ErrNoContent: No HTML content provided
-//   - ErrTemplateNotFound: Template file not found
+//   - ErrTemplateNotFound: Template or partial not found
 //   - ErrLayoutNotFound: Layout file not found
 //   - ErrRenderFailed: Template rendering failed
 //   - ErrSendFailed: Email sending failed
+//   - ErrInvalidUnsubscribeURL: Unsubscribe URL is not absolute HTTPS
 //   - ErrInvalidFrontmatter: Invalid YAML frontmatter
 package mailer
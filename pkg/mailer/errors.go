@@ -12,7 +12,7 @@ var (
 	// ErrNoContent indicates no HTML content was provided.
 	ErrNoContent = errors.New("email must have HTML content")
 
-	// ErrTemplateNotFound indicates the template file was not found.
+	// ErrTemplateNotFound indicates the template file or an included partial was not found.
 	ErrTemplateNotFound = errors.New("template not found")
 
 	// ErrLayoutNotFound indicates the layout file was not found.
@@ -24,6 +24,9 @@ var (
 	// ErrSendFailed indicates email sending failed.
 	ErrSendFailed = errors.New("failed to send email")
 
+	// ErrInvalidUnsubscribeURL indicates the unsubscribe URL is not an absolute HTTPS URL.
+	ErrInvalidUnsubscribeURL = errors.New("unsubscribe URL must be an absolute https URL")
+
 	// ErrInvalidFrontmatter indicates invalid YAML frontmatter.
 	ErrInvalidFrontmatter = errors.New("invalid frontmatter")
 )
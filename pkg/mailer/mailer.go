@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"net/url"
 	texttemplate "text/template"
 )
 
@@ -37,6 +38,10 @@ type SendParams struct {
 	CC          []string     // Carbon copy
 	BCC         []string     // Blind carbon copy
 	Attachments []Attachment // File attachments
+
+	// UnsubscribeURL overrides Config.UnsubscribeURL. It is a template
+	// executed with Data, like the subject.
+	UnsubscribeURL string
 }
 
 // Send renders a template and sends an email.
@@ -71,16 +76,31 @@ func (m *Mailer) Send(ctx context.Context, params SendParams) error {
 		return errors.Join(ErrRenderFailed, err)
 	}
 
+	unsubscribeURL := params.UnsubscribeURL
+	if unsubscribeURL == "" {
+		unsubscribeURL = m.config.UnsubscribeURL
+	}
+	if unsubscribeURL != "" {
+		unsubscribeURL, err = m.processSubject(unsubscribeURL, params.Data)
+		if err != nil {
+			return errors.Join(ErrRenderFailed, err)
+		}
+		if err := validateUnsubscribeURL(unsubscribeURL); err != nil {
+			return err
+		}
+	}
+
 	email := &Email{
-		To:          []string{params.To},
-		Subject:     processedSubject,
-		HTML:        result.HTML,
-		Text:        result.Text,
-		From:        params.From,
-		ReplyTo:     params.ReplyTo,
-		CC:          params.CC,
-		BCC:         params.BCC,
-		Attachments: params.Attachments,
+		To:             []string{params.To},
+		Subject:        processedSubject,
+		HTML:           result.HTML,
+		Text:           result.Text,
+		From:           params.From,
+		ReplyTo:        params.ReplyTo,
+		CC:             params.CC,
+		BCC:            params.BCC,
+		Attachments:    params.Attachments,
+		UnsubscribeURL: unsubscribeURL,
 	}
 
 	if err := m.sender.Send(ctx, email); err != nil {
@@ -101,6 +121,11 @@ func (m *Mailer) SendRaw(ctx context.Context, email *Email) error {
 	if email.HTML == "" {
 		return ErrNoContent
 	}
+	if email.UnsubscribeURL != "" {
+		if err := validateUnsubscribeURL(email.UnsubscribeURL); err != nil {
+			return err
+		}
+	}
 
 	if err := m.sender.Send(ctx, email); err != nil {
 		return errors.Join(ErrSendFailed, err)
@@ -109,6 +134,16 @@ func (m *Mailer) SendRaw(ctx context.Context, email *Email) error {
 	return nil
 }
 
+// validateUnsubscribeURL checks that raw is an absolute HTTPS URL, which
+// one-click unsubscribe (RFC 8058) requires.
+func validateUnsubscribeURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return ErrInvalidUnsubscribeURL
+	}
+	return nil
+}
+
 func (m *Mailer) processSubject(subject string, data any) (string, error) {
 	tmpl, err := texttemplate.New("subject").Parse(subject)
 	if err != nil {
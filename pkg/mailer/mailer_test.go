@@ -450,3 +450,106 @@ func TestMailer_SendRaw_SenderFailure(t *testing.T) {
 	require.ErrorIs(t, err, senderErr)
 	mockSender.AssertExpectations(t)
 }
+
+func TestMailer_Send_UnsubscribeURL(t *testing.T) {
+	t.Parallel()
+
+	fs := fstest.MapFS{
+		"layouts/base.html": &fstest.MapFile{
+			Data: []byte(`<html>{{.Content}}</html>`),
+		},
+		"news.md": &fstest.MapFile{
+			Data: []byte(`---
+Subject: News
+---
+Latest news.
+`),
+		},
+	}
+	data := map[string]string{"Token": "abc123"}
+
+	t.Run("config default is templated per recipient", func(t *testing.T) {
+		t.Parallel()
+
+		mockSender := &MockSender{}
+		renderer := NewRendererWithConfig(fs, RendererConfig{LayoutDir: "layouts"})
+		mailer := New(mockSender, renderer, Config{
+			DefaultLayout:  "base.html",
+			UnsubscribeURL: "https://example.com/unsubscribe?token={{.Token}}",
+		})
+
+		mockSender.On("Send", mock.Anything, mock.MatchedBy(func(email *Email) bool {
+			return email.UnsubscribeURL == "https://example.com/unsubscribe?token=abc123"
+		})).Return(nil)
+
+		err := mailer.Send(context.Background(), SendParams{
+			To:       "user@example.com",
+			Template: "news.md",
+			Data:     data,
+		})
+
+		require.NoError(t, err)
+		mockSender.AssertExpectations(t)
+	})
+
+	t.Run("params override config", func(t *testing.T) {
+		t.Parallel()
+
+		mockSender := &MockSender{}
+		renderer := NewRendererWithConfig(fs, RendererConfig{LayoutDir: "layouts"})
+		mailer := New(mockSender, renderer, Config{
+			DefaultLayout:  "base.html",
+			UnsubscribeURL: "https://example.com/default",
+		})
+
+		mockSender.On("Send", mock.Anything, mock.MatchedBy(func(email *Email) bool {
+			return email.UnsubscribeURL == "https://example.com/u/abc123"
+		})).Return(nil)
+
+		err := mailer.Send(context.Background(), SendParams{
+			To:             "user@example.com",
+			Template:       "news.md",
+			Data:           data,
+			UnsubscribeURL: "https://example.com/u/{{.Token}}",
+		})
+
+		require.NoError(t, err)
+		mockSender.AssertExpectations(t)
+	})
+
+	t.Run("rejects non-https and relative URLs", func(t *testing.T) {
+		t.Parallel()
+
+		for _, raw := range []string{"http://example.com/u", "/unsubscribe", "mailto:unsubscribe@example.com"} {
+			mockSender := &MockSender{}
+			renderer := NewRendererWithConfig(fs, RendererConfig{LayoutDir: "layouts"})
+			mailer := New(mockSender, renderer, Config{DefaultLayout: "base.html"})
+
+			err := mailer.Send(context.Background(), SendParams{
+				To:             "user@example.com",
+				Template:       "news.md",
+				UnsubscribeURL: raw,
+			})
+
+			require.ErrorIs(t, err, ErrInvalidUnsubscribeURL, raw)
+			mockSender.AssertNotCalled(t, "Send")
+		}
+	})
+}
+
+func TestMailer_SendRaw_InvalidUnsubscribeURL(t *testing.T) {
+	t.Parallel()
+
+	mockSender := &MockSender{}
+	mailer := New(mockSender, nil, Config{})
+
+	err := mailer.SendRaw(context.Background(), &Email{
+		To:             []string{"user@example.com"},
+		Subject:        "Test",
+		HTML:           "<p>Hello</p>",
+		UnsubscribeURL: "http://example.com/unsubscribe",
+	})
+
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeURL)
+	mockSender.AssertNotCalled(t, "Send")
+}
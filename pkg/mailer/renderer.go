@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"html/template"
 	"io/fs"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	texttemplate "text/template"
+	"text/template/parse"
 
 	"github.com/yuin/goldmark"
 )
@@ -18,11 +21,17 @@ type Renderer struct {
 	fs fs.FS
 	md goldmark.Markdown // cached markdown processor
 
+	// Partials parsed at construction; every template is parsed into a clone
+	// of this set so {{template "partials/header" .}} resolves.
+	partials    *texttemplate.Template
+	partialsErr error
+
 	// Caches (safe: stores parsed structure, not rendered output)
 	templateCache map[string]*cachedTemplate
 	layoutCache   map[string]*template.Template
 	templateDir   string
 	layoutDir     string
+	partialDir    string
 
 	mu sync.RWMutex
 }
@@ -37,6 +46,7 @@ type cachedTemplate struct {
 type RendererConfig struct {
 	TemplateDir string // Default: "."
 	LayoutDir   string // Default: "layouts"
+	PartialDir  string // Default: "partials", relative to TemplateDir
 }
 
 // NewRenderer creates a new renderer with default config.
@@ -45,6 +55,11 @@ func NewRenderer(filesystem fs.FS) *Renderer {
 }
 
 // NewRendererWithConfig creates a new renderer with custom config.
+//
+// Every file under PartialDir is parsed up front and can be included from
+// any template by its path without extension, e.g. partials/header.md as
+// {{template "partials/header" .}}. A partial that fails to parse makes
+// every Render return the error.
 func NewRendererWithConfig(filesystem fs.FS, opts RendererConfig) *Renderer {
 	if opts.TemplateDir == "" {
 		opts.TemplateDir = "."
@@ -52,17 +67,23 @@ func NewRendererWithConfig(filesystem fs.FS, opts RendererConfig) *Renderer {
 	if opts.LayoutDir == "" {
 		opts.LayoutDir = "layouts"
 	}
+	if opts.PartialDir == "" {
+		opts.PartialDir = "partials"
+	}
 
-	return &Renderer{
+	r := &Renderer{
 		fs:          filesystem,
 		templateDir: opts.TemplateDir,
 		layoutDir:   opts.LayoutDir,
+		partialDir:  opts.PartialDir,
 		md: goldmark.New(
 			goldmark.WithExtensions(NewButtonExtension()),
 		),
 		templateCache: make(map[string]*cachedTemplate),
 		layoutCache:   make(map[string]*template.Template),
 	}
+	r.partials, r.partialsErr = r.loadPartials()
+	return r
 }
 
 // RenderResult contains the rendered HTML, plain text, and extracted metadata.
@@ -138,6 +159,10 @@ func (r *Renderer) getTemplate(name string) (*cachedTemplate, error) {
 		return cached, nil
 	}
 
+	if r.partialsErr != nil {
+		return nil, r.partialsErr
+	}
+
 	path := filepath.Join(r.templateDir, name)
 	content, err := fs.ReadFile(r.fs, path)
 	if err != nil {
@@ -149,11 +174,20 @@ func (r *Renderer) getTemplate(name string) (*cachedTemplate, error) {
 		return nil, errors.Join(ErrRenderFailed, fmt.Errorf("%s: %w", name, err))
 	}
 
-	tmpl, err := texttemplate.New(name).Parse(parsed.Body)
+	set, err := r.partials.Clone()
+	if err != nil {
+		return nil, errors.Join(ErrRenderFailed, fmt.Errorf("failed to clone partials: %w", err))
+	}
+	tmpl, err := set.New(name).Parse(parsed.Body)
 	if err != nil {
 		return nil, errors.Join(ErrRenderFailed, fmt.Errorf("failed to parse template body: %w", err))
 	}
 
+	// Report missing partials now rather than as a generic execution error.
+	if missing := missingTemplate(tmpl, tmpl.Tree.Root, make(map[string]bool)); missing != "" {
+		return nil, errors.Join(ErrTemplateNotFound, fmt.Errorf("%s: partial %q", name, missing))
+	}
+
 	cached := &cachedTemplate{metadata: parsed.Metadata, tmpl: tmpl}
 	r.templateCache[name] = cached
 	return cached, nil
@@ -191,3 +225,76 @@ func (r *Renderer) getLayout(name string) (*template.Template, error) {
 	r.layoutCache[name] = layoutTmpl
 	return layoutTmpl, nil
 }
+
+// loadPartials parses every file under the partial directory into one
+// template set. A missing directory yields an empty set.
+func (r *Renderer) loadPartials() (*texttemplate.Template, error) {
+	set := texttemplate.New("")
+	root := path.Join(r.templateDir, r.partialDir)
+	err := fs.WalkDir(r.fs, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(r.fs, p)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(p, root+"/")
+		name := path.Join(r.partialDir, strings.TrimSuffix(rel, path.Ext(rel)))
+		if _, err := set.New(name).Parse(string(content)); err != nil {
+			return errors.Join(ErrRenderFailed, fmt.Errorf("failed to parse partial %s: %w", name, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// missingTemplate returns the name of the first template invoked from node,
+// directly or through included partials, that is not defined in t's set.
+func missingTemplate(t *texttemplate.Template, node parse.Node, seen map[string]bool) string {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return ""
+		}
+		for _, child := range n.Nodes {
+			if missing := missingTemplate(t, child, seen); missing != "" {
+				return missing
+			}
+		}
+	case *parse.IfNode:
+		return missingBranch(t, &n.BranchNode, seen)
+	case *parse.RangeNode:
+		return missingBranch(t, &n.BranchNode, seen)
+	case *parse.WithNode:
+		return missingBranch(t, &n.BranchNode, seen)
+	case *parse.TemplateNode:
+		if seen[n.Name] {
+			return ""
+		}
+		seen[n.Name] = true
+		included := t.Lookup(n.Name)
+		if included == nil || included.Tree == nil {
+			return n.Name
+		}
+		return missingTemplate(t, included.Tree.Root, seen)
+	}
+	return ""
+}
+
+func missingBranch(t *texttemplate.Template, n *parse.BranchNode, seen map[string]bool) string {
+	if missing := missingTemplate(t, n.List, seen); missing != "" {
+		return missing
+	}
+	return missingTemplate(t, n.ElseList, seen)
+}
@@ -171,6 +171,98 @@ Hello {{.ID}}
 	}
 }
 
+func TestRenderer_Render_Partials(t *testing.T) {
+	t.Parallel()
+
+	layout := &fstest.MapFile{Data: []byte(`<html>{{.Content}}</html>`)}
+
+	t.Run("includes partials from the template directory", func(t *testing.T) {
+		t.Parallel()
+
+		fs := fstest.MapFS{
+			"emails/layouts/default.html": layout,
+			"emails/partials/header.md":   &fstest.MapFile{Data: []byte("# Hi {{.Name}}\n")},
+			"emails/partials/footer/legal.md": &fstest.MapFile{
+				Data: []byte(`{{if .Company}}Sent by {{.Company}}{{end}}`),
+			},
+			"emails/welcome.md": &fstest.MapFile{
+				Data: []byte(`---
+Subject: Welcome
+---
+{{template "partials/header" .}}
+Thanks for joining.
+
+{{template "partials/footer/legal" .}}
+`),
+			},
+		}
+
+		renderer := NewRendererWithConfig(fs, RendererConfig{
+			TemplateDir: "emails",
+			LayoutDir:   "emails/layouts",
+		})
+
+		result, err := renderer.Render("default.html", "welcome.md", map[string]string{"Name": "Alice", "Company": "Acme"})
+		require.NoError(t, err)
+		require.Contains(t, result.Text, "# Hi Alice")
+		require.Contains(t, result.Text, "Sent by Acme")
+		require.Contains(t, result.HTML, "<h1>Hi Alice</h1>")
+	})
+
+	t.Run("custom partial directory", func(t *testing.T) {
+		t.Parallel()
+
+		fs := fstest.MapFS{
+			"layouts/default.html": layout,
+			"shared/footer.md":     &fstest.MapFile{Data: []byte("The Team")},
+			"note.md":              &fstest.MapFile{Data: []byte(`Bye. {{template "shared/footer"}}`)},
+		}
+
+		renderer := NewRendererWithConfig(fs, RendererConfig{PartialDir: "shared"})
+
+		result, err := renderer.Render("default.html", "note.md", nil)
+		require.NoError(t, err)
+		require.Contains(t, result.Text, "Bye. The Team")
+	})
+
+	t.Run("missing partial", func(t *testing.T) {
+		t.Parallel()
+
+		fs := fstest.MapFS{
+			"layouts/default.html": layout,
+			"partials/header.md":   &fstest.MapFile{Data: []byte(`{{template "partials/logo"}}`)},
+			"direct.md":            &fstest.MapFile{Data: []byte(`{{if .}}{{template "partials/missing" .}}{{end}}`)},
+			"nested.md":            &fstest.MapFile{Data: []byte(`{{template "partials/header" .}}`)},
+		}
+
+		renderer := NewRenderer(fs)
+
+		_, err := renderer.Render("default.html", "direct.md", nil)
+		require.ErrorIs(t, err, ErrTemplateNotFound)
+		require.ErrorContains(t, err, `"partials/missing"`)
+
+		_, err = renderer.Render("default.html", "nested.md", nil)
+		require.ErrorIs(t, err, ErrTemplateNotFound)
+		require.ErrorContains(t, err, `"partials/logo"`)
+	})
+
+	t.Run("invalid partial fails every render", func(t *testing.T) {
+		t.Parallel()
+
+		fs := fstest.MapFS{
+			"layouts/default.html": layout,
+			"partials/broken.md":   &fstest.MapFile{Data: []byte(`{{.Name`)},
+			"welcome.md":           &fstest.MapFile{Data: []byte(`Hello`)},
+		}
+
+		renderer := NewRenderer(fs)
+
+		_, err := renderer.Render("default.html", "welcome.md", nil)
+		require.ErrorIs(t, err, ErrRenderFailed)
+		require.ErrorContains(t, err, "partials/broken")
+	})
+}
+
 // countingFS wraps MapFS and counts ReadFile calls.
 type countingFS struct {
 	fstest.MapFS
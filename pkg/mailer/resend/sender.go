@@ -44,7 +44,7 @@ func (s *Sender) Send(ctx context.Context, email *mailer.Email) error {
 		ReplyTo: email.ReplyTo,
 		Cc:      email.CC,
 		Bcc:     email.BCC,
-		Headers: email.Headers,
+		Headers: email.AllHeaders(),
 	}
 
 	// Convert attachments
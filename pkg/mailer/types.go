@@ -1,6 +1,9 @@
 package mailer
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+)
 
 // Tags represents email tags/categories that can be either presence-only
 // (using struct{}{}) or key-value pairs (using string values).
@@ -30,17 +33,32 @@ func Recipient(name, email string) string {
 
 // Email represents a fully-prepared email message ready for sending.
 type Email struct {
-	Headers     map[string]string // Custom headers
-	Tags        Tags              // Provider-specific tags/categories
-	Subject     string            // Email subject
-	HTML        string            // HTML body content
-	Text        string            // Plain text alternative
-	From        string            // Override default sender (if provider allows)
-	ReplyTo     string            // Reply-to address
-	To          []string          // Recipients (at least one required)
-	CC          []string          // Carbon copy recipients
-	BCC         []string          // Blind carbon copy recipients
-	Attachments []Attachment      // File attachments
+	Headers        map[string]string // Custom headers
+	Tags           Tags              // Provider-specific tags/categories
+	Subject        string            // Email subject
+	UnsubscribeURL string            // One-click unsubscribe URL (absolute HTTPS)
+	HTML           string            // HTML body content
+	Text           string            // Plain text alternative
+	From           string            // Override default sender (if provider allows)
+	ReplyTo        string            // Reply-to address
+	To             []string          // Recipients (at least one required)
+	CC             []string          // Carbon copy recipients
+	BCC            []string          // Blind carbon copy recipients
+	Attachments    []Attachment      // File attachments
+}
+
+// AllHeaders returns the custom headers plus the list-management headers
+// derived from UnsubscribeURL: List-Unsubscribe and the RFC 8058 one-click
+// List-Unsubscribe-Post. Senders should use it instead of Headers.
+func (e *Email) AllHeaders() map[string]string {
+	if e.UnsubscribeURL == "" {
+		return e.Headers
+	}
+	h := make(map[string]string, len(e.Headers)+2)
+	maps.Copy(h, e.Headers)
+	h["List-Unsubscribe"] = "<" + e.UnsubscribeURL + ">"
+	h["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+	return h
 }
 
 // Attachment represents an email attachment.
@@ -149,3 +149,31 @@ func TestAttachment_Structure(t *testing.T) {
 	require.Equal(t, "cid:report", attachment.ContentID)
 	require.Equal(t, []byte("PDF content here"), attachment.Content)
 }
+
+func TestEmail_AllHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without unsubscribe URL returns custom headers", func(t *testing.T) {
+		t.Parallel()
+
+		email := &Email{Headers: map[string]string{"X-Campaign": "spring"}}
+
+		require.Equal(t, map[string]string{"X-Campaign": "spring"}, email.AllHeaders())
+	})
+
+	t.Run("adds one-click unsubscribe headers", func(t *testing.T) {
+		t.Parallel()
+
+		email := &Email{
+			Headers:        map[string]string{"X-Campaign": "spring"},
+			UnsubscribeURL: "https://example.com/unsubscribe?token=abc",
+		}
+
+		require.Equal(t, map[string]string{
+			"X-Campaign":            "spring",
+			"List-Unsubscribe":      "<https://example.com/unsubscribe?token=abc>",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}, email.AllHeaders())
+		require.Len(t, email.Headers, 1, "custom headers must not be modified")
+	})
+}
@@ -0,0 +1,146 @@
+package oauth_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/oauth"
+)
+
+var (
+	_ oauth.AvatarFetcher = (*oauth.GoogleProvider)(nil)
+	_ oauth.AvatarFetcher = (*oauth.GitHubProvider)(nil)
+	_ oauth.AvatarFetcher = (*oauth.LinkedInProvider)(nil)
+	_ oauth.AvatarFetcher = (*oauth.GitLabProvider)(nil)
+)
+
+func TestFetchAvatar(t *testing.T) {
+	t.Parallel()
+
+	png := []byte("\x89PNG\r\n\x1a\nimage-bytes")
+
+	newProvider := func(t *testing.T, client *http.Client) *oauth.GitHubProvider {
+		t.Helper()
+		p, err := oauth.NewGitHubProvider(oauth.GitHubConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+		}, oauth.WithHTTPClient(client))
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("downloads image", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Empty(t, r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(png)
+		}))
+		defer ts.Close()
+
+		body, contentType, err := newProvider(t, ts.Client()).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: ts.URL + "/a.png"})
+		require.NoError(t, err)
+		defer body.Close()
+
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, png, data)
+		require.Equal(t, "image/png", contentType)
+	})
+
+	t.Run("normalizes content type parameters", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "Image/JPEG; charset=binary")
+			_, _ = w.Write([]byte("jpeg"))
+		}))
+		defer ts.Close()
+
+		body, contentType, err := newProvider(t, ts.Client()).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: ts.URL})
+		require.NoError(t, err)
+		defer body.Close()
+		require.Equal(t, "image/jpeg", contentType)
+	})
+
+	t.Run("no avatar URL", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := newProvider(t, nil).FetchAvatar(context.Background(), &oauth.UserInfo{})
+		require.ErrorIs(t, err, oauth.ErrNoAvatar)
+	})
+
+	t.Run("rejects non-http URL", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := newProvider(t, nil).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: "file:///etc/passwd"})
+		require.ErrorIs(t, err, oauth.ErrFetchFailed)
+	})
+
+	t.Run("rejects unsupported content type", func(t *testing.T) {
+		t.Parallel()
+
+		for _, ct := range []string{"image/svg+xml", "text/html", ""} {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header()["Content-Type"] = []string{ct}
+				_, _ = w.Write([]byte("<svg/>"))
+			}))
+
+			_, _, err := newProvider(t, ts.Client()).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: ts.URL})
+			require.ErrorIs(t, err, oauth.ErrAvatarType, ct)
+			ts.Close()
+		}
+	})
+
+	t.Run("non-OK status", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		_, _, err := newProvider(t, ts.Client()).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: ts.URL})
+		require.ErrorIs(t, err, oauth.ErrRequestFailed)
+	})
+
+	t.Run("rejects declared size over cap", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("Content-Length", "6291456")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		_, _, err := newProvider(t, ts.Client()).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: ts.URL})
+		require.ErrorIs(t, err, oauth.ErrAvatarTooLarge)
+	})
+
+	t.Run("caps streamed body", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush() // chunked, no Content-Length
+			_, _ = w.Write(bytes.Repeat([]byte{0}, oauth.MaxAvatarSize+1))
+		}))
+		defer ts.Close()
+
+		body, _, err := newProvider(t, ts.Client()).FetchAvatar(context.Background(), &oauth.UserInfo{AvatarURL: ts.URL})
+		require.NoError(t, err)
+		defer body.Close()
+
+		_, err = io.ReadAll(body)
+		require.ErrorIs(t, err, oauth.ErrAvatarTooLarge)
+	})
+}
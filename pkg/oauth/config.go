@@ -15,3 +15,23 @@ type GitHubConfig struct {
 	RedirectURL  string   `env:"GITHUB_OAUTH_REDIRECT_URL" envDefault:""`
 	Scopes       []string `env:"GITHUB_OAUTH_SCOPES" envSeparator:","`
 }
+
+// LinkedInConfig holds LinkedIn OAuth configuration.
+type LinkedInConfig struct {
+	ClientID     string   `env:"LINKEDIN_OAUTH_CLIENT_ID,required"`
+	ClientSecret string   `env:"LINKEDIN_OAUTH_CLIENT_SECRET,required"`
+	RedirectURL  string   `env:"LINKEDIN_OAUTH_REDIRECT_URL" envDefault:""`
+	Scopes       []string `env:"LINKEDIN_OAUTH_SCOPES" envSeparator:","`
+}
+
+// GitLabConfig holds GitLab OAuth configuration.
+type GitLabConfig struct {
+	ClientID     string   `env:"GITLAB_OAUTH_CLIENT_ID,required"`
+	ClientSecret string   `env:"GITLAB_OAUTH_CLIENT_SECRET,required"`
+	RedirectURL  string   `env:"GITLAB_OAUTH_REDIRECT_URL" envDefault:""`
+	Scopes       []string `env:"GITLAB_OAUTH_SCOPES" envSeparator:","`
+
+	// BaseURL is the GitLab instance URL; set it for self-hosted GitLab.
+	// Default: https://gitlab.com.
+	BaseURL string `env:"GITLAB_OAUTH_BASE_URL" envDefault:"https://gitlab.com"`
+}
@@ -1,6 +1,7 @@
 // Package oauth provides OAuth2 authorization code flow implementations for common providers.
 //
-// This package includes a Provider interface and concrete implementations for Google and GitHub.
+// This package includes a Provider interface and concrete implementations for Google, GitHub,
+// LinkedIn, and GitLab.
 // Each provider handles the full OAuth2 flow: generating authorization URLs, exchanging codes
 // for tokens, and fetching verified user information.
 //
@@ -9,6 +10,10 @@
 //   - Provider interface for pluggable OAuth2 implementations
 //   - Google OAuth2 with email verification
 //   - GitHub OAuth2 with primary verified email resolution
+//   - LinkedIn and GitLab (including self-hosted) via OpenID Connect userinfo
+//   - Account linking across providers by verified email
+//   - Normalized avatar URL with size- and type-checked download
+//   - Token refresh with persistence and per-user coordination
 //   - Functional options for custom HTTP clients (testing, custom transports)
 //   - Configuration structs with env tags for environment-based setup
 //   - Sentinel errors with "oauth:" prefix for consistent error handling
@@ -52,6 +57,95 @@
 //		log.Fatal(err)
 //	}
 //
+// LinkedIn and GitLab providers read OpenID Connect userinfo, so the
+// openid scope is required; LinkedIn adds it to custom scopes that lack it.
+// Set BaseURL for a self-hosted GitLab instance:
+//
+//	linkedin, err := oauth.NewLinkedInProvider(oauth.LinkedInConfig{
+//		ClientID:     os.Getenv("LINKEDIN_OAUTH_CLIENT_ID"),
+//		ClientSecret: os.Getenv("LINKEDIN_OAUTH_CLIENT_SECRET"),
+//		RedirectURL:  "https://example.com/auth/linkedin/callback",
+//	})
+//
+//	gitlab, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+//		ClientID:     os.Getenv("GITLAB_OAUTH_CLIENT_ID"),
+//		ClientSecret: os.Getenv("GITLAB_OAUTH_CLIENT_SECRET"),
+//		RedirectURL:  "https://example.com/auth/gitlab/callback",
+//		BaseURL:      "https://gitlab.acme.internal",
+//	})
+//
+// Both return every userinfo claim in UserInfo.RawClaims, e.g. GitLab's
+// groups or LinkedIn's locale.
+//
+// # Account Linking
+//
+// A Linker attaches a new provider identity to the existing account with the
+// same verified email, instead of creating a duplicate user. The app supplies
+// an IdentityStore with FindByEmail and Link:
+//
+//	linker := oauth.NewLinker(store)
+//
+//	// In the callback, after checking the identity is not already known:
+//	res, err := linker.Resolve(ctx, provider.Name(), user)
+//	if err != nil {
+//		return err
+//	}
+//	if !res.Linked {
+//		userID, err = createUserWithIdentity(ctx, provider.Name(), user)
+//	}
+//
+// Resolve returns ErrEmailNotVerified unless UserInfo.EmailVerified is set, so
+// custom providers must set it only for emails the provider has verified.
+//
+// # Avatars
+//
+// UserInfo.AvatarURL holds the profile picture URL from every provider
+// (Google's picture, GitHub's avatar_url, the OpenID Connect picture claim
+// for LinkedIn and GitLab). Provider URLs can change or expire, so copy the
+// image to your own storage at signup instead of hotlinking it. All built-in
+// providers implement AvatarFetcher:
+//
+//	body, contentType, err := provider.FetchAvatar(ctx, user)
+//	if errors.Is(err, oauth.ErrNoAvatar) {
+//		// keep the default avatar
+//	}
+//	if err != nil {
+//		return err
+//	}
+//	defer body.Close()
+//	data, err := io.ReadAll(body)
+//	if err != nil {
+//		return err
+//	}
+//	_, err = store.Put(ctx, bytes.NewReader(data), int64(len(data)),
+//		storage.WithKey("avatars/"+userID),
+//		storage.WithContentType(contentType),
+//	)
+//
+// Only JPEG, PNG, GIF, and WebP images up to MaxAvatarSize are accepted;
+// anything else fails with ErrAvatarType or ErrAvatarTooLarge. The download
+// does not send the OAuth token.
+//
+// # Calling Provider APIs
+//
+// To call a provider's API on the user's behalf, persist the token from
+// Exchange and get it back through a TokenSource. It refreshes tokens that
+// are about to expire, saves the new token, and lets concurrent requests for
+// the same user share one refresh. The refresh outlives a cancelled request,
+// up to WithTokenRefreshTimeout, so a rotated token is never left unsaved:
+//
+//	tokens := oauth.NewTokenSource(google, store) // store implements TokenStore
+//
+//	token, err := tokens.Token(ctx, userID)
+//	if errors.Is(err, oauth.ErrTokenRevoked) {
+//		// the user revoked access; send them through the OAuth flow again
+//	}
+//	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
+//
+// Google only returns a refresh token when the consent URL asks for offline
+// access, e.g. AuthCodeURL(state, oauth2.AccessTypeOffline). MemoryTokenStore
+// is a TokenStore for tests and development.
+//
 // # Custom Providers
 //
 // Implement the Provider interface to add support for other OAuth2 providers:
@@ -85,6 +179,14 @@
 //   - ErrNilResponse: Provider returned nil HTTP response
 //   - ErrRequestFailed: Provider returned non-OK HTTP status
 //   - ErrDecodeFailed: Failed to decode provider JSON response
+//   - ErrLinkFailed: IdentityStore lookup or link failed
+//   - ErrNoAvatar: FetchAvatar called for a user without an avatar URL
+//   - ErrAvatarTooLarge: Avatar exceeds MaxAvatarSize
+//   - ErrAvatarType: Avatar is not a JPEG, PNG, GIF, or WebP image
+//   - ErrTokenNotFound: TokenStore has no token for the user
+//   - ErrNoRefreshToken: Expired token has no refresh token
+//   - ErrTokenRevoked: Provider rejected the refresh token
+//   - ErrRefreshFailed: Refreshing or saving a token failed
 //
 // Use errors.Is for checking:
 //
@@ -97,6 +199,6 @@
 //   - Always validate the state parameter to prevent CSRF attacks
 //   - Use HTTPS redirect URIs in production
 //   - Store tokens securely (encrypted at rest, never in URLs)
-//   - Both providers enforce email verification before returning user info
+//   - All built-in providers enforce email verification before returning user info
 //   - Keep client secrets out of source control (use environment variables)
 package oauth
@@ -22,6 +22,33 @@ var (
 	// ErrRequestFailed is returned when the OAuth provider returns a non-OK status.
 	ErrRequestFailed = errors.New("oauth: request returned non-OK status")
 
+	// ErrLinkFailed is returned when looking up or linking an account
+	// through the Linker's IdentityStore fails.
+	ErrLinkFailed = errors.New("oauth: account linking failed")
+
 	// ErrDecodeFailed is returned when decoding the OAuth provider response fails.
 	ErrDecodeFailed = errors.New("oauth: failed to decode response")
+
+	// ErrNoAvatar is returned by FetchAvatar when the user has no avatar URL.
+	ErrNoAvatar = errors.New("oauth: user has no avatar")
+
+	// ErrAvatarTooLarge is returned when an avatar exceeds MaxAvatarSize.
+	ErrAvatarTooLarge = errors.New("oauth: avatar too large")
+
+	// ErrAvatarType is returned when an avatar is not a JPEG, PNG, GIF, or WebP image.
+	ErrAvatarType = errors.New("oauth: unsupported avatar content type")
+
+	// ErrTokenNotFound is returned by a TokenStore when the user has no token.
+	ErrTokenNotFound = errors.New("oauth: token not found")
+
+	// ErrNoRefreshToken is returned when an expired token has no refresh token.
+	ErrNoRefreshToken = errors.New("oauth: no refresh token")
+
+	// ErrTokenRevoked is returned when the provider rejects the refresh token,
+	// usually because the user revoked access. The user must authorize again.
+	ErrTokenRevoked = errors.New("oauth: refresh token revoked")
+
+	// ErrRefreshFailed is returned when refreshing or saving a token fails
+	// for any other reason.
+	ErrRefreshFailed = errors.New("oauth: token refresh failed")
 )
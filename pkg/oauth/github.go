@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"golang.org/x/oauth2"
@@ -104,13 +105,27 @@ func (p *GitHubProvider) FetchUserInfo(ctx context.Context, token *oauth2.Token)
 	}
 
 	return &UserInfo{
-		ID:      fmt.Sprintf("%d", ghUser.ID),
-		Email:   email,
-		Name:    ghUser.Name,
-		Picture: ghUser.AvatarURL,
+		ID:            fmt.Sprintf("%d", ghUser.ID),
+		Email:         email,
+		Name:          ghUser.Name,
+		AvatarURL:     ghUser.AvatarURL,
+		Picture:       ghUser.AvatarURL,
+		EmailVerified: true,
 	}, nil
 }
 
+// FetchAvatar downloads the user's GitHub avatar so it can be copied to the
+// app's own storage. The image must be JPEG, PNG, GIF, or WebP and at most
+// MaxAvatarSize; the caller must close the returned body.
+func (p *GitHubProvider) FetchAvatar(ctx context.Context, info *UserInfo) (io.ReadCloser, string, error) {
+	return fetchAvatar(ctx, p.httpClient, info.AvatarURL)
+}
+
+// RefreshToken exchanges token's refresh token for a new access token.
+func (p *GitHubProvider) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	return refreshToken(p.contextWithHTTPClient(ctx), p.config, token)
+}
+
 func (p *GitHubProvider) contextWithHTTPClient(ctx context.Context) context.Context {
 	if p.httpClient != nil {
 		return context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
@@ -225,8 +225,10 @@ func TestGitHubProvider_FetchUserInfo(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "42", user.ID)
 		require.Equal(t, "primary@example.com", user.Email)
+		require.True(t, user.EmailVerified)
 		require.Equal(t, "Octocat", user.Name)
-		require.Equal(t, "https://example.com/octocat.png", user.Picture)
+		require.Equal(t, "https://example.com/octocat.png", user.AvatarURL)
+		require.Equal(t, user.AvatarURL, user.Picture)
 	})
 
 	t.Run("fallback verified email", func(t *testing.T) {
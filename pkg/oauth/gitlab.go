@@ -0,0 +1,133 @@
+package oauth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GitLabProviderName is the identifier for GitLab OAuth provider.
+	GitLabProviderName = "gitlab"
+
+	// GitLabDefaultBaseURL is the base URL of gitlab.com.
+	GitLabDefaultBaseURL = "https://gitlab.com"
+)
+
+// GitLabDefaultScopes returns the default scopes for GitLab OAuth.
+func GitLabDefaultScopes() []string {
+	return []string{"openid", "profile", "email"}
+}
+
+// GitLabProvider implements Provider for GitLab OAuth, on gitlab.com or a
+// self-hosted instance, using GitLab's OpenID Connect userinfo endpoint.
+type GitLabProvider struct {
+	config      *oauth2.Config
+	httpClient  *http.Client
+	userInfoURL string
+}
+
+// NewGitLabProvider creates a new GitLab OAuth provider for the instance at
+// cfg.BaseURL, or gitlab.com when it is empty.
+// Returns an error if ClientID or ClientSecret is empty.
+//
+// GitLab serves userinfo only to tokens with the openid scope, so it is
+// added to custom scopes that lack it.
+func NewGitLabProvider(cfg GitLabConfig, opts ...Option) (*GitLabProvider, error) {
+	if cfg.ClientID == "" {
+		return nil, ErrMissingClientID
+	}
+	if cfg.ClientSecret == "" {
+		return nil, ErrMissingClientSecret
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = GitLabDefaultScopes()
+	} else if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = GitLabDefaultBaseURL
+	}
+
+	return &GitLabProvider{
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  baseURL + "/oauth/authorize",
+				TokenURL: baseURL + "/oauth/token",
+			},
+		},
+		httpClient:  o.httpClient,
+		userInfoURL: baseURL + "/oauth/userinfo",
+	}, nil
+}
+
+// Name returns the provider identifier.
+func (p *GitLabProvider) Name() string {
+	return GitLabProviderName
+}
+
+// AuthCodeURL generates the authorization URL.
+func (p *GitLabProvider) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.config.AuthCodeURL(state, opts...)
+}
+
+// Exchange trades an authorization code for tokens.
+func (p *GitLabProvider) Exchange(ctx context.Context, code, redirectURI string) (*oauth2.Token, error) {
+	cfg := p.config
+	if redirectURI != "" {
+		cfg = &oauth2.Config{
+			ClientID:     p.config.ClientID,
+			ClientSecret: p.config.ClientSecret,
+			RedirectURL:  redirectURI,
+			Scopes:       p.config.Scopes,
+			Endpoint:     p.config.Endpoint,
+		}
+	}
+	ctx = p.contextWithHTTPClient(ctx)
+	return cfg.Exchange(ctx, code)
+}
+
+// FetchUserInfo retrieves user information from GitLab's OpenID Connect
+// userinfo endpoint, which requires the openid scope. Returns
+// ErrEmailNotVerified if the user's email is missing or not confirmed.
+func (p *GitLabProvider) FetchUserInfo(ctx context.Context, token *oauth2.Token) (*UserInfo, error) {
+	ctx = p.contextWithHTTPClient(ctx)
+	return fetchOIDCUserInfo(p.config.Client(ctx, token), p.userInfoURL)
+}
+
+// FetchAvatar downloads the user's GitLab avatar so it can be copied to the
+// app's own storage. The image must be JPEG, PNG, GIF, or WebP and at most
+// MaxAvatarSize; the caller must close the returned body.
+func (p *GitLabProvider) FetchAvatar(ctx context.Context, info *UserInfo) (io.ReadCloser, string, error) {
+	return fetchAvatar(ctx, p.httpClient, info.AvatarURL)
+}
+
+// RefreshToken exchanges token's refresh token for a new access token.
+// GitLab rotates refresh tokens, so persist the returned token.
+func (p *GitLabProvider) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	return refreshToken(p.contextWithHTTPClient(ctx), p.config, token)
+}
+
+func (p *GitLabProvider) contextWithHTTPClient(ctx context.Context) context.Context {
+	if p.httpClient != nil {
+		return context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	}
+	return ctx
+}
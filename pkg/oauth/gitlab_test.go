@@ -0,0 +1,229 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/dmitrymomot/forge/pkg/oauth"
+)
+
+var _ oauth.Provider = (*oauth.GitLabProvider)(nil)
+
+// newGitLabServer starts a self-hosted GitLab stand-in served by handler and
+// returns a provider pointed at it.
+func newGitLabServer(t *testing.T, handler http.Handler) *oauth.GitLabProvider {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	p, err := oauth.NewGitLabProvider(
+		oauth.GitLabConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+			BaseURL:      ts.URL + "/",
+		},
+		oauth.WithHTTPClient(ts.Client()),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewGitLabProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing client ID", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+			ClientSecret: "test-secret",
+		})
+		require.ErrorIs(t, err, oauth.ErrMissingClientID)
+		require.Nil(t, p)
+	})
+
+	t.Run("missing client secret", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+			ClientID: "test-id",
+		})
+		require.ErrorIs(t, err, oauth.ErrMissingClientSecret)
+		require.Nil(t, p)
+	})
+
+	t.Run("defaults to gitlab.com", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+		})
+		require.NoError(t, err)
+
+		u := p.AuthCodeURL("state")
+		require.True(t, strings.HasPrefix(u, "https://gitlab.com/oauth/authorize?"))
+		require.Contains(t, u, "scope=openid+profile+email")
+	})
+
+	t.Run("self-hosted base URL", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+			BaseURL:      "https://gitlab.acme.internal/",
+			Scopes:       []string{"openid", "email"},
+		})
+		require.NoError(t, err)
+
+		u := p.AuthCodeURL("state")
+		require.True(t, strings.HasPrefix(u, "https://gitlab.acme.internal/oauth/authorize?"))
+		require.Contains(t, u, "scope=openid+email")
+	})
+
+	t.Run("custom scopes get openid", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+			Scopes:       []string{"read_user"},
+		})
+		require.NoError(t, err)
+		require.Contains(t, p.AuthCodeURL("state"), "scope=openid+read_user")
+	})
+}
+
+func TestGitLabProvider_Name(t *testing.T) {
+	t.Parallel()
+	p, err := oauth.NewGitLabProvider(oauth.GitLabConfig{
+		ClientID:     "test-id",
+		ClientSecret: "test-secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "gitlab", p.Name())
+}
+
+func TestGitLabDefaultScopes(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, []string{"openid", "profile", "email"}, oauth.GitLabDefaultScopes())
+}
+
+func TestGitLabProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful exchange", func(t *testing.T) {
+		t.Parallel()
+
+		var redirectURI string
+		p := newGitLabServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/oauth/token", r.URL.Path)
+			redirectURI = r.FormValue("redirect_uri")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token":  "test-access-token",
+				"refresh_token": "test-refresh-token",
+				"token_type":    "Bearer",
+				"expires_in":    7200,
+			})
+		}))
+
+		token, err := p.Exchange(context.Background(), "test-code", "https://example.com/callback")
+		require.NoError(t, err)
+		require.Equal(t, "test-access-token", token.AccessToken)
+		require.Equal(t, "test-refresh-token", token.RefreshToken)
+		require.Equal(t, "https://example.com/callback", redirectURI)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		p := newGitLabServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		}))
+
+		_, err := p.Exchange(context.Background(), "bad-code", "")
+		require.Error(t, err)
+	})
+}
+
+func TestGitLabProvider_FetchUserInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		p := newGitLabServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/oauth/userinfo", r.URL.Path)
+			require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"sub":            "42",
+				"name":           "Test User",
+				"nickname":       "tuser",
+				"email":          "user@example.com",
+				"email_verified": true,
+				"picture":        "https://gitlab.acme.internal/uploads/avatar.png",
+				"groups":         []string{"acme", "acme/platform"},
+			})
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.NoError(t, err)
+		require.Equal(t, "42", user.ID)
+		require.Equal(t, "user@example.com", user.Email)
+		require.True(t, user.EmailVerified)
+		require.Equal(t, "Test User", user.Name)
+		require.Equal(t, "https://gitlab.acme.internal/uploads/avatar.png", user.AvatarURL)
+		require.Equal(t, "tuser", user.RawClaims["nickname"])
+		require.Equal(t, []any{"acme", "acme/platform"}, user.RawClaims["groups"])
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		t.Parallel()
+
+		p := newGitLabServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"sub":            "42",
+				"email":          "user@example.com",
+				"email_verified": false,
+			})
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrEmailNotVerified)
+		require.Nil(t, user)
+	})
+
+	t.Run("non-OK status", func(t *testing.T) {
+		t.Parallel()
+
+		p := newGitLabServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"insufficient_scope"}`))
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrRequestFailed)
+		require.Nil(t, user)
+	})
+
+	t.Run("bad JSON", func(t *testing.T) {
+		t.Parallel()
+
+		p := newGitLabServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("not-json"))
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrDecodeFailed)
+		require.Nil(t, user)
+	})
+}
@@ -120,13 +120,27 @@ func (p *GoogleProvider) FetchUserInfo(ctx context.Context, token *oauth2.Token)
 	}
 
 	return &UserInfo{
-		ID:      googleUser.ID,
-		Email:   googleUser.Email,
-		Name:    googleUser.Name,
-		Picture: googleUser.Picture,
+		ID:            googleUser.ID,
+		Email:         googleUser.Email,
+		Name:          googleUser.Name,
+		AvatarURL:     googleUser.Picture,
+		Picture:       googleUser.Picture,
+		EmailVerified: true,
 	}, nil
 }
 
+// FetchAvatar downloads the user's Google avatar so it can be copied to the
+// app's own storage. The image must be JPEG, PNG, GIF, or WebP and at most
+// MaxAvatarSize; the caller must close the returned body.
+func (p *GoogleProvider) FetchAvatar(ctx context.Context, info *UserInfo) (io.ReadCloser, string, error) {
+	return fetchAvatar(ctx, p.httpClient, info.AvatarURL)
+}
+
+// RefreshToken exchanges token's refresh token for a new access token.
+func (p *GoogleProvider) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	return refreshToken(p.contextWithHTTPClient(ctx), p.config, token)
+}
+
 func (p *GoogleProvider) contextWithHTTPClient(ctx context.Context) context.Context {
 	if p.httpClient != nil {
 		return context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
@@ -248,8 +248,10 @@ func TestGoogleProvider_FetchUserInfo(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "12345", user.ID)
 		require.Equal(t, "user@example.com", user.Email)
+		require.True(t, user.EmailVerified)
 		require.Equal(t, "Test User", user.Name)
-		require.Equal(t, "https://example.com/photo.jpg", user.Picture)
+		require.Equal(t, "https://example.com/photo.jpg", user.AvatarURL)
+		require.Equal(t, user.AvatarURL, user.Picture)
 	})
 
 	t.Run("unverified email", func(t *testing.T) {
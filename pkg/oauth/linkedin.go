@@ -0,0 +1,124 @@
+package oauth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+
+	"golang.org/x/oauth2"
+	linkedinOAuth "golang.org/x/oauth2/linkedin"
+)
+
+const (
+	// LinkedInProviderName is the identifier for LinkedIn OAuth provider.
+	LinkedInProviderName = "linkedin"
+	linkedinUserInfoURL  = "https://api.linkedin.com/v2/userinfo"
+)
+
+// LinkedInDefaultScopes returns the default scopes for LinkedIn OAuth, used
+// by "Sign In with LinkedIn using OpenID Connect".
+func LinkedInDefaultScopes() []string {
+	return []string{"openid", "profile", "email"}
+}
+
+// LinkedInProvider implements Provider for LinkedIn OAuth using LinkedIn's
+// OpenID Connect userinfo endpoint.
+type LinkedInProvider struct {
+	config     *oauth2.Config
+	httpClient *http.Client
+}
+
+// NewLinkedInProvider creates a new LinkedIn OAuth provider.
+// Returns an error if ClientID or ClientSecret is empty.
+//
+// LinkedIn serves userinfo only to tokens with the openid scope, so it is
+// added to custom scopes that lack it. The legacy r_liteprofile and
+// r_emailaddress scopes are not supported by this provider.
+func NewLinkedInProvider(cfg LinkedInConfig, opts ...Option) (*LinkedInProvider, error) {
+	if cfg.ClientID == "" {
+		return nil, ErrMissingClientID
+	}
+	if cfg.ClientSecret == "" {
+		return nil, ErrMissingClientSecret
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = LinkedInDefaultScopes()
+	} else if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	return &LinkedInProvider{
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+			Endpoint:     linkedinOAuth.Endpoint,
+		},
+		httpClient: o.httpClient,
+	}, nil
+}
+
+// Name returns the provider identifier.
+func (p *LinkedInProvider) Name() string {
+	return LinkedInProviderName
+}
+
+// AuthCodeURL generates the authorization URL.
+func (p *LinkedInProvider) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.config.AuthCodeURL(state, opts...)
+}
+
+// Exchange trades an authorization code for tokens.
+func (p *LinkedInProvider) Exchange(ctx context.Context, code, redirectURI string) (*oauth2.Token, error) {
+	cfg := p.config
+	if redirectURI != "" {
+		cfg = &oauth2.Config{
+			ClientID:     p.config.ClientID,
+			ClientSecret: p.config.ClientSecret,
+			RedirectURL:  redirectURI,
+			Scopes:       p.config.Scopes,
+			Endpoint:     p.config.Endpoint,
+		}
+	}
+	ctx = p.contextWithHTTPClient(ctx)
+	return cfg.Exchange(ctx, code)
+}
+
+// FetchUserInfo retrieves user information from LinkedIn's OpenID Connect
+// userinfo endpoint. Returns ErrEmailNotVerified if the email is missing
+// (the email scope was not granted) or not verified.
+func (p *LinkedInProvider) FetchUserInfo(ctx context.Context, token *oauth2.Token) (*UserInfo, error) {
+	ctx = p.contextWithHTTPClient(ctx)
+	return fetchOIDCUserInfo(p.config.Client(ctx, token), linkedinUserInfoURL)
+}
+
+// FetchAvatar downloads the user's LinkedIn profile picture so it can be
+// copied to the app's own storage. LinkedIn picture URLs expire, so copy it
+// soon after sign-in. The image must be JPEG, PNG, GIF, or WebP and at most
+// MaxAvatarSize; the caller must close the returned body.
+func (p *LinkedInProvider) FetchAvatar(ctx context.Context, info *UserInfo) (io.ReadCloser, string, error) {
+	return fetchAvatar(ctx, p.httpClient, info.AvatarURL)
+}
+
+// RefreshToken exchanges token's refresh token for a new access token.
+// LinkedIn issues refresh tokens only to approved apps; for others it
+// returns ErrNoRefreshToken once the token expires.
+func (p *LinkedInProvider) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	return refreshToken(p.contextWithHTTPClient(ctx), p.config, token)
+}
+
+func (p *LinkedInProvider) contextWithHTTPClient(ctx context.Context) context.Context {
+	if p.httpClient != nil {
+		return context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	}
+	return ctx
+}
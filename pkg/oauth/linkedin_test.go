@@ -0,0 +1,240 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/dmitrymomot/forge/pkg/oauth"
+)
+
+var _ oauth.Provider = (*oauth.LinkedInProvider)(nil)
+
+// newLinkedInProvider returns a LinkedIn provider whose requests are served by handler.
+func newLinkedInProvider(t *testing.T, handler http.Handler) *oauth.LinkedInProvider {
+	t.Helper()
+
+	transport := &linkedinRewriteTransport{base: http.DefaultTransport, handler: handler}
+	p, err := oauth.NewLinkedInProvider(
+		oauth.LinkedInConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+		},
+		oauth.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewLinkedInProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing client ID", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewLinkedInProvider(oauth.LinkedInConfig{
+			ClientSecret: "test-secret",
+		})
+		require.ErrorIs(t, err, oauth.ErrMissingClientID)
+		require.Nil(t, p)
+	})
+
+	t.Run("missing client secret", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewLinkedInProvider(oauth.LinkedInConfig{
+			ClientID: "test-id",
+		})
+		require.ErrorIs(t, err, oauth.ErrMissingClientSecret)
+		require.Nil(t, p)
+	})
+
+	t.Run("default scopes applied", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewLinkedInProvider(oauth.LinkedInConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+		})
+		require.NoError(t, err)
+
+		u := p.AuthCodeURL("state")
+		require.True(t, strings.HasPrefix(u, "https://www.linkedin.com/oauth/v2/authorization?"))
+		require.Contains(t, u, "scope=openid+profile+email")
+	})
+
+	t.Run("custom scopes get openid", func(t *testing.T) {
+		t.Parallel()
+		p, err := oauth.NewLinkedInProvider(oauth.LinkedInConfig{
+			ClientID:     "test-id",
+			ClientSecret: "test-secret",
+			Scopes:       []string{"email"},
+		})
+		require.NoError(t, err)
+		require.Contains(t, p.AuthCodeURL("state"), "scope=openid+email")
+	})
+}
+
+func TestLinkedInProvider_Name(t *testing.T) {
+	t.Parallel()
+	p, err := oauth.NewLinkedInProvider(oauth.LinkedInConfig{
+		ClientID:     "test-id",
+		ClientSecret: "test-secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "linkedin", p.Name())
+}
+
+func TestLinkedInDefaultScopes(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, []string{"openid", "profile", "email"}, oauth.LinkedInDefaultScopes())
+}
+
+func TestLinkedInProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends client credentials in body", func(t *testing.T) {
+		t.Parallel()
+
+		var clientID, clientSecret string
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/oauth/v2/accessToken", r.URL.Path)
+			clientID = r.FormValue("client_id")
+			clientSecret = r.FormValue("client_secret")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token": "test-access-token",
+				"expires_in":   5184000,
+			})
+		}))
+
+		token, err := p.Exchange(context.Background(), "test-code", "")
+		require.NoError(t, err)
+		require.Equal(t, "test-access-token", token.AccessToken)
+		require.Equal(t, "test-id", clientID)
+		require.Equal(t, "test-secret", clientSecret)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_request"})
+		}))
+
+		_, err := p.Exchange(context.Background(), "bad-code", "")
+		require.Error(t, err)
+	})
+}
+
+func TestLinkedInProvider_FetchUserInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v2/userinfo", r.URL.Path)
+			require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"sub":            "782bbtaQ",
+				"name":           "Test User",
+				"given_name":     "Test",
+				"family_name":    "User",
+				"picture":        "https://media.licdn.com/photo.jpg",
+				"locale":         map[string]string{"country": "US", "language": "en"},
+				"email":          "user@example.com",
+				"email_verified": true,
+			})
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.NoError(t, err)
+		require.Equal(t, "782bbtaQ", user.ID)
+		require.Equal(t, "user@example.com", user.Email)
+		require.True(t, user.EmailVerified)
+		require.Equal(t, "Test User", user.Name)
+		require.Equal(t, "https://media.licdn.com/photo.jpg", user.AvatarURL)
+		require.Equal(t, user.AvatarURL, user.Picture)
+		require.Equal(t, "Test", user.RawClaims["given_name"])
+		require.Equal(t, map[string]any{"country": "US", "language": "en"}, user.RawClaims["locale"])
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		t.Parallel()
+
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"sub":            "782bbtaQ",
+				"email":          "user@example.com",
+				"email_verified": false,
+			})
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrEmailNotVerified)
+		require.Nil(t, user)
+	})
+
+	t.Run("email scope not granted", func(t *testing.T) {
+		t.Parallel()
+
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"sub": "782bbtaQ", "name": "Test User"})
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrEmailNotVerified)
+		require.Nil(t, user)
+	})
+
+	t.Run("non-OK status", func(t *testing.T) {
+		t.Parallel()
+
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"serviceErrorCode":65600,"message":"Invalid access token"}`))
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrRequestFailed)
+		require.Nil(t, user)
+	})
+
+	t.Run("bad JSON", func(t *testing.T) {
+		t.Parallel()
+
+		p := newLinkedInProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("not-json"))
+		}))
+
+		user, err := p.FetchUserInfo(context.Background(), &oauth2.Token{AccessToken: "test-token"})
+		require.ErrorIs(t, err, oauth.ErrDecodeFailed)
+		require.Nil(t, user)
+	})
+}
+
+// linkedinRewriteTransport intercepts requests to LinkedIn endpoints and routes
+// them to a local handler instead.
+type linkedinRewriteTransport struct {
+	base    http.RoundTripper
+	handler http.Handler
+}
+
+func (t *linkedinRewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Host, "linkedin.com") {
+		recorder := httptest.NewRecorder()
+		t.handler.ServeHTTP(recorder, req)
+		return recorder.Result(), nil
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// IdentityStore is implemented by the application to let a Linker find
+// existing users and attach provider identities to them.
+type IdentityStore interface {
+	// FindByEmail returns the ID of the user with the given email, or an
+	// empty string and nil error if there is none. Comparison should be
+	// case-insensitive.
+	FindByEmail(ctx context.Context, email string) (string, error)
+
+	// Link attaches the provider identity to an existing user.
+	// It should be idempotent: linking an identity twice is not an error.
+	Link(ctx context.Context, userID, provider, providerUserID string) error
+}
+
+// LinkResult is the outcome of Linker.Resolve.
+type LinkResult struct {
+	UserID string // Existing user the identity was linked to; empty when Linked is false
+	Linked bool   // False means no user has this email and the app should create one
+}
+
+// Linker links a provider identity to an existing account with the same
+// verified email, so a user who signed up with one provider can sign in with
+// another without creating a duplicate account.
+//
+// Linking trusts the provider's claim that the user owns the email. Resolve
+// refuses unverified emails, since otherwise anyone able to register an
+// unverified address with some provider could take over the account.
+type Linker struct {
+	store IdentityStore
+}
+
+// NewLinker creates a Linker backed by store.
+func NewLinker(store IdentityStore) *Linker {
+	return &Linker{store: store}
+}
+
+// Resolve links info to the existing user with the same email, if any.
+// Call it in the OAuth callback after the app has checked that the
+// provider identity is not already known. When the result is not Linked,
+// the app should create a new user and attach the identity to it.
+// Returns ErrEmailNotVerified if info has no verified email, and
+// ErrLinkFailed if the store fails.
+func (l *Linker) Resolve(ctx context.Context, provider string, info *UserInfo) (LinkResult, error) {
+	if info == nil || !info.EmailVerified {
+		return LinkResult{}, ErrEmailNotVerified
+	}
+	email := strings.TrimSpace(info.Email)
+	if email == "" {
+		return LinkResult{}, ErrEmailNotVerified
+	}
+
+	userID, err := l.store.FindByEmail(ctx, email)
+	if err != nil {
+		return LinkResult{}, errors.Join(ErrLinkFailed, fmt.Errorf("find user by email: %w", err))
+	}
+	if userID == "" {
+		return LinkResult{}, nil
+	}
+
+	if err := l.store.Link(ctx, userID, provider, info.ID); err != nil {
+		return LinkResult{}, errors.Join(ErrLinkFailed, fmt.Errorf("link %s identity: %w", provider, err))
+	}
+	return LinkResult{UserID: userID, Linked: true}, nil
+}
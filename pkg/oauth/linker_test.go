@@ -0,0 +1,90 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/oauth"
+)
+
+type fakeIdentityStore struct {
+	users   map[string]string // email -> user ID
+	findErr error
+	linkErr error
+	links   []string
+}
+
+func (s *fakeIdentityStore) FindByEmail(_ context.Context, email string) (string, error) {
+	return s.users[email], s.findErr
+}
+
+func (s *fakeIdentityStore) Link(_ context.Context, userID, provider, providerUserID string) error {
+	if s.linkErr != nil {
+		return s.linkErr
+	}
+	s.links = append(s.links, userID+":"+provider+":"+providerUserID)
+	return nil
+}
+
+func TestLinker(t *testing.T) {
+	t.Parallel()
+
+	verified := &oauth.UserInfo{ID: "gh-42", Email: "jane@example.com", EmailVerified: true}
+
+	t.Run("links existing user with same verified email", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeIdentityStore{users: map[string]string{"jane@example.com": "user-1"}}
+		res, err := oauth.NewLinker(store).Resolve(context.Background(), oauth.GitHubProviderName, verified)
+		require.NoError(t, err)
+		require.True(t, res.Linked)
+		require.Equal(t, "user-1", res.UserID)
+		require.Equal(t, []string{"user-1:github:gh-42"}, store.links)
+	})
+
+	t.Run("unknown email asks caller to create", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeIdentityStore{}
+		res, err := oauth.NewLinker(store).Resolve(context.Background(), oauth.GitHubProviderName, verified)
+		require.NoError(t, err)
+		require.False(t, res.Linked)
+		require.Empty(t, res.UserID)
+		require.Empty(t, store.links)
+	})
+
+	t.Run("unverified email is never linked", func(t *testing.T) {
+		t.Parallel()
+
+		store := &fakeIdentityStore{users: map[string]string{"jane@example.com": "user-1"}}
+		linker := oauth.NewLinker(store)
+
+		for _, info := range []*oauth.UserInfo{
+			nil,
+			{ID: "gh-42", Email: "jane@example.com"},
+			{ID: "gh-42", Email: " ", EmailVerified: true},
+		} {
+			_, err := linker.Resolve(context.Background(), oauth.GitHubProviderName, info)
+			require.ErrorIs(t, err, oauth.ErrEmailNotVerified)
+		}
+		require.Empty(t, store.links)
+	})
+
+	t.Run("store errors are wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		dbErr := errors.New("connection reset")
+		_, err := oauth.NewLinker(&fakeIdentityStore{findErr: dbErr}).
+			Resolve(context.Background(), oauth.GoogleProviderName, verified)
+		require.ErrorIs(t, err, oauth.ErrLinkFailed)
+		require.ErrorIs(t, err, dbErr)
+
+		store := &fakeIdentityStore{users: map[string]string{"jane@example.com": "user-1"}, linkErr: dbErr}
+		_, err = oauth.NewLinker(store).Resolve(context.Background(), oauth.GoogleProviderName, verified)
+		require.ErrorIs(t, err, oauth.ErrLinkFailed)
+		require.ErrorIs(t, err, dbErr)
+	})
+}
@@ -0,0 +1,64 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// oidcClaims holds the standard OpenID Connect userinfo claims the
+// normalized UserInfo is built from.
+type oidcClaims struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// fetchOIDCUserInfo reads an OpenID Connect userinfo endpoint and returns the
+// normalized UserInfo with every claim in RawClaims.
+// Returns ErrEmailNotVerified unless the email is present and verified.
+func fetchOIDCUserInfo(client *http.Client, userInfoURL string) (*UserInfo, error) {
+	resp, err := client.Get(userInfoURL)
+	if err != nil {
+		return nil, errors.Join(ErrFetchFailed, fmt.Errorf("fetch userinfo: %w", err))
+	}
+	if resp == nil {
+		return nil, errors.Join(ErrNilResponse, errors.New("unexpected nil response from userinfo endpoint"))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Join(ErrFetchFailed, fmt.Errorf("read userinfo: %w", err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Join(ErrRequestFailed, fmt.Errorf("userinfo request failed: status=%d body=%s", resp.StatusCode, body))
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, errors.Join(ErrDecodeFailed, fmt.Errorf("decode userinfo: %w", err))
+	}
+	var claims oidcClaims
+	if err := json.Unmarshal(body, &claims); err != nil {
+		return nil, errors.Join(ErrDecodeFailed, fmt.Errorf("decode userinfo: %w", err))
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	return &UserInfo{
+		ID:            claims.Sub,
+		Email:         claims.Email,
+		Name:          claims.Name,
+		AvatarURL:     claims.Picture,
+		Picture:       claims.Picture,
+		EmailVerified: true,
+		RawClaims:     raw,
+	}, nil
+}
@@ -9,9 +9,18 @@ import (
 // UserInfo represents provider-agnostic user information
 // retrieved from an OAuth provider's userinfo endpoint.
 type UserInfo struct {
-	ID      string // Provider's unique user identifier
-	Email   string
-	Name    string
+	ID            string // Provider's unique user identifier
+	Email         string
+	Name          string
+	AvatarURL     string // Profile picture URL; may change or expire, see FetchAvatar
+	EmailVerified bool   // Provider confirmed the user owns Email; required by Linker
+
+	// RawClaims holds every claim of the provider's OpenID Connect userinfo
+	// response, for provider-specific fields such as locale or groups.
+	// Set by LinkedIn and GitLab; nil for other providers.
+	RawClaims map[string]any
+
+	// Deprecated: Use AvatarURL. Picture holds the same value.
 	Picture string
 }
 
@@ -31,6 +40,6 @@ type Provider interface {
 
 	// FetchUserInfo retrieves user information using the access token.
 	// Implementations must verify the user's email and return ErrEmailNotVerified
-	// if the email is not verified.
+	// if the email is not verified, and set EmailVerified on success.
 	FetchUserInfo(ctx context.Context, token *oauth2.Token) (*UserInfo, error)
 }
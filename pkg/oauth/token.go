@@ -0,0 +1,214 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultTokenExpiryDelta is how long before its expiry TokenSource treats
+// an access token as expired, so it does not run out mid-request.
+const DefaultTokenExpiryDelta = time.Minute
+
+// DefaultTokenRefreshTimeout bounds a refresh and the save that follows it.
+const DefaultTokenRefreshTimeout = 30 * time.Second
+
+// TokenRefresher is implemented by providers that can exchange a refresh
+// token for a new access token. Both built-in providers implement it.
+type TokenRefresher interface {
+	// RefreshToken returns a new token for token.RefreshToken. The result
+	// keeps the old refresh token when the provider does not rotate it.
+	// Returns ErrNoRefreshToken if token has none and ErrTokenRevoked if the
+	// provider rejects it.
+	RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error)
+}
+
+// TokenStore is implemented by the application to persist each user's
+// provider token, typically encrypted in the database.
+type TokenStore interface {
+	// LoadToken returns the user's token, or ErrTokenNotFound if there is none.
+	LoadToken(ctx context.Context, userID string) (*oauth2.Token, error)
+
+	// SaveToken stores the user's token, replacing the previous one.
+	SaveToken(ctx context.Context, userID string, token *oauth2.Token) error
+}
+
+// TokenSourceOption configures a TokenSource.
+type TokenSourceOption func(*TokenSource)
+
+// WithTokenExpiryDelta sets how long before expiry a token is refreshed
+// (default: DefaultTokenExpiryDelta).
+func WithTokenExpiryDelta(d time.Duration) TokenSourceOption {
+	return func(s *TokenSource) {
+		s.expiryDelta = d
+	}
+}
+
+// WithTokenRefreshTimeout sets how long a refresh, including saving the new
+// token, may take (default: DefaultTokenRefreshTimeout).
+func WithTokenRefreshTimeout(d time.Duration) TokenSourceOption {
+	return func(s *TokenSource) {
+		s.refreshTimeout = d
+	}
+}
+
+// TokenSource hands out valid access tokens for calling a provider's API on
+// behalf of a user. It is the per-user, persistent counterpart of
+// oauth2.TokenSource: tokens are loaded from a TokenStore, refreshed when
+// they are about to expire, and the refreshed token is saved back.
+//
+// Concurrent calls for the same user share a single refresh, so a burst of
+// requests does not spend a rotating refresh token twice. The coordination is
+// per process; across instances the store should tolerate the occasional
+// duplicate refresh.
+type TokenSource struct {
+	refresher      TokenRefresher
+	store          TokenStore
+	expiryDelta    time.Duration
+	refreshTimeout time.Duration
+	group          singleflight.Group
+}
+
+// NewTokenSource creates a TokenSource that refreshes tokens with refresher
+// and persists them in store.
+func NewTokenSource(refresher TokenRefresher, store TokenStore, opts ...TokenSourceOption) *TokenSource {
+	s := &TokenSource{
+		refresher:      refresher,
+		store:          store,
+		expiryDelta:    DefaultTokenExpiryDelta,
+		refreshTimeout: DefaultTokenRefreshTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Token returns a valid token for userID, refreshing and saving it first if
+// it has expired. Returns ErrTokenNotFound if the user has no token,
+// ErrTokenRevoked if the refresh token was revoked and the user must sign in
+// with the provider again, and ErrRefreshFailed for other refresh failures.
+//
+// The shared refresh runs with ctx's values but not its cancellation, bounded
+// by the refresh timeout, so a caller that gives up does not fail the refresh
+// for the others waiting on it or leave a rotated token unsaved. A cancelled
+// caller returns ctx.Err() without waiting.
+func (s *TokenSource) Token(ctx context.Context, userID string) (*oauth2.Token, error) {
+	token, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.expired(token) {
+		return token, nil
+	}
+
+	ch := s.group.DoChan(userID, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.refreshTimeout)
+		defer cancel()
+
+		// Another call may have refreshed the token while this one waited.
+		token, err := s.load(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !s.expired(token) {
+			return token, nil
+		}
+
+		fresh, err := s.refresher.RefreshToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.store.SaveToken(ctx, userID, fresh); err != nil {
+			return nil, errors.Join(ErrRefreshFailed, fmt.Errorf("save token: %w", err))
+		}
+		return fresh, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*oauth2.Token), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *TokenSource) load(ctx context.Context, userID string) (*oauth2.Token, error) {
+	token, err := s.store.LoadToken(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("oauth: load token: %w", err)
+	}
+	if token == nil {
+		return nil, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// expired reports whether token expires within the expiry delta. Tokens
+// without an expiry never expire.
+func (s *TokenSource) expired(token *oauth2.Token) bool {
+	if token.Expiry.IsZero() {
+		return false
+	}
+	return !time.Now().Add(s.expiryDelta).Before(token.Expiry)
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory. Use it in
+// tests and development; tokens are lost on restart.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]oauth2.Token
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]oauth2.Token)}
+}
+
+// LoadToken returns a copy of the user's token.
+func (m *MemoryTokenStore) LoadToken(_ context.Context, userID string) (*oauth2.Token, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	token, ok := m.tokens[userID]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &token, nil
+}
+
+// SaveToken stores a copy of token.
+func (m *MemoryTokenStore) SaveToken(_ context.Context, userID string, token *oauth2.Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[userID] = *token
+	return nil
+}
+
+// refreshToken exchanges token's refresh token at cfg's token endpoint.
+func refreshToken(ctx context.Context, cfg *oauth2.Config, token *oauth2.Token) (*oauth2.Token, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	// Without an access token the oauth2 source always hits the endpoint. It
+	// carries the old refresh token over when the provider does not rotate it.
+	fresh, err := cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+	if err != nil {
+		var re *oauth2.RetrieveError
+		if errors.As(err, &re) && (re.ErrorCode == "invalid_grant" || re.ErrorCode == "bad_refresh_token") {
+			return nil, errors.Join(ErrTokenRevoked, err)
+		}
+		return nil, errors.Join(ErrRefreshFailed, err)
+	}
+	return fresh, nil
+}
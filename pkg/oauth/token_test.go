@@ -0,0 +1,295 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/dmitrymomot/forge/pkg/oauth"
+)
+
+var (
+	_ oauth.TokenRefresher = (*oauth.GoogleProvider)(nil)
+	_ oauth.TokenRefresher = (*oauth.GitHubProvider)(nil)
+	_ oauth.TokenRefresher = (*oauth.LinkedInProvider)(nil)
+	_ oauth.TokenRefresher = (*oauth.GitLabProvider)(nil)
+	_ oauth.TokenStore     = (*oauth.MemoryTokenStore)(nil)
+)
+
+// fakeRefresher hands out numbered access tokens and counts refreshes.
+type fakeRefresher struct {
+	calls atomic.Int32
+	delay time.Duration
+	err   error
+}
+
+func (f *fakeRefresher) RefreshToken(_ context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	n := f.calls.Add(1)
+	time.Sleep(f.delay)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &oauth2.Token{
+		AccessToken:  "access-" + strconv.Itoa(int(n)),
+		RefreshToken: token.RefreshToken,
+		Expiry:       time.Now().Add(time.Hour),
+	}, nil
+}
+
+// ctxRefresher blocks until the refresh context is done.
+type ctxRefresher struct{}
+
+func (ctxRefresher) RefreshToken(ctx context.Context, _ *oauth2.Token) (*oauth2.Token, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type failingStore struct {
+	*oauth.MemoryTokenStore
+}
+
+func (failingStore) SaveToken(context.Context, string, *oauth2.Token) error {
+	return errors.New("db down")
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Parallel()
+
+	expired := func() *oauth2.Token {
+		return &oauth2.Token{AccessToken: "old", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)}
+	}
+
+	t.Run("returns valid token without refreshing", func(t *testing.T) {
+		t.Parallel()
+
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(context.Background(), "u1", &oauth2.Token{AccessToken: "valid", Expiry: time.Now().Add(time.Hour)}))
+		refresher := &fakeRefresher{}
+
+		token, err := oauth.NewTokenSource(refresher, store).Token(context.Background(), "u1")
+		require.NoError(t, err)
+		require.Equal(t, "valid", token.AccessToken)
+		require.Zero(t, refresher.calls.Load())
+	})
+
+	t.Run("token without expiry never refreshes", func(t *testing.T) {
+		t.Parallel()
+
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(context.Background(), "u1", &oauth2.Token{AccessToken: "forever"}))
+		refresher := &fakeRefresher{}
+
+		token, err := oauth.NewTokenSource(refresher, store).Token(context.Background(), "u1")
+		require.NoError(t, err)
+		require.Equal(t, "forever", token.AccessToken)
+		require.Zero(t, refresher.calls.Load())
+	})
+
+	t.Run("refreshes expired token and saves it", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(ctx, "u1", expired()))
+		refresher := &fakeRefresher{}
+
+		token, err := oauth.NewTokenSource(refresher, store).Token(ctx, "u1")
+		require.NoError(t, err)
+		require.Equal(t, "access-1", token.AccessToken)
+
+		saved, err := store.LoadToken(ctx, "u1")
+		require.NoError(t, err)
+		require.Equal(t, "access-1", saved.AccessToken)
+		require.Equal(t, "refresh", saved.RefreshToken)
+	})
+
+	t.Run("refreshes within expiry delta", func(t *testing.T) {
+		t.Parallel()
+
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(context.Background(), "u1",
+			&oauth2.Token{AccessToken: "old", RefreshToken: "refresh", Expiry: time.Now().Add(5 * time.Minute)}))
+		refresher := &fakeRefresher{}
+
+		ts := oauth.NewTokenSource(refresher, store, oauth.WithTokenExpiryDelta(10*time.Minute))
+		token, err := ts.Token(context.Background(), "u1")
+		require.NoError(t, err)
+		require.Equal(t, "access-1", token.AccessToken)
+	})
+
+	t.Run("concurrent calls share one refresh", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(ctx, "u1", expired()))
+		refresher := &fakeRefresher{delay: 50 * time.Millisecond}
+		ts := oauth.NewTokenSource(refresher, store)
+
+		var wg sync.WaitGroup
+		tokens := make([]*oauth2.Token, 10)
+		for i := range tokens {
+			wg.Go(func() {
+				token, err := ts.Token(ctx, "u1")
+				require.NoError(t, err)
+				tokens[i] = token
+			})
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(1), refresher.calls.Load())
+		for _, token := range tokens {
+			require.Equal(t, "access-1", token.AccessToken)
+		}
+	})
+
+	t.Run("cancelled caller does not cancel the shared refresh", func(t *testing.T) {
+		t.Parallel()
+
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(context.Background(), "u1", expired()))
+		refresher := &fakeRefresher{delay: 50 * time.Millisecond}
+		ts := oauth.NewTokenSource(refresher, store)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errc := make(chan error, 1)
+		go func() {
+			_, err := ts.Token(ctx, "u1")
+			errc <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-errc, context.Canceled)
+
+		token, err := ts.Token(context.Background(), "u1")
+		require.NoError(t, err)
+		require.Equal(t, "access-1", token.AccessToken)
+		require.Equal(t, int32(1), refresher.calls.Load())
+
+		saved, err := store.LoadToken(context.Background(), "u1")
+		require.NoError(t, err)
+		require.Equal(t, "access-1", saved.AccessToken)
+	})
+
+	t.Run("refresh is bounded by the refresh timeout", func(t *testing.T) {
+		t.Parallel()
+
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(context.Background(), "u1", expired()))
+		ts := oauth.NewTokenSource(ctxRefresher{}, store, oauth.WithTokenRefreshTimeout(10*time.Millisecond))
+
+		_, err := ts.Token(context.Background(), "u1")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := oauth.NewTokenSource(&fakeRefresher{}, oauth.NewMemoryTokenStore()).Token(context.Background(), "nobody")
+		require.ErrorIs(t, err, oauth.ErrTokenNotFound)
+	})
+
+	t.Run("revoked refresh token is returned as is", func(t *testing.T) {
+		t.Parallel()
+
+		store := oauth.NewMemoryTokenStore()
+		require.NoError(t, store.SaveToken(context.Background(), "u1", expired()))
+		refresher := &fakeRefresher{err: oauth.ErrTokenRevoked}
+
+		_, err := oauth.NewTokenSource(refresher, store).Token(context.Background(), "u1")
+		require.ErrorIs(t, err, oauth.ErrTokenRevoked)
+	})
+
+	t.Run("save failure", func(t *testing.T) {
+		t.Parallel()
+
+		store := failingStore{oauth.NewMemoryTokenStore()}
+		require.NoError(t, store.MemoryTokenStore.SaveToken(context.Background(), "u1", expired()))
+
+		_, err := oauth.NewTokenSource(&fakeRefresher{}, store).Token(context.Background(), "u1")
+		require.ErrorIs(t, err, oauth.ErrRefreshFailed)
+	})
+}
+
+func TestProviderRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	newProvider := func(t *testing.T, handler http.HandlerFunc) *oauth.GitHubProvider {
+		t.Helper()
+		mux := http.NewServeMux()
+		mux.HandleFunc("/login/oauth/access_token", handler)
+		p, err := oauth.NewGitHubProvider(
+			oauth.GitHubConfig{ClientID: "test-id", ClientSecret: "test-secret"},
+			oauth.WithHTTPClient(&http.Client{Transport: &githubRewriteTransport{base: http.DefaultTransport, handler: mux}}),
+		)
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("keeps refresh token when not rotated", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+			require.Equal(t, "refresh-1", r.PostForm.Get("refresh_token"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token": "new-access",
+				"token_type":   "bearer",
+				"expires_in":   3600,
+			})
+		})
+
+		token, err := p.RefreshToken(context.Background(), &oauth2.Token{AccessToken: "old", RefreshToken: "refresh-1"})
+		require.NoError(t, err)
+		require.Equal(t, "new-access", token.AccessToken)
+		require.Equal(t, "refresh-1", token.RefreshToken)
+		require.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+	})
+
+	t.Run("invalid grant means revoked", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+		})
+
+		_, err := p.RefreshToken(context.Background(), &oauth2.Token{RefreshToken: "refresh-1"})
+		require.ErrorIs(t, err, oauth.ErrTokenRevoked)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		_, err := p.RefreshToken(context.Background(), &oauth2.Token{RefreshToken: "refresh-1"})
+		require.ErrorIs(t, err, oauth.ErrRefreshFailed)
+		require.NotErrorIs(t, err, oauth.ErrTokenRevoked)
+	})
+
+	t.Run("no refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("token endpoint must not be called")
+		})
+
+		_, err := p.RefreshToken(context.Background(), &oauth2.Token{AccessToken: "old"})
+		require.ErrorIs(t, err, oauth.ErrNoRefreshToken)
+	})
+}
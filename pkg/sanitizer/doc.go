@@ -209,6 +209,9 @@
 //	// user.Website becomes "https://example.com/profile"
 //	// user.Tags becomes ["go", "web"]
 //
+// Tags on []string fields apply to every element, and tags on map[string]string
+// fields apply to every value (keys are left unchanged).
+//
 // # Available Sanitizer Tags
 //
 // The following tags can be used with SanitizeStruct:
@@ -225,6 +228,7 @@
 //	// Security sanitizers
 //	"strip_html", "escape_html", "xss", "sql_string", "sql_identifier"
 //	"path", "user_input", "secure_filename", "no_control", "no_null"
+//	"html" (conservative formatting allowlist), "html:name" (registered policy)
 //
 //	// Composite sanitizers
 //	"username" (alphanum + lower + trim)
@@ -234,6 +238,31 @@
 //	"safe_text" (escape_html + no_spaces + trim)
 //	"safe_html" (xss + trim)
 //
+// # Rich-Text HTML Policies
+//
+// The "xss" and "strip_html" sanitizers remove or escape all markup. For editor
+// content that must keep limited formatting, register an allowlist policy and
+// reference it by name:
+//
+//	sanitizer.RegisterHTMLPolicy("articlebody", sanitizer.Policy{
+//		Elements:          []string{"p", "br", "strong", "em", "a", "ul", "ol", "li", "blockquote"},
+//		Attributes:        map[string][]string{"a": {"href", "title"}},
+//		URLSchemes:        []string{"https", "mailto"},
+//		AllowRelativeURLs: true,
+//		RequireNoFollow:   true,
+//	})
+//
+//	type Article struct {
+//		Body string `sanitize:"trim,html:articlebody"`
+//	}
+//
+// Policies are enforced with bluemonday: disallowed elements are removed (their
+// text is kept), event handlers and unlisted attributes are dropped, and URLs
+// must parse and use an allowed scheme. URLSchemes defaults to http, https, and
+// mailto. Referencing an unregistered policy makes SanitizeStruct return an error
+// rather than passing markup through. Plain "html" uses the built-in conservative
+// policy (paragraphs, emphasis, lists, code, blockquotes, and links).
+//
 // # Custom Sanitizer Registration
 //
 // Register your own sanitization functions:
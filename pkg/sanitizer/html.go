@@ -1,6 +1,7 @@
 package sanitizer
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/microcosm-cc/bluemonday"
@@ -48,3 +49,90 @@ func SanitizeHTMLCustom(s string, policy *bluemonday.Policy) string {
 	}
 	return policy.Sanitize(s)
 }
+
+// Policy describes an HTML allowlist for rich-text fields.
+// Anything not listed is removed; text content of removed elements is kept.
+type Policy struct {
+	// Attributes maps an element name to the attributes allowed on it.
+	// The "*" key applies its attributes to every element in Elements.
+	Attributes map[string][]string
+
+	// Elements lists allowed tag names, e.g. "p", "strong", "a", "ul", "li".
+	Elements []string
+
+	// URLSchemes lists schemes allowed in href and src values.
+	// Defaults to http, https, and mailto when empty.
+	URLSchemes []string
+
+	// AllowRelativeURLs permits relative links such as "/docs" or "#top".
+	AllowRelativeURLs bool
+
+	// RequireNoFollow adds rel="nofollow" to links.
+	RequireNoFollow bool
+}
+
+var htmlPolicies = map[string]*bluemonday.Policy{}
+
+// RegisterHTMLPolicy compiles policy and registers it under name for use as
+// `sanitize:"html:name"`. Registering a name again replaces the previous policy.
+//
+// Example:
+//
+//	sanitizer.RegisterHTMLPolicy("articlebody", sanitizer.Policy{
+//		Elements:   []string{"p", "br", "strong", "em", "a", "ul", "ol", "li"},
+//		Attributes: map[string][]string{"a": {"href", "title"}},
+//		URLSchemes: []string{"https", "mailto"},
+//	})
+//
+//	type Article struct {
+//		Body string `sanitize:"trim,html:articlebody"`
+//	}
+func RegisterHTMLPolicy(name string, policy Policy) {
+	compiled := compilePolicy(policy)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	htmlPolicies[name] = compiled
+}
+
+// SanitizeHTMLPolicy sanitizes s with the policy registered under name.
+// Returns an error if no policy with that name is registered.
+func SanitizeHTMLPolicy(s, name string) (string, error) {
+	registryMu.RLock()
+	policy, ok := htmlPolicies[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("sanitizer: unknown html policy %q", name)
+	}
+	return policy.Sanitize(s), nil
+}
+
+func compilePolicy(policy Policy) *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(policy.Elements...)
+
+	for element, attrs := range policy.Attributes {
+		if len(attrs) == 0 {
+			continue
+		}
+		if element == "*" {
+			if len(policy.Elements) > 0 {
+				p.AllowAttrs(attrs...).OnElements(policy.Elements...)
+			}
+			continue
+		}
+		p.AllowAttrs(attrs...).OnElements(element)
+	}
+
+	schemes := policy.URLSchemes
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https", "mailto"}
+	}
+	p.RequireParseableURLs(true)
+	p.AllowURLSchemes(schemes...)
+	p.AllowRelativeURLs(policy.AllowRelativeURLs)
+	p.RequireNoFollowOnLinks(policy.RequireNoFollow)
+
+	return p
+}
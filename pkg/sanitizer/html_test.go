@@ -5,6 +5,7 @@ import (
 
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dmitrymomot/forge/pkg/sanitizer"
 )
@@ -401,3 +402,96 @@ func TestHTMLStructTag(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterHTMLPolicy(t *testing.T) {
+	t.Parallel()
+
+	sanitizer.RegisterHTMLPolicy("test_articlebody", sanitizer.Policy{
+		Elements:   []string{"p", "strong", "em", "a", "ul", "li"},
+		Attributes: map[string][]string{"a": {"href"}, "*": {"title"}},
+		URLSchemes: []string{"https"},
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "keeps allowed inline and block elements",
+			input:    `<p>Hello <strong>bold</strong> and <em>em</em></p><ul><li>one</li></ul>`,
+			expected: `<p>Hello <strong>bold</strong> and <em>em</em></p><ul><li>one</li></ul>`,
+		},
+		{
+			name:     "keeps allowed link with allowed scheme",
+			input:    `<a href="https://example.com">link</a>`,
+			expected: `<a href="https://example.com">link</a>`,
+		},
+		{
+			name:     "drops href with disallowed scheme",
+			input:    `<a href="http://example.com">link</a>`,
+			expected: `link`,
+		},
+		{
+			name:     "drops javascript URLs",
+			input:    `<a href="javascript:alert(1)">x</a>`,
+			expected: `x`,
+		},
+		{
+			name:     "applies wildcard attributes to listed elements",
+			input:    `<p title="intro" class="lead">text</p>`,
+			expected: `<p title="intro">text</p>`,
+		},
+		{
+			name:     "strips elements outside the allowlist",
+			input:    `<h1>Title</h1><img src="https://example.com/x.png"><script>alert(1)</script>`,
+			expected: `Title`,
+		},
+		{
+			name:     "strips event handlers",
+			input:    `<p onclick="alert(1)">text</p>`,
+			expected: `<p>text</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := sanitizer.SanitizeHTMLPolicy(tt.input, "test_articlebody")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("unknown policy returns error", func(t *testing.T) {
+		t.Parallel()
+		_, err := sanitizer.SanitizeHTMLPolicy("<p>x</p>", "test_missing_policy")
+		require.Error(t, err)
+	})
+
+	t.Run("struct tag references policy by name", func(t *testing.T) {
+		t.Parallel()
+		type Article struct {
+			Body    string `sanitize:"trim,html:test_articlebody"`
+			Summary string `sanitize:"html"`
+		}
+
+		input := Article{
+			Body:    `  <p>Hi <a href="https://example.com" onclick="x()">there</a></p><script>bad()</script>  `,
+			Summary: `<p>Ok</p><h2>Heading</h2>`,
+		}
+		require.NoError(t, sanitizer.SanitizeStruct(&input))
+		assert.Equal(t, `<p>Hi <a href="https://example.com">there</a></p>`, input.Body)
+		assert.Equal(t, `<p>Ok</p>Heading`, input.Summary)
+	})
+
+	t.Run("struct tag with unknown policy fails", func(t *testing.T) {
+		t.Parallel()
+		type Article struct {
+			Body string `sanitize:"html:test_missing_policy"`
+		}
+
+		input := Article{Body: "<p>x</p>"}
+		require.Error(t, sanitizer.SanitizeStruct(&input))
+	})
+}
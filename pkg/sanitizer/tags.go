@@ -168,6 +168,19 @@ func sanitizeStructRecursive(rv reflect.Value) error {
 					elem.SetString(sanitized)
 				}
 			}
+
+		case reflect.Map:
+			// Handle string values of string-keyed maps only if tag is present
+			if tag != "" && field.Type().Key().Kind() == reflect.String && field.Type().Elem().Kind() == reflect.String {
+				iter := field.MapRange()
+				for iter.Next() {
+					sanitized, err := applySanitizers(iter.Value().String(), tag)
+					if err != nil {
+						return err
+					}
+					field.SetMapIndex(iter.Key(), reflect.ValueOf(sanitized).Convert(field.Type().Elem()))
+				}
+			}
 		}
 	}
 
@@ -201,6 +214,16 @@ func applySanitizers(value string, tag string) (string, error) {
 			continue
 		}
 
+		// Handle named HTML policy: html:articlebody
+		if policyName, ok := strings.CutPrefix(sanitizerName, "html:"); ok {
+			policy, exists := htmlPolicies[policyName]
+			if !exists {
+				return "", fmt.Errorf("sanitizer: unknown html policy %q", policyName)
+			}
+			result = policy.Sanitize(result)
+			continue
+		}
+
 		if fn, ok := registry[sanitizerName]; ok {
 			result = fn(result)
 		}
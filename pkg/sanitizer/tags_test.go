@@ -191,6 +191,38 @@ func TestSanitizeStruct_Slices(t *testing.T) {
 	}
 }
 
+func TestSanitizeStruct_Maps(t *testing.T) {
+	type TestStruct struct {
+		Filter map[string]string `sanitize:"trim,lower"`
+		NoTag  map[string]string
+		Counts map[string]int `sanitize:"trim"`
+	}
+
+	input := TestStruct{
+		Filter: map[string]string{"status": "  OPEN  ", "owner": " Me "},
+		NoTag:  map[string]string{"key": "  UNCHANGED  "},
+		Counts: map[string]int{"a": 1},
+	}
+
+	err := sanitizer.SanitizeStruct(&input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if input.Filter["status"] != "open" {
+		t.Errorf("Filter[status]: got %q, want %q", input.Filter["status"], "open")
+	}
+	if input.Filter["owner"] != "me" {
+		t.Errorf("Filter[owner]: got %q, want %q", input.Filter["owner"], "me")
+	}
+	if input.NoTag["key"] != "  UNCHANGED  " {
+		t.Errorf("NoTag[key]: got %q, want %q", input.NoTag["key"], "  UNCHANGED  ")
+	}
+	if input.Counts["a"] != 1 {
+		t.Errorf("Counts[a]: got %d, want %d", input.Counts["a"], 1)
+	}
+}
+
 func TestSanitizeStruct_CompositeSanitizers(t *testing.T) {
 	type TestStruct struct {
 		Email    string `sanitize:"email"`
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// csrfSecretKey is the session value holding the per-session CSRF secret.
+const csrfSecretKey = "_csrf_secret"
+
+// csrfSecretSize is the CSRF secret length in bytes.
+const csrfSecretSize = 32
+
+// CSRFToken returns a CSRF token for the session, generating and storing
+// a per-session secret on first use (which marks the session dirty).
+//
+// Each call returns a different token: the secret is XOR-masked with a fresh
+// one-time pad, so tokens embedded in compressed HTML do not leak the secret
+// to BREACH-style attacks. Every token stays valid for the life of the
+// secret; ValidCSRFToken checks them against the session.
+//
+// Keeping the secret in the session means the server holds the reference
+// value, so no separate CSRF cookie (double-submit) is needed. The trade-off
+// is that the session must exist and be loaded before rendering any form,
+// including for anonymous visitors, and a token is tied to that secret:
+// RotateCSRFSecret, which c.AuthenticateSession calls on login, and
+// destroying the session invalidate open forms. Rotating only the session
+// token keeps the secret. The cookie-based
+// double-submit scheme needs no server state but relies on cookies not being
+// writable by an attacker, which subdomain takeovers and plain-HTTP siblings
+// can break.
+func (s *Session) CSRFToken() string {
+	secret := s.csrfSecret()
+	if secret == nil {
+		secret = make([]byte, csrfSecretSize)
+		_, _ = rand.Read(secret)
+		s.SetValue(csrfSecretKey, base64.RawURLEncoding.EncodeToString(secret))
+	}
+
+	token := make([]byte, 2*csrfSecretSize)
+	pad, masked := token[:csrfSecretSize], token[csrfSecretSize:]
+	_, _ = rand.Read(pad)
+	subtle.XORBytes(masked, pad, secret)
+
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// ValidCSRFToken reports whether token was issued by CSRFToken for this
+// session. It returns false if the session has no CSRF secret yet.
+// The comparison runs in constant time.
+func (s *Session) ValidCSRFToken(token string) bool {
+	secret := s.csrfSecret()
+	if secret == nil {
+		return false
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != 2*len(secret) {
+		return false
+	}
+
+	pad, masked := raw[:len(secret)], raw[len(secret):]
+	unmasked := make([]byte, len(secret))
+	subtle.XORBytes(unmasked, pad, masked)
+
+	return subtle.ConstantTimeCompare(unmasked, secret) == 1
+}
+
+// RotateCSRFSecret discards the session's CSRF secret so the next CSRFToken
+// call generates a new one, invalidating every token issued so far.
+// c.AuthenticateSession calls it on login; call it on other privilege
+// changes, such as a role upgrade.
+func (s *Session) RotateCSRFSecret() {
+	s.DeleteValue(csrfSecretKey)
+}
+
+// csrfSecret returns the stored secret, or nil if none is set or it is malformed.
+func (s *Session) csrfSecret() []byte {
+	encoded, ok := s.GetValue(csrfSecretKey)
+	if !ok {
+		return nil
+	}
+	str, ok := encoded.(string)
+	if !ok {
+		return nil
+	}
+	secret, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil || len(secret) != csrfSecretSize {
+		return nil
+	}
+	return secret
+}
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_CSRFToken(t *testing.T) {
+	t.Parallel()
+
+	newSession := func() *Session {
+		s := New("id", "token", time.Now().Add(time.Hour))
+		s.ClearDirty()
+		return s
+	}
+
+	t.Run("generates and stores secret lazily", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession()
+		require.False(t, s.ValidCSRFToken("anything"))
+
+		token := s.CSRFToken()
+		require.NotEmpty(t, token)
+		require.True(t, s.IsDirty())
+		require.True(t, s.ValidCSRFToken(token))
+	})
+
+	t.Run("issues distinct tokens for the same secret", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession()
+		first := s.CSRFToken()
+		s.ClearDirty()
+		second := s.CSRFToken()
+
+		require.NotEqual(t, first, second)
+		require.False(t, s.IsDirty(), "existing secret must not be rewritten")
+		require.True(t, s.ValidCSRFToken(first))
+		require.True(t, s.ValidCSRFToken(second))
+	})
+
+	t.Run("rejects tokens from another session", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := newSession(), newSession()
+		b.CSRFToken()
+		require.False(t, b.ValidCSRFToken(a.CSRFToken()))
+	})
+
+	t.Run("rejects malformed tokens", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession()
+		token := s.CSRFToken()
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		raw[len(raw)-1] ^= 0xff
+
+		require.False(t, s.ValidCSRFToken(""))
+		require.False(t, s.ValidCSRFToken("not base64!"))
+		require.False(t, s.ValidCSRFToken(token[:len(token)/2]))
+		require.False(t, s.ValidCSRFToken(base64.RawURLEncoding.EncodeToString(raw)))
+	})
+
+	t.Run("rotation invalidates issued tokens", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession()
+		old := s.CSRFToken()
+		s.RotateCSRFSecret()
+
+		require.False(t, s.ValidCSRFToken(old))
+		require.True(t, s.ValidCSRFToken(s.CSRFToken()))
+	})
+}
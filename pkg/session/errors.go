@@ -20,4 +20,8 @@ var (
 	// ErrFingerprintMismatch is returned when session fingerprint validation fails.
 	// This may indicate a session hijacking attempt.
 	ErrFingerprintMismatch = errors.New("session: fingerprint mismatch")
+
+	// ErrTooManySessions is returned when a login is refused because the user
+	// already has the maximum number of concurrent sessions.
+	ErrTooManySessions = errors.New("session: too many sessions")
 )
@@ -23,6 +23,11 @@ type Store interface {
 	// Delete removes a session by its ID.
 	Delete(ctx context.Context, id string) error
 
+	// ListByUserID returns all sessions for a user, in any order.
+	// Anonymous sessions are never returned. Used to enforce per-user
+	// session limits and to show a user their active devices.
+	ListByUserID(ctx context.Context, userID string) ([]*Session, error)
+
 	// DeleteByUserID removes all sessions for a user.
 	// Useful for "logout from all devices" functionality.
 	DeleteByUserID(ctx context.Context, userID string) error
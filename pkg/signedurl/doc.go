@@ -0,0 +1,33 @@
+// Package signedurl creates and verifies tamper-proof, expiring URLs for links
+// sent outside the application, such as magic-link sign-in and one-time
+// downloads.
+//
+// A signed URL carries an expires timestamp and an HMAC-SHA256 signature over
+// the path and all query parameters. Changing any of them, or using the URL
+// after it expires, makes verification fail.
+//
+// # Usage
+//
+// Create a Signer with a secret of at least 32 bytes. The cookie secret can be
+// reused, since the signing key is derived from it:
+//
+//	signer, err := signedurl.New(os.Getenv("COOKIE_SECRET"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	link := signer.Sign("/downloads/report.pdf", time.Now().Add(24*time.Hour), url.Values{
+//		"user": {"42"},
+//	})
+//	// /downloads/report.pdf?expires=1767225600&signature=...&user=42
+//
+// Verify an incoming request:
+//
+//	if err := signer.Verify(r.URL.Path, r.URL.Query()); err != nil {
+//		// signedurl.ErrInvalidSignature or signedurl.ErrExpired
+//	}
+//
+// Signed URLs are bearer credentials: anyone holding one can use it until it
+// expires. Keep expiries short, and for one-time links record use on the
+// server side.
+package signedurl
@@ -0,0 +1,10 @@
+package signedurl
+
+import "errors"
+
+var (
+	ErrNotConfigured    = errors.New("signedurl: not configured")
+	ErrBadSecret        = errors.New("signedurl: secret must be 32+ bytes")
+	ErrInvalidSignature = errors.New("signedurl: invalid signature")
+	ErrExpired          = errors.New("signedurl: url has expired")
+)
@@ -0,0 +1,106 @@
+package signedurl_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/signedurl"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newSigner(t *testing.T) *signedurl.Signer {
+	t.Helper()
+	s, err := signedurl.New(testSecret)
+	require.NoError(t, err)
+	return s
+}
+
+func parse(t *testing.T, raw string) (string, url.Values) {
+	t.Helper()
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return u.Path, u.Query()
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := signedurl.New("short")
+	require.ErrorIs(t, err, signedurl.ErrBadSecret)
+}
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSigner(t)
+		raw := s.Sign("/downloads/report.pdf", time.Now().Add(time.Hour), url.Values{"user": {"42"}})
+		require.True(t, strings.HasPrefix(raw, "/downloads/report.pdf?"))
+
+		path, q := parse(t, raw)
+		require.Equal(t, "42", q.Get("user"))
+		require.NoError(t, s.Verify(path, q))
+	})
+
+	t.Run("does not modify params", func(t *testing.T) {
+		t.Parallel()
+
+		params := url.Values{"user": {"42"}}
+		newSigner(t).Sign("/a", time.Now().Add(time.Hour), params)
+		require.Equal(t, url.Values{"user": {"42"}}, params)
+	})
+
+	t.Run("rejects tampering", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSigner(t)
+		path, q := parse(t, s.Sign("/files/1", time.Now().Add(time.Hour), url.Values{"user": {"42"}}))
+
+		tampered := url.Values{}
+		for k, v := range q {
+			tampered[k] = v
+		}
+		tampered.Set("user", "43")
+		require.ErrorIs(t, s.Verify(path, tampered), signedurl.ErrInvalidSignature)
+
+		require.ErrorIs(t, s.Verify("/files/2", q), signedurl.ErrInvalidSignature)
+
+		extended := url.Values{}
+		for k, v := range q {
+			extended[k] = v
+		}
+		extended.Set(signedurl.ExpiresParam, "99999999999")
+		require.ErrorIs(t, s.Verify(path, extended), signedurl.ErrInvalidSignature)
+	})
+
+	t.Run("rejects missing signature", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, newSigner(t).Verify("/a", url.Values{}), signedurl.ErrInvalidSignature)
+	})
+
+	t.Run("rejects other secret", func(t *testing.T) {
+		t.Parallel()
+
+		other, err := signedurl.New(strings.Repeat("x", 32))
+		require.NoError(t, err)
+
+		path, q := parse(t, newSigner(t).Sign("/a", time.Now().Add(time.Hour), nil))
+		require.ErrorIs(t, other.Verify(path, q), signedurl.ErrInvalidSignature)
+	})
+
+	t.Run("rejects expired", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSigner(t)
+		path, q := parse(t, s.Sign("/a", time.Now().Add(-time.Minute), nil))
+		require.ErrorIs(t, s.Verify(path, q), signedurl.ErrExpired)
+	})
+}
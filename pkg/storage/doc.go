@@ -45,6 +45,38 @@
 //		}
 //	}
 //
+// # Large Files
+//
+// Uploads larger than DefaultMultipartThreshold (64MB) are streamed as S3
+// multipart uploads: the input is read part by part, up to four parts upload
+// concurrently, and each failed part is retried on its own. If a part keeps
+// failing, the upload is aborted so no orphaned parts are billed. Adjust the
+// threshold and follow progress per upload:
+//
+//	info, err := store.Put(ctx, f, size,
+//		storage.WithMultipartThreshold(32<<20),
+//		storage.WithProgress(func(uploaded, total int64) {
+//			log.Printf("uploaded %d of %d bytes", uploaded, total)
+//		}),
+//	)
+//
+// Multipart uploads detect the content type from the first bytes only, so
+// the file is never buffered in full.
+//
+// # Metadata
+//
+// Head reads a file's size, content type, and stored headers without
+// downloading it, and Exists turns a missing key into false:
+//
+//	info, err := store.Head(ctx, key)
+//	if errors.Is(err, storage.ErrNotFound) {
+//		// no such file
+//	}
+//
+//	ok, err := storage.Exists(ctx, store, key)
+//
+// In handlers, c.FileInfo and c.FileExists do the same with the configured store.
+//
 // # URL Generation
 //
 // Generate URLs for stored files:
@@ -62,6 +94,73 @@
 //		storage.WithDownload("document.pdf"),
 //	)
 //
+// To have S3 or a CDN serve headers directly, including for public files,
+// store them on the object at upload time:
+//
+//	info, err := store.Put(ctx, r, size,
+//		storage.WithContentDisposition(`attachment; filename="invoice.pdf"`),
+//		storage.WithCacheControl("public, max-age=31536000, immutable"),
+//	)
+//
+// # Serving Files Through the App
+//
+// When the bucket is not reachable by clients, handlers can stream files
+// themselves after their own access checks. c.ServeFile sets Content-Type,
+// Content-Length, Content-Disposition, and Cache-Control from the stored
+// metadata and answers Range requests with 206 Partial Content, so video
+// and audio players can seek:
+//
+//	r.GET("/files/{id}", func(c forge.Context) error {
+//		doc, err := loadDocument(c, c.Param("id")) // checks ownership
+//		if err != nil {
+//			return err
+//		}
+//		return c.ServeFile(doc.Key, forge.WithServeFileDownload(doc.Name))
+//	})
+//
+// Ranges are read with GetRange, which uses a ranged GET when the store
+// implements RangeGetter (S3Storage does) and otherwise skips to the offset.
+//
+// # Tags and Metadata
+//
+// Tags let S3 lifecycle rules expire or transition objects on the server,
+// instead of a cleanup job in the app. Metadata travels with the object and
+// comes back from Head:
+//
+//	info, err := store.Put(ctx, r, size,
+//		storage.WithTags(map[string]string{"retention": "30d", "tenant": tenantID}),
+//		storage.WithMetadata(map[string]string{"category": "invoice"}),
+//	)
+//
+//	meta, err := store.Head(ctx, info.Key) // meta.Metadata["category"] == "invoice"
+//
+//	tags, err := store.GetTags(ctx, info.Key)
+//	tags["retention"] = "365d"
+//	err = store.SetTags(ctx, info.Key, tags) // replaces all tags
+//
+// A lifecycle rule with a tag filter such as retention=30d and a 30-day
+// expiration then deletes matching objects. S3 allows at most 10 tags per
+// object; invalid sets fail with ErrInvalidTags before anything is uploaded.
+//
+// # Server-Side Encryption
+//
+// Set Config.ServerSideEncryption to encrypt every upload at rest, and
+// WithSSE to override it for a single upload:
+//
+//	store, err := storage.New(storage.Config{
+//		// ...
+//		ServerSideEncryption: storage.SSEKMS,
+//		KMSKeyID:             "arn:aws:kms:us-east-1:123456789012:key/...",
+//	})
+//
+//	info, err := store.Put(ctx, r, size, storage.WithSSE(storage.SSES3, ""))
+//	// info.ServerSideEncryption == storage.SSES3
+//
+// With SSEKMS, the key policy must allow the storage credentials to use the
+// key (kms:GenerateDataKey for uploads, kms:Decrypt for downloads), otherwise
+// requests fail with ErrAccessDenied. Copy encrypts the new object with the
+// configured default.
+//
 // # Multi-Tenant Support
 //
 // Use WithTenant for tenant isolation:
@@ -72,6 +171,73 @@
 //	)
 //	// Key: {tenant}/{prefix}/{ulid}.{ext}
 //
+// # Tenant Quotas
+//
+// WithQuota wraps a Storage so Put rejects uploads that would take a tenant
+// past its limit with ErrQuotaExceeded. The tenant is the one set with
+// WithTenant; uploads without it are not counted. Deleting a file releases
+// its size:
+//
+//	quotas := storage.NewPostgresQuotaStore(pool, 5<<30) // 5GB per tenant
+//	store = storage.WithQuota(quotas)(store)
+//
+//	_, err := storage.PutFile(ctx, store, fh, storage.WithTenant(tenantID))
+//	if errors.Is(err, storage.ErrQuotaExceeded) {
+//		return c.Error(http.StatusRequestEntityTooLarge, "storage quota exceeded")
+//	}
+//
+// Create the table with QuotaSchema and change limits with SetLimit.
+// Tracked uploads must declare their exact size; a negative size or a body
+// of a different length fails with ErrInvalidSize, so usage never drifts.
+//
+// Reading usage, comparing it with the limit, and then writing it back is a
+// race: two concurrent uploads both see room for themselves and together
+// overshoot the limit. A custom QuotaStore must make Reserve atomic, either
+// with a conditional update (UPDATE ... WHERE used + size <= limit, as
+// PostgresQuotaStore does) or by serializing a tenant's reservations with
+// db.WithAdvisoryLock.
+//
+// # Key Strategies
+//
+// Config.KeyStrategy, or WithKeyStrategy per upload, replaces the default key
+// layout. DateKeyStrategy partitions keys by upload month for lifecycle
+// rules, and FilenameKeyStrategy keeps a slug of the original filename:
+//
+//	store, err := storage.New(storage.Config{
+//		// ...
+//		KeyStrategy: storage.DateKeyStrategy(), // {tenant}/{prefix}/2024/02/{ulid}.{ext}
+//	})
+//
+//	info, err := storage.PutFile(ctx, store, fh,
+//		storage.WithKeyStrategy(storage.FilenameKeyStrategy()),
+//	)
+//	// Key: {ulid}/q3-report.pdf
+//
+// A custom KeyStrategy receives the sanitized tenant and prefix, the original
+// filename, the extension for the detected content type, and a new ULID.
+// The filename is untrusted input; sanitize it before using it in a key.
+//
+// # Testing
+//
+// NewMemory returns an in-memory Storage for tests of code that uploads or
+// serves files, such as handlers calling c.Upload or c.FileURL. Put applies
+// the same options, content type detection, validation, and key strategies
+// as S3Storage; URL returns synthetic links under DefaultMemoryBaseURL:
+//
+//	store := storage.NewMemory()
+//	app := forge.New(forge.WithStorage(store), ...)
+//
+//	// after the request
+//	data, ok := store.Bytes(key)
+//	keys := store.Keys()
+//
+// FailOn makes one operation return an error until it is cleared with nil
+// or Reset, so failure paths can be tested without a broken bucket:
+//
+//	store.FailOn(storage.OpPut, storage.ErrUploadFailed)
+//	store.FailOn(storage.OpURL, storage.ErrPresignFailed)
+//	store.FailOn(storage.OpPut, nil) // Put succeeds again
+//
 // # Configuration
 //
 // The Config struct supports environment variables:
@@ -86,5 +252,8 @@
 //		DefaultACL      ACL    // STORAGE_DEFAULT_ACL (default: private)
 //		PathStyle       bool   // STORAGE_PATH_STYLE (for MinIO)
 //		MaxDownloadSize int64  // STORAGE_MAX_DOWNLOAD (default: 50MB)
+//
+//		ServerSideEncryption SSEMode // STORAGE_SSE (AES256 or aws:kms)
+//		KMSKeyID             string  // STORAGE_KMS_KEY_ID (for aws:kms)
 //	}
 package storage
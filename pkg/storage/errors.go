@@ -20,10 +20,16 @@ var (
 	ErrAccessDenied     = errors.New("storage: access denied")
 	ErrUploadFailed     = errors.New("storage: upload failed")
 	ErrDeleteFailed     = errors.New("storage: delete failed")
+	ErrHeadFailed       = errors.New("storage: failed to read file metadata")
+	ErrInvalidTags      = errors.New("storage: invalid object tags")
+	ErrTaggingFailed    = errors.New("storage: failed to read or write object tags")
 	ErrPresignFailed    = errors.New("storage: presign failed")
 	ErrInvalidURL       = errors.New("storage: invalid URL")
 	ErrDownloadFailed   = errors.New("storage: failed to download from URL")
 	ErrDownloadTooLarge = errors.New("storage: download exceeds size limit")
+	ErrQuotaExceeded    = errors.New("storage: tenant quota exceeded")
+	ErrQuotaFailed      = errors.New("storage: failed to update tenant quota")
+	ErrInvalidSize      = errors.New("storage: upload size does not match declared size")
 )
 
 // wrapS3Error wraps S3 errors with appropriate sentinel errors.
@@ -24,6 +24,7 @@ func TestSentinelErrors(t *testing.T) {
 		ErrAccessDenied,
 		ErrUploadFailed,
 		ErrDeleteFailed,
+		ErrHeadFailed,
 		ErrPresignFailed,
 		ErrInvalidURL,
 		ErrDownloadFailed,
@@ -3,6 +3,7 @@ package storage
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -20,6 +21,9 @@ func PutFile(ctx context.Context, s Storage, fh *multipart.FileHeader, opts ...O
 		return nil, ErrEmptyFile
 	}
 
+	// Prepend so an explicit WithFilename wins.
+	opts = append([]Option{WithFilename(fh.Filename)}, opts...)
+
 	o := &putOptions{}
 	for _, opt := range opts {
 		opt(o)
@@ -44,14 +48,50 @@ func PutFile(ctx context.Context, s Storage, fh *multipart.FileHeader, opts ...O
 }
 
 // PutBytes uploads byte data to storage.
-// The filename is used to help with key generation but MIME type is detected from content.
+// The filename is passed to the key strategy (see WithFilename) but MIME
+// type is detected from content.
 func PutBytes(ctx context.Context, s Storage, data []byte, filename string, opts ...Option) (*FileInfo, error) {
 	if len(data) == 0 {
 		return nil, ErrEmptyFile
 	}
 
 	r := bytes.NewReader(data)
-	return s.Put(ctx, r, int64(len(data)), opts...)
+	return s.Put(ctx, r, int64(len(data)), append([]Option{WithFilename(filename)}, opts...)...)
+}
+
+// Exists reports whether a file with the given key exists, using Head so
+// nothing is downloaded. A missing key is not an error.
+func Exists(ctx context.Context, s Storage, key string) (bool, error) {
+	if _, err := s.Head(ctx, key); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetRange returns length bytes of a file starting at offset. Storages that
+// implement RangeGetter fetch only that part; for others the file is read
+// from the start and the bytes before offset are discarded.
+// The caller is responsible for closing the returned reader.
+func GetRange(ctx context.Context, s Storage, key string, offset, length int64) (io.ReadCloser, error) {
+	if rg, ok := s.(RangeGetter); ok {
+		return rg.GetRange(ctx, key, offset, length)
+	}
+
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, rc, offset); err != nil {
+		_ = rc.Close()
+		return nil, fmt.Errorf("storage: skip to offset %d: %w", offset, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(rc, length), rc}, nil
 }
 
 // PutFromURL downloads a file from a URL and uploads it to storage.
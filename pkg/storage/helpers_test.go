@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -21,6 +22,7 @@ type mockStorage struct {
 	putFunc    func(ctx context.Context, r io.Reader, size int64, opts ...Option) (*FileInfo, error)
 	getFunc    func(ctx context.Context, key string) (io.ReadCloser, error)
 	deleteFunc func(ctx context.Context, key string) error
+	headFunc   func(ctx context.Context, key string) (*FileInfo, error)
 	urlFunc    func(ctx context.Context, key string, opts ...URLOption) (string, error)
 }
 
@@ -45,6 +47,21 @@ func (m *mockStorage) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (m *mockStorage) Head(ctx context.Context, key string) (*FileInfo, error) {
+	if m.headFunc != nil {
+		return m.headFunc(ctx, key)
+	}
+	return &FileInfo{Key: key}, nil
+}
+
+func (m *mockStorage) GetTags(ctx context.Context, key string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (m *mockStorage) SetTags(ctx context.Context, key string, tags map[string]string) error {
+	return nil
+}
+
 func (m *mockStorage) URL(ctx context.Context, key string, opts ...URLOption) (string, error) {
 	if m.urlFunc != nil {
 		return m.urlFunc(ctx, key, opts...)
@@ -226,6 +243,102 @@ func TestPutFile(t *testing.T) {
 }
 
 // TestPutBytes tests the PutBytes helper function.
+func TestExists(t *testing.T) {
+	t.Parallel()
+
+	t.Run("existing file", func(t *testing.T) {
+		t.Parallel()
+
+		ok, err := Exists(context.Background(), &mockStorage{}, "avatars/a.png")
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("missing file is not an error", func(t *testing.T) {
+		t.Parallel()
+
+		s := &mockStorage{
+			headFunc: func(_ context.Context, key string) (*FileInfo, error) {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+			},
+		}
+		ok, err := Exists(context.Background(), s, "missing")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("other errors propagate", func(t *testing.T) {
+		t.Parallel()
+
+		s := &mockStorage{
+			headFunc: func(context.Context, string) (*FileInfo, error) {
+				return nil, ErrAccessDenied
+			},
+		}
+		ok, err := Exists(context.Background(), s, "secret")
+		require.ErrorIs(t, err, ErrAccessDenied)
+		require.False(t, ok)
+	})
+}
+
+type rangeStorage struct {
+	mockStorage
+	gotOffset, gotLength int64
+}
+
+func (r *rangeStorage) GetRange(_ context.Context, _ string, offset, length int64) (io.ReadCloser, error) {
+	r.gotOffset, r.gotLength = offset, length
+	return io.NopCloser(strings.NewReader("ranged")), nil
+}
+
+func TestGetRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uses RangeGetter when implemented", func(t *testing.T) {
+		t.Parallel()
+
+		s := &rangeStorage{}
+		rc, err := GetRange(context.Background(), s, "video.mp4", 100, 50)
+		require.NoError(t, err)
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, "ranged", string(data))
+		require.Equal(t, int64(100), s.gotOffset)
+		require.Equal(t, int64(50), s.gotLength)
+	})
+
+	t.Run("falls back to skipping through Get", func(t *testing.T) {
+		t.Parallel()
+
+		s := &mockStorage{
+			getFunc: func(context.Context, string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("0123456789")), nil
+			},
+		}
+		rc, err := GetRange(context.Background(), s, "digits.txt", 3, 4)
+		require.NoError(t, err)
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, "3456", string(data))
+	})
+
+	t.Run("offset past end fails", func(t *testing.T) {
+		t.Parallel()
+
+		s := &mockStorage{
+			getFunc: func(context.Context, string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("short")), nil
+			},
+		}
+		_, err := GetRange(context.Background(), s, "short.txt", 10, 1)
+		require.ErrorIs(t, err, io.EOF)
+	})
+}
+
 func TestPutBytes(t *testing.T) {
 	t.Parallel()
 
@@ -271,6 +384,24 @@ func TestPutBytes(t *testing.T) {
 		require.Equal(t, data, capturedData)
 	})
 
+	t.Run("passes filename to key strategy", func(t *testing.T) {
+		t.Parallel()
+
+		storage := &mockStorage{
+			putFunc: func(_ context.Context, _ io.Reader, size int64, opts ...Option) (*FileInfo, error) {
+				o := &putOptions{}
+				for _, opt := range opts {
+					opt(o)
+				}
+				require.Equal(t, "report.pdf", o.filename)
+				return &FileInfo{Key: "test-key", Size: size}, nil
+			},
+		}
+
+		_, err := PutBytes(context.Background(), storage, []byte("data"), "report.pdf")
+		require.NoError(t, err)
+	})
+
 	t.Run("large data upload", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dmitrymomot/forge/pkg/id"
+	"github.com/dmitrymomot/forge/pkg/slug"
+)
+
+// KeyContext carries the parts a KeyStrategy builds a storage key from.
+type KeyContext struct {
+	// Tenant is the sanitized WithTenant value, or empty.
+	Tenant string
+
+	// Prefix is the sanitized WithPrefix value, or empty.
+	Prefix string
+
+	// Filename is the original filename from WithFilename, PutFile, or
+	// PutBytes, or empty. It is not sanitized.
+	Filename string
+
+	// Ext is the extension for the detected content type, including the
+	// leading dot (".bin" if unknown).
+	Ext string
+
+	// ID is a freshly generated ULID.
+	ID string
+}
+
+// KeyStrategy builds the storage key for an upload without an explicit
+// WithKey. Set it for all uploads with Config.KeyStrategy or per upload with
+// WithKeyStrategy.
+type KeyStrategy func(KeyContext) string
+
+// DefaultKeyStrategy returns the default strategy: {tenant}/{prefix}/{ulid}.{ext}.
+func DefaultKeyStrategy() KeyStrategy {
+	return func(kc KeyContext) string {
+		return joinKey(kc.Tenant, kc.Prefix, kc.ID+kc.Ext)
+	}
+}
+
+// DateKeyStrategy returns a strategy that partitions keys by upload month in
+// UTC, so lifecycle rules can expire whole months:
+// {tenant}/{prefix}/{yyyy}/{mm}/{ulid}.{ext}.
+func DateKeyStrategy() KeyStrategy {
+	return func(kc KeyContext) string {
+		return joinKey(kc.Tenant, kc.Prefix, time.Now().UTC().Format("2006/01"), kc.ID+kc.Ext)
+	}
+}
+
+// FilenameKeyStrategy returns a strategy that keeps a slug of the original
+// filename under a unique directory: {tenant}/{prefix}/{ulid}/{slug}.{ext}.
+// The extension comes from the detected content type, not the filename.
+// Uploads without a usable filename fall back to {ulid}.{ext}.
+func FilenameKeyStrategy() KeyStrategy {
+	return func(kc KeyContext) string {
+		name := path.Base(strings.ReplaceAll(kc.Filename, `\`, "/"))
+		name = slug.Make(strings.TrimSuffix(name, path.Ext(name)), slug.MaxLength(100))
+		if name == "" {
+			return joinKey(kc.Tenant, kc.Prefix, kc.ID+kc.Ext)
+		}
+		return joinKey(kc.Tenant, kc.Prefix, kc.ID, name+kc.Ext)
+	}
+}
+
+// newKey builds the key for an upload without WithKey, using the upload's
+// key strategy, then fallback, then DefaultKeyStrategy.
+func newKey(o *putOptions, contentType string, fallback KeyStrategy) string {
+	kc := KeyContext{
+		Filename: o.filename,
+		Ext:      ExtFromMIME(contentType),
+		ID:       id.NewULID(),
+	}
+	if o.tenant != "" {
+		kc.Tenant = sanitizePathSegment(o.tenant)
+	}
+	if o.prefix != "" {
+		kc.Prefix = sanitizePathSegment(o.prefix)
+	}
+	if kc.Ext == "" {
+		kc.Ext = ".bin"
+	}
+
+	strategy := o.keyStrategy
+	if strategy == nil {
+		strategy = fallback
+	}
+	if strategy == nil {
+		strategy = DefaultKeyStrategy()
+	}
+	return strategy(kc)
+}
+
+// joinKey joins the non-empty key segments with slashes.
+func joinKey(segments ...string) string {
+	parts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "/")
+}
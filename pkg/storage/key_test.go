@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyStrategies(t *testing.T) {
+	t.Parallel()
+
+	kc := KeyContext{
+		Tenant:   "acme",
+		Prefix:   "invoices",
+		Filename: "Invoice #42 (final).PDF",
+		Ext:      ".pdf",
+		ID:       "01HQXYZ0000000000000000000",
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "acme/invoices/01HQXYZ0000000000000000000.pdf", DefaultKeyStrategy()(kc))
+		require.Equal(t, "01HQXYZ0000000000000000000.pdf", DefaultKeyStrategy()(KeyContext{Ext: ".pdf", ID: kc.ID}))
+	})
+
+	t.Run("date", func(t *testing.T) {
+		t.Parallel()
+		month := time.Now().UTC().Format("2006/01")
+		require.Equal(t, "acme/invoices/"+month+"/01HQXYZ0000000000000000000.pdf", DateKeyStrategy()(kc))
+		require.Equal(t, month+"/01HQXYZ0000000000000000000.pdf", DateKeyStrategy()(KeyContext{Ext: ".pdf", ID: kc.ID}))
+	})
+
+	t.Run("filename", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "acme/invoices/01HQXYZ0000000000000000000/invoice-42-final.pdf", FilenameKeyStrategy()(kc))
+	})
+
+	t.Run("filename strips directories", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range []string{"../../etc/passwd.png", `C:\Users\me\passwd.png`} {
+			got := FilenameKeyStrategy()(KeyContext{Filename: name, Ext: ".png", ID: kc.ID})
+			require.Equal(t, "01HQXYZ0000000000000000000/passwd.png", got)
+		}
+	})
+
+	t.Run("filename falls back to ID", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range []string{"", "..", "???.png"} {
+			got := FilenameKeyStrategy()(KeyContext{Filename: name, Ext: ".png", ID: kc.ID})
+			require.Equal(t, "01HQXYZ0000000000000000000.png", got)
+		}
+	})
+}
@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MemoryOp names a Memory operation for error injection with FailOn.
+type MemoryOp string
+
+// Memory operations.
+const (
+	OpPut      MemoryOp = "put"
+	OpGet      MemoryOp = "get"
+	OpGetRange MemoryOp = "get_range"
+	OpDelete   MemoryOp = "delete"
+	OpHead     MemoryOp = "head"
+	OpGetTags  MemoryOp = "get_tags"
+	OpSetTags  MemoryOp = "set_tags"
+	OpURL      MemoryOp = "url"
+)
+
+// DefaultMemoryBaseURL is the prefix of URLs returned by Memory.URL.
+const DefaultMemoryBaseURL = "https://storage.test"
+
+// MemoryOption configures the in-memory storage.
+type MemoryOption func(*memoryOptions)
+
+type memoryOptions struct {
+	baseURL     string
+	defaultACL  ACL
+	keyStrategy KeyStrategy
+}
+
+// WithMemoryBaseURL sets the prefix of the synthetic URLs returned by URL.
+// Default: DefaultMemoryBaseURL.
+func WithMemoryBaseURL(u string) MemoryOption {
+	return func(o *memoryOptions) {
+		o.baseURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithMemoryDefaultACL sets the ACL of uploads without WithACL.
+// Default: ACLPrivate.
+func WithMemoryDefaultACL(acl ACL) MemoryOption {
+	return func(o *memoryOptions) {
+		o.defaultACL = acl
+	}
+}
+
+// WithMemoryKeyStrategy sets how keys are built for uploads without WithKey,
+// like Config.KeyStrategy. Default: DefaultKeyStrategy.
+func WithMemoryKeyStrategy(fn KeyStrategy) MemoryOption {
+	return func(o *memoryOptions) {
+		o.keyStrategy = fn
+	}
+}
+
+// memoryFile is a stored object.
+type memoryFile struct {
+	info FileInfo
+	tags map[string]string
+	data []byte
+}
+
+// Memory is a Storage that keeps files in memory. It is a test double for
+// code that uploads or serves files: Put applies the same options, content
+// type detection, validation, and key strategies as S3Storage, and URL
+// returns synthetic URLs that never resolve. Use FailOn to make an
+// operation fail and exercise error paths.
+//
+// Memory is safe for concurrent use. Files are lost when it is discarded.
+type Memory struct {
+	files map[string]*memoryFile
+	errs  map[MemoryOp]error
+	opts  memoryOptions
+	mu    sync.RWMutex
+}
+
+// Compile-time interface checks.
+var (
+	_ Storage     = (*Memory)(nil)
+	_ RangeGetter = (*Memory)(nil)
+)
+
+// NewMemory creates an empty in-memory storage.
+func NewMemory(opts ...MemoryOption) *Memory {
+	o := memoryOptions{
+		baseURL:    DefaultMemoryBaseURL,
+		defaultACL: ACLPrivate,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Memory{
+		files: make(map[string]*memoryFile),
+		errs:  make(map[MemoryOp]error),
+		opts:  o,
+	}
+}
+
+// FailOn makes every later call of op return err until it is cleared with
+// a nil err or Reset. The error is returned as is, so tests can pass a
+// sentinel such as ErrUploadFailed and match it with errors.Is.
+//
+//	store.FailOn(storage.OpPut, storage.ErrUploadFailed)
+func (m *Memory) FailOn(op MemoryOp, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil {
+		delete(m.errs, op)
+		return
+	}
+	m.errs[op] = err
+}
+
+// Reset removes all files and injected errors.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.files)
+	clear(m.errs)
+}
+
+// Keys returns the keys of all stored files in sorted order.
+func (m *Memory) Keys() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Sorted(maps.Keys(m.files))
+}
+
+// Bytes returns a copy of the file's contents and whether it exists.
+func (m *Memory) Bytes(key string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f, ok := m.files[key]
+	if !ok {
+		return nil, false
+	}
+	return bytes.Clone(f.data), true
+}
+
+// Put reads r into memory and stores it under the key S3Storage would use.
+// It returns the same validation errors as S3Storage, and the injected
+// error for OpPut if one is set.
+func (m *Memory) Put(ctx context.Context, r io.Reader, size int64, opts ...Option) (*FileInfo, error) {
+	if err := m.fail(ctx, OpPut); err != nil {
+		return nil, err
+	}
+
+	o := &putOptions{acl: m.opts.defaultACL}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	contentType := o.contentType
+	if contentType == "" {
+		contentType = detectMIMEFromReader(bytes.NewReader(data))
+	}
+
+	if err := validateTags(o.tags); err != nil {
+		return nil, err
+	}
+	if len(o.validationRules) > 0 {
+		if err := ValidateReader(size, contentType, o.validationRules...); err != nil {
+			return nil, err
+		}
+	}
+
+	var sse SSEMode
+	if o.sse != nil {
+		if !o.sse.mode.valid() {
+			return nil, ErrInvalidConfig
+		}
+		sse = o.sse.mode
+	}
+
+	key := o.key
+	if key == "" {
+		key = newKey(o, contentType, m.opts.keyStrategy)
+	}
+
+	info := FileInfo{
+		Key:                  key,
+		Size:                 int64(len(data)),
+		ContentType:          contentType,
+		ContentDisposition:   o.disposition,
+		CacheControl:         o.cacheControl,
+		ACL:                  o.acl,
+		ServerSideEncryption: sse,
+		Metadata:             maps.Clone(o.metadata),
+	}
+
+	m.mu.Lock()
+	m.files[key] = &memoryFile{info: info, tags: maps.Clone(o.tags), data: data}
+	m.mu.Unlock()
+
+	if o.progress != nil {
+		o.progress(info.Size, info.Size)
+	}
+
+	result := info
+	result.Metadata = maps.Clone(info.Metadata)
+	return &result, nil
+}
+
+// Get returns a reader over a copy of the file's contents.
+// Returns ErrNotFound if the key does not exist.
+func (m *Memory) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := m.fail(ctx, OpGet); err != nil {
+		return nil, err
+	}
+	data, ok := m.Bytes(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// GetRange returns length bytes of the file starting at offset.
+// Returns ErrNotFound if the key does not exist.
+func (m *Memory) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if err := m.fail(ctx, OpGetRange); err != nil {
+		return nil, err
+	}
+	data, ok := m.Bytes(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	start := min(max(offset, 0), int64(len(data)))
+	end := min(start+max(length, 0), int64(len(data)))
+	return io.NopCloser(bytes.NewReader(data[start:end])), nil
+}
+
+// Delete removes a file. Deleting a missing key is not an error, as with S3.
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	if err := m.fail(ctx, OpDelete); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	delete(m.files, key)
+	m.mu.Unlock()
+	return nil
+}
+
+// Head returns the file's metadata.
+// Returns ErrNotFound if the key does not exist.
+func (m *Memory) Head(ctx context.Context, key string) (*FileInfo, error) {
+	if err := m.fail(ctx, OpHead); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f, ok := m.files[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	info := f.info
+	info.Metadata = maps.Clone(f.info.Metadata)
+	return &info, nil
+}
+
+// GetTags returns a copy of the file's tags.
+// Returns ErrNotFound if the key does not exist.
+func (m *Memory) GetTags(ctx context.Context, key string) (map[string]string, error) {
+	if err := m.fail(ctx, OpGetTags); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f, ok := m.files[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	tags := make(map[string]string, len(f.tags))
+	maps.Copy(tags, f.tags)
+	return tags, nil
+}
+
+// SetTags replaces the file's tags.
+// Returns ErrInvalidTags if tags exceed S3 limits and ErrNotFound if the key
+// does not exist.
+func (m *Memory) SetTags(ctx context.Context, key string, tags map[string]string) error {
+	if err := m.fail(ctx, OpSetTags); err != nil {
+		return err
+	}
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.files[key]
+	if !ok {
+		return ErrNotFound
+	}
+	f.tags = maps.Clone(tags)
+	return nil
+}
+
+// URL returns a synthetic URL under the base URL. Like S3Storage, it is
+// signed unless WithPublic is used without WithDownload or WithSigned; a
+// signed URL carries its expiry in seconds and the download filename as
+// query parameters, so tests can assert on them. The key is not checked.
+func (m *Memory) URL(ctx context.Context, key string, opts ...URLOption) (string, error) {
+	if err := m.fail(ctx, OpURL); err != nil {
+		return "", err
+	}
+	o := &urlOptions{expiry: DefaultURLExpiry}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	u := m.opts.baseURL + "/" + key
+	if o.forcePublic && o.downloadName == "" && !o.forceSigned {
+		return u, nil
+	}
+
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(int64(o.expiry.Seconds()), 10))
+	if o.downloadName != "" {
+		q.Set("download", o.downloadName)
+	}
+	return u + "?" + q.Encode(), nil
+}
+
+// fail returns the context's error or the error injected for op, if any.
+func (m *Memory) fail(ctx context.Context, op MemoryOp) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.errs[op]
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	png := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("x", 32))
+
+	t.Run("put stores bytes that get returns", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		info, err := store.Put(ctx, bytes.NewReader(png), int64(len(png)),
+			WithTenant("acme"), WithPrefix("avatars"),
+			WithMetadata(map[string]string{"owner": "42"}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "image/png", info.ContentType)
+		require.Equal(t, ACLPrivate, info.ACL)
+		require.Equal(t, int64(len(png)), info.Size)
+		require.True(t, strings.HasPrefix(info.Key, "acme/avatars/"))
+		require.True(t, strings.HasSuffix(info.Key, ".png"))
+		require.Equal(t, []string{info.Key}, store.Keys())
+
+		rc, err := store.Get(ctx, info.Key)
+		require.NoError(t, err)
+		defer rc.Close()
+		got, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, png, got)
+
+		head, err := store.Head(ctx, info.Key)
+		require.NoError(t, err)
+		require.Equal(t, info, head)
+	})
+
+	t.Run("missing keys return ErrNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		_, err := store.Get(ctx, "nope")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = store.Head(ctx, "nope")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = store.GetTags(ctx, "nope")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, store.SetTags(ctx, "nope", nil), ErrNotFound)
+		require.NoError(t, store.Delete(ctx, "nope"))
+	})
+
+	t.Run("explicit key overwrites and delete removes", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		_, err := PutBytes(ctx, store, []byte("one"), "a.txt", WithKey("docs/a.txt"))
+		require.NoError(t, err)
+		_, err = PutBytes(ctx, store, []byte("two"), "a.txt", WithKey("docs/a.txt"))
+		require.NoError(t, err)
+
+		data, ok := store.Bytes("docs/a.txt")
+		require.True(t, ok)
+		require.Equal(t, "two", string(data))
+
+		require.NoError(t, store.Delete(ctx, "docs/a.txt"))
+		ok, err = Exists(ctx, store, "docs/a.txt")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("validation and tag limits apply", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		_, err := store.Put(ctx, strings.NewReader("plain text"), 10, WithValidation(ImageOnly()))
+		var verr *FileValidationError
+		require.ErrorAs(t, err, &verr)
+
+		_, err = store.Put(ctx, strings.NewReader("x"), 1, WithTags(map[string]string{"": "x"}))
+		require.ErrorIs(t, err, ErrInvalidTags)
+		require.Empty(t, store.Keys())
+	})
+
+	t.Run("tags round trip", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		info, err := store.Put(ctx, strings.NewReader("x"), 1, WithTags(map[string]string{"retention": "30d"}))
+		require.NoError(t, err)
+
+		tags, err := store.GetTags(ctx, info.Key)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"retention": "30d"}, tags)
+
+		require.NoError(t, store.SetTags(ctx, info.Key, map[string]string{}))
+		tags, err = store.GetTags(ctx, info.Key)
+		require.NoError(t, err)
+		require.Empty(t, tags)
+	})
+
+	t.Run("get range reads part of the file", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		info, err := store.Put(ctx, strings.NewReader("0123456789"), 10)
+		require.NoError(t, err)
+
+		rc, err := GetRange(ctx, store, info.Key, 2, 3)
+		require.NoError(t, err)
+		got, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, "234", string(got))
+	})
+
+	t.Run("url is signed unless public", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory(WithMemoryBaseURL("https://cdn.test/"))
+
+		u, err := store.URL(ctx, "a/b.png")
+		require.NoError(t, err)
+		require.Equal(t, "https://cdn.test/a/b.png?expires=900", u)
+
+		u, err = store.URL(ctx, "a/b.png", WithPublic())
+		require.NoError(t, err)
+		require.Equal(t, "https://cdn.test/a/b.png", u)
+
+		u, err = store.URL(ctx, "a/b.png", WithPublic(), WithDownload("b.png"))
+		require.NoError(t, err)
+		require.Equal(t, "https://cdn.test/a/b.png?download=b.png&expires=900", u)
+	})
+
+	t.Run("injected errors fail only their operation", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemory()
+		info, err := store.Put(ctx, strings.NewReader("x"), 1)
+		require.NoError(t, err)
+
+		boom := errors.New("boom")
+		store.FailOn(OpGet, boom)
+		store.FailOn(OpPut, ErrUploadFailed)
+
+		_, err = store.Get(ctx, info.Key)
+		require.ErrorIs(t, err, boom)
+		_, err = store.Put(ctx, strings.NewReader("y"), 1)
+		require.ErrorIs(t, err, ErrUploadFailed)
+		_, err = store.Head(ctx, info.Key)
+		require.NoError(t, err)
+
+		store.FailOn(OpGet, nil)
+		_, err = store.Get(ctx, info.Key)
+		require.NoError(t, err)
+
+		store.Reset()
+		require.Empty(t, store.Keys())
+		_, err = store.Put(ctx, strings.NewReader("y"), 1)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Multipart upload tuning.
+const (
+	minPartSize         = 5 << 20  // S3 minimum for every part but the last
+	defaultPartSize     = 16 << 20 // Parts per upload stay well under the limit
+	maxParts            = 10000    // S3 limit per upload
+	uploadConcurrency   = 4        // Parts in flight, each holding one buffer
+	partAttempts        = 3        // Attempts per part on top of SDK retries
+	partRetryBackoffMin = 200 * time.Millisecond
+)
+
+// errSizeMismatch is reported when the reader yields a different number of
+// bytes than the size passed to Put.
+var errSizeMismatch = errors.New("reader size does not match declared size")
+
+// sniffMIME detects the content type from the first bytes of r without
+// buffering the rest. If contentType is set, r is returned unchanged.
+func sniffMIME(r io.Reader, contentType string) (string, io.Reader) {
+	if contentType != "" {
+		return contentType, r
+	}
+	head := make([]byte, mimeDetectionBytes)
+	n, _ := io.ReadFull(r, head)
+	if n == 0 {
+		return MIMEOctetStream, r
+	}
+	return http.DetectContentType(head[:n]), io.MultiReader(bytes.NewReader(head[:n]), r)
+}
+
+// partSizeFor returns the part size for an upload of size bytes, growing
+// past the default when needed to stay within the S3 part count limit.
+func partSizeFor(size int64) int64 {
+	partSize := int64(defaultPartSize)
+	if minForCount := (size + maxParts - 1) / maxParts; minForCount > partSize {
+		partSize = minForCount
+	}
+	return max(partSize, minPartSize)
+}
+
+// putMultipart streams body to key as a multipart upload. Parts are read
+// sequentially and uploaded by up to uploadConcurrency goroutines; each part
+// is retried on failure. On any unrecoverable error the upload is aborted
+// so no orphaned parts are left behind.
+func (s *S3Storage) putMultipart(ctx context.Context, key string, body io.Reader, size int64, h objectHeaders, o *putOptions) error {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.cfg.Bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(h.contentType),
+		ACL:         h.cannedACL(),
+	}
+	if h.disposition != "" {
+		input.ContentDisposition = aws.String(h.disposition)
+	}
+	if h.cacheControl != "" {
+		input.CacheControl = aws.String(h.cacheControl)
+	}
+	if h.sse.mode != SSENone {
+		input.ServerSideEncryption = types.ServerSideEncryption(h.sse.mode)
+		if h.sse.mode == SSEKMS && h.sse.kmsKeyID != "" {
+			input.SSEKMSKeyId = aws.String(h.sse.kmsKeyID)
+		}
+	}
+	input.Tagging = h.tagging()
+	input.Metadata = h.metadata
+
+	created, err := s.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return wrapS3Error(err, ErrUploadFailed)
+	}
+	uploadID := created.UploadId
+
+	parts, err := s.uploadParts(ctx, key, uploadID, body, size, o)
+	if err == nil {
+		_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(s.cfg.Bucket),
+			Key:             aws.String(key),
+			UploadId:        uploadID,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+	}
+	if err != nil {
+		// Abort even if ctx was canceled, or the parts keep accruing storage costs
+		_, _ = s.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(s.cfg.Bucket),
+			Key:      aws.String(key),
+			UploadId: uploadID,
+		})
+		return wrapS3Error(err, ErrUploadFailed)
+	}
+	return nil
+}
+
+// uploadParts reads body in parts and uploads them concurrently, returning
+// the completed parts in part-number order.
+func (s *S3Storage) uploadParts(ctx context.Context, key string, uploadID *string, body io.Reader, size int64, o *putOptions) ([]types.CompletedPart, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	partSize := o.partSize
+	if partSize <= 0 {
+		partSize = partSizeFor(size)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		parts    []types.CompletedPart
+		uploaded int64
+	)
+	sem := make(chan struct{}, uploadConcurrency)
+	r := io.LimitReader(body, size)
+	var total int64
+
+	for partNumber := int32(1); ; partNumber++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		buf := make([]byte, partSize)
+		n, readErr := io.ReadFull(r, buf)
+		if n == 0 {
+			<-sem
+			if readErr != nil && readErr != io.EOF {
+				cancel(fmt.Errorf("failed to read input: %w", readErr))
+			}
+			break
+		}
+		total += int64(n)
+
+		wg.Add(1)
+		go func(partNumber int32, data []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			etag, err := s.uploadPart(ctx, key, uploadID, partNumber, data)
+			if err != nil {
+				cancel(err)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			parts = append(parts, types.CompletedPart{ETag: etag, PartNumber: aws.Int32(partNumber)})
+			uploaded += int64(len(data))
+			if o.progress != nil {
+				o.progress(uploaded, size)
+			}
+		}(partNumber, buf[:n])
+
+		if readErr != nil {
+			if readErr != io.ErrUnexpectedEOF && readErr != io.EOF {
+				cancel(fmt.Errorf("failed to read input: %w", readErr))
+			}
+			break
+		}
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	if total != size {
+		return nil, fmt.Errorf("%w: read %d of %d bytes", errSizeMismatch, total, size)
+	}
+
+	slices.SortFunc(parts, func(a, b types.CompletedPart) int {
+		return int(aws.ToInt32(a.PartNumber) - aws.ToInt32(b.PartNumber))
+	})
+	return parts, nil
+}
+
+// uploadPart uploads one part, retrying with exponential backoff.
+func (s *S3Storage) uploadPart(ctx context.Context, key string, uploadID *string, partNumber int32, data []byte) (*string, error) {
+	backoff := partRetryBackoffMin
+	var err error
+	for attempt := range partAttempts {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+				backoff *= 2
+			case <-ctx.Done():
+				return nil, context.Cause(ctx)
+			}
+		}
+
+		var out *s3.UploadPartOutput
+		out, err = s.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(s.cfg.Bucket),
+			Key:           aws.String(key),
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(partNumber),
+			Body:          bytes.NewReader(data),
+			ContentLength: aws.Int64(int64(len(data))),
+		})
+		if err == nil {
+			return out.ETag, nil
+		}
+		if ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+	}
+	return nil, fmt.Errorf("part %d failed after %d attempts: %w", partNumber, partAttempts, err)
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMultipartS3 is a minimal S3 endpoint that supports multipart uploads.
+type fakeMultipartS3 struct {
+	mu        sync.Mutex
+	parts     map[int][]byte
+	failures  map[int]int // remaining failures per part number
+	completed []byte
+	created   http.Header
+	aborted   bool
+	puts      int
+}
+
+func (f *fakeMultipartS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		f.created = r.Header.Clone()
+		fmt.Fprint(w, `<InitiateMultipartUploadResult><Bucket>test-bucket</Bucket><Key>k</Key><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`)
+	case r.Method == http.MethodPut && q.Has("partNumber"):
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		if f.failures[n] > 0 {
+			f.failures[n]--
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<Error><Code>InvalidRequest</Code><Message>flaky</Message></Error>`)
+			return
+		}
+		f.parts[n] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, n))
+	case r.Method == http.MethodPost && q.Has("uploadId"):
+		var req struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}
+		_ = xml.Unmarshal(body, &req)
+		for i, p := range req.Parts {
+			if p.PartNumber != i+1 || p.ETag != fmt.Sprintf(`"etag-%d"`, i+1) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `<Error><Code>InvalidPartOrder</Code></Error>`)
+				return
+			}
+			f.completed = append(f.completed, f.parts[p.PartNumber]...)
+		}
+		fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>test-bucket</Bucket><Key>k</Key><ETag>"final"</ETag></CompleteMultipartUploadResult>`)
+	case r.Method == http.MethodDelete && q.Has("uploadId"):
+		f.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.puts++
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestS3Storage_Put_Multipart(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(t *testing.T) (*S3Storage, *fakeMultipartS3) {
+		t.Helper()
+
+		fake := &fakeMultipartS3{parts: make(map[int][]byte), failures: make(map[int]int)}
+		srv := httptest.NewServer(fake)
+		t.Cleanup(srv.Close)
+
+		store, err := New(Config{
+			Bucket:    "test-bucket",
+			AccessKey: "test-access-key",
+			SecretKey: "test-secret-key",
+			Endpoint:  srv.URL,
+			PathStyle: true,
+		})
+		require.NoError(t, err)
+		return store, fake
+	}
+
+	data := bytes.Repeat([]byte("0123456789"), 250) // 2500 bytes, 3 parts
+	smallParts := func(o *putOptions) { o.partSize = 1000 }
+
+	t.Run("splits large uploads into ordered parts", func(t *testing.T) {
+		t.Parallel()
+		store, fake := newStore(t)
+
+		var mu sync.Mutex
+		var progress []int64
+		info, err := store.Put(context.Background(), io.NopCloser(bytes.NewReader(data)), int64(len(data)),
+			WithMultipartThreshold(1000),
+			WithCacheControl("no-cache"),
+			WithProgress(func(uploaded, total int64) {
+				mu.Lock()
+				defer mu.Unlock()
+				require.Equal(t, int64(len(data)), total)
+				progress = append(progress, uploaded)
+			}),
+			smallParts,
+		)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(data)), info.Size)
+		require.Equal(t, "text/plain; charset=utf-8", info.ContentType)
+
+		require.Len(t, fake.parts, 3)
+		require.Equal(t, data, fake.completed)
+		require.Equal(t, "no-cache", fake.created.Get("Cache-Control"))
+		require.Zero(t, fake.puts)
+		require.False(t, fake.aborted)
+
+		require.Len(t, progress, 3)
+		require.True(t, sort.SliceIsSorted(progress, func(i, j int) bool { return progress[i] < progress[j] }))
+		require.Equal(t, int64(len(data)), progress[2])
+	})
+
+	t.Run("retries failed parts", func(t *testing.T) {
+		t.Parallel()
+		store, fake := newStore(t)
+		fake.failures[2] = 1
+
+		_, err := store.Put(context.Background(), bytes.NewReader(data), int64(len(data)),
+			WithMultipartThreshold(1000), WithContentType("video/mp4"), smallParts)
+		require.NoError(t, err)
+		require.Equal(t, data, fake.completed)
+		require.False(t, fake.aborted)
+	})
+
+	t.Run("aborts when a part keeps failing", func(t *testing.T) {
+		t.Parallel()
+		store, fake := newStore(t)
+		fake.failures[3] = partAttempts
+
+		_, err := store.Put(context.Background(), bytes.NewReader(data), int64(len(data)),
+			WithMultipartThreshold(1000), WithContentType("video/mp4"), smallParts)
+		require.ErrorIs(t, err, ErrUploadFailed)
+		require.True(t, fake.aborted)
+		require.Nil(t, fake.completed)
+	})
+
+	t.Run("aborts when the reader is short", func(t *testing.T) {
+		t.Parallel()
+		store, fake := newStore(t)
+
+		_, err := store.Put(context.Background(), bytes.NewReader(data), int64(len(data))+500,
+			WithMultipartThreshold(1000), WithContentType("video/mp4"), smallParts)
+		require.ErrorIs(t, err, ErrUploadFailed)
+		require.True(t, fake.aborted)
+	})
+
+	t.Run("small uploads use a single put", func(t *testing.T) {
+		t.Parallel()
+		store, fake := newStore(t)
+
+		var reported int64
+		_, err := store.Put(context.Background(), bytes.NewReader(data), int64(len(data)),
+			WithContentType("text/plain"),
+			WithProgress(func(uploaded, _ int64) { reported = uploaded }),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 1, fake.puts)
+		require.Empty(t, fake.parts)
+		require.Equal(t, int64(len(data)), reported)
+	})
+}
+
+func TestPartSizeFor(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, int64(defaultPartSize), partSizeFor(100<<20))
+	// 1TB needs larger parts to stay within 10,000 parts.
+	size := int64(1 << 40)
+	require.LessOrEqual(t, (size+partSizeFor(size)-1)/partSizeFor(size), int64(maxParts))
+}
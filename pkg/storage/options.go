@@ -1,16 +1,35 @@
 package storage
 
+import "maps"
+
 // Option configures Put operations.
 type Option func(*putOptions)
 
 // putOptions holds configuration for Put operations.
 type putOptions struct {
-	key             string           // Explicit S3 key (prevents auto-generation)
-	prefix          string           // Path component within the key
-	tenant          string           // First path component for isolation
-	contentType     string           // Skip auto-detection with explicit type
-	acl             ACL              // Upload ACL setting
-	validationRules []ValidationRule // Applied before upload
+	key             string            // Explicit S3 key (prevents auto-generation)
+	prefix          string            // Path component within the key
+	tenant          string            // First path component for isolation
+	filename        string            // Original filename passed to the key strategy
+	keyStrategy     KeyStrategy       // Overrides Config.KeyStrategy when set
+	contentType     string            // Skip auto-detection with explicit type
+	disposition     string            // Stored Content-Disposition header
+	cacheControl    string            // Stored Cache-Control header
+	acl             ACL               // Upload ACL setting
+	tags            map[string]string // S3 object tags
+	metadata        map[string]string // S3 user metadata (x-amz-meta-*)
+	sse             *sseOptions       // Overrides Config encryption when set
+	validationRules []ValidationRule  // Applied before upload
+
+	multipartThreshold int64                       // Sizes above this use multipart upload
+	partSize           int64                       // Multipart part size (0 = derived from size)
+	progress           func(uploaded, total int64) // Upload progress callback
+}
+
+// sseOptions holds a per-upload server-side encryption override.
+type sseOptions struct {
+	mode     SSEMode
+	kmsKeyID string
 }
 
 // WithKey sets an explicit storage key, replacing the auto-generated ULID-based key.
@@ -39,6 +58,23 @@ func WithTenant(id string) Option {
 	}
 }
 
+// WithFilename sets the original filename, which key strategies such as
+// FilenameKeyStrategy can use. PutFile and PutBytes set it automatically.
+func WithFilename(name string) Option {
+	return func(o *putOptions) {
+		o.filename = name
+	}
+}
+
+// WithKeyStrategy sets how the key is built for this upload, overriding
+// Config.KeyStrategy. It has no effect with WithKey.
+// Example: WithKeyStrategy(DateKeyStrategy()) results in "{prefix}/2024/02/{ulid}.{ext}"
+func WithKeyStrategy(fn KeyStrategy) Option {
+	return func(o *putOptions) {
+		o.keyStrategy = fn
+	}
+}
+
 // WithContentType overrides the auto-detected content type.
 // Use sparingly; auto-detection from magic bytes is preferred.
 func WithContentType(ct string) Option {
@@ -47,6 +83,50 @@ func WithContentType(ct string) Option {
 	}
 }
 
+// WithContentDisposition stores a Content-Disposition header on the object,
+// served by S3/CDN on every GET. Unlike the URL-time WithDownload, it applies
+// to public URLs too.
+// Example: WithContentDisposition(`attachment; filename="invoice.pdf"`)
+func WithContentDisposition(cd string) Option {
+	return func(o *putOptions) {
+		o.disposition = cd
+	}
+}
+
+// WithCacheControl stores a Cache-Control header on the object, served by
+// S3/CDN on every GET.
+// Example: WithCacheControl("public, max-age=31536000, immutable")
+func WithCacheControl(cc string) Option {
+	return func(o *putOptions) {
+		o.cacheControl = cc
+	}
+}
+
+// WithTags sets S3 object tags, which lifecycle rules can match to expire or
+// transition objects, e.g. {"retention": "30d"}. Repeated calls merge.
+// S3 allows up to 10 tags per object, with keys up to 128 and values up to
+// 256 characters; Put returns ErrInvalidTags otherwise.
+func WithTags(tags map[string]string) Option {
+	return func(o *putOptions) {
+		if o.tags == nil {
+			o.tags = make(map[string]string, len(tags))
+		}
+		maps.Copy(o.tags, tags)
+	}
+}
+
+// WithMetadata stores user metadata on the object, returned by Head in
+// FileInfo.Metadata. S3 lowercases keys and limits all metadata to 2KB.
+// Repeated calls merge.
+func WithMetadata(md map[string]string) Option {
+	return func(o *putOptions) {
+		if o.metadata == nil {
+			o.metadata = make(map[string]string, len(md))
+		}
+		maps.Copy(o.metadata, md)
+	}
+}
+
 // WithACL overrides the default ACL for this upload.
 func WithACL(acl ACL) Option {
 	return func(o *putOptions) {
@@ -54,6 +134,16 @@ func WithACL(acl ACL) Option {
 	}
 }
 
+// WithSSE sets server-side encryption for this upload, overriding
+// Config.ServerSideEncryption. kmsKeyID is used only with SSEKMS; leave it
+// empty for the AWS managed key. WithSSE(SSENone, "") sends no encryption
+// header, leaving the bucket's default encryption in effect.
+func WithSSE(mode SSEMode, kmsKeyID string) Option {
+	return func(o *putOptions) {
+		o.sse = &sseOptions{mode: mode, kmsKeyID: kmsKeyID}
+	}
+}
+
 // WithValidation adds validation rules to be applied before upload.
 // If any rule fails, the upload is aborted and a *FileValidationError is returned.
 func WithValidation(rules ...ValidationRule) Option {
@@ -61,3 +151,20 @@ func WithValidation(rules ...ValidationRule) Option {
 		o.validationRules = append(o.validationRules, rules...)
 	}
 }
+
+// WithMultipartThreshold sets the size above which Put switches from a single
+// PutObject to a multipart upload. Default: DefaultMultipartThreshold (64MB).
+func WithMultipartThreshold(n int64) Option {
+	return func(o *putOptions) {
+		o.multipartThreshold = n
+	}
+}
+
+// WithProgress sets a callback that reports uploaded bytes out of total.
+// Multipart uploads report after each completed part; single-request uploads
+// report once on completion. Calls are never concurrent.
+func WithProgress(fn func(uploaded, total int64)) Option {
+	return func(o *putOptions) {
+		o.progress = fn
+	}
+}
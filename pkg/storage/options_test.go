@@ -38,6 +38,27 @@ func TestPutOptions(t *testing.T) {
 		require.Equal(t, "image/png", opts.contentType)
 	})
 
+	t.Run("WithContentDisposition", func(t *testing.T) {
+		t.Parallel()
+		opts := &putOptions{}
+		WithContentDisposition(`attachment; filename="invoice.pdf"`)(opts)
+		require.Equal(t, `attachment; filename="invoice.pdf"`, opts.disposition)
+	})
+
+	t.Run("WithCacheControl", func(t *testing.T) {
+		t.Parallel()
+		opts := &putOptions{}
+		WithCacheControl("public, max-age=31536000, immutable")(opts)
+		require.Equal(t, "public, max-age=31536000, immutable", opts.cacheControl)
+	})
+
+	t.Run("WithSSE", func(t *testing.T) {
+		t.Parallel()
+		opts := &putOptions{}
+		WithSSE(SSEKMS, "key-123")(opts)
+		require.Equal(t, &sseOptions{mode: SSEKMS, kmsKeyID: "key-123"}, opts.sse)
+	})
+
 	t.Run("WithACL", func(t *testing.T) {
 		t.Parallel()
 		opts := &putOptions{}
@@ -45,6 +66,22 @@ func TestPutOptions(t *testing.T) {
 		require.Equal(t, ACLPublicRead, opts.acl)
 	})
 
+	t.Run("WithTags merges", func(t *testing.T) {
+		t.Parallel()
+		opts := &putOptions{}
+		WithTags(map[string]string{"retention": "30d"})(opts)
+		WithTags(map[string]string{"tenant": "123"})(opts)
+		require.Equal(t, map[string]string{"retention": "30d", "tenant": "123"}, opts.tags)
+	})
+
+	t.Run("WithMetadata merges", func(t *testing.T) {
+		t.Parallel()
+		opts := &putOptions{}
+		WithMetadata(map[string]string{"category": "invoice"})(opts)
+		WithMetadata(map[string]string{"owner": "u1"})(opts)
+		require.Equal(t, map[string]string{"category": "invoice", "owner": "u1"}, opts.metadata)
+	})
+
 	t.Run("WithValidation single rule", func(t *testing.T) {
 		t.Parallel()
 		opts := &putOptions{}
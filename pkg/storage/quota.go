@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// quotaTenantMetadataKey is the user metadata key WithQuota stores the
+// tenant under, so Delete can credit the right tenant. S3 lowercases keys.
+const quotaTenantMetadataKey = "forge-quota-tenant"
+
+// QuotaStore tracks the bytes each tenant stores and the limit they may use.
+type QuotaStore interface {
+	// Usage returns the tenant's used bytes and limit. A limit of 0 or less
+	// means unlimited.
+	Usage(ctx context.Context, tenant string) (used, limit int64, err error)
+
+	// Reserve adds size to the tenant's usage if the new total stays within
+	// its limit, and returns ErrQuotaExceeded otherwise. The check and the
+	// update must be atomic, or concurrent uploads can all pass the check
+	// and overshoot the limit together.
+	Reserve(ctx context.Context, tenant string, size int64) error
+
+	// Release subtracts size from the tenant's usage, never going below zero.
+	Release(ctx context.Context, tenant string, size int64) error
+}
+
+// WithQuota returns a decorator that enforces per-tenant quotas on a Storage.
+//
+// Put reserves the upload size for the tenant set with WithTenant before
+// uploading and returns ErrQuotaExceeded if the projected total would exceed
+// the tenant's limit. The reservation is released if the upload fails, and
+// replacing a file with WithKey releases the old file's size. Uploads without
+// a tenant are not tracked.
+//
+// For tracked uploads the declared size must be exact: Put returns
+// ErrInvalidSize for a negative size or a reader that yields a different
+// number of bytes, so the bytes reserved are the bytes Delete releases.
+//
+// Put records the tenant in the object's metadata, so Delete can release the
+// file's size; deleting a file costs an extra Head request.
+//
+// Example:
+//
+//	store = storage.WithQuota(storage.NewPostgresQuotaStore(pool, 5<<30))(store)
+func WithQuota(quotas QuotaStore) func(Storage) Storage {
+	return func(s Storage) Storage {
+		return &quotaStorage{Storage: s, quotas: quotas}
+	}
+}
+
+// quotaStorage is the Storage returned by WithQuota.
+type quotaStorage struct {
+	Storage
+	quotas QuotaStore
+}
+
+// Put reserves size for the upload's tenant before delegating.
+func (q *quotaStorage) Put(ctx context.Context, r io.Reader, size int64, opts ...Option) (*FileInfo, error) {
+	o := &putOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.tenant == "" {
+		return q.Storage.Put(ctx, r, size, opts...)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("%w: negative size %d", ErrInvalidSize, size)
+	}
+
+	// The file being replaced is credited back only after the new one is
+	// stored, so a failed upload leaves usage untouched.
+	var replaced *FileInfo
+	if o.key != "" {
+		info, err := q.Storage.Head(ctx, o.key)
+		switch {
+		case err == nil && quotaTenant(info) == o.tenant:
+			replaced = info
+		case err != nil && !errors.Is(err, ErrNotFound):
+			return nil, err
+		}
+	}
+
+	if err := q.quotas.Reserve(ctx, o.tenant, size); err != nil {
+		if errors.Is(err, ErrQuotaExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrQuotaFailed, err)
+	}
+
+	opts = append(opts, WithMetadata(map[string]string{
+		quotaTenantMetadataKey: url.PathEscape(o.tenant),
+	}))
+	info, err := q.Storage.Put(ctx, exactSize(r, size), size, opts...)
+	if err != nil {
+		// Release with a fresh context so a canceled upload still frees its reservation.
+		if rerr := q.quotas.Release(context.WithoutCancel(ctx), o.tenant, size); rerr != nil {
+			return nil, errors.Join(err, fmt.Errorf("%w: %v", ErrQuotaFailed, rerr))
+		}
+		return nil, err
+	}
+
+	if replaced != nil {
+		if err := q.quotas.Release(ctx, o.tenant, replaced.Size); err != nil {
+			return info, fmt.Errorf("%w: %v", ErrQuotaFailed, err)
+		}
+	}
+	return info, nil
+}
+
+// Delete removes the file and releases its size from the tenant recorded by Put.
+func (q *quotaStorage) Delete(ctx context.Context, key string) error {
+	info, err := q.Storage.Head(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return q.Storage.Delete(ctx, key)
+		}
+		return err
+	}
+
+	if err := q.Storage.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	tenant := quotaTenant(info)
+	if tenant == "" {
+		return nil
+	}
+	if err := q.quotas.Release(ctx, tenant, info.Size); err != nil {
+		return fmt.Errorf("%w: %v", ErrQuotaFailed, err)
+	}
+	return nil
+}
+
+// GetRange keeps range reads on the wrapped storage efficient.
+func (q *quotaStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	return GetRange(ctx, q.Storage, key, offset, length)
+}
+
+// exactSize wraps r so reading fails with ErrInvalidSize once it yields more
+// or fewer than size bytes. Seeking is kept when r supports it, so storages
+// can still rewind instead of buffering the upload.
+func exactSize(r io.Reader, size int64) io.Reader {
+	er := &exactReader{r: r, size: size}
+	if rs, ok := r.(io.ReadSeeker); ok {
+		return &exactReadSeeker{exactReader: er, seeker: rs}
+	}
+	return er
+}
+
+// exactReader checks the bytes read from r against the declared size.
+type exactReader struct {
+	r    io.Reader
+	size int64
+	pos  int64
+}
+
+func (e *exactReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	e.pos += int64(n)
+	if e.pos > e.size {
+		return n, fmt.Errorf("%w: read more than %d bytes", ErrInvalidSize, e.size)
+	}
+	if errors.Is(err, io.EOF) && e.pos < e.size {
+		return n, fmt.Errorf("%w: read %d of %d bytes", ErrInvalidSize, e.pos, e.size)
+	}
+	return n, err
+}
+
+// exactReadSeeker is an exactReader over an io.ReadSeeker.
+type exactReadSeeker struct {
+	*exactReader
+	seeker io.Seeker
+}
+
+func (e *exactReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos, err := e.seeker.Seek(offset, whence)
+	if err == nil {
+		e.pos = pos
+	}
+	return pos, err
+}
+
+// quotaTenant returns the tenant WithQuota recorded on the file, or "".
+func quotaTenant(info *FileInfo) string {
+	raw := info.Metadata[quotaTenantMetadataKey]
+	if raw == "" {
+		return ""
+	}
+	tenant, err := url.PathUnescape(raw)
+	if err != nil {
+		return ""
+	}
+	return tenant
+}
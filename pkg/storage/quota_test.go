@@ -0,0 +1,264 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memQuotaStore is an in-memory QuotaStore for tests.
+type memQuotaStore struct {
+	used   map[string]int64
+	limits map[string]int64
+	mu     sync.Mutex
+}
+
+func newMemQuotaStore(limits map[string]int64) *memQuotaStore {
+	return &memQuotaStore{used: make(map[string]int64), limits: limits}
+}
+
+func (m *memQuotaStore) Usage(_ context.Context, tenant string) (int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.used[tenant], m.limits[tenant], nil
+}
+
+func (m *memQuotaStore) Reserve(_ context.Context, tenant string, size int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit := m.limits[tenant]; limit > 0 && m.used[tenant]+size > limit {
+		return ErrQuotaExceeded
+	}
+	m.used[tenant] += size
+	return nil
+}
+
+func (m *memQuotaStore) Release(_ context.Context, tenant string, size int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.used[tenant] = max(m.used[tenant]-size, 0)
+	return nil
+}
+
+// memObjects backs a mockStorage with an in-memory object map.
+func memObjects() (*mockStorage, map[string]*FileInfo) {
+	objects := make(map[string]*FileInfo)
+	var mu sync.Mutex
+	s := &mockStorage{
+		putFunc: func(_ context.Context, r io.Reader, size int64, opts ...Option) (*FileInfo, error) {
+			o := &putOptions{}
+			for _, opt := range opts {
+				opt(o)
+			}
+			if _, err := io.Copy(io.Discard, r); err != nil {
+				return nil, err
+			}
+			key := o.key
+			if key == "" {
+				key = o.tenant + "/file"
+			}
+			info := &FileInfo{Key: key, Size: size, Metadata: o.metadata}
+			mu.Lock()
+			objects[key] = info
+			mu.Unlock()
+			return info, nil
+		},
+		headFunc: func(_ context.Context, key string) (*FileInfo, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			info, ok := objects[key]
+			if !ok {
+				return nil, ErrNotFound
+			}
+			return info, nil
+		},
+		deleteFunc: func(_ context.Context, key string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(objects, key)
+			return nil
+		},
+	}
+	return s, objects
+}
+
+func TestWithQuota(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("reserves usage for the tenant", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+		inner, _ := memObjects()
+		s := WithQuota(quotas)(inner)
+
+		info, err := s.Put(ctx, strings.NewReader("hello"), 5, WithTenant("acme"))
+		require.NoError(t, err)
+		require.Equal(t, "acme", quotaTenant(info))
+
+		used, _, err := quotas.Usage(ctx, "acme")
+		require.NoError(t, err)
+		require.Equal(t, int64(5), used)
+	})
+
+	t.Run("rejects uploads over the limit", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 4})
+		called := false
+		inner := &mockStorage{putFunc: func(context.Context, io.Reader, int64, ...Option) (*FileInfo, error) {
+			called = true
+			return &FileInfo{}, nil
+		}}
+		s := WithQuota(quotas)(inner)
+
+		_, err := s.Put(ctx, strings.NewReader("hello"), 5, WithTenant("acme"))
+		require.ErrorIs(t, err, ErrQuotaExceeded)
+		require.False(t, called)
+	})
+
+	t.Run("releases reservation when upload fails", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+		inner := &mockStorage{putFunc: func(context.Context, io.Reader, int64, ...Option) (*FileInfo, error) {
+			return nil, ErrUploadFailed
+		}}
+		s := WithQuota(quotas)(inner)
+
+		_, err := s.Put(ctx, strings.NewReader("hello"), 5, WithTenant("acme"))
+		require.ErrorIs(t, err, ErrUploadFailed)
+
+		used, _, _ := quotas.Usage(ctx, "acme")
+		require.Zero(t, used)
+	})
+
+	t.Run("rejects sizes that do not match the data", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]struct {
+			data string
+			size int64
+		}{
+			"negative":  {data: "hello", size: -5},
+			"too small": {data: "hello", size: 2},
+			"too large": {data: "hello", size: 8},
+		}
+		for name, tc := range cases {
+			quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+			inner, objects := memObjects()
+			s := WithQuota(quotas)(inner)
+
+			_, err := s.Put(ctx, strings.NewReader(tc.data), tc.size, WithTenant("acme"))
+			require.ErrorIs(t, err, ErrInvalidSize, name)
+			require.Empty(t, objects, name)
+
+			used, _, _ := quotas.Usage(ctx, "acme")
+			require.Zero(t, used, name)
+		}
+	})
+
+	t.Run("reserves and releases the same bytes", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+		s := WithQuota(quotas)(NewMemory())
+
+		info, err := s.Put(ctx, strings.NewReader("hello"), 5, WithTenant("acme"))
+		require.NoError(t, err)
+		used, _, _ := quotas.Usage(ctx, "acme")
+		require.Equal(t, int64(5), used)
+
+		require.NoError(t, s.Delete(ctx, info.Key))
+		used, _, _ = quotas.Usage(ctx, "acme")
+		require.Zero(t, used)
+	})
+
+	t.Run("does not track uploads without tenant", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(nil)
+		inner, _ := memObjects()
+		s := WithQuota(quotas)(inner)
+
+		info, err := s.Put(ctx, strings.NewReader("hello"), 5)
+		require.NoError(t, err)
+		require.Empty(t, quotaTenant(info))
+		require.Empty(t, quotas.used)
+	})
+
+	t.Run("delete releases usage", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+		inner, objects := memObjects()
+		s := WithQuota(quotas)(inner)
+
+		info, err := s.Put(ctx, strings.NewReader("hello"), 5, WithTenant("acme"))
+		require.NoError(t, err)
+		require.NoError(t, s.Delete(ctx, info.Key))
+		require.Empty(t, objects)
+
+		used, _, _ := quotas.Usage(ctx, "acme")
+		require.Zero(t, used)
+	})
+
+	t.Run("delete of missing file succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		inner, _ := memObjects()
+		s := WithQuota(newMemQuotaStore(nil))(inner)
+
+		require.NoError(t, s.Delete(ctx, "missing"))
+	})
+
+	t.Run("replacing a file releases the old size", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+		inner, _ := memObjects()
+		s := WithQuota(quotas)(inner)
+
+		_, err := s.Put(ctx, strings.NewReader("hello"), 5, WithTenant("acme"), WithKey("acme/avatar"))
+		require.NoError(t, err)
+		_, err = s.Put(ctx, strings.NewReader("hi"), 2, WithTenant("acme"), WithKey("acme/avatar"))
+		require.NoError(t, err)
+
+		used, _, _ := quotas.Usage(ctx, "acme")
+		require.Equal(t, int64(2), used)
+	})
+
+	t.Run("concurrent uploads stay within limit", func(t *testing.T) {
+		t.Parallel()
+
+		quotas := newMemQuotaStore(map[string]int64{"acme": 10})
+		inner, _ := memObjects()
+		s := WithQuota(quotas)(inner)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		exceeded := 0
+		for range 5 {
+			wg.Go(func() {
+				_, err := s.Put(ctx, strings.NewReader("abc"), 3, WithTenant("acme"))
+				if errors.Is(err, ErrQuotaExceeded) {
+					mu.Lock()
+					exceeded++
+					mu.Unlock()
+				}
+			})
+		}
+		wg.Wait()
+
+		used, _, _ := quotas.Usage(ctx, "acme")
+		require.Equal(t, int64(9), used)
+		require.Equal(t, 2, exceeded)
+	})
+}
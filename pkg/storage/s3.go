@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net/url"
 	"regexp"
 	"strings"
@@ -13,8 +14,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
-
-	"github.com/dmitrymomot/forge/pkg/id"
 )
 
 // S3Storage implements Storage using S3-compatible object storage.
@@ -60,17 +59,26 @@ func New(cfg Config) (*S3Storage, error) {
 }
 
 // Put uploads data from a reader to S3.
+// Uploads larger than the multipart threshold (see WithMultipartThreshold)
+// are streamed as a multipart upload with parts sent concurrently and
+// retried individually; smaller ones use a single PutObject.
 func (s *S3Storage) Put(ctx context.Context, r io.Reader, size int64, opts ...Option) (*FileInfo, error) {
 	o := &putOptions{
-		acl: s.cfg.DefaultACL,
+		acl:                s.cfg.DefaultACL,
+		multipartThreshold: DefaultMultipartThreshold,
 	}
 	for _, opt := range opts {
 		opt(o)
 	}
+	multipart := size > o.multipartThreshold
 
 	var contentType string
-	var body io.ReadSeeker
-	if o.contentType != "" {
+	var body io.Reader
+	switch {
+	case multipart:
+		// Stream large uploads instead of buffering them for detection
+		contentType, body = sniffMIME(r, o.contentType)
+	case o.contentType != "":
 		contentType = o.contentType
 		if rs, ok := r.(io.ReadSeeker); ok {
 			body = rs
@@ -81,10 +89,14 @@ func (s *S3Storage) Put(ctx context.Context, r io.Reader, size int64, opts ...Op
 			}
 			body = bytes.NewReader(data)
 		}
-	} else {
+	default:
 		contentType, body = detectMIMEWithReader(r)
 	}
 
+	if err := validateTags(o.tags); err != nil {
+		return nil, err
+	}
+
 	// Run validation if rules present.
 	if len(o.validationRules) > 0 {
 		if err := ValidateReader(size, contentType, o.validationRules...); err != nil {
@@ -94,36 +106,112 @@ func (s *S3Storage) Put(ctx context.Context, r io.Reader, size int64, opts ...Op
 
 	key := o.key
 	if key == "" {
-		key = s.buildKey(o.tenant, o.prefix, contentType)
+		key = s.buildKey(o, contentType)
 	}
 
-	var acl types.ObjectCannedACL
-	if o.acl == ACLPublicRead {
-		acl = types.ObjectCannedACLPublicRead
+	sse := s.defaultSSE()
+	if o.sse != nil {
+		if !o.sse.mode.valid() {
+			return nil, ErrInvalidConfig
+		}
+		sse = *o.sse
+	}
+
+	obj := objectHeaders{
+		contentType:  contentType,
+		disposition:  o.disposition,
+		cacheControl: o.cacheControl,
+		acl:          o.acl,
+		sse:          sse,
+		tags:         o.tags,
+		metadata:     o.metadata,
+	}
+
+	var err error
+	if multipart {
+		err = s.putMultipart(ctx, key, body, size, obj, o)
 	} else {
-		acl = types.ObjectCannedACLPrivate
+		err = s.putSingle(ctx, key, body.(io.ReadSeeker), size, obj)
+		if err == nil && o.progress != nil {
+			o.progress(size, size)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileInfo{
+		Key:                  key,
+		Size:                 size,
+		ContentType:          contentType,
+		ContentDisposition:   o.disposition,
+		CacheControl:         o.cacheControl,
+		ACL:                  o.acl,
+		ServerSideEncryption: sse.mode,
+		Metadata:             maps.Clone(o.metadata),
+	}, nil
+}
+
+// objectHeaders holds the object metadata sent when an upload starts.
+type objectHeaders struct {
+	contentType  string
+	disposition  string
+	cacheControl string
+	acl          ACL
+	sse          sseOptions
+	tags         map[string]string
+	metadata     map[string]string
+}
+
+// tagging returns the tags encoded for the x-amz-tagging header, or nil.
+func (h objectHeaders) tagging() *string {
+	if len(h.tags) == 0 {
+		return nil
 	}
+	return aws.String(encodeTags(h.tags))
+}
+
+func (h objectHeaders) cannedACL() types.ObjectCannedACL {
+	if h.acl == ACLPublicRead {
+		return types.ObjectCannedACLPublicRead
+	}
+	return types.ObjectCannedACLPrivate
+}
 
+// putSingle uploads body with one PutObject request.
+func (s *S3Storage) putSingle(ctx context.Context, key string, body io.ReadSeeker, size int64, h objectHeaders) error {
 	input := &s3.PutObjectInput{
 		Bucket:        aws.String(s.cfg.Bucket),
 		Key:           aws.String(key),
 		Body:          body,
 		ContentLength: aws.Int64(size),
-		ContentType:   aws.String(contentType),
-		ACL:           acl,
+		ContentType:   aws.String(h.contentType),
+		ACL:           h.cannedACL(),
+	}
+	if h.disposition != "" {
+		input.ContentDisposition = aws.String(h.disposition)
+	}
+	if h.cacheControl != "" {
+		input.CacheControl = aws.String(h.cacheControl)
 	}
+	if h.sse.mode != SSENone {
+		input.ServerSideEncryption = types.ServerSideEncryption(h.sse.mode)
+		if h.sse.mode == SSEKMS && h.sse.kmsKeyID != "" {
+			input.SSEKMSKeyId = aws.String(h.sse.kmsKeyID)
+		}
+	}
+	input.Tagging = h.tagging()
+	input.Metadata = h.metadata
 
-	_, err := s.client.PutObject(ctx, input)
-	if err != nil {
-		return nil, wrapS3Error(err, ErrUploadFailed)
+	if _, err := s.client.PutObject(ctx, input); err != nil {
+		return wrapS3Error(err, ErrUploadFailed)
 	}
+	return nil
+}
 
-	return &FileInfo{
-		Key:         key,
-		Size:        size,
-		ContentType: contentType,
-		ACL:         o.acl,
-	}, nil
+// defaultSSE returns the encryption configured for all uploads.
+func (s *S3Storage) defaultSSE() sseOptions {
+	return sseOptions{mode: s.cfg.ServerSideEncryption, kmsKeyID: s.cfg.KMSKeyID}
 }
 
 // Get retrieves a file from S3.
@@ -141,6 +229,23 @@ func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error)
 	return output.Body, nil
 }
 
+// GetRange retrieves length bytes of a file starting at offset, using an
+// HTTP Range request so only that part is transferred.
+func (s *S3Storage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	}
+
+	output, err := s.client.GetObject(ctx, input)
+	if err != nil {
+		return nil, wrapS3Error(err, ErrNotFound)
+	}
+
+	return output.Body, nil
+}
+
 // Delete removes a file from S3.
 func (s *S3Storage) Delete(ctx context.Context, key string) error {
 	input := &s3.DeleteObjectInput{
@@ -177,27 +282,10 @@ func (s *S3Storage) URL(ctx context.Context, key string, opts ...URLOption) (str
 	return s.signedURL(ctx, key, o)
 }
 
-// buildKey constructs a storage key from tenant, prefix, and content type.
-// Format: {tenant}/{prefix}/{ulid}.{ext}
-func (s *S3Storage) buildKey(tenant, prefix, contentType string) string {
-	var parts []string
-
-	if tenant != "" {
-		parts = append(parts, sanitizePathSegment(tenant))
-	}
-	if prefix != "" {
-		parts = append(parts, sanitizePathSegment(prefix))
-	}
-
-	ext := ExtFromMIME(contentType)
-	if ext == "" {
-		ext = ".bin"
-	}
-	filename := id.NewULID() + ext
-
-	parts = append(parts, filename)
-
-	return strings.Join(parts, "/")
+// buildKey constructs a storage key with the upload's key strategy, falling
+// back to Config.KeyStrategy and then DefaultKeyStrategy.
+func (s *S3Storage) buildKey(o *putOptions, contentType string) string {
+	return newKey(o, contentType, s.cfg.KeyStrategy)
 }
 
 // publicURL generates a public URL for the file.
@@ -262,8 +350,9 @@ func sanitizePathSegment(segment string) string {
 	return url.PathEscape(segment)
 }
 
-// HeadObject checks if a file exists and returns its metadata without downloading it.
-func (s *S3Storage) HeadObject(ctx context.Context, key string) (*FileInfo, error) {
+// Head returns a file's metadata using S3 HeadObject, without downloading it.
+// Returns ErrNotFound for missing keys and ErrHeadFailed for other failures.
+func (s *S3Storage) Head(ctx context.Context, key string) (*FileInfo, error) {
 	input := &s3.HeadObjectInput{
 		Bucket: aws.String(s.cfg.Bucket),
 		Key:    aws.String(key),
@@ -271,7 +360,7 @@ func (s *S3Storage) HeadObject(ctx context.Context, key string) (*FileInfo, erro
 
 	output, err := s.client.HeadObject(ctx, input)
 	if err != nil {
-		return nil, wrapS3Error(err, ErrNotFound)
+		return nil, wrapS3Error(err, ErrHeadFailed)
 	}
 
 	contentType := ""
@@ -285,21 +374,92 @@ func (s *S3Storage) HeadObject(ctx context.Context, key string) (*FileInfo, erro
 	}
 
 	return &FileInfo{
-		Key:         key,
-		Size:        size,
-		ContentType: contentType,
-		ACL:         s.cfg.DefaultACL,
+		Key:                  key,
+		Size:                 size,
+		ContentType:          contentType,
+		ContentDisposition:   aws.ToString(output.ContentDisposition),
+		CacheControl:         aws.ToString(output.CacheControl),
+		ACL:                  s.cfg.DefaultACL,
+		ServerSideEncryption: SSEMode(output.ServerSideEncryption),
+		Metadata:             output.Metadata,
 	}, nil
 }
 
+// GetTags returns the object's tags using S3 GetObjectTagging.
+// Returns ErrNotFound for missing keys and ErrTaggingFailed for other failures.
+func (s *S3Storage) GetTags(ctx context.Context, key string) (map[string]string, error) {
+	input := &s3.GetObjectTaggingInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(key),
+	}
+
+	output, err := s.client.GetObjectTagging(ctx, input)
+	if err != nil {
+		return nil, wrapS3Error(err, ErrTaggingFailed)
+	}
+
+	tags := make(map[string]string, len(output.TagSet))
+	for _, t := range output.TagSet {
+		tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return tags, nil
+}
+
+// SetTags replaces the object's tags using S3 PutObjectTagging, or
+// DeleteObjectTagging when tags is empty.
+// Returns ErrInvalidTags if tags exceed S3 limits, ErrNotFound for missing
+// keys, and ErrTaggingFailed for other failures.
+func (s *S3Storage) SetTags(ctx context.Context, key string, tags map[string]string) error {
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+
+	var err error
+	if len(tags) == 0 {
+		_, err = s.client.DeleteObjectTagging(ctx, &s3.DeleteObjectTaggingInput{
+			Bucket: aws.String(s.cfg.Bucket),
+			Key:    aws.String(key),
+		})
+	} else {
+		tagSet := make([]types.Tag, 0, len(tags))
+		for k, v := range tags {
+			tagSet = append(tagSet, types.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		_, err = s.client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+			Bucket:  aws.String(s.cfg.Bucket),
+			Key:     aws.String(key),
+			Tagging: &types.Tagging{TagSet: tagSet},
+		})
+	}
+	if err != nil {
+		return wrapS3Error(err, ErrTaggingFailed)
+	}
+	return nil
+}
+
+// HeadObject checks if a file exists and returns its metadata without downloading it.
+//
+// Deprecated: Use Head, which is part of the Storage interface.
+func (s *S3Storage) HeadObject(ctx context.Context, key string) (*FileInfo, error) {
+	return s.Head(ctx, key)
+}
+
 // Copy copies a file from one key to another within the same bucket.
-// S3 CopyObject preserves ACL by default.
+// S3 CopyObject preserves ACL by default. The copy is encrypted with the
+// configured Config.ServerSideEncryption, since S3 does not carry over the
+// source object's encryption settings.
 func (s *S3Storage) Copy(ctx context.Context, srcKey, dstKey string) error {
 	input := &s3.CopyObjectInput{
 		Bucket:     aws.String(s.cfg.Bucket),
 		Key:        aws.String(dstKey),
 		CopySource: aws.String(s.cfg.Bucket + "/" + srcKey),
 	}
+	if sse := s.defaultSSE(); sse.mode != SSENone {
+		input.ServerSideEncryption = types.ServerSideEncryption(sse.mode)
+		if sse.mode == SSEKMS && sse.kmsKeyID != "" {
+			input.SSEKMSKeyId = aws.String(sse.kmsKeyID)
+		}
+	}
 
 	_, err := s.client.CopyObject(ctx, input)
 	if err != nil {
@@ -309,5 +469,8 @@ func (s *S3Storage) Copy(ctx context.Context, srcKey, dstKey string) error {
 	return nil
 }
 
-// Ensure S3Storage implements Storage.
-var _ Storage = (*S3Storage)(nil)
+// Ensure S3Storage implements Storage and RangeGetter.
+var (
+	_ Storage     = (*S3Storage)(nil)
+	_ RangeGetter = (*S3Storage)(nil)
+)
@@ -344,6 +344,84 @@ func TestS3Integration_URL(t *testing.T) {
 	})
 }
 
+func TestS3Integration_Head(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	t.Run("get metadata for existing file", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte("content for head request")
+		info, err := s.Put(ctx, bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = s.Delete(ctx, info.Key)
+		})
+
+		headInfo, err := s.Head(ctx, info.Key)
+		require.NoError(t, err)
+		require.Equal(t, info.Key, headInfo.Key)
+		require.Equal(t, info.Size, headInfo.Size)
+		require.Equal(t, info.ContentType, headInfo.ContentType)
+		// Head returns DefaultACL (ACLPrivate by default)
+		require.Equal(t, storage.ACLPrivate, headInfo.ACL)
+	})
+
+	t.Run("returns stored disposition and cache control", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte("%PDF-1.4 stored headers")
+		info, err := s.Put(ctx, bytes.NewReader(data), int64(len(data)),
+			storage.WithContentDisposition(`attachment; filename="invoice.pdf"`),
+			storage.WithCacheControl("public, max-age=3600"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, `attachment; filename="invoice.pdf"`, info.ContentDisposition)
+		require.Equal(t, "public, max-age=3600", info.CacheControl)
+
+		t.Cleanup(func() {
+			_ = s.Delete(ctx, info.Key)
+		})
+
+		headInfo, err := s.Head(ctx, info.Key)
+		require.NoError(t, err)
+		require.Equal(t, info.ContentDisposition, headInfo.ContentDisposition)
+		require.Equal(t, info.CacheControl, headInfo.CacheControl)
+	})
+
+	t.Run("head non-existent file returns error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := s.Head(ctx, "non-existent-key-head")
+		require.Error(t, err)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("exists reports presence", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte("content for exists check")
+		info, err := s.Put(ctx, bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = s.Delete(ctx, info.Key)
+		})
+
+		ok, err := storage.Exists(ctx, s, info.Key)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = storage.Exists(ctx, s, "non-existent-key-exists")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
+// TestS3Integration_HeadObject covers the deprecated HeadObject wrapper.
 func TestS3Integration_HeadObject(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,12 @@
 package storage
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -41,6 +47,19 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, store)
 	})
 
+	t.Run("invalid encryption mode", func(t *testing.T) {
+		t.Parallel()
+		cfg := Config{
+			Bucket:               "test-bucket",
+			AccessKey:            "test-access-key",
+			SecretKey:            "test-secret-key",
+			ServerSideEncryption: "aws:unknown",
+		}
+
+		_, err := New(cfg)
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+
 	t.Run("invalid config", func(t *testing.T) {
 		t.Parallel()
 		cfg := Config{} // Missing required fields.
@@ -93,38 +112,56 @@ func TestS3Storage_buildKey(t *testing.T) {
 
 	t.Run("no tenant no prefix", func(t *testing.T) {
 		t.Parallel()
-		key := store.buildKey("", "", "image/jpeg")
+		key := store.buildKey(&putOptions{}, "image/jpeg")
 		// Should be just {ulid}.jpg
 		require.Regexp(t, `^[0-9A-Z]{26}\.jpg$`, key)
 	})
 
 	t.Run("with prefix", func(t *testing.T) {
 		t.Parallel()
-		key := store.buildKey("", "avatars", "image/png")
+		key := store.buildKey(&putOptions{prefix: "avatars"}, "image/png")
 		// Should be avatars/{ulid}.png
 		require.Regexp(t, `^avatars/[0-9A-Z]{26}\.png$`, key)
 	})
 
 	t.Run("with tenant", func(t *testing.T) {
 		t.Parallel()
-		key := store.buildKey("tenant123", "", "application/pdf")
+		key := store.buildKey(&putOptions{tenant: "tenant123"}, "application/pdf")
 		// Should be tenant123/{ulid}.pdf
 		require.Regexp(t, `^tenant123/[0-9A-Z]{26}\.pdf$`, key)
 	})
 
 	t.Run("with tenant and prefix", func(t *testing.T) {
 		t.Parallel()
-		key := store.buildKey("tenant123", "documents", "application/pdf")
+		key := store.buildKey(&putOptions{tenant: "tenant123", prefix: "documents"}, "application/pdf")
 		// Should be tenant123/documents/{ulid}.pdf
 		require.Regexp(t, `^tenant123/documents/[0-9A-Z]{26}\.pdf$`, key)
 	})
 
 	t.Run("unknown mime type", func(t *testing.T) {
 		t.Parallel()
-		key := store.buildKey("", "", "application/unknown")
+		key := store.buildKey(&putOptions{}, "application/unknown")
 		// Should use .bin extension
 		require.Regexp(t, `^[0-9A-Z]{26}\.bin$`, key)
 	})
+
+	t.Run("config key strategy", func(t *testing.T) {
+		t.Parallel()
+		s := &S3Storage{cfg: Config{KeyStrategy: DateKeyStrategy()}}
+		key := s.buildKey(&putOptions{tenant: "tenant123"}, "image/png")
+		require.Regexp(t, `^tenant123/\d{4}/\d{2}/[0-9A-Z]{26}\.png$`, key)
+	})
+
+	t.Run("upload key strategy overrides config", func(t *testing.T) {
+		t.Parallel()
+		s := &S3Storage{cfg: Config{KeyStrategy: DateKeyStrategy()}}
+		key := s.buildKey(&putOptions{
+			prefix:      "docs",
+			filename:    "Q3 Report.pdf",
+			keyStrategy: FilenameKeyStrategy(),
+		}, "application/pdf")
+		require.Regexp(t, `^docs/[0-9A-Z]{26}/q3-report\.pdf$`, key)
+	})
 }
 
 func TestS3Storage_publicURL(t *testing.T) {
@@ -220,3 +257,232 @@ func TestS3Storage_URL_Defaults(t *testing.T) {
 		require.Equal(t, ACLPrivate, store.cfg.DefaultACL)
 	})
 }
+
+func TestS3Storage_Put_ServerSideEncryption(t *testing.T) {
+	t.Parallel()
+
+	// newStore returns a store backed by a fake S3 endpoint that records the
+	// headers of each PUT request.
+	newStore := func(t *testing.T, cfg Config) (*S3Storage, func() http.Header) {
+		t.Helper()
+
+		var mu sync.Mutex
+		var got http.Header
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			got = r.Header.Clone()
+			mu.Unlock()
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+
+		cfg.Bucket = "test-bucket"
+		cfg.AccessKey = "test-access-key"
+		cfg.SecretKey = "test-secret-key"
+		cfg.Endpoint = srv.URL
+		cfg.PathStyle = true
+		store, err := New(cfg)
+		require.NoError(t, err)
+
+		return store, func() http.Header {
+			mu.Lock()
+			defer mu.Unlock()
+			return got
+		}
+	}
+
+	put := func(t *testing.T, store *S3Storage, opts ...Option) *FileInfo {
+		t.Helper()
+		opts = append(opts, WithContentType("text/plain"))
+		info, err := store.Put(context.Background(), strings.NewReader("hello"), 5, opts...)
+		require.NoError(t, err)
+		return info
+	}
+
+	t.Run("no encryption by default", func(t *testing.T) {
+		t.Parallel()
+		store, headers := newStore(t, Config{})
+
+		info := put(t, store)
+		require.Empty(t, headers().Get("X-Amz-Server-Side-Encryption"))
+		require.Equal(t, SSENone, info.ServerSideEncryption)
+	})
+
+	t.Run("config default SSE-KMS", func(t *testing.T) {
+		t.Parallel()
+		store, headers := newStore(t, Config{ServerSideEncryption: SSEKMS, KMSKeyID: "arn:aws:kms:key/default"})
+
+		info := put(t, store)
+		require.Equal(t, "aws:kms", headers().Get("X-Amz-Server-Side-Encryption"))
+		require.Equal(t, "arn:aws:kms:key/default", headers().Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+		require.Equal(t, SSEKMS, info.ServerSideEncryption)
+	})
+
+	t.Run("per-upload override", func(t *testing.T) {
+		t.Parallel()
+		store, headers := newStore(t, Config{ServerSideEncryption: SSEKMS, KMSKeyID: "arn:aws:kms:key/default"})
+
+		info := put(t, store, WithSSE(SSES3, ""))
+		require.Equal(t, "AES256", headers().Get("X-Amz-Server-Side-Encryption"))
+		require.Empty(t, headers().Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+		require.Equal(t, SSES3, info.ServerSideEncryption)
+	})
+
+	t.Run("rejects unknown mode", func(t *testing.T) {
+		t.Parallel()
+		store, _ := newStore(t, Config{})
+
+		_, err := store.Put(context.Background(), strings.NewReader("hello"), 5, WithSSE("aws:unknown", ""))
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestS3Storage_TagsAndMetadata(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		method string
+		query  string
+		header http.Header
+		body   string
+	}
+
+	// newStore returns a store backed by a fake S3 endpoint that records
+	// every request and answers with respond.
+	newStore := func(t *testing.T, respond func(w http.ResponseWriter, r *http.Request)) (*S3Storage, func() []request) {
+		t.Helper()
+
+		var mu sync.Mutex
+		var got []request
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			got = append(got, request{method: r.Method, query: r.URL.RawQuery, header: r.Header.Clone(), body: string(body)})
+			mu.Unlock()
+			if respond != nil {
+				respond(w, r)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+
+		store, err := New(Config{
+			Bucket:    "test-bucket",
+			AccessKey: "test-access-key",
+			SecretKey: "test-secret-key",
+			Endpoint:  srv.URL,
+			PathStyle: true,
+		})
+		require.NoError(t, err)
+
+		return store, func() []request {
+			mu.Lock()
+			defer mu.Unlock()
+			return got
+		}
+	}
+
+	t.Run("Put sends tags and metadata", func(t *testing.T) {
+		t.Parallel()
+		store, requests := newStore(t, nil)
+
+		info, err := store.Put(context.Background(), strings.NewReader("hello"), 5,
+			WithContentType("text/plain"),
+			WithTags(map[string]string{"retention": "30d", "category": "invoice"}),
+			WithMetadata(map[string]string{"owner": "user-1"}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"owner": "user-1"}, info.Metadata)
+
+		h := requests()[0].header
+		require.Equal(t, "category=invoice&retention=30d", h.Get("X-Amz-Tagging"))
+		require.Equal(t, "user-1", h.Get("X-Amz-Meta-Owner"))
+	})
+
+	t.Run("Put rejects invalid tags before uploading", func(t *testing.T) {
+		t.Parallel()
+		store, requests := newStore(t, nil)
+
+		tags := make(map[string]string, 11)
+		for i := range 11 {
+			tags[string(rune('a'+i))] = "v"
+		}
+		_, err := store.Put(context.Background(), strings.NewReader("hello"), 5, WithTags(tags))
+		require.ErrorIs(t, err, ErrInvalidTags)
+
+		_, err = store.Put(context.Background(), strings.NewReader("hello"), 5,
+			WithTags(map[string]string{"k": strings.Repeat("v", 257)}))
+		require.ErrorIs(t, err, ErrInvalidTags)
+		require.Empty(t, requests())
+	})
+
+	t.Run("Head returns metadata", func(t *testing.T) {
+		t.Parallel()
+		store, _ := newStore(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-Amz-Meta-Owner", "user-1")
+			w.Header().Set("Content-Length", "5")
+			w.WriteHeader(http.StatusOK)
+		})
+
+		info, err := store.Head(context.Background(), "docs/a.txt")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"owner": "user-1"}, info.Metadata)
+	})
+
+	t.Run("GetTags parses tag set", func(t *testing.T) {
+		t.Parallel()
+		store, requests := newStore(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<Tagging><TagSet>
+<Tag><Key>retention</Key><Value>30d</Value></Tag>
+<Tag><Key>tenant</Key><Value>123</Value></Tag>
+</TagSet></Tagging>`)
+		})
+
+		tags, err := store.GetTags(context.Background(), "docs/a.txt")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"retention": "30d", "tenant": "123"}, tags)
+		require.Equal(t, http.MethodGet, requests()[0].method)
+		require.Contains(t, requests()[0].query, "tagging")
+	})
+
+	t.Run("GetTags maps missing key", func(t *testing.T) {
+		t.Parallel()
+		store, _ := newStore(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>`)
+		})
+
+		_, err := store.GetTags(context.Background(), "missing")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("SetTags puts tag set", func(t *testing.T) {
+		t.Parallel()
+		store, requests := newStore(t, nil)
+
+		require.NoError(t, store.SetTags(context.Background(), "docs/a.txt", map[string]string{"retention": "7d"}))
+
+		req := requests()[0]
+		require.Equal(t, http.MethodPut, req.method)
+		require.Contains(t, req.query, "tagging")
+		require.Contains(t, req.body, "<Key>retention</Key><Value>7d</Value>")
+	})
+
+	t.Run("SetTags with no tags deletes them", func(t *testing.T) {
+		t.Parallel()
+		store, requests := newStore(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		require.NoError(t, store.SetTags(context.Background(), "docs/a.txt", nil))
+
+		req := requests()[0]
+		require.Equal(t, http.MethodDelete, req.method)
+		require.Contains(t, req.query, "tagging")
+	})
+}
@@ -19,12 +19,32 @@ type Storage interface {
 	// Delete removes a file from storage.
 	Delete(ctx context.Context, key string) error
 
+	// Head returns a file's metadata without downloading its contents.
+	// Returns ErrNotFound if the key does not exist.
+	Head(ctx context.Context, key string) (*FileInfo, error)
+
+	// GetTags returns the object's tags.
+	// Returns ErrNotFound if the key does not exist.
+	GetTags(ctx context.Context, key string) (map[string]string, error)
+
+	// SetTags replaces all of the object's tags. An empty map removes them.
+	// Returns ErrNotFound if the key does not exist.
+	SetTags(ctx context.Context, key string, tags map[string]string) error
+
 	// URL generates a URL for accessing the file.
 	// For private files, returns a signed URL. For public files, returns the public URL.
 	// Use URLOptions to customize expiry, download disposition, or force signed/public.
 	URL(ctx context.Context, key string, opts ...URLOption) (string, error)
 }
 
+// RangeGetter is implemented by storages that can read part of a file
+// without downloading the rest. GetRange uses it when available.
+type RangeGetter interface {
+	// GetRange returns length bytes of the file starting at offset.
+	// The caller is responsible for closing the returned reader.
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+}
+
 // Config holds S3-compatible storage configuration.
 type Config struct {
 	Bucket string
@@ -44,6 +64,18 @@ type Config struct {
 
 	DefaultACL ACL
 
+	// ServerSideEncryption is the default encryption at rest for uploads
+	// (optional). WithSSE overrides it per upload.
+	ServerSideEncryption SSEMode
+
+	// KMSKeyID is the KMS key used with SSEKMS (optional).
+	// If empty, S3 uses the account's AWS managed key.
+	KMSKeyID string
+
+	// KeyStrategy builds keys for uploads without WithKey (optional).
+	// WithKeyStrategy overrides it per upload. Default: DefaultKeyStrategy.
+	KeyStrategy KeyStrategy
+
 	// PathStyle enables path-style URLs (required for MinIO).
 	PathStyle bool
 
@@ -52,10 +84,14 @@ type Config struct {
 
 // FileInfo contains metadata about an uploaded file.
 type FileInfo struct {
-	Key         string
-	ContentType string
-	ACL         ACL
-	Size        int64
+	Key                  string
+	ContentType          string
+	ContentDisposition   string
+	CacheControl         string
+	ACL                  ACL
+	ServerSideEncryption SSEMode
+	Metadata             map[string]string // Set with WithMetadata; S3 returns keys in lowercase
+	Size                 int64
 }
 
 // ACL represents access control levels for stored files.
@@ -69,11 +105,32 @@ const (
 	ACLPublicRead ACL = "public-read"
 )
 
+// SSEMode selects server-side encryption at rest for stored objects.
+// Values match the x-amz-server-side-encryption header.
+type SSEMode string
+
+const (
+	// SSENone sends no encryption header; the bucket's default encryption applies.
+	SSENone SSEMode = ""
+
+	// SSES3 encrypts with S3-managed keys.
+	SSES3 SSEMode = "AES256"
+
+	// SSEKMS encrypts with an AWS KMS key. The key policy must allow the
+	// storage credentials to use the key, or uploads fail with access denied.
+	SSEKMS SSEMode = "aws:kms"
+)
+
+func (m SSEMode) valid() bool {
+	return m == SSENone || m == SSES3 || m == SSEKMS
+}
+
 // Default configuration values.
 const (
-	DefaultRegion          = "us-east-1"
-	DefaultMaxDownloadSize = 50 << 20 // 50MB to prevent abuse
-	DefaultSignedURLExpiry = 15 * 60  // 15 minutes in seconds
+	DefaultRegion             = "us-east-1"
+	DefaultMaxDownloadSize    = 50 << 20 // 50MB to prevent abuse
+	DefaultSignedURLExpiry    = 15 * 60  // 15 minutes in seconds
+	DefaultMultipartThreshold = 64 << 20 // 64MB; larger uploads use multipart
 )
 
 func (c *Config) applyDefaults() {
@@ -98,5 +155,8 @@ func (c *Config) validate() error {
 	if c.SecretKey == "" {
 		return ErrInvalidConfig
 	}
+	if !c.ServerSideEncryption.valid() {
+		return ErrInvalidConfig
+	}
 	return nil
 }
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+)
+
+// S3 object tag limits.
+const (
+	maxTags           = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// validateTags checks tags against the S3 limits, so an invalid set fails
+// before any bytes are uploaded.
+func validateTags(tags map[string]string) error {
+	if len(tags) > maxTags {
+		return fmt.Errorf("%w: %d tags, at most %d allowed", ErrInvalidTags, len(tags), maxTags)
+	}
+	for k, v := range tags {
+		if k == "" || utf8.RuneCountInString(k) > maxTagKeyLength {
+			return fmt.Errorf("%w: key %q must be 1 to %d characters", ErrInvalidTags, k, maxTagKeyLength)
+		}
+		if utf8.RuneCountInString(v) > maxTagValueLength {
+			return fmt.Errorf("%w: value for %q exceeds %d characters", ErrInvalidTags, k, maxTagValueLength)
+		}
+	}
+	return nil
+}
+
+// encodeTags formats tags as the URL query string S3 expects in the
+// x-amz-tagging header.
+func encodeTags(tags map[string]string) string {
+	q := make(url.Values, len(tags))
+	for k, v := range tags {
+		q.Set(k, v)
+	}
+	return q.Encode()
+}
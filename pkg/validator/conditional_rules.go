@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// requiredIfValidator makes the field required when the sibling named by params[0]
+// equals any of the remaining params: required_if:Country,DE,FR,IT
+func requiredIfValidator(field string, value, parent reflect.Value, params []string) Rule {
+	if len(params) < 2 || parent.Kind() != reflect.Struct {
+		return Rule{Check: func() bool { return true }}
+	}
+
+	other, values := params[0], params[1:]
+	return conditionalRequiredRule(field, value, "required_if",
+		fmt.Sprintf("field is required when %s is %s", other, strings.Join(values, " or ")),
+		map[string]any{"other": other, "value": strings.Join(values, ", ")},
+		func() bool {
+			return slices.Contains(values, fieldString(parent.FieldByName(other)))
+		},
+	)
+}
+
+// requiredUnlessValidator makes the field required unless the sibling named by params[0]
+// equals any of the remaining params: required_unless:Plan,free
+func requiredUnlessValidator(field string, value, parent reflect.Value, params []string) Rule {
+	if len(params) < 2 || parent.Kind() != reflect.Struct {
+		return Rule{Check: func() bool { return true }}
+	}
+
+	other, values := params[0], params[1:]
+	return conditionalRequiredRule(field, value, "required_unless",
+		fmt.Sprintf("field is required unless %s is %s", other, strings.Join(values, " or ")),
+		map[string]any{"other": other, "value": strings.Join(values, ", ")},
+		func() bool {
+			return !slices.Contains(values, fieldString(parent.FieldByName(other)))
+		},
+	)
+}
+
+// requiredWithValidator makes the field required when any sibling named in params
+// is present (non-zero): required_with:Street,City
+func requiredWithValidator(field string, value, parent reflect.Value, params []string) Rule {
+	if len(params) < 1 || parent.Kind() != reflect.Struct {
+		return Rule{Check: func() bool { return true }}
+	}
+
+	others := strings.Join(params, ", ")
+	return conditionalRequiredRule(field, value, "required_with",
+		fmt.Sprintf("field is required when %s is present", others),
+		map[string]any{"other": others},
+		func() bool {
+			for _, name := range params {
+				if fieldPresent(parent.FieldByName(name)) {
+					return true
+				}
+			}
+			return false
+		},
+	)
+}
+
+// conditionalRequiredRule applies the required check only when condition holds.
+func conditionalRequiredRule(field string, value reflect.Value, name, msg string, values map[string]any, condition func() bool) Rule {
+	values["field"] = field
+	return Rule{
+		Check: func() bool {
+			return !condition() || fieldPresent(value)
+		},
+		Error: ValidationError{
+			Field:             field,
+			Message:           msg,
+			TranslationKey:    "validation." + name,
+			TranslationValues: values,
+		},
+	}
+}
+
+// fieldPresent reports whether v is non-empty using the same rules as "required".
+// Invalid values (e.g. unknown sibling names) are treated as absent.
+func fieldPresent(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	return requiredValidator("", v, nil).Check()
+}
+
+// fieldString formats a sibling value for comparison against tag params.
+// Nil pointers and unknown fields format as the empty string.
+func fieldString(v reflect.Value) string {
+	v, ok := derefField(v)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
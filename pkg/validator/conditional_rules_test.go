@@ -0,0 +1,211 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/validator"
+)
+
+func TestValidateStruct_RequiredIf(t *testing.T) {
+	t.Parallel()
+
+	type Billing struct {
+		Country   string
+		VATNumber string `validate:"required_if:Country,DE,FR,IT"`
+	}
+
+	t.Run("required when sibling matches", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidateStruct(&Billing{Country: "FR"})
+		require.Error(t, err)
+
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+		assert.Equal(t, "VATNumber", ve[0].Field)
+		assert.Equal(t, "validation.required_if", ve[0].TranslationKey)
+		assert.Equal(t, "Country", ve[0].TranslationValues["other"])
+		assert.Equal(t, "DE, FR, IT", ve[0].TranslationValues["value"])
+	})
+
+	t.Run("satisfied when value present", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, validator.ValidateStruct(&Billing{Country: "DE", VATNumber: "DE123456789"}))
+	})
+
+	t.Run("optional when sibling does not match", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, validator.ValidateStruct(&Billing{Country: "US"}))
+	})
+
+	t.Run("optional when sibling is empty", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, validator.ValidateStruct(&Billing{}))
+	})
+
+	t.Run("whitespace value counts as missing", func(t *testing.T) {
+		t.Parallel()
+
+		require.Error(t, validator.ValidateStruct(&Billing{Country: "IT", VATNumber: "   "}))
+	})
+
+	t.Run("non-string sibling", func(t *testing.T) {
+		t.Parallel()
+
+		type Shipping struct {
+			Express bool
+			Phone   string `validate:"required_if:Express,true"`
+			Count   int
+			Note    string `validate:"required_if:Count,0"`
+		}
+
+		require.Error(t, validator.ValidateStruct(&Shipping{Express: true, Count: 1}))
+		require.NoError(t, validator.ValidateStruct(&Shipping{Express: false, Count: 1}))
+
+		// Zero value of a sibling still matches an explicit "0"
+		err := validator.ValidateStruct(&Shipping{})
+		require.Error(t, err)
+		assert.Equal(t, []string{"Note"}, validator.ExtractValidationErrors(err).Fields())
+	})
+
+	t.Run("nil pointer sibling matches empty value", func(t *testing.T) {
+		t.Parallel()
+
+		type Form struct {
+			Reason *string
+			Other  string `validate:"required_if:Reason,"`
+		}
+
+		require.Error(t, validator.ValidateStruct(&Form{}))
+
+		reason := "spam"
+		require.NoError(t, validator.ValidateStruct(&Form{Reason: &reason}))
+	})
+
+	t.Run("pointer field is required when nil", func(t *testing.T) {
+		t.Parallel()
+
+		type Form struct {
+			Country   string
+			VATNumber *string `validate:"required_if:Country,DE"`
+		}
+
+		require.Error(t, validator.ValidateStruct(&Form{Country: "DE"}))
+
+		vat := "DE123"
+		require.NoError(t, validator.ValidateStruct(&Form{Country: "DE", VATNumber: &vat}))
+	})
+}
+
+func TestValidateStruct_RequiredUnless(t *testing.T) {
+	t.Parallel()
+
+	type Subscription struct {
+		Plan        string
+		PaymentCard string `validate:"required_unless:Plan,free,trial"`
+	}
+
+	tests := []struct {
+		name    string
+		input   Subscription
+		wantErr bool
+	}{
+		{name: "excepted value", input: Subscription{Plan: "free"}},
+		{name: "second excepted value", input: Subscription{Plan: "trial"}},
+		{name: "other value without field", input: Subscription{Plan: "pro"}, wantErr: true},
+		{name: "other value with field", input: Subscription{Plan: "pro", PaymentCard: "tok_123"}},
+		{name: "empty sibling without field", input: Subscription{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateStruct(&tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				ve := validator.ExtractValidationErrors(err)
+				require.Len(t, ve, 1)
+				assert.Equal(t, "PaymentCard", ve[0].Field)
+				assert.Equal(t, "validation.required_unless", ve[0].TranslationKey)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateStruct_RequiredWith(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		Street  string
+		City    string
+		ZipCode string `validate:"required_with:Street,City"`
+		Floor   int
+		Unit    string `validate:"required_with:Floor"`
+	}
+
+	tests := []struct {
+		name       string
+		input      Address
+		wantFields []string
+	}{
+		{name: "no siblings present", input: Address{}},
+		{name: "first sibling present", input: Address{Street: "Main St"}, wantFields: []string{"ZipCode"}},
+		{name: "second sibling present", input: Address{City: "Berlin"}, wantFields: []string{"ZipCode"}},
+		{name: "siblings and field present", input: Address{Street: "Main St", City: "Berlin", ZipCode: "10115"}},
+		{name: "zero numeric sibling is absent", input: Address{Floor: 0}},
+		{name: "non-zero numeric sibling is present", input: Address{Floor: 3}, wantFields: []string{"Unit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateStruct(&tt.input)
+			if len(tt.wantFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			ve := validator.ExtractValidationErrors(err)
+			assert.Equal(t, tt.wantFields, ve.Fields())
+			assert.Equal(t, "validation.required_with", ve[0].TranslationKey)
+		})
+	}
+}
+
+func TestValidateStruct_ConditionalWithOtherRules(t *testing.T) {
+	t.Parallel()
+
+	type Billing struct {
+		Country   string
+		VATNumber string `validate:"required_if:Country,DE;min:5"`
+	}
+
+	t.Run("reports both rules independently", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidateStruct(&Billing{Country: "DE", VATNumber: "DE1"})
+		require.Error(t, err)
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+		assert.Equal(t, "validation.min_length", ve[0].TranslationKey)
+	})
+
+	t.Run("unknown sibling never requires the field", func(t *testing.T) {
+		t.Parallel()
+
+		type Broken struct {
+			Value string `validate:"required_if:Missing,x;required_with:Missing"`
+		}
+		require.NoError(t, validator.ValidateStruct(&Broken{}))
+	})
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -84,6 +85,22 @@ func (ve ValidationErrors) IsEmpty() bool {
 	return len(ve) == 0
 }
 
+// AsMap groups error messages by field, keeping messages in the order they
+// were added. It always returns a non-nil map.
+func (ve ValidationErrors) AsMap() map[string][]string {
+	m := make(map[string][]string, len(ve))
+	for _, err := range ve {
+		m[err.Field] = append(m[err.Field], err.Message)
+	}
+	return m
+}
+
+// MarshalJSON encodes the errors as an object mapping each field to its
+// messages, e.g. {"email":["is required","must be a valid email"]}.
+func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ve.AsMap())
+}
+
 // TranslateFunc translates a message key with the given values into a localized string.
 type TranslateFunc func(key string, values map[string]any) string
 
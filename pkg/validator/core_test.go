@@ -1,6 +1,7 @@
 package validator_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -249,6 +250,63 @@ func TestValidationErrors_IsEmpty(t *testing.T) {
 	})
 }
 
+func TestValidationErrors_AsMap(t *testing.T) {
+	t.Parallel()
+	t.Run("groups messages by field in order", func(t *testing.T) {
+		t.Parallel()
+		var errs validator.ValidationErrors
+		errs.Add(validator.ValidationError{Field: "email", Message: "is required"})
+		errs.Add(validator.ValidationError{Field: "name", Message: "is too short"})
+		errs.Add(validator.ValidationError{Field: "email", Message: "must be a valid email"})
+
+		assert.Equal(t, map[string][]string{
+			"email": {"is required", "must be a valid email"},
+			"name":  {"is too short"},
+		}, errs.AsMap())
+	})
+
+	t.Run("returns empty map for no errors", func(t *testing.T) {
+		t.Parallel()
+		var errs validator.ValidationErrors
+
+		m := errs.AsMap()
+		require.NotNil(t, m)
+		assert.Empty(t, m)
+	})
+}
+
+func TestValidationErrors_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	t.Run("encodes field to messages object", func(t *testing.T) {
+		t.Parallel()
+		var errs validator.ValidationErrors
+		errs.Add(validator.ValidationError{Field: "email", Message: "is required", TranslationKey: "validation.required"})
+		errs.Add(validator.ValidationError{Field: "age", Message: "must be at least 18"})
+
+		data, err := json.Marshal(errs)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"age":["must be at least 18"],"email":["is required"]}`, string(data))
+	})
+
+	t.Run("encodes empty errors as empty object", func(t *testing.T) {
+		t.Parallel()
+		var errs validator.ValidationErrors
+
+		data, err := json.Marshal(errs)
+		require.NoError(t, err)
+		assert.Equal(t, `{}`, string(data))
+	})
+
+	t.Run("encodes nested in a struct", func(t *testing.T) {
+		t.Parallel()
+		errs := validator.ValidationErrors{{Field: "email", Message: "is required"}}
+
+		data, err := json.Marshal(map[string]any{"fields": errs})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"fields":{"email":["is required"]}}`, string(data))
+	})
+}
+
 func TestApply(t *testing.T) {
 	t.Parallel()
 	t.Run("returns nil when all rules pass", func(t *testing.T) {
@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// crossFieldFunc builds a Rule that compares a field against a sibling field of parent.
+type crossFieldFunc func(field string, value, parent reflect.Value, params []string) Rule
+
+var crossFieldRegistry = map[string]crossFieldFunc{
+	"eqfield":  eqFieldValidator,
+	"nefield":  neFieldValidator,
+	"gtfield":  gtFieldValidator,
+	"gtefield": gteFieldValidator,
+	"ltfield":  ltFieldValidator,
+	"ltefield": lteFieldValidator,
+
+	// Conditional requiredness
+	"required_if":     requiredIfValidator,
+	"required_unless": requiredUnlessValidator,
+	"required_with":   requiredWithValidator,
+}
+
+func eqFieldValidator(field string, value, parent reflect.Value, params []string) Rule {
+	return crossFieldRule(field, value, parent, params, "eqfield", "must match %s", func(a, b reflect.Value) bool {
+		return fieldsEqual(a, b)
+	})
+}
+
+func neFieldValidator(field string, value, parent reflect.Value, params []string) Rule {
+	return crossFieldRule(field, value, parent, params, "nefield", "must not match %s", func(a, b reflect.Value) bool {
+		return !fieldsEqual(a, b)
+	})
+}
+
+func gtFieldValidator(field string, value, parent reflect.Value, params []string) Rule {
+	return crossFieldRule(field, value, parent, params, "gtfield", "must be greater than %s", func(a, b reflect.Value) bool {
+		c, ok := compareFields(a, b)
+		return !ok || c > 0
+	})
+}
+
+func gteFieldValidator(field string, value, parent reflect.Value, params []string) Rule {
+	return crossFieldRule(field, value, parent, params, "gtefield", "must be greater than or equal to %s", func(a, b reflect.Value) bool {
+		c, ok := compareFields(a, b)
+		return !ok || c >= 0
+	})
+}
+
+func ltFieldValidator(field string, value, parent reflect.Value, params []string) Rule {
+	return crossFieldRule(field, value, parent, params, "ltfield", "must be less than %s", func(a, b reflect.Value) bool {
+		c, ok := compareFields(a, b)
+		return !ok || c < 0
+	})
+}
+
+func lteFieldValidator(field string, value, parent reflect.Value, params []string) Rule {
+	return crossFieldRule(field, value, parent, params, "ltefield", "must be less than or equal to %s", func(a, b reflect.Value) bool {
+		c, ok := compareFields(a, b)
+		return !ok || c <= 0
+	})
+}
+
+// crossFieldRule resolves the sibling field named by params[0] and applies check.
+// The rule passes when the sibling does not exist or either side is a nil pointer,
+// so optional pointer fields are only compared when both are set.
+func crossFieldRule(field string, value, parent reflect.Value, params []string, name, msg string, check func(a, b reflect.Value) bool) Rule {
+	if len(params) < 1 || parent.Kind() != reflect.Struct {
+		return Rule{Check: func() bool { return true }}
+	}
+
+	otherName := params[0]
+	other := parent.FieldByName(otherName)
+
+	return Rule{
+		Check: func() bool {
+			a, aok := derefField(value)
+			b, bok := derefField(other)
+			if !aok || !bok {
+				return true
+			}
+			return check(a, b)
+		},
+		Error: ValidationError{
+			Field:          field,
+			Message:        fmt.Sprintf(msg, otherName),
+			TranslationKey: "validation." + name,
+			TranslationValues: map[string]any{
+				"field": field,
+				"other": otherName,
+			},
+		},
+	}
+}
+
+// derefField unwraps pointers and reports false for invalid values and nil pointers.
+func derefField(v reflect.Value) (reflect.Value, bool) {
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid() && v.CanInterface()
+}
+
+func fieldsEqual(a, b reflect.Value) bool {
+	if c, ok := compareFields(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// compareFields orders two values of the same kind family.
+// It supports signed and unsigned integers, floats, strings, and time.Time.
+func compareFields(a, b reflect.Value) (int, bool) {
+	if a.Type() == timeType && b.Type() == timeType {
+		ta, _ := a.Interface().(time.Time)
+		tb, _ := b.Interface().(time.Time)
+		return ta.Compare(tb), true
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch b.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(a.Int(), b.Int()), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch b.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return cmp.Compare(a.Uint(), b.Uint()), true
+		}
+	case reflect.Float32, reflect.Float64:
+		switch b.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(a.Float(), b.Float()), true
+		}
+	case reflect.String:
+		if b.Kind() == reflect.String {
+			return strings.Compare(a.String(), b.String()), true
+		}
+	}
+	return 0, false
+}
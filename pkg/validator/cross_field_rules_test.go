@@ -0,0 +1,242 @@
+package validator_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrymomot/forge/pkg/validator"
+)
+
+func TestValidateStruct_EqField(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Password        string `validate:"required"`
+		PasswordConfirm string `validate:"eqfield:Password"`
+	}
+
+	t.Run("matching values pass", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidateStruct(&Signup{Password: "secret123", PasswordConfirm: "secret123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatched values fail on dependent field", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidateStruct(&Signup{Password: "secret123", PasswordConfirm: "secret124"})
+		require.Error(t, err)
+
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+		assert.Equal(t, "PasswordConfirm", ve[0].Field)
+		assert.Equal(t, "validation.eqfield", ve[0].TranslationKey)
+		assert.Equal(t, "Password", ve[0].TranslationValues["other"])
+	})
+}
+
+func TestValidateStruct_NeField(t *testing.T) {
+	t.Parallel()
+
+	type ChangePassword struct {
+		Current string
+		New     string `validate:"nefield:Current"`
+	}
+
+	require.NoError(t, validator.ValidateStruct(&ChangePassword{Current: "old", New: "new"}))
+	require.Error(t, validator.ValidateStruct(&ChangePassword{Current: "same", New: "same"}))
+}
+
+func TestValidateStruct_OrderedFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("time fields", func(t *testing.T) {
+		t.Parallel()
+
+		type Booking struct {
+			StartDate time.Time
+			EndDate   time.Time `validate:"gtfield:StartDate"`
+		}
+
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, validator.ValidateStruct(&Booking{StartDate: start, EndDate: start.Add(24 * time.Hour)}))
+		require.Error(t, validator.ValidateStruct(&Booking{StartDate: start, EndDate: start}))
+		require.Error(t, validator.ValidateStruct(&Booking{StartDate: start, EndDate: start.Add(-time.Hour)}))
+	})
+
+	t.Run("date strings", func(t *testing.T) {
+		t.Parallel()
+
+		type Range struct {
+			From string
+			To   string `validate:"gtefield:From"`
+		}
+
+		require.NoError(t, validator.ValidateStruct(&Range{From: "2024-01-01", To: "2024-01-01"}))
+		require.Error(t, validator.ValidateStruct(&Range{From: "2024-02-01", To: "2024-01-31"}))
+	})
+
+	t.Run("numeric fields", func(t *testing.T) {
+		t.Parallel()
+
+		type Limits struct {
+			Min  int
+			Max  int64   `validate:"gtfield:Min"`
+			Soft float64 `validate:"ltefield:Hard"`
+			Hard float32
+			Used uint `validate:"ltfield:Cap"`
+			Cap  uint8
+		}
+
+		require.NoError(t, validator.ValidateStruct(&Limits{Min: 1, Max: 2, Soft: 1.5, Hard: 1.5, Used: 1, Cap: 2}))
+
+		err := validator.ValidateStruct(&Limits{Min: 2, Max: 2, Soft: 2, Hard: 1, Used: 2, Cap: 2})
+		require.Error(t, err)
+		ve := validator.ExtractValidationErrors(err)
+		assert.Equal(t, []string{"Max", "Soft", "Used"}, ve.Fields())
+	})
+
+	t.Run("nil pointer fields are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		type Optional struct {
+			StartDate *time.Time
+			EndDate   *time.Time `validate:"gtfield:StartDate"`
+		}
+
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := start.Add(-time.Hour)
+
+		require.NoError(t, validator.ValidateStruct(&Optional{}))
+		require.NoError(t, validator.ValidateStruct(&Optional{StartDate: &start}))
+		require.Error(t, validator.ValidateStruct(&Optional{StartDate: &start, EndDate: &before}))
+	})
+
+	t.Run("unknown field is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		type Broken struct {
+			Value int `validate:"gtfield:Missing"`
+		}
+
+		require.NoError(t, validator.ValidateStruct(&Broken{Value: 1}))
+	})
+
+	t.Run("nested struct compares against its own fields", func(t *testing.T) {
+		t.Parallel()
+
+		type Period struct {
+			From int
+			To   int `validate:"gtfield:From"`
+		}
+		type Report struct {
+			From   int
+			Period Period
+		}
+
+		err := validator.ValidateStruct(&Report{From: 0, Period: Period{From: 5, To: 3}})
+		require.Error(t, err)
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+		assert.Equal(t, "Period.To", ve[0].Field)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom rule receives value, param and parent", func(t *testing.T) {
+		t.Parallel()
+
+		type Account struct {
+			Country string
+			Phone   string `validate:"test_phone_prefix:+"`
+		}
+
+		var gotParam string
+		var gotParent any
+		validator.Register("test_phone_prefix", func(value, param, parent any) bool {
+			gotParam, _ = param.(string)
+			gotParent = parent
+			s, _ := value.(string)
+			return len(s) > 0 && s[:1] == param
+		})
+
+		input := Account{Country: "DE", Phone: "+4930123"}
+		require.NoError(t, validator.ValidateStruct(&input))
+		assert.Equal(t, "+", gotParam)
+		assert.Equal(t, input, gotParent)
+
+		err := validator.ValidateStruct(&Account{Phone: "030123"})
+		require.Error(t, err)
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+		assert.Equal(t, "Phone", ve[0].Field)
+		assert.Equal(t, "validation.test_phone_prefix", ve[0].TranslationKey)
+		assert.Equal(t, "+", ve[0].TranslationValues["param"])
+	})
+
+	t.Run("nil pointer value is passed as nil", func(t *testing.T) {
+		t.Parallel()
+
+		type Profile struct {
+			Slug *string `validate:"test_optional_slug"`
+		}
+
+		slugPattern := regexp.MustCompile(`^[a-z0-9-]+$`)
+		validator.Register("test_optional_slug", func(value, _, _ any) bool {
+			if value == nil {
+				return true
+			}
+			s, _ := value.(string)
+			return slugPattern.MatchString(s)
+		})
+
+		valid := "my-slug"
+		invalid := "My Slug"
+		require.NoError(t, validator.ValidateStruct(&Profile{}))
+		require.NoError(t, validator.ValidateStruct(&Profile{Slug: &valid}))
+		require.Error(t, validator.ValidateStruct(&Profile{Slug: &invalid}))
+	})
+
+	t.Run("takes precedence over RegisterValidator", func(t *testing.T) {
+		t.Parallel()
+
+		type Item struct {
+			Code string `validate:"test_precedence"`
+		}
+
+		validator.RegisterValidator("test_precedence", func(field string, value reflect.Value, params []string) validator.Rule {
+			return validator.Rule{Check: func() bool { return false }}
+		})
+		validator.Register("test_precedence", func(_, _, _ any) bool { return true })
+
+		require.NoError(t, validator.ValidateStruct(&Item{Code: "x"}))
+	})
+
+	t.Run("translates through ValidationErrors.Translate", func(t *testing.T) {
+		t.Parallel()
+
+		type Form struct {
+			Code string `validate:"test_never"`
+		}
+
+		validator.Register("test_never", func(_, _, _ any) bool { return false })
+
+		err := validator.ValidateStruct(&Form{Code: "x"})
+		ve := validator.ExtractValidationErrors(err)
+		require.Len(t, ve, 1)
+
+		ve.Translate(func(key string, values map[string]any) string {
+			return key + ":" + values["field"].(string)
+		})
+		assert.Equal(t, "validation.test_never:Code", ve[0].Message)
+	})
+}
@@ -150,9 +150,71 @@
 //		}
 //	}
 //
+// # Cross-Field Validation
+//
+// Cross-field tags compare a field against a sibling field of the same struct,
+// referenced by its Go field name:
+//
+//	type Signup struct {
+//		Password        string    `validate:"required;min:8"`
+//		PasswordConfirm string    `validate:"eqfield:Password"`
+//		StartDate       time.Time `validate:"required"`
+//		EndDate         time.Time `validate:"gtfield:StartDate"`
+//	}
+//
+// Supported tags are eqfield, nefield, gtfield, gtefield, ltfield, and ltefield.
+// Ordering works for integers, unsigned integers, floats, strings (lexical, so
+// ISO-8601 dates compare correctly), and time.Time. The sibling is looked up in
+// the struct that directly contains the field, so fields of a nested struct
+// compare against other fields of that nested struct. The rule passes when the
+// sibling does not exist, when either side is a nil pointer, or when the types
+// cannot be ordered; combine with required when a value must be present.
+// Errors are reported on the tagged field with TranslationKey "validation.<tag>"
+// and TranslationValues "field" and "other".
+//
+// # Conditional Requiredness
+//
+// A field can be required depending on its siblings:
+//
+//	type Billing struct {
+//		Country   string
+//		VATNumber string `validate:"required_if:Country,DE,FR,IT"`   // required for listed countries
+//		Card      string `validate:"required_unless:Plan,free"`      // required unless Plan is "free"
+//		Plan      string
+//		ZipCode   string `validate:"required_with:Street,City"`      // required if Street or City is set
+//		Street    string
+//		City      string
+//	}
+//
+// required_if and required_unless compare the sibling's value, formatted with
+// fmt.Sprint, against each listed value; a nil pointer sibling formats as "".
+// required_with treats a sibling as present using the same rules as required:
+// zero numbers, false, empty strings, and empty collections are absent.
+// Errors are keyed to the dependent field with TranslationKeys
+// "validation.required_if", "validation.required_unless", and
+// "validation.required_with", and TranslationValues "field", "other", and
+// (for required_if/required_unless) "value".
+//
 // # Custom Validators
 //
-// Register custom validation functions for struct tags:
+// Register adds a named rule with access to the containing struct, which is
+// enough for most domain-specific and cross-field rules:
+//
+//	validator.Register("iso_country", func(value, param, parent any) bool {
+//		s, _ := value.(string)
+//		return countries.Valid(s)
+//	})
+//
+// value is the field value (nil for a nil pointer), param is the raw text after
+// the colon in the tag, and parent is the struct that directly contains the field,
+// passed by value. A failing rule yields TranslationKey "validation.<name>".
+//
+// When a tag name is registered more than once, rules added with Register win
+// over rules added with RegisterValidator, which in turn win over the built-in
+// cross-field rules. RegisterValidator shares its registry with the built-in
+// rules, so registering an existing name replaces it.
+//
+// RegisterValidator gives full control over the produced Rule:
 //
 //	validator.RegisterValidator("business_email", func(field string, value reflect.Value, params []string) validator.Rule {
 //		email := value.String()
@@ -188,6 +250,11 @@
 //		}
 //	}
 //
+// ValidationErrors encodes to JSON as an object mapping each field to its
+// messages in the order they were added. AsMap returns the same structure:
+//
+//	{"email": ["is required", "must be a valid email"], "name": ["is too short"]}
+//
 // # Translation Support
 //
 // ValidationError includes translation keys and values for internationalization.
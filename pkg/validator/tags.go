@@ -99,6 +99,55 @@ func RegisterValidator(name string, fn ValidatorFunc) {
 	registry[name] = fn
 }
 
+// CustomFunc reports whether value satisfies a custom rule.
+// value is the field value (dereferenced when it is a non-nil pointer, nil for a nil pointer),
+// param is the raw text after the colon in the tag (empty if absent), and parent is the
+// struct that directly contains the field, passed by value.
+type CustomFunc func(value, param, parent any) bool
+
+var customRegistry = map[string]CustomFunc{}
+
+// Register adds a named rule usable in validate tags.
+// Rules added with Register take precedence over built-in rules and rules added with
+// RegisterValidator that share the same name. Registering a name again replaces the
+// previous rule.
+//
+// A failing rule produces a ValidationError with TranslationKey "validation.<name>"
+// and TranslationValues "field" and "param".
+func Register(name string, fn CustomFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	customRegistry[name] = fn
+}
+
+func customRule(name string, fn CustomFunc, field string, value, parent reflect.Value, param string) Rule {
+	return Rule{
+		Check: func() bool {
+			return fn(interfaceOf(value), param, interfaceOf(parent))
+		},
+		Error: ValidationError{
+			Field:          field,
+			Message:        fmt.Sprintf("failed %s validation", name),
+			TranslationKey: "validation." + name,
+			TranslationValues: map[string]any{
+				"field": field,
+				"param": param,
+			},
+		},
+	}
+}
+
+// interfaceOf returns the value as any, or nil for invalid values and nil pointers.
+func interfaceOf(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
+
 // ValidateStruct validates a struct based on its field tags
 func ValidateStruct(v any) error {
 	rv := reflect.ValueOf(v)
@@ -154,14 +203,14 @@ func validateStructRecursive(rv reflect.Value, prefix string, errors *Validation
 			if field.IsNil() {
 				// If nil and has validation tag, might need to validate required
 				if tag != "" {
-					validateField(fieldPath, field, tag, errors)
+					validateField(fieldPath, field, rv, tag, errors)
 				}
 			} else {
 				elem := field.Elem()
 				if elem.Kind() == reflect.Struct && tag == "" {
 					validateStructRecursive(elem, fieldPath, errors)
 				} else if tag != "" {
-					validateField(fieldPath, elem, tag, errors)
+					validateField(fieldPath, elem, rv, tag, errors)
 				}
 			}
 			continue
@@ -173,11 +222,11 @@ func validateStructRecursive(rv reflect.Value, prefix string, errors *Validation
 		}
 
 		// Validate the field
-		validateField(fieldPath, field, tag, errors)
+		validateField(fieldPath, field, rv, tag, errors)
 	}
 }
 
-func validateField(fieldPath string, field reflect.Value, tag string, errors *ValidationErrors) {
+func validateField(fieldPath string, field, parent reflect.Value, tag string, errors *ValidationErrors) {
 	// Parse validation rules separated by semicolon
 	rules := strings.Split(tag, ";")
 
@@ -198,9 +247,10 @@ func validateField(fieldPath string, field reflect.Value, tag string, errors *Va
 		ruleName := strings.TrimSpace(parts[0])
 
 		var params []string
+		var paramStr string
 		if len(parts) > 1 {
 			// Split parameters by comma
-			paramStr := strings.TrimSpace(parts[1])
+			paramStr = strings.TrimSpace(parts[1])
 			if paramStr != "" {
 				params = strings.Split(paramStr, ",")
 				for i := range params {
@@ -209,12 +259,20 @@ func validateField(fieldPath string, field reflect.Value, tag string, errors *Va
 			}
 		}
 
-		// Get validator function
-		if validatorFn, ok := registry[ruleName]; ok {
-			rule := validatorFn(fieldPath, field, params)
-			if !rule.Check() {
-				errors.Add(rule.Error)
-			}
+		// Resolve rule: Register > RegisterValidator/built-in > cross-field
+		var rule Rule
+		if fn, ok := customRegistry[ruleName]; ok {
+			rule = customRule(ruleName, fn, fieldPath, field, parent, paramStr)
+		} else if validatorFn, ok := registry[ruleName]; ok {
+			rule = validatorFn(fieldPath, field, params)
+		} else if crossFn, ok := crossFieldRegistry[ruleName]; ok {
+			rule = crossFn(fieldPath, field, parent, params)
+		} else {
+			continue
+		}
+
+		if !rule.Check() {
+			errors.Add(rule.Error)
 		}
 	}
 }